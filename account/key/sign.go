@@ -6,12 +6,11 @@
 package key
 
 import (
-	"encoding/binary"
+	"bytes"
 
 	"github.com/aergoio/aergo/v2/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
-	sha256 "github.com/minio/sha256-simd"
 )
 
 // Sign return signature using stored key
@@ -52,18 +51,54 @@ func VerifyTx(tx *types.Tx) error {
 }
 
 func VerifyTxWithAddress(tx *types.Tx, address []byte) error {
-	txBody := tx.Body
-	hash := CalculateHashWithoutSign(txBody)
-	sign, err := ecdsa.ParseSignature(txBody.Sign)
+	return tx.VerifySign(address)
+}
+
+// AddMultisigSign adds the signature of the key to the sign of a tx, which is sent by a multisig account
+func AddMultisigSign(tx *types.Tx, key *aergokey) error {
+	multiSign, err := types.DecodeMultisigSign(tx.Body.Sign)
 	if err != nil {
 		return err
 	}
-	pubkey, err := btcec.ParsePubKey(address)
+	hash := CalculateHashWithoutSign(tx.Body)
+	sign := ecdsa.Sign(key, hash)
+	multiSign.Add(key.PubKey().SerializeCompressed(), sign.Serialize())
+	tx.Body.Sign = multiSign.Encode()
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+// VerifyMultisigTx return result to verify the signs of a tx sent by a multisig account. The signs should be made by
+// the keys of the account, at least as many as the threshold
+func VerifyMultisigTx(tx *types.Tx, multisig *types.MultisigAccount) error {
+	multiSign, err := types.DecodeMultisigSign(tx.Body.Sign)
 	if err != nil {
 		return err
 	}
-	if !sign.Verify(hash, pubkey) {
-		return types.ErrSignNotMatch
+	hash := CalculateHashWithoutSign(tx.Body)
+	for i, address := range multiSign.Pubkeys {
+		if !multisig.HasKey(address) {
+			return types.ErrSignNotMatch
+		}
+		for _, prev := range multiSign.Pubkeys[:i] {
+			if bytes.Equal(prev, address) {
+				return types.ErrSignNotMatch
+			}
+		}
+		sign, err := ecdsa.ParseSignature(multiSign.Signs[i])
+		if err != nil {
+			return err
+		}
+		pubkey, err := btcec.ParsePubKey(address)
+		if err != nil {
+			return err
+		}
+		if !sign.Verify(hash, pubkey) {
+			return types.ErrSignNotMatch
+		}
+	}
+	if uint32(len(multiSign.Pubkeys)) < multisig.GetThreshold() {
+		return types.ErrNotEnoughSignatures
 	}
 	return nil
 }
@@ -75,15 +110,5 @@ func (ks *Store) VerifyTx(tx *types.Tx) error {
 
 // CalculateHashWithoutSign return hash of tx without sign field
 func CalculateHashWithoutSign(txBody *types.TxBody) []byte {
-	h := sha256.New()
-	binary.Write(h, binary.LittleEndian, txBody.Nonce)
-	h.Write(txBody.Account)
-	h.Write(txBody.Recipient)
-	h.Write(txBody.Amount)
-	h.Write(txBody.Payload)
-	binary.Write(h, binary.LittleEndian, txBody.GasLimit)
-	h.Write(txBody.GasPrice)
-	binary.Write(h, binary.LittleEndian, txBody.Type)
-	h.Write(txBody.ChainIdHash)
-	return h.Sum(nil)
+	return txBody.CalculateHashWithoutSign()
 }
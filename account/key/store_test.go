@@ -160,6 +160,55 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignTxValidity(t *testing.T) {
+	privkey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	addr := crypto.GenerateAddress(privkey.PubKey().ToECDSA())
+	tx := &types.Tx{Body: &types.TxBody{Account: addr, Nonce: 1, ValidUntilBlock: 100}}
+	assert.NoError(t, SignTx(tx, privkey))
+	assert.NoError(t, VerifyTx(tx))
+
+	// the validity window is signed
+	tx.Body.ValidUntilBlock = 200
+	assert.Error(t, VerifyTx(tx))
+}
+
+func TestMultisigTx(t *testing.T) {
+	var keys []*btcec.PrivateKey
+	var pubkeys [][]byte
+	for i := 0; i < 3; i++ {
+		key, err := btcec.NewPrivateKey()
+		assert.NoError(t, err)
+		keys = append(keys, key)
+		pubkeys = append(pubkeys, key.PubKey().SerializeCompressed())
+	}
+	multisig, err := types.NewMultisigAccount(2, pubkeys)
+	assert.NoError(t, err)
+	assert.True(t, types.IsMultisigAddress(multisig.Address()))
+
+	tx := &types.Tx{Body: &types.TxBody{Account: multisig.Address(), Nonce: 1, Amount: []byte{1}}}
+	assert.True(t, tx.HasMultisigAccount())
+	assert.Equal(t, types.ErrNotEnoughSignatures, VerifyMultisigTx(tx, multisig))
+
+	assert.NoError(t, AddMultisigSign(tx, keys[0]))
+	assert.NoError(t, AddMultisigSign(tx, keys[0]), "signing twice replaces the sign")
+	assert.Equal(t, types.ErrNotEnoughSignatures, VerifyMultisigTx(tx, multisig))
+
+	assert.NoError(t, AddMultisigSign(tx, keys[2]))
+	assert.NoError(t, VerifyMultisigTx(tx, multisig))
+	assert.Equal(t, tx.CalculateTxHash(), tx.Hash)
+
+	// a key not in the account
+	other, _ := btcec.NewPrivateKey()
+	signed := &types.Tx{Body: &types.TxBody{Account: multisig.Address(), Nonce: 1, Amount: []byte{1}, Sign: tx.Body.Sign}}
+	assert.NoError(t, AddMultisigSign(signed, other))
+	assert.Equal(t, types.ErrSignNotMatch, VerifyMultisigTx(signed, multisig))
+
+	// the body is changed after signing
+	tx.Body.Nonce = 2
+	assert.Equal(t, types.ErrSignNotMatch, VerifyMultisigTx(tx, multisig))
+}
+
 func TestConcurrentUnlockAndLock(t *testing.T) {
 	initTest()
 	defer deinitTest()
@@ -683,6 +683,38 @@ func (cdb *ChainDB) getInternalOperations(blockNo types.BlockNo) string {
 	return string(data)
 }
 
+func (cdb *ChainDB) getScheduledCallReceipts(blockNo types.BlockNo) string {
+	data := cdb.store.Get(dbkey.ScheduledCalls(blockNo))
+	return string(data)
+}
+
+// writeContractSource registers the verified source of a contract. The registry is local to the node.
+func (cdb *ChainDB) writeContractSource(source *types.ContractSource) error {
+	val, err := proto.Encode(source)
+	if err != nil {
+		return err
+	}
+	dbTx := cdb.store.NewTx()
+	defer dbTx.Discard()
+
+	dbTx.Set(dbkey.ContractSource(source.ContractAddress), val)
+
+	dbTx.Commit()
+	return nil
+}
+
+func (cdb *ChainDB) getContractSource(contract []byte) (*types.ContractSource, error) {
+	data := cdb.store.Get(dbkey.ContractSource(contract))
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var source types.ContractSource
+	if err := proto.Decode(data, &source); err != nil {
+		return nil, err
+	}
+	return &source, nil
+}
+
 type ChainTree struct {
 	Tree []ChainInfo
 }
@@ -709,11 +741,12 @@ func (cdb *ChainDB) GetChainTree() ([]byte, error) {
 	return jsonBytes, nil
 }
 
-func (cdb *ChainDB) writeReceiptsAndOperations(block *types.Block, receipts *types.Receipts, internalOps string) {
+func (cdb *ChainDB) writeReceiptsAndOperations(block *types.Block, receipts *types.Receipts, internalOps string, scheduledCalls string) {
 	hasReceipts := len(receipts.Get()) != 0
 	hasInternalOps := len(internalOps) != 0
+	hasScheduledCalls := len(scheduledCalls) != 0
 
-	if !hasReceipts && !hasInternalOps {
+	if !hasReceipts && !hasInternalOps && !hasScheduledCalls {
 		return
 	}
 
@@ -732,12 +765,17 @@ func (cdb *ChainDB) writeReceiptsAndOperations(block *types.Block, receipts *typ
 		dbTx.Set(dbkey.InternalOps(blockNo), []byte(internalOps))
 	}
 
+	if hasScheduledCalls {
+		dbTx.Set(dbkey.ScheduledCalls(blockNo), []byte(scheduledCalls))
+	}
+
 	dbTx.Commit()
 }
 
 func (cdb *ChainDB) deleteReceiptsAndOperations(dbTx *db.Transaction, blockHash []byte, blockNo types.BlockNo) {
 	(*dbTx).Delete(dbkey.Receipts(blockHash, blockNo))
 	(*dbTx).Delete(dbkey.InternalOps(blockNo))
+	(*dbTx).Delete(dbkey.ScheduledCalls(blockNo))
 }
 
 func (cdb *ChainDB) writeReorgMarker(marker *ReorgMarker) error {
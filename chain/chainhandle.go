@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/aergoio/aergo/v2/consensus"
 	"github.com/aergoio/aergo/v2/contract"
@@ -292,6 +293,182 @@ func (cs *ChainService) getInternalOperations(blockNo types.BlockNo) (string, er
 	return cs.cdb.getInternalOperations(blockNo), nil
 }
 
+func (cs *ChainService) getScheduledCallReceipts(blockNo types.BlockNo) (string, error) {
+	blockInMainChain, err := cs.cdb.GetBlockByNo(blockNo)
+	if err != nil {
+		return "", &ErrNoBlock{blockNo}
+	}
+
+	block, err := cs.cdb.getBlock(blockInMainChain.BlockHash())
+	if !bytes.Equal(block.BlockHash(), blockInMainChain.BlockHash()) {
+		return "", errors.New("scheduled call receipts not found")
+	}
+
+	return cs.cdb.getScheduledCallReceipts(blockNo), nil
+}
+
+// traceTx re-executes the tx on the state of its parent block after replaying the previous txs of the block, and
+// returns the execution trace in json. Governance txs are not supported, since they may change the system parameters
+// or the consensus of this node, so neither is a tx following a governance tx in the block.
+//
+// The replay doesn't change the node: the sql databases are opened read-only, the governance calls of the contracts
+// fail, and the activated params are not applied. The sql databases are read as of the parent block, at the recovery
+// points of the contracts in its state, so a tx reading the rows written by a previous tx of the same block is traced
+// without them. The trace warns of it, and of a replayed tx whose result differs from its receipt.
+func (cs *ChainService) traceTx(txHash []byte) (string, error) {
+	_, txIdx, err := cs.getTx(txHash)
+	if err != nil {
+		return "", err
+	}
+	block, err := cs.cdb.getBlock(txIdx.BlockHash)
+	if err != nil {
+		return "", err
+	}
+	txs := block.GetBody().GetTxs()
+	for i, tx := range txs[:txIdx.Idx+1] {
+		if tx.GetBody().GetType() == types.TxType_GOVERNANCE {
+			if i == int(txIdx.Idx) {
+				return "", errors.New("governance tx cannot be traced")
+			}
+			return "", fmt.Errorf("cannot trace tx after governance tx %s in the block", base58.Encode(tx.GetHash()))
+		}
+	}
+
+	// the vm is kept for the current hardfork version, so txs of the other versions are not replayed
+	bi := types.NewBlockHeaderInfo(block)
+	bestBlock, err := cs.cdb.GetBestBlock()
+	if err != nil {
+		return "", err
+	}
+	if forkVersion := types.NewBlockHeaderInfo(bestBlock).ForkVersion; bi.ForkVersion != forkVersion {
+		return "", fmt.Errorf("cannot trace tx of hardfork version %d (current %d)", bi.ForkVersion, forkVersion)
+	}
+
+	parent, err := cs.cdb.getBlock(block.GetHeader().GetPrevBlockHash())
+	if err != nil {
+		return "", err
+	}
+	bState := state.NewBlockState(
+		cs.sdb.OpenNewStateDB(parent.GetHeader().GetBlocksRootHash()),
+		state.SetPrevBlockHash(parent.BlockHash()),
+	)
+	scs, err := statedb.GetSystemAccountState(bState.StateDB)
+	if err != nil {
+		return "", err
+	}
+	bState.SetGasPrice(system.GetGasPriceFromState(scs))
+	bState.Receipts().SetHardFork(cs.cfg.Hardfork, block.BlockNo())
+
+	// the params are activated and the scheduled calls are executed at the start of the block, before its txs. The
+	// calls are replayed like the txs, in their own context slot with the sql databases read-only, so the trace
+	// doesn't change anything
+	tracer := contract.NewTracer(txHash)
+	execCtx := contract.WithTracer(context.Background(), tracer)
+	if _, err = contract.ActivateParams(execCtx, bState, bi); err != nil {
+		return "", err
+	}
+	if _, err = NewScheduledCallExecutor(execCtx, cs.cdb, bi, contract.ChainService)(bState); err != nil {
+		return "", err
+	}
+
+	exec := NewTxExecutor(execCtx, nil, cs.cdb, bi, contract.ChainService)
+	trace := &contract.TxTrace{TxHash: base58.Encode(txHash), BlockNo: block.BlockNo()}
+	for _, tx := range txs[:txIdx.Idx+1] {
+		if err = exec(bState, types.NewTransaction(tx)); err != nil {
+			return "", err
+		}
+	}
+
+	receipts := bState.Receipts().Get()
+	receipt := receipts[len(receipts)-1]
+	trace.Status = receipt.Status
+	trace.Ret = receipt.Ret
+	trace.GasUsed = receipt.GasUsed
+	trace.FeeUsed = new(big.Int).SetBytes(receipt.FeeUsed).String()
+	trace.Call = tracer.Root()
+	trace.ErrorAt = tracer.ErrorAt()
+
+	if tracer.SqlRead() {
+		trace.Warnings = append(trace.Warnings, "the sql databases are read as of the parent block, without the rows written by the previous txs of the block")
+	}
+	stored, err := cs.cdb.getReceipts(block.BlockHash(), block.BlockNo(), cs.cfg.Hardfork)
+	if err != nil {
+		return "", err
+	}
+	for i, r := range receipts {
+		if i >= len(stored.Get()) {
+			break
+		}
+		if orig := stored.Get()[i]; r.Status != orig.Status || r.Ret != orig.Ret || r.GasUsed != orig.GasUsed {
+			trace.Warnings = append(trace.Warnings, fmt.Sprintf("the replay of tx %s differs from its receipt", base58.Encode(txs[i].GetHash())))
+		}
+	}
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// profileTxTimeout is the deadline of the dry run of a tx with the profiler, which holds the chain lock.
+const profileTxTimeout = 3 * time.Second
+
+// profileTx executes the tx on the state of the best block as a tx of the next block, and returns the gas consumed by
+// the call stacks in json. The state and the sql databases are not changed. The tx doesn't need to be signed, but it
+// must have a valid hash and nonce. The tx fails with a timeout error if it runs longer than profileTxTimeout.
+func (cs *ChainService) profileTx(tx *types.Tx) (string, error) {
+	if tx.GetBody().GetType() == types.TxType_GOVERNANCE {
+		return "", errors.New("governance tx cannot be profiled")
+	}
+	// the tx writes the sql databases as in a block, so it runs under the chain lock like the block execution, which
+	// shares the connections and the context slot of the chain service. The sql transactions are rolled back. The
+	// block factory can't generate a block while the lock is held, so the dry run has a deadline.
+	InAddBlock <- struct{}{}
+	defer func() {
+		contract.CloseDatabase()
+		<-InAddBlock
+	}()
+
+	bestBlock, err := cs.cdb.GetBestBlock()
+	if err != nil {
+		return "", err
+	}
+	bState := state.NewBlockState(
+		cs.sdb.OpenNewStateDB(bestBlock.GetHeader().GetBlocksRootHash()),
+		state.SetPrevBlockHash(bestBlock.BlockHash()),
+	)
+	scs, err := statedb.GetSystemAccountState(bState.StateDB)
+	if err != nil {
+		return "", err
+	}
+	bState.SetGasPrice(system.GetGasPriceFromState(scs))
+	bi := types.NewBlockHeaderInfoFromPrevBlock(bestBlock, time.Now().UnixNano(), cs.cfg.Hardfork)
+	bState.Receipts().SetHardFork(cs.cfg.Hardfork, bi.No)
+
+	profiler := contract.NewProfiler(tx.GetHash())
+	execCtx, cancel := context.WithTimeout(contract.WithProfiler(context.Background(), profiler), profileTxTimeout)
+	defer cancel()
+	if err = NewTxExecutor(execCtx, nil, cs.cdb, bi, contract.ChainService)(bState, types.NewTransaction(tx)); err != nil {
+		return "", err
+	}
+
+	receipt := bState.Receipts().Get()[0]
+	profile := &contract.TxProfile{
+		Status:  receipt.Status,
+		Ret:     receipt.Ret,
+		GasUsed: receipt.GasUsed,
+		FeeUsed: new(big.Int).SetBytes(receipt.FeeUsed).String(),
+		Gas:     profiler.Folded(),
+		Storage: profiler.FoldedStorage(),
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 type chainProcessor struct {
 	*ChainService
 	block       *types.Block // starting block
@@ -636,6 +813,7 @@ func (cs *ChainService) CountTxsInChain() int {
 }
 
 type TxExecFn func(bState *state.BlockState, tx types.Transaction) error
+type ScheduledCallExecFn func(bState *state.BlockState) (int, error)
 type ValidatePostFn func() error
 type ValidateSignWaitFn func() error
 
@@ -643,6 +821,9 @@ type blockExecutor struct {
 	*state.BlockState
 	sdb              *state.ChainStateDB
 	execTx           TxExecFn
+	execScheduled    ScheduledCallExecFn
+	scheduledCalls   int  // the number of the scheduled calls executed before the txs
+	paramsActivated  bool // whether a voted system param is activated before the txs
 	txs              []*types.Tx
 	validatePost     ValidatePostFn
 	coinbaseAccount  []byte
@@ -650,10 +831,14 @@ type blockExecutor struct {
 	verifyOnly       bool
 	validateSignWait ValidateSignWaitFn
 	bi               *types.BlockHeaderInfo
+	speculate        txSpeculateFn // executes txs speculatively in parallel if not nil, see executeTxsParallel
+	workers          int
 }
 
 func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.Block, verifyOnly bool) (*blockExecutor, error) {
 	var exec TxExecFn
+	var speculate txSpeculateFn
+	var execScheduled ScheduledCallExecFn
 	var validateSignWait ValidateSignWaitFn
 	var bi *types.BlockHeaderInfo
 
@@ -675,6 +860,10 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 		bi = types.NewBlockHeaderInfo(block)
 		// FIXME currently the verify only function is allowed long execution time,
 		exec = NewTxExecutor(context.Background(), cs.ChainConsensus, cs.cdb, bi, contract.ChainService)
+		execScheduled = NewScheduledCallExecutor(context.Background(), cs.cdb, bi, contract.ChainService)
+		if cs.cfg.Blockchain.ParallelExec {
+			speculate = newTxSpeculator(context.Background(), cs.ChainConsensus, cs.cdb, bi, contract.ChainService)
+		}
 
 		validateSignWait = func() error {
 			return cs.validator.WaitVerifyDone()
@@ -692,6 +881,7 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 		BlockState:      bState,
 		sdb:             cs.sdb,
 		execTx:          exec,
+		execScheduled:   execScheduled,
 		txs:             block.GetBody().GetTxs(),
 		coinbaseAccount: block.GetHeader().GetCoinbaseAccount(),
 		validatePost: func() error {
@@ -701,9 +891,27 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 		verifyOnly:       verifyOnly,
 		validateSignWait: validateSignWait,
 		bi:               bi,
+		speculate:        speculate,
+		workers:          cs.cfg.Blockchain.NumWorkers,
 	}, nil
 }
 
+// NewScheduledCallExecutor returns a new ScheduledCallExecFn, which executes the scheduled calls due at the block
+// before its txs.
+func NewScheduledCallExecutor(execCtx context.Context, cdb contract.ChainAccessor, bi *types.BlockHeaderInfo, executionMode int) ScheduledCallExecFn {
+	return func(bState *state.BlockState) (int, error) {
+		n, err := contract.ExecuteScheduledCalls(execCtx, bState, cdb, bi, executionMode)
+		if err != nil {
+			logger.Error().Err(err).Uint64("no", bi.No).Msg("scheduled call failed")
+			return n, err
+		}
+		if n > 0 {
+			logger.Debug().Uint64("no", bi.No).Int("count", n).Msg("executed scheduled calls")
+		}
+		return n, nil
+	}
+}
+
 // NewTxExecutor returns a new TxExecFn.
 func NewTxExecutor(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb contract.ChainAccessor, bi *types.BlockHeaderInfo, executionMode int) TxExecFn {
 	return func(bState *state.BlockState, tx types.Transaction) error {
@@ -734,14 +942,22 @@ func (e *blockExecutor) execute() error {
 	// Receipt must be committed unconditionally.
 	if !e.commitOnly {
 		defer contract.CloseDatabase()
-		logger.Trace().Int("txCount", len(e.txs)).Msg("executing txs")
-		for _, tx := range e.txs {
-			// execute the transaction
-			if err := e.execTx(e.BlockState, types.NewTransaction(tx)); err != nil {
-				//FIXME maybe system error. restart or panic
-				// all txs have executed successfully in BP node
+		activated, err := contract.ActivateParams(context.Background(), e.BlockState, e.bi)
+		if err != nil {
+			return err
+		}
+		e.paramsActivated = activated
+		if e.execScheduled != nil {
+			n, err := e.execScheduled(e.BlockState)
+			if err != nil {
 				return err
 			}
+			e.scheduledCalls = n
+		}
+
+		logger.Trace().Int("txCount", len(e.txs)).Msg("executing txs")
+		if err := e.executeTxs(); err != nil {
+			return err
 		}
 
 		if e.validateSignWait != nil {
@@ -781,6 +997,23 @@ func (e *blockExecutor) execute() error {
 	return nil
 }
 
+func (e *blockExecutor) executeTxs() error {
+	// the speculation runs on the state of the previous block, which misses the changes of the scheduled calls and the
+	// activated params
+	if e.speculate != nil && e.workers > 0 && e.scheduledCalls == 0 && !e.paramsActivated {
+		return e.executeTxsParallel()
+	}
+	for _, tx := range e.txs {
+		// execute the transaction
+		if err := e.execTx(e.BlockState, types.NewTransaction(tx)); err != nil {
+			//FIXME maybe system error. restart or panic
+			// all txs have executed successfully in BP node
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *blockExecutor) commit() error {
 	if err := e.BlockState.Commit(); err != nil {
 		return err
@@ -825,7 +1058,7 @@ func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Bloc
 		return err
 	}
 
-	cs.cdb.writeReceiptsAndOperations(block, ex.BlockState.Receipts(), ex.BlockState.InternalOps())
+	cs.cdb.writeReceiptsAndOperations(block, ex.BlockState.Receipts(), ex.BlockState.InternalOps(), ex.BlockState.ScheduledCallReceipts())
 
 	cs.notifyEvents(block, ex.BlockState)
 
@@ -964,7 +1197,7 @@ func executeTx(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb
 		err       error
 	)
 
-	if account, err = name.Resolve(bs, txBody.GetAccount(), isQuirkTx); err != nil {
+	if account, err = name.Resolve(bs, txBody.GetAccount(), isQuirkTx, bi.No); err != nil {
 		return err
 	}
 
@@ -981,6 +1214,11 @@ func executeTx(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb
 		return err
 	}
 
+	err = tx.GetTx().ValidateWithBlockNo(bi.No, bi.ForkVersion)
+	if err != nil {
+		return err
+	}
+
 	sender, err := state.GetAccountState(account, bs.StateDB)
 	if err != nil {
 		return err
@@ -991,10 +1229,16 @@ func executeTx(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb
 		return err
 	}
 
+	if bi.ForkVersion >= 6 {
+		if err = verifyRotatedKey(bs, tx.GetTx()); err != nil {
+			return err
+		}
+	}
+
 	isMultiCall := (txBody.Type == types.TxType_MULTICALL)
 
 	if !isMultiCall {
-		if recipient, err = name.Resolve(bs, txBody.Recipient, isQuirkTx); err != nil {
+		if recipient, err = name.Resolve(bs, txBody.Recipient, isQuirkTx, bi.No); err != nil {
 			return err
 		}
 	}
@@ -1028,7 +1272,7 @@ func executeTx(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb
 		sender.SubBalance(txFee)
 	case types.TxType_GOVERNANCE:
 		txFee = new(big.Int).SetUint64(0)
-		events, err = executeGovernanceTx(ccc, bs, txBody, sender, receiver, bi)
+		events, err = executeGovernanceTx(ccc, bs, txBody, tx.GetHash(), sender, receiver, bi)
 		if err != nil {
 			logger.Warn().Err(err).Str("txhash", base58.Encode(tx.GetHash())).Msg("governance tx Error")
 		}
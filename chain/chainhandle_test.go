@@ -5,6 +5,7 @@
 package chain
 
 import (
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"os"
@@ -12,10 +13,12 @@ import (
 
 	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo/v2/account/key"
+	crypto "github.com/aergoio/aergo/v2/account/key/crypto"
 	"github.com/aergoio/aergo/v2/contract"
 	"github.com/aergoio/aergo/v2/internal/common"
 	"github.com/aergoio/aergo/v2/state"
 	"github.com/aergoio/aergo/v2/types"
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,7 +26,7 @@ var sdb *state.ChainStateDB
 var keystore *key.Store
 var chainID []byte
 
-func initTest(t *testing.T, testmode bool) {
+func initTest(t testing.TB, testmode bool) {
 	sdb = state.NewChainStateDB()
 	tmpdir, _ := ioutil.TempDir("", "test")
 	keystore = key.NewStore(tmpdir, 0)
@@ -43,7 +46,7 @@ func deinitTest() {
 	sdb.Close()
 	os.RemoveAll("test")
 }
-func makeTestAddress(t *testing.T) []byte {
+func makeTestAddress(t testing.TB) []byte {
 	addr, err := keystore.CreateKey("test")
 	assert.NoError(t, err, "could not create key")
 	return addr
@@ -137,3 +140,99 @@ func TestBasicExecuteTx(t *testing.T) {
 	err = executeTx(nil, nil, nil, bs, types.NewTransaction(tx), newTestBlockInfo(chainID), contract.ChainService)
 	assert.NoError(t, err, "execute governance type")
 }
+
+func TestExecuteTxToSubName(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+	bs := state.NewBlockState(sdb.GetStateDB())
+	bi := newTestBlockInfo(chainID)
+	bi.ForkVersion = 6
+
+	sender := makeTestAddress(t)
+	receiver := makeTestAddress(t)
+	subName := "pay.AB1234567890"
+	var nonce uint64
+	execute := func(recipient []byte, txType types.TxType, amount *big.Int, payload string) error {
+		nonce++
+		tx := &types.Tx{Body: &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     sender,
+			Recipient:   recipient,
+			Nonce:       nonce,
+			Amount:      amount.Bytes(),
+			Payload:     []byte(payload),
+			Type:        txType,
+		}}
+		signTestAddress(t, tx)
+		return executeTx(nil, nil, nil, bs, types.NewTransaction(tx), bi, contract.ChainService)
+	}
+
+	err := execute([]byte(types.AergoName), types.TxType_GOVERNANCE, types.NewAmount(1, types.Aergo),
+		`{"Name":"v1createName","Args":["AB1234567890"]}`)
+	assert.NoError(t, err, "create name")
+	err = execute([]byte(types.AergoName), types.TxType_GOVERNANCE, big.NewInt(0),
+		fmt.Sprintf(`{"Name":"v1createName","Args":["%s"]}`, subName))
+	assert.NoError(t, err, "create subname")
+	err = execute([]byte(types.AergoName), types.TxType_GOVERNANCE, types.NewAmount(1, types.Aergo),
+		fmt.Sprintf(`{"Name":"v1updateName","Args":["%s","%s"]}`, subName, types.EncodeAddress(receiver)))
+	assert.NoError(t, err, "update subname")
+
+	before, err := state.GetAccountState(receiver, bs.StateDB)
+	assert.NoError(t, err)
+	balance := before.Balance()
+	err = execute([]byte(subName), types.TxType_TRANSFER, big.NewInt(1000), "")
+	assert.NoError(t, err, "send to the subname")
+	after, err := state.GetAccountState(receiver, bs.StateDB)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(balance, big.NewInt(1000)), after.Balance(), "the subname resolves to the receiver")
+}
+
+func TestExecuteTxWithRotatedKey(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+	bs := state.NewBlockState(sdb.GetStateDB())
+	bi := newTestBlockInfo(chainID)
+	bi.ForkVersion = 6
+
+	oldKey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	newKey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	account := crypto.GenerateAddress(oldKey.PubKey().ToECDSA())
+	recipient := makeTestAddress(t)
+	newTx := func(nonce uint64, recipient []byte, txType types.TxType, payload string, signer *btcec.PrivateKey) types.Transaction {
+		tx := &types.Tx{Body: &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     account,
+			Recipient:   recipient,
+			Nonce:       nonce,
+			Payload:     []byte(payload),
+			Type:        txType,
+		}}
+		assert.NoError(t, key.SignTx(tx, signer))
+		return types.NewTransaction(tx)
+	}
+
+	// both txs are valid for the state of the best block, by which the txs of the block are verified
+	rotate := newTx(1, []byte(types.AergoSystem), types.TxType_GOVERNANCE,
+		fmt.Sprintf(`{"Name":"v1rotateKey","Args":["%s"]}`, types.EncodeAddress(newKey.PubKey().SerializeCompressed())), oldKey)
+	err = executeTx(nil, nil, nil, bs, rotate, bi, contract.ChainService)
+	assert.NoError(t, err, "rotate key")
+
+	err = executeTx(nil, nil, nil, bs, newTx(2, recipient, types.TxType_NORMAL, "", oldKey), bi, contract.ChainService)
+	assert.EqualError(t, err, types.ErrSignNotMatch.Error(), "signed with the old key after the rotation")
+	err = executeTx(nil, nil, nil, bs, newTx(2, recipient, types.TxType_NORMAL, "", newKey), bi, contract.ChainService)
+	assert.NoError(t, err, "signed with the new key")
+
+	// the tx of a name account is checked by the key of the owner
+	tx := newTx(3, []byte(types.AergoName), types.TxType_GOVERNANCE, `{"Name":"v1createName","Args":["AB1234567890"]}`, newKey)
+	tx.GetTx().Body.Amount = types.NewAmount(1, types.Aergo).Bytes()
+	assert.NoError(t, key.SignTx(tx.GetTx(), newKey))
+	err = executeTx(nil, nil, nil, bs, tx, bi, contract.ChainService)
+	assert.NoError(t, err, "create name")
+	account = []byte("AB1234567890")
+	err = executeTx(nil, nil, nil, bs, newTx(4, recipient, types.TxType_NORMAL, "", oldKey), bi, contract.ChainService)
+	assert.EqualError(t, err, types.ErrSignNotMatch.Error(), "signed by the name owner with the old key")
+	err = executeTx(nil, nil, nil, bs, newTx(4, recipient, types.TxType_NORMAL, "", newKey), bi, contract.ChainService)
+	assert.NoError(t, err, "signed by the name owner with the new key")
+}
@@ -6,6 +6,8 @@
 package chain
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/aergoio/aergo-lib/db"
@@ -16,6 +18,7 @@ import (
 	"runtime"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-lib/log"
@@ -190,11 +193,24 @@ type IChainHandler interface {
 	getReceipts(blockHash []byte) (*types.Receipts, error)
 	getReceiptsByNo(blockNo types.BlockNo) (*types.Receipts, error)
 	getInternalOperations(blockNo types.BlockNo) (string, error)
+	traceTx(txHash []byte) (string, error)
+	profileTx(tx *types.Tx) (string, error)
+	getScheduledCallReceipts(blockNo types.BlockNo) (string, error)
+	listScheduledCalls(addr []byte) (string, error)
+	verifyContractSource(addr []byte, source string) (*types.ContractSource, error)
+	getContractSource(addr []byte) (*types.ContractSource, error)
 	getAccountVote(addr []byte) (*types.AccountVoteInfo, error)
 	getVotes(id string, n uint32) (*types.VoteList, error)
 	getStaking(addr []byte) (*types.Staking, error)
+	getKeyRotations(addr []byte) (*types.KeyRotationList, error)
+	getDelegations(addr []byte) (*types.DelegationList, error)
+	getSystemParams() (*types.SystemParamList, error)
 	getNameInfo(name string, blockNo types.BlockNo) (*types.NameInfo, error)
+	getReverseName(addr []byte) (*types.NameInfo, error)
+	getNameRecords(name string, blockNo types.BlockNo) (*types.NameRecordList, error)
 	getEnterpriseConf(key string) (*types.EnterpriseConfig, error)
+	listEnterpriseHistory(query *types.EnterpriseHistoryQuery) (*types.EnterpriseHistoryList, error)
+	listEnterpriseProposals() (*types.EnterpriseProposalList, error)
 	addBlock(newBlock *types.Block, usedBstate *state.BlockState, peerID types.PeerID) error
 	getAnchorsNew() (ChainAnchor, types.BlockNo, error)
 	findAncestor(Hashes [][]byte) (*types.BlockInfo, error)
@@ -453,14 +469,27 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetReceipts,
 		*message.GetReceiptsByNo,
 		*message.GetInternalOperations,
+		*message.TraceTx,
+		*message.ProfileTx,
+		*message.GetScheduledCallReceipts,
+		*message.ListScheduledCalls,
+		*message.VerifyContractSource,
+		*message.GetContractSource,
 		*message.GetABI,
 		*message.GetQuery,
 		*message.GetStateQuery,
 		*message.GetElected,
 		*message.GetVote,
 		*message.GetStaking,
+		*message.GetKeyRotations,
+		*message.GetDelegations,
+		*message.GetSystemParams,
 		*message.GetNameInfo,
+		*message.GetReverseName,
+		*message.GetNameRecords,
 		*message.GetEnterpriseConf,
+		*message.ListEnterpriseHistory,
+		*message.ListEnterpriseProposals,
 		*message.GetParams,
 		*message.ListEvents,
 		*message.CheckFeeDelegation:
@@ -553,6 +582,94 @@ func (cs *ChainService) getAccountVote(addr []byte) (*types.AccountVoteInfo, err
 	return &types.AccountVoteInfo{Voting: voteInfo}, nil
 }
 
+// listScheduledCalls returns the queued scheduled calls of the contract in json, or all of them if addr is empty.
+func (cs *ChainService) listScheduledCalls(addr []byte) (string, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	scs, err := statedb.GetSystemAccountState(sdb)
+	if err != nil {
+		return "", err
+	}
+	var contract string
+	if len(addr) != 0 {
+		address, err := getAddressNameResolved(sdb, addr)
+		if err != nil {
+			return "", err
+		}
+		contract = types.EncodeAddress(address)
+	}
+	calls, err := system.ListScheduledCalls(scs, contract)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(calls)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// verifyContractSource compiles the source and registers it on the node if it matches the deployed code of the
+// contract.
+func (cs *ChainService) verifyContractSource(addr []byte, source string) (*types.ContractSource, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	address, err := getAddressNameResolved(sdb, addr)
+	if err != nil {
+		return nil, err
+	}
+	ctrState, err := statedb.OpenContractStateAccount(address, sdb)
+	if err != nil {
+		return nil, err
+	}
+	typedABI, err := contract.VerifySource(ctrState, source)
+	if err != nil {
+		return nil, err
+	}
+	verified := &types.ContractSource{
+		ContractAddress: address,
+		Source:          source,
+		CodeHash:        ctrState.GetCodeHash(),
+		TypedAbi:        typedABI,
+		VerifiedBlockNo: cs.cdb.getBestBlockNo(),
+		VerifiedTime:    time.Now().Unix(),
+	}
+	if err = cs.cdb.writeContractSource(verified); err != nil {
+		return nil, err
+	}
+	logger.Info().Str("contract", types.EncodeAddress(address)).Msg("contract source verified")
+	return verified, nil
+}
+
+// getContractSource returns the verified source of the contract, or the source deployed on chain from the hardfork
+// version 4.
+func (cs *ChainService) getContractSource(addr []byte) (*types.ContractSource, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	address, err := getAddressNameResolved(sdb, addr)
+	if err != nil {
+		return nil, err
+	}
+	ctrState, err := statedb.OpenContractStateAccount(address, sdb)
+	if err != nil {
+		return nil, err
+	}
+	verified, err := cs.cdb.getContractSource(address)
+	if err != nil {
+		return nil, err
+	}
+	// the registered source is stale if the contract is redeployed
+	if verified != nil && bytes.Equal(verified.CodeHash, ctrState.GetCodeHash()) {
+		return verified, nil
+	}
+	if source := ctrState.GetSourceCode(); len(source) > 0 {
+		return &types.ContractSource{
+			ContractAddress: address,
+			Source:          string(source),
+			CodeHash:        ctrState.GetCodeHash(),
+			OnChain:         true,
+		}, nil
+	}
+	return nil, errors.New("the source of the contract is not verified")
+}
+
 func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	if cs.GetType() != consensus.ConsensusDPOS {
 		return nil, ErrNotSupportedConsensus
@@ -574,23 +691,93 @@ func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	return staking, nil
 }
 
+func (cs *ChainService) getKeyRotations(addr []byte) (*types.KeyRotationList, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	scs, err := statedb.GetSystemAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	namescs, err := statedb.GetNameAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	return system.GetKeyRotations(scs, name.GetAddress(namescs, addr))
+}
+
+func (cs *ChainService) getDelegations(addr []byte) (*types.DelegationList, error) {
+	if cs.GetType() != consensus.ConsensusDPOS {
+		return nil, ErrNotSupportedConsensus
+	}
+
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	scs, err := statedb.GetSystemAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	namescs, err := statedb.GetNameAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	return system.GetDelegations(scs, name.GetAddress(namescs, addr))
+}
+
+func (cs *ChainService) getSystemParams() (*types.SystemParamList, error) {
+	if cs.GetType() != consensus.ConsensusDPOS {
+		return nil, ErrNotSupportedConsensus
+	}
+
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	scs, err := statedb.GetSystemAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	return system.GetSystemParams(scs)
+}
+
 func (cs *ChainService) getNameInfo(qname string, blockNo types.BlockNo) (*types.NameInfo, error) {
+	ncs, blockNo, err := cs.getNameState(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	return name.GetNameInfo(ncs, qname, blockNo)
+}
+
+func (cs *ChainService) getReverseName(addr []byte) (*types.NameInfo, error) {
+	ncs, blockNo, err := cs.getNameState(0)
+	if err != nil {
+		return nil, err
+	}
+	return name.GetReverseName(ncs, addr, blockNo)
+}
+
+func (cs *ChainService) getNameRecords(qname string, blockNo types.BlockNo) (*types.NameRecordList, error) {
+	ncs, blockNo, err := cs.getNameState(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	return name.GetNameRecords(ncs, qname, blockNo)
+}
+
+// getNameState opens the state of the name contract at the block, or at the
+// best block if blockNo is 0.
+func (cs *ChainService) getNameState(blockNo types.BlockNo) (*statedb.ContractState, types.BlockNo, error) {
 	var stateDB *statedb.StateDB
 	if blockNo != 0 {
 		block, err := cs.cdb.GetBlockByNo(blockNo)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		stateDB = cs.sdb.OpenNewStateDB(block.GetHeader().GetBlocksRootHash())
 	} else {
 		stateDB = cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+		blockNo = cs.getBestBlockNo()
 	}
 
 	ncs, err := statedb.GetNameAccountState(stateDB)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return name.GetNameInfo(ncs, qname)
+	return ncs, blockNo, nil
 }
 
 func (cs *ChainService) getEnterpriseConf(key string) (*types.EnterpriseConfig, error) {
@@ -605,6 +792,26 @@ func (cs *ChainService) getEnterpriseConf(key string) (*types.EnterpriseConfig,
 	return enterprise.GetAdmin(ecs)
 }
 
+func (cs *ChainService) listEnterpriseHistory(query *types.EnterpriseHistoryQuery) (*types.EnterpriseHistoryList, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	ecs, err := statedb.GetEnterpriseAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	return enterprise.ListHistory(ecs, query)
+}
+
+// listEnterpriseProposals returns the proposals which can be approved in the
+// next block.
+func (cs *ChainService) listEnterpriseProposals() (*types.EnterpriseProposalList, error) {
+	sdb := cs.sdb.OpenNewStateDB(cs.sdb.GetRoot())
+	ecs, err := statedb.GetEnterpriseAccountState(sdb)
+	if err != nil {
+		return nil, err
+	}
+	return enterprise.ListProposals(ecs, cs.getBestBlockNo()+1)
+}
+
 func (cs *ChainService) getSystemValue(key types.SystemValue) (*big.Int, error) {
 	switch key {
 	case types.StakingTotal:
@@ -827,6 +1034,48 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Operations: operations,
 			Err:        err,
 		})
+	case *message.TraceTx:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		trace, err := cw.traceTx(msg.TxHash)
+		context.Respond(message.TraceTxRsp{
+			Trace: trace,
+			Err:   err,
+		})
+	case *message.ProfileTx:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		profile, err := cw.profileTx(msg.Tx)
+		context.Respond(message.ProfileTxRsp{
+			Profile: profile,
+			Err:     err,
+		})
+	case *message.GetScheduledCallReceipts:
+		receipts, err := cw.getScheduledCallReceipts(msg.BlockNo)
+		context.Respond(message.GetScheduledCallReceiptsRsp{
+			Receipts: receipts,
+			Err:      err,
+		})
+	case *message.ListScheduledCalls:
+		calls, err := cw.listScheduledCalls(msg.Contract)
+		context.Respond(message.ListScheduledCallsRsp{
+			Calls: calls,
+			Err:   err,
+		})
+	case *message.VerifyContractSource:
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		source, err := cw.verifyContractSource(msg.Contract, msg.Source)
+		context.Respond(message.VerifyContractSourceRsp{
+			Source: source,
+			Err:    err,
+		})
+	case *message.GetContractSource:
+		source, err := cw.getContractSource(msg.Contract)
+		context.Respond(message.GetContractSourceRsp{
+			Source: source,
+			Err:    err,
+		})
 	case *message.GetABI:
 		sdb = cw.sdb.OpenNewStateDB(cw.sdb.GetRoot())
 		address, err := getAddressNameResolved(sdb, msg.Contract)
@@ -918,12 +1167,54 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Staking: staking,
 			Err:     err,
 		})
+	case *message.GetKeyRotations:
+		rotations, err := cw.getKeyRotations(msg.Addr)
+		context.Respond(&message.GetKeyRotationsRsp{
+			Rotations: rotations,
+			Err:       err,
+		})
+	case *message.GetDelegations:
+		delegations, err := cw.getDelegations(msg.Addr)
+		context.Respond(&message.GetDelegationsRsp{
+			Delegations: delegations,
+			Err:         err,
+		})
+	case *message.GetSystemParams:
+		params, err := cw.getSystemParams()
+		context.Respond(&message.GetSystemParamsRsp{
+			Params: params,
+			Err:    err,
+		})
 	case *message.GetNameInfo:
 		owner, err := cw.getNameInfo(msg.Name, msg.BlockNo)
 		context.Respond(&message.GetNameInfoRsp{
 			Owner: owner,
 			Err:   err,
 		})
+	case *message.GetReverseName:
+		info, err := cw.getReverseName(msg.Addr)
+		context.Respond(&message.GetReverseNameRsp{
+			Info: info,
+			Err:  err,
+		})
+	case *message.GetNameRecords:
+		records, err := cw.getNameRecords(msg.Name, msg.BlockNo)
+		context.Respond(&message.GetNameRecordsRsp{
+			Records: records,
+			Err:     err,
+		})
+	case *message.ListEnterpriseHistory:
+		history, err := cw.listEnterpriseHistory(msg.Query)
+		context.Respond(&message.ListEnterpriseHistoryRsp{
+			History: history,
+			Err:     err,
+		})
+	case *message.ListEnterpriseProposals:
+		proposals, err := cw.listEnterpriseProposals()
+		context.Respond(&message.ListEnterpriseProposalsRsp{
+			Proposals: proposals,
+			Err:       err,
+		})
 	case *message.GetEnterpriseConf:
 		conf, err := cw.getEnterpriseConf(msg.Key)
 		context.Respond(&message.GetEnterpriseConfRsp{
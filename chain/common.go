@@ -9,6 +9,7 @@ import (
 	"errors"
 
 	"github.com/aergoio/aergo/v2/consensus"
+	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/types"
 )
@@ -22,7 +23,6 @@ var (
 
 	// maxBlockBodySize is the upper limit of block size.
 	maxBlockBodySize uint32
-	maxBlockSize     uint32
 	pubNet           bool
 	mainNet          bool
 	consensusName    string
@@ -90,14 +90,26 @@ func initChainParams(genesis *types.Genesis) {
 	Genesis = genesis
 }
 
-// MaxBlockBodySize returns the max block body size.
+// MaxBlockBodySize returns the max block body size. The size voted as a
+// system param overrides the node config.
 func MaxBlockBodySize() uint32 {
+	if size := system.GetMaxBlockBodySize(); size != 0 {
+		return size
+	}
 	return maxBlockBodySize
 }
 
 // MaxBlockSize returns the max block size.
 func MaxBlockSize() uint32 {
-	return maxBlockSize
+	return MaxBlockBodySize() + types.DefaultMaxHdrSize
+}
+
+// MaxBlockSizeLimit returns the hard limit of the block size, which bounds the
+// blocks received from the peers. MaxBlockSize can't bound them, since the
+// voted block size is active from its activation block, which a syncing node
+// may not have reached yet.
+func MaxBlockSizeLimit() uint32 {
+	return types.BlockSizeHardLimit() + types.DefaultMaxHdrSize
 }
 
 func setMaxBlockBodySize(size uint32) {
@@ -109,7 +121,6 @@ func setMaxBlockBodySize(size uint32) {
 
 func setBlockSizeLimit(maxBlockBodySize uint32) {
 	setMaxBlockBodySize(maxBlockBodySize)
-	maxBlockSize = MaxBlockBodySize() + types.DefaultMaxHdrSize
 }
 
 func setConsensusName(val string) error {
@@ -18,7 +18,7 @@ import (
 	"github.com/aergoio/aergo/v2/types"
 )
 
-func executeGovernanceTx(ccc consensus.ChainConsensusCluster, bs *state.BlockState, txBody *types.TxBody, sender, receiver *state.AccountState,
+func executeGovernanceTx(ccc consensus.ChainConsensusCluster, bs *state.BlockState, txBody *types.TxBody, txHash []byte, sender, receiver *state.AccountState,
 	blockInfo *types.BlockHeaderInfo) ([]*types.Event, error) {
 
 	if len(txBody.Payload) <= 0 {
@@ -30,7 +30,6 @@ func executeGovernanceTx(ccc consensus.ChainConsensusCluster, bs *state.BlockSta
 	if err != nil {
 		return nil, err
 	}
-	blockNo := blockInfo.No
 	var events []*types.Event
 	switch governance {
 	case types.AergoSystem:
@@ -38,7 +37,7 @@ func executeGovernanceTx(ccc consensus.ChainConsensusCluster, bs *state.BlockSta
 	case types.AergoName:
 		events, err = name.ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
 	case types.AergoEnterprise:
-		events, err = enterprise.ExecuteEnterpriseTx(bs, ccc, scs, txBody, sender, receiver, blockNo)
+		events, err = enterprise.ExecuteEnterpriseTx(bs, ccc, scs, txBody, txHash, sender, receiver, blockInfo)
 		if err != nil {
 			err = contract.NewGovEntErr(err)
 		}
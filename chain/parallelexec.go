@@ -0,0 +1,154 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/aergoio/aergo/v2/consensus"
+	"github.com/aergoio/aergo/v2/contract"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+var errNotSpeculated = errors.New("tx is not executed speculatively")
+
+// txSpeculateFn executes a transaction speculatively on an overlay state.
+type txSpeculateFn func(bState *state.BlockState, tx types.Transaction, s *contract.Speculation) error
+
+// txSpeculation is a transaction executed on its own overlay of the state of the block start.
+type txSpeculation struct {
+	bs     *state.BlockState
+	access *statedb.AccessSet
+	err    error
+}
+
+// newTxSpeculator returns a txSpeculateFn which executes a transaction on an overlay state without logging its error
+// or rolling back the state, since a failed speculation is executed again on the block state.
+func newTxSpeculator(execCtx context.Context, ccc consensus.ChainConsensusCluster, cdb contract.ChainAccessor, bi *types.BlockHeaderInfo, executionMode int) txSpeculateFn {
+	return func(bState *state.BlockState, tx types.Transaction, s *contract.Speculation) error {
+		if bi.ForkVersion < 0 {
+			return ErrInvalidBlockHeader
+		}
+		return executeTx(contract.WithSpeculation(execCtx, s), ccc, cdb, bState, tx, bi, executionMode)
+	}
+}
+
+// executeTxsParallel executes the txs of the block speculatively in parallel, each on its own overlay of the
+// state of the block start, while recording the account states and the storage keys of the contracts which it
+// reads and writes. Then the results are applied to the block state in block order. A tx which has read an account
+// state or a storage key written by a previous tx of the block, or which could not be speculated, is executed again
+// on the block state, so the state and the receipts are identical to the sequential execution.
+//
+// The balance transfers, the contract calls and the deploys are speculated. The vm aborts the speculation of a tx
+// opening a sql database or running the governance, since the sql databases and the system parameters are out of
+// the account states. The governance, fee delegation and redeploy txs are always executed on the block state.
+func (e *blockExecutor) executeTxsParallel() error {
+	var (
+		root  = e.GetRoot()
+		specs = make([]*txSpeculation, len(e.txs))
+		next  = make(chan int)
+		wg    sync.WaitGroup
+	)
+	for w := 0; w < e.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				specs[i] = e.speculateTx(root, e.txs[i])
+			}
+		}()
+	}
+	for i := range e.txs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var (
+		written   = statedb.NewAccessSet()
+		reexecute int
+	)
+	for i, tx := range e.txs {
+		spec := specs[i]
+		if spec.err == nil && !spec.access.Conflicts(written) {
+			if err := e.applySpeculation(spec); err != nil {
+				return err
+			}
+			written.Merge(spec.access)
+			continue
+		}
+		reexecute++
+		access := statedb.NewAccessSet()
+		e.SetAccessSet(access)
+		err := e.execTx(e.BlockState, types.NewTransaction(tx))
+		e.SetAccessSet(nil)
+		if err != nil {
+			return err
+		}
+		written.Merge(access)
+	}
+	logger.Debug().Int("txCount", len(e.txs)).Int("reexecuted", reexecute).Msg("executed txs in parallel")
+	return nil
+}
+
+func (e *blockExecutor) speculateTx(root []byte, tx *types.Tx) *txSpeculation {
+	access := statedb.NewAccessSet()
+	sdb := statedb.NewStateDB(e.StateDB.Store, root, e.StateDB.Testmode)
+	sdb.SetAccessSet(access)
+	bs := state.NewBlockState(sdb, state.SetPrevBlockHash(e.PrevBlockHash()), state.SetGasPrice(e.GasPrice))
+
+	spec := &txSpeculation{bs: bs, access: access}
+	if !isSpeculative(tx) {
+		spec.err = errNotSpeculated
+		return spec
+	}
+	var s contract.Speculation
+	if spec.err = e.speculate(bs, types.NewTransaction(tx), &s); spec.err == nil && s.Aborted() {
+		spec.err = errNotSpeculated
+	}
+	return spec
+}
+
+// applySpeculation puts the account states and the storage keys written by the speculated tx to the block state,
+// and adds its receipt, internal operations and fee.
+func (e *blockExecutor) applySpeculation(spec *txSpeculation) error {
+	spec.bs.SetAccessSet(nil)
+	ids := spec.access.WrittenStates()
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	for _, id := range ids {
+		st, err := spec.bs.GetState(id)
+		if err != nil {
+			return err
+		}
+		if err = e.PutState(id, st); err != nil {
+			return err
+		}
+	}
+	e.CopyStorage(spec.bs.StateDB, spec.access)
+	return e.AddTxResults(spec.bs)
+}
+
+// isSpeculative tells whether the tx may be executed speculatively. The governance txs, including the name txs,
+// change the global state of the node, the check of the fee delegation may open a sql database out of the vm
+// context of the tx, and the redeploy invalidates the code cached in the block state.
+func isSpeculative(tx *types.Tx) bool {
+	body := tx.GetBody()
+	switch body.GetType() {
+	case types.TxType_NORMAL, types.TxType_TRANSFER, types.TxType_CALL, types.TxType_DEPLOY, types.TxType_MULTICALL:
+	default:
+		return false
+	}
+	// the txs to the system accounts before the governance type
+	return !types.IsSpecialAccount(body.GetRecipient())
+}
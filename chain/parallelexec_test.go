@@ -0,0 +1,329 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
+	"github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/contract"
+	"github.com/aergoio/aergo/v2/contract/system"
+	"github.com/aergoio/aergo/v2/internal/common"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testWorkers is the number of the workers speculating the txs in the tests.
+const testWorkers = 4
+
+// executeTestTxs executes the txs on a new block state at the root of sdb, sequentially or speculatively in
+// parallel, and returns the updated block state.
+func executeTestTxs(t testing.TB, bi *types.BlockHeaderInfo, txs []*types.Tx, parallel bool) (*state.BlockState, error) {
+	bs := state.NewBlockState(sdb.OpenNewStateDB(sdb.GetRoot()), state.SetGasPrice(system.GetGasPrice()))
+	e := &blockExecutor{
+		BlockState: bs,
+		execTx:     NewTxExecutor(context.Background(), nil, nil, bi, contract.ChainService),
+		txs:        txs,
+		bi:         bi,
+	}
+	if parallel {
+		e.speculate = newTxSpeculator(context.Background(), nil, nil, bi, contract.ChainService)
+		e.workers = testWorkers
+	}
+	if err := e.executeTxs(); err != nil {
+		return nil, err
+	}
+	assert.NoError(t, bs.Update())
+	return bs, nil
+}
+
+// checkParallelExecution is the differential test of the parallel execution against the sequential one.
+func checkParallelExecution(t *testing.T, bi *types.BlockHeaderInfo, txs []*types.Tx) {
+	seq, seqErr := executeTestTxs(t, bi, txs, false)
+	par, parErr := executeTestTxs(t, bi, txs, true)
+	if seqErr != nil || parErr != nil {
+		assert.Equal(t, seqErr, parErr, "different error")
+		return
+	}
+	assert.Equal(t, seq.GetRoot(), par.GetRoot(), "different state root")
+	assert.Equal(t, seq.Receipts().MerkleRoot(), par.Receipts().MerkleRoot(), "different receipts root")
+	assert.Equal(t, seq.Receipts().Get(), par.Receipts().Get(), "different receipts")
+	assert.Equal(t, 0, seq.BpReward.Cmp(&par.BpReward), "different bp reward")
+	assert.Equal(t, seq.InternalOps(), par.InternalOps(), "different internal operations")
+}
+
+// newTestTxs generates random txs between the accounts. Some of them send the balance from the same sender or to
+// the same recipient, and some stake by the governance, so that they conflict each other.
+func newTestTxs(t *testing.T, r *rand.Rand, accounts [][]byte, count int) []*types.Tx {
+	nonces := make([]uint64, len(accounts))
+	staked := make([]bool, len(accounts))
+	txs := make([]*types.Tx, 0, count)
+	for len(txs) < count {
+		from := r.Intn(len(accounts))
+		body := &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     accounts[from],
+			Nonce:       nonces[from] + 1,
+			Type:        types.TxType_TRANSFER,
+		}
+		switch n := r.Intn(10); {
+		case n == 0 && !staked[from]:
+			body.Type = types.TxType_GOVERNANCE
+			body.Recipient = []byte(types.AergoSystem)
+			body.Amount = types.StakingMinimum.Bytes()
+			body.Payload = []byte(`{"Name":"v1stake"}`)
+			staked[from] = true
+		case n == 1:
+			body.Recipient = makeTestAddress(t)
+			body.Amount = new(big.Int).SetUint64(uint64(r.Intn(1000) + 1)).Bytes()
+		default:
+			body.Recipient = accounts[r.Intn(len(accounts))]
+			body.Amount = new(big.Int).SetUint64(uint64(r.Intn(1000) + 1)).Bytes()
+		}
+		tx := &types.Tx{Body: body}
+		assert.NoError(t, keystore.SignTx(tx, nil), "could not sign tx")
+		nonces[from]++
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// newTestAccounts creates the accounts whose keys are unlocked to sign the txs.
+func newTestAccounts(t testing.TB, count int) [][]byte {
+	accounts := make([][]byte, count)
+	for i := range accounts {
+		accounts[i] = makeTestAddress(t)
+		_, err := keystore.Unlock(accounts[i], "test")
+		assert.NoError(t, err, "could not unlock key")
+	}
+	return accounts
+}
+
+func TestParallelExecuteTxs(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+
+	bi := newTestBlockInfo(chainID)
+	accounts := newTestAccounts(t, 8)
+	for seed := int64(0); seed < 10; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		checkParallelExecution(t, bi, newTestTxs(t, r, accounts, 50))
+	}
+
+	// disjoint transfers
+	var txs []*types.Tx
+	for i := 0; i < len(accounts); i += 2 {
+		tx := &types.Tx{Body: &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     accounts[i],
+			Recipient:   accounts[i+1],
+			Nonce:       1,
+			Amount:      new(big.Int).SetUint64(1000).Bytes(),
+			Type:        types.TxType_TRANSFER,
+		}}
+		assert.NoError(t, keystore.SignTx(tx, nil), "could not sign tx")
+		txs = append(txs, tx)
+	}
+	checkParallelExecution(t, bi, txs)
+
+	// invalid nonce fails the block in both ways
+	txs = append(txs, txs[0])
+	checkParallelExecution(t, bi, txs)
+}
+
+// testCounterContract counts the calls by the key, and calls the other contract to count there too.
+const testCounterContract = `
+function inc(key)
+	system.setItem(key, (system.getItem(key) or 0) + 1)
+end
+
+function incOther(addr, key)
+	inc(key)
+	contract.call(addr, "inc", key)
+end
+
+function get(key)
+	return system.getItem(key)
+end
+
+abi.register(inc, incOther)
+abi.register_view(get)
+`
+
+// initTestVm prepares the vm to execute the contract txs, with the context slots of the speculation.
+func initTestVm(t testing.TB) {
+	assert.NoError(t, contract.LoadTestDatabase(t.TempDir()))
+	contract.StartLStateFactory(16, config.GetDefaultNumLStateClosers(), 1)
+	contract.InitContext(testWorkers+2, false)
+}
+
+// deployTestContracts deploys the counter contracts at the root of sdb.
+func deployTestContracts(t testing.TB, bi *types.BlockHeaderInfo, count int) [][]byte {
+	deployer := newTestAccounts(t, 1)[0]
+	bs := state.NewBlockState(sdb.OpenNewStateDB(sdb.GetRoot()), state.SetGasPrice(system.GetGasPrice()))
+	contracts := make([][]byte, count)
+	for i := range contracts {
+		tx := &types.Tx{Body: &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     deployer,
+			Nonce:       uint64(i + 1),
+			Payload:     util.NewLuaCodePayload([]byte(testCounterContract), nil),
+			Type:        types.TxType_DEPLOY,
+		}}
+		assert.NoError(t, keystore.SignTx(tx, nil), "could not sign tx")
+		assert.NoError(t, executeTx(nil, nil, nil, bs, types.NewTransaction(tx), bi, contract.ChainService))
+		contracts[i] = contract.CreateContractID(deployer, uint64(i+1))
+	}
+	for _, r := range bs.Receipts().Get() {
+		assert.Equal(t, "CREATED", r.Status, r.Ret)
+	}
+	assert.NoError(t, sdb.Apply(bs))
+	return contracts
+}
+
+// newTestContractTxs generates random calls of the counter contracts with a few keys, so that some of them write
+// the same storage keys, and some transfers between the accounts.
+func newTestContractTxs(t *testing.T, r *rand.Rand, accounts, contracts [][]byte, count int) []*types.Tx {
+	nonces := make([]uint64, len(accounts))
+	txs := make([]*types.Tx, 0, count)
+	for len(txs) < count {
+		from := r.Intn(len(accounts))
+		body := &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     accounts[from],
+			Nonce:       nonces[from] + 1,
+			Recipient:   contracts[r.Intn(len(contracts))],
+			Type:        types.TxType_CALL,
+		}
+		key := fmt.Sprintf("key%d", r.Intn(3))
+		switch n := r.Intn(10); {
+		case n < 4:
+			body.Payload = []byte(fmt.Sprintf(`{"Name":"inc","Args":["%s"]}`, key))
+		case n < 6:
+			other := types.EncodeAddress(contracts[r.Intn(len(contracts))])
+			body.Payload = []byte(fmt.Sprintf(`{"Name":"incOther","Args":["%s","%s"]}`, other, key))
+		case n == 6:
+			// the function is not payable, so the call fails
+			body.Payload = []byte(fmt.Sprintf(`{"Name":"inc","Args":["%s"]}`, key))
+			body.Amount = new(big.Int).SetUint64(1000).Bytes()
+		default:
+			body.Recipient = accounts[r.Intn(len(accounts))]
+			body.Amount = new(big.Int).SetUint64(uint64(r.Intn(1000) + 1)).Bytes()
+			body.Type = types.TxType_TRANSFER
+		}
+		tx := &types.Tx{Body: body}
+		assert.NoError(t, keystore.SignTx(tx, nil), "could not sign tx")
+		nonces[from]++
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func TestParallelExecuteContractTxs(t *testing.T) {
+	initTest(t, true)
+	defer deinitTest()
+	initTestVm(t)
+
+	bi := newTestBlockInfo(chainID)
+	bi.ForkVersion = 6
+	contracts := deployTestContracts(t, bi, 2)
+	accounts := newTestAccounts(t, 8)
+	for seed := int64(0); seed < 10; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		checkParallelExecution(t, bi, newTestContractTxs(t, r, accounts, contracts, 50))
+	}
+
+	// all the txs count the same key of a contract, so each of them is executed again
+	var txs []*types.Tx
+	for _, account := range accounts {
+		tx := &types.Tx{Body: &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     account,
+			Recipient:   contracts[0],
+			Nonce:       1,
+			Payload:     []byte(`{"Name":"inc","Args":["key"]}`),
+			Type:        types.TxType_CALL,
+		}}
+		assert.NoError(t, keystore.SignTx(tx, nil), "could not sign tx")
+		txs = append(txs, tx)
+	}
+	checkParallelExecution(t, bi, txs)
+	bs, err := executeTestTxs(t, bi, txs, true)
+	assert.NoError(t, err)
+	for _, r := range bs.Receipts().Get() {
+		assert.Equal(t, "SUCCESS", r.Status, r.Ret)
+	}
+}
+
+// newDisjointTestTxs generates the txs of new accounts which don't conflict each other: the transfers between the
+// pairs of the accounts, or the calls of the contract counting a key of their own.
+func newDisjointTestTxs(b *testing.B, count int, contractID []byte) []*types.Tx {
+	txs := make([]*types.Tx, 0, count)
+	for i := 0; i < count; i++ {
+		sender := newTestAccounts(b, 1)[0]
+		body := &types.TxBody{
+			ChainIdHash: common.Hasher(chainID),
+			Account:     sender,
+			Nonce:       1,
+		}
+		if contractID != nil {
+			body.Recipient = contractID
+			body.Payload = []byte(fmt.Sprintf(`{"Name":"inc","Args":["key%d"]}`, i))
+			body.Type = types.TxType_CALL
+		} else {
+			body.Recipient = makeTestAddress(b)
+			body.Amount = new(big.Int).SetUint64(1000).Bytes()
+			body.Type = types.TxType_TRANSFER
+		}
+		tx := &types.Tx{Body: body}
+		assert.NoError(b, keystore.SignTx(tx, nil), "could not sign tx")
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func benchmarkExecuteTxs(b *testing.B, calls, parallel bool) {
+	initTest(b, true)
+	defer deinitTest()
+
+	bi := newTestBlockInfo(chainID)
+	var contractID []byte
+	if calls {
+		initTestVm(b)
+		bi.ForkVersion = 6
+		contractID = deployTestContracts(b, bi, 1)[0]
+	}
+	txs := newDisjointTestTxs(b, 500, contractID)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := executeTestTxs(b, bi, txs, parallel); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The benchmarks compare the both ways on the blocks of the txs which don't conflict, where the parallel execution
+// gains the most.
+func BenchmarkExecuteTxsSerial(b *testing.B) {
+	benchmarkExecuteTxs(b, false, false)
+}
+
+func BenchmarkExecuteTxsParallel(b *testing.B) {
+	benchmarkExecuteTxs(b, false, true)
+}
+
+func BenchmarkExecuteCallsSerial(b *testing.B) {
+	benchmarkExecuteTxs(b, true, false)
+}
+
+func BenchmarkExecuteCallsParallel(b *testing.B) {
+	benchmarkExecuteTxs(b, true, true)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo/v2/account/key"
 	"github.com/aergoio/aergo/v2/contract/name"
+	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/pkg/component"
 	"github.com/aergoio/aergo/v2/state"
@@ -132,19 +133,41 @@ func (sv *SignVerifier) verifyTx(comm component.IComponentRequester, tx *types.T
 		}
 	}
 
-	if tx.NeedNameVerify() {
+	if tx.HasMultisigAccount() {
+		scs, err := statedb.GetSystemAccountState(sv.sdb.GetStateDB())
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to get verify because of opening contract error")
+			return false, err
+		}
+		multisig, err := system.GetMultisig(scs, account)
+		if err != nil {
+			return false, err
+		}
+		err = key.VerifyMultisigTx(tx, multisig)
+		if err != nil {
+			return false, err
+		}
+	} else if tx.NeedNameVerify() {
 		cs, err := statedb.GetNameAccountState(sv.sdb.GetStateDB())
 		if err != nil {
 			logger.Error().Err(err).Msg("failed to get verify because of opening contract error")
 			return false, err
 		}
 		address := name.GetOwner(cs, tx.Body.Account)
-		err = key.VerifyTxWithAddress(tx, address)
+		signingKey, err := sv.getSigningKey(address)
+		if err != nil {
+			return false, err
+		}
+		err = key.VerifyTxWithAddress(tx, signingKey)
 		if err != nil {
 			return false, err
 		}
 	} else {
-		err := key.VerifyTx(tx)
+		signingKey, err := sv.getSigningKey(account)
+		if err != nil {
+			return false, err
+		}
+		err = key.VerifyTxWithAddress(tx, signingKey)
 		if err != nil {
 			return false, err
 		}
@@ -153,6 +176,18 @@ func (sv *SignVerifier) verifyTx(comm component.IComponentRequester, tx *types.T
 	return false, nil
 }
 
+// getSigningKey returns the key which signs the txs of the address with the state of the best block. The key
+// rotated in the block being verified is checked on the execution of the txs, by ValidateWithSenderState or, for the
+// owner of a name account, by verifyRotatedKey.
+func (sv *SignVerifier) getSigningKey(address []byte) ([]byte, error) {
+	state, err := sv.sdb.GetStateDB().GetAccountState(types.ToAccountID(address))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to get verify because of getting account state error")
+		return nil, err
+	}
+	return state.SigningKeyOf(address), nil
+}
+
 func (sv *SignVerifier) RequestVerifyTxs(txlist *types.TxList) {
 	txs := txlist.GetTxs()
 	txLen := len(txs)
@@ -252,3 +287,30 @@ func (sv *SignVerifier) verifyTxsInplace(txlist *types.TxList) (bool, []error) {
 func (sv *SignVerifier) SetSkipMempool(val bool) {
 	sv.skipMempool = val
 }
+
+// verifyRotatedKey verifies the sign of the tx of a name account again with the key of the owner of the name at the
+// execution of the tx, if the owner has rotated its key. The verification before the execution uses the state of the
+// best block, and the txs in the mempool are not verified again, so a tx signed with the old key must be rejected
+// here. The key of the other senders is checked by ValidateWithSenderState.
+func verifyRotatedKey(bs *state.BlockState, tx *types.Tx) error {
+	if !tx.NeedNameVerify() {
+		return nil
+	}
+	scs, err := statedb.GetNameAccountState(bs.StateDB)
+	if err != nil {
+		return err
+	}
+	owner := name.GetOwner(scs, tx.GetBody().GetAccount())
+	if owner == nil {
+		return types.ErrSignNotMatch
+	}
+	signer, err := state.GetAccountState(owner, bs.StateDB)
+	if err != nil {
+		return err
+	}
+	signingKey := signer.State().GetSigningKey()
+	if len(signingKey) == 0 {
+		return nil
+	}
+	return key.VerifyTxWithAddress(tx, signingKey)
+}
@@ -63,7 +63,28 @@ func init() {
 	unstakeCmd.MarkFlagRequired("amount")
 	unstakeCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
 
-	accountCmd.AddCommand(newCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, voteCmd, stakeCmd, unstakeCmd)
+	delegateCmd.Flags().StringVar(&address, "address", "", "account address")
+	delegateCmd.MarkFlagRequired("address")
+	delegateCmd.Flags().StringVar(&to, "to", "", "address of the delegate account")
+	delegateCmd.MarkFlagRequired("to")
+	delegateCmd.Flags().StringVar(&amount, "amount", "0", "amount of the stake to delegate")
+	delegateCmd.MarkFlagRequired("amount")
+	delegateCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+
+	undelegateCmd.Flags().StringVar(&address, "address", "", "account address")
+	undelegateCmd.MarkFlagRequired("address")
+	undelegateCmd.Flags().StringVar(&amount, "amount", "0", "amount of the delegated stake to take back")
+	undelegateCmd.MarkFlagRequired("amount")
+	undelegateCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+
+	rotateKeyCmd.Flags().StringVar(&address, "address", "", "account address")
+	rotateKeyCmd.MarkFlagRequired("address")
+	rotateKeyCmd.Flags().StringVar(&signingKey, "key", "", "address of the new signing key")
+	rotateKeyCmd.MarkFlagRequired("key")
+	rotateKeyCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+
+	accountCmd.AddCommand(newCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, voteCmd, stakeCmd, unstakeCmd,
+		delegateCmd, undelegateCmd, multisigCmd, rotateKeyCmd)
 	rootCmd.AddCommand(accountCmd)
 }
 
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
 	luacEncoding "github.com/aergoio/aergo/v2/cmd/aergoluac/encoding"
@@ -31,6 +32,7 @@ var (
 	contractID    string
 	gas           uint64
 	fullProof     bool
+	profileFile   string
 )
 
 func intListToString(ns []int, word string) string {
@@ -101,6 +103,7 @@ func init() {
 	callCmd.PersistentFlags().BoolVar(&gover, "governance", false, "setting type")
 	callCmd.PersistentFlags().BoolVar(&feeDelegation, "delegation", false, "request fee delegation to contract")
 	callCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+	callCmd.Flags().StringVar(&profileFile, "profile", "", "dry run the call on the node and write the consumed gas to the file in the folded stack format, instead of sending it (needs the permission to control the node)")
 
 	multicallCmd := &cobra.Command{
 		Use: `multicall [flags] <sender> <script>
@@ -114,6 +117,7 @@ func init() {
 	multicallCmd.PersistentFlags().StringVar(&chainIdHash, "chainidhash", "", "chain id hash value encoded by base58")
 	multicallCmd.PersistentFlags().BoolVar(&toJSON, "tojson", false, "display json transaction instead of sending to blockchain")
 	multicallCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+	multicallCmd.Flags().StringVar(&profileFile, "profile", "", "dry run the calls on the node and write the consumed gas to the file in the folded stack format, instead of sending it (needs the permission to control the node)")
 
 	stateQueryCmd := &cobra.Command{
 		Use:   "statequery [flags] <contractAddress> <varname> [varindex]",
@@ -142,6 +146,18 @@ func init() {
 			RunE:  runQueryCmd,
 		},
 		stateQueryCmd,
+		&cobra.Command{
+			Use:   "verify [flags] <contractAddress> <path-to-lua-file>",
+			Short: "Verify that the source matches the deployed code and register it on the node",
+			Args:  cobra.ExactArgs(2),
+			RunE:  runVerifyCmd,
+		},
+		&cobra.Command{
+			Use:   "source [flags] <contractAddress>",
+			Short: "Get the verified source of the contract",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runGetSourceCmd,
+		},
 	)
 	rootCmd.AddCommand(contractCmd)
 }
@@ -291,6 +307,9 @@ func runCallCmd(cmd *cobra.Command, args []string) error {
 		if !abi.HasFunction(args[2]) {
 			return fmt.Errorf("function %v not found in contract at address %s", args[2], args[1])
 		}
+		if err = abi.GetFunction(args[2]).CheckArgs(ci.Args); err != nil {
+			return fmt.Errorf("invalid arguments: %v", err.Error())
+		}
 	}
 
 	amountBigInt, err := jsonrpc.ParseUnit(amount)
@@ -370,6 +389,11 @@ func sendCallTx(cmd *cobra.Command, tx *types.Tx, sender []byte) error {
 		}
 	}
 
+	// the dry run doesn't need the signature
+	if profileFile != "" {
+		return profileCallTx(cmd, tx)
+	}
+
 	if pw == "" {
 		pw, err = getPasswd(cmd, false)
 		if err != nil {
@@ -405,6 +429,42 @@ func sendCallTx(cmd *cobra.Command, tx *types.Tx, sender []byte) error {
 	return nil
 }
 
+// profileCallTx runs the tx with the gas profiler on the node. The consumed gas is written to the profile file, and the
+// size of the state updates to the file with the .storage suffix, both in the folded stack format.
+func profileCallTx(cmd *cobra.Command, tx *types.Tx) error {
+	tx.Hash = tx.CalculateTxHash()
+	msg, err := client.ProfileTx(context.Background(), tx)
+	if err != nil {
+		return fmt.Errorf("failed to profile tx: %v", err.Error())
+	}
+	var profile struct {
+		Status  string `json:"status"`
+		Ret     string `json:"ret,omitempty"`
+		GasUsed uint64 `json:"gasUsed"`
+		FeeUsed string `json:"feeUsed"`
+		Gas     string `json:"gas,omitempty"`
+		Storage string `json:"storage,omitempty"`
+	}
+	if err = json.Unmarshal(msg.Value, &profile); err != nil {
+		return fmt.Errorf("failed to parse the profile: %v", err.Error())
+	}
+	if err = os.WriteFile(profileFile, []byte(profile.Gas), 0644); err != nil {
+		return fmt.Errorf("failed to write the profile: %v", err.Error())
+	}
+	if profile.Storage != "" {
+		if err = os.WriteFile(profileFile+".storage", []byte(profile.Storage), 0644); err != nil {
+			return fmt.Errorf("failed to write the profile: %v", err.Error())
+		}
+	}
+	profile.Gas, profile.Storage = "", ""
+	out, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(out))
+	return nil
+}
+
 func runGetABICmd(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 
@@ -421,6 +481,46 @@ func runGetABICmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runVerifyCmd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	contract, err := types.DecodeAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode address: %v", err.Error())
+	}
+	source, err := luaUtil.ReadContract(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to read code file: %v", err.Error())
+	}
+	verified, err := client.VerifyContractSource(context.Background(), &types.ContractSource{
+		ContractAddress: contract,
+		Source:          source,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify source: %v", err.Error())
+	}
+	res := jsonrpc.ConvContractSource(verified)
+	res.Source = ""
+	cmd.Println(jsonrpc.MarshalJSON(res))
+	return nil
+}
+
+func runGetSourceCmd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	contract, err := types.DecodeAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode address: %v", err.Error())
+	}
+	source, err := client.GetContractSource(context.Background(), &types.SingleBytes{Value: contract})
+	if err != nil {
+		return fmt.Errorf("failed to get source: %v", err.Error())
+	}
+	res := jsonrpc.ConvContractSource(source)
+	cmd.Println(jsonrpc.MarshalJSON(res))
+	return nil
+}
+
 func runQueryCmd(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 
@@ -437,6 +537,14 @@ func runQueryCmd(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to parse JSON: %v", err.Error())
 		}
 	}
+	// the arguments are not checked if the abi is not available, as the node does
+	if abi, err := client.GetABI(context.Background(), &types.SingleBytes{Value: contract}); err == nil {
+		if fn := abi.GetFunction(ci.Name); fn != nil {
+			if err = fn.CheckArgs(ci.Args); err != nil {
+				return fmt.Errorf("invalid arguments: %v", err.Error())
+			}
+		}
+	}
 	callinfo, err := json.Marshal(ci)
 	if err != nil {
 		return fmt.Errorf("failed to encode JSON: %v", err.Error())
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyContractSourceWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testContract := "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	contract, _ := types.DecodeAddress(testContract)
+	source := "function hello() return 'world' end\nabi.register(hello)\n"
+	file := filepath.Join(t.TempDir(), "hello.lua")
+	if err := os.WriteFile(file, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.EXPECT().VerifyContractSource(
+		gomock.Any(),
+		&types.ContractSource{ContractAddress: contract, Source: source},
+	).Return(
+		&types.ContractSource{ContractAddress: contract, Source: source, CodeHash: []byte{1, 2, 3}, VerifiedBlockNo: 10},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "contract", "verify", testContract, file)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testContract, result["address"])
+	assert.Equal(t, float64(10), result["verifiedBlockNo"])
+	assert.Nil(t, result["source"])
+}
+
+func TestGetContractSourceWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testContract := "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	contract, _ := types.DecodeAddress(testContract)
+	source := "function hello() return 'world' end\nabi.register(hello)\n"
+
+	mock.EXPECT().GetContractSource(
+		gomock.Any(),
+		&types.SingleBytes{Value: contract},
+	).Return(
+		&types.ContractSource{ContractAddress: contract, Source: source, OnChain: true},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "contract", "source", testContract)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, source, result["source"])
+	assert.Equal(t, true, result["onChain"])
+}
+
+func TestProfileMulticallWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+	defer func() {
+		profileFile, nonce, chainIdHash = "", 0, ""
+	}()
+
+	testAccount := "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	file := filepath.Join(t.TempDir(), "multicall.folded")
+	profile := `{"status":"SUCCESS","gasUsed":1200,"feeUsed":"60000000000000","gas":"A;run;add:3 1000\nA;run:2;state.set 200\n","storage":"A;run:2 36\n"}`
+
+	mock.EXPECT().ProfileTx(
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(_ interface{}, tx *types.Tx, _ ...interface{}) (*types.SingleBytes, error) {
+		assert.Equal(t, types.TxType_MULTICALL, tx.GetBody().GetType())
+		assert.Equal(t, uint64(3), tx.GetBody().GetNonce())
+		assert.Equal(t, tx.CalculateTxHash(), tx.GetHash())
+		assert.Empty(t, tx.GetBody().GetSign())
+		return &types.SingleBytes{Value: []byte(profile)}, nil
+	}).Times(1)
+
+	output, err := executeCommand(rootCmd, "contract", "multicall", testAccount, `[["let","a",1]]`,
+		"--nonce", "3", "--chainidhash", "7Bnd2Yj6nhjyR3KUQPMfSCG8e5qb5ZyvDnGxGdJzBGbF", "--profile", file)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "SUCCESS", result["status"])
+	assert.Equal(t, float64(1200), result["gasUsed"])
+	assert.Nil(t, result["gas"])
+
+	folded, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "A;run;add:3 1000\nA;run:2;state.set 200\n", string(folded))
+	storage, err := os.ReadFile(file + ".storage")
+	assert.NoError(t, err)
+	assert.Equal(t, "A;run:2 36\n", string(storage))
+}
+
+func TestQueryWithoutABIWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testContract := "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	contract, _ := types.DecodeAddress(testContract)
+
+	mock.EXPECT().GetABI(
+		gomock.Any(),
+		&types.SingleBytes{Value: contract},
+	).Return(
+		nil,
+		errors.New("abi not served"),
+	).Times(1)
+	mock.EXPECT().QueryContract(
+		gomock.Any(),
+		&types.Query{ContractAddress: contract, Queryinfo: []byte(`{"Name":"hello","Args":[1]}`)},
+	).Return(
+		&types.SingleBytes{Value: []byte(`"world"`)},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "contract", "query", testContract, "hello", "[1]")
+	assert.NoError(t, err, "should query without the abi")
+	assert.Contains(t, output, "world")
+}
@@ -26,6 +26,14 @@ var (
 	ccBlockNo uint64
 	timeout   uint64
 
+	historyKey    string
+	historyAdmin  string
+	historyAction string
+	historyFrom   uint64
+	historyTo     uint64
+	historyBefore uint64
+	historyLimit  uint32
+
 	ErrNotExecutedConfChange = errors.New("change cluster request may be not proposed")
 )
 
@@ -34,8 +42,18 @@ func init() {
 
 	enterpriseTxCmd.Flags().Uint64VarP(&timeout, "timeout", "t", 30, "timeout(second) of geting status of enterprise transaction")
 
+	enterpriseHistoryCmd.Flags().StringVar(&historyKey, "key", "", "Config key (admins | <config key>)")
+	enterpriseHistoryCmd.Flags().StringVar(&historyAdmin, "admin", "", "Address of the admin who made the changes")
+	enterpriseHistoryCmd.Flags().StringVar(&historyAction, "action", "", "Name of the enterprise call (e.g. setConf, appendAdmin)")
+	enterpriseHistoryCmd.Flags().Uint64Var(&historyFrom, "from", 0, "First block number of the changes")
+	enterpriseHistoryCmd.Flags().Uint64Var(&historyTo, "to", 0, "Last block number of the changes (default: latest)")
+	enterpriseHistoryCmd.Flags().Uint64Var(&historyBefore, "before", 0, "Print the changes before the sequence number, e.g. the next of the previous output, to page through the history")
+	enterpriseHistoryCmd.Flags().Uint32Var(&historyLimit, "limit", 0, "Maximum number of the changes (default: 100)")
+
 	enterpriseCmd.AddCommand(enterpriseKeyCmd)
 	enterpriseCmd.AddCommand(enterpriseTxCmd)
+	enterpriseCmd.AddCommand(enterpriseHistoryCmd)
+	enterpriseCmd.AddCommand(enterpriseProposalsCmd)
 }
 
 var enterpriseCmd = &cobra.Command{
@@ -71,6 +89,54 @@ var enterpriseKeyCmd = &cobra.Command{
 	},
 }
 
+var enterpriseHistoryCmd = &cobra.Command{
+	Use:   "history [flags]",
+	Short: "Print the change history of enterprise, latest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		query := &aergorpc.EnterpriseHistoryQuery{
+			Key:       historyKey,
+			Action:    historyAction,
+			FromBlock: historyFrom,
+			ToBlock:   historyTo,
+			Before:    historyBefore,
+			Limit:     historyLimit,
+		}
+		if historyAdmin != "" {
+			admin, err := types.DecodeAddress(historyAdmin)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			query.Admin = admin
+		}
+		msg, err := client.ListEnterpriseHistory(context.Background(), query)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvEnterpriseHistory(msg)))
+	},
+}
+
+var enterpriseProposalsCmd = &cobra.Command{
+	Use:   "proposals",
+	Short: "Print the approval policy and the pending proposals of enterprise",
+	Long: "If the threshold of the approval policy is more than 1, a change of enterprise is a proposal, " +
+		"which is applied when the threshold number of admins approve it within the window blocks. " +
+		"Admins approve or reject it by calling approveProposal or rejectProposal of aergo.enterprise with the proposal id. " +
+		"An expired proposal is not printed. Its expire event is in the receipt of the next enterprise tx.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		msg, err := client.ListEnterpriseProposals(context.Background(), &aergorpc.Empty{})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvEnterpriseProposals(msg)))
+	},
+}
+
 func getConfChangeBlockNo(blockHash []byte) (aergorpc.BlockNo, error) {
 	if len(blockHash) == 0 {
 		return 0, fmt.Errorf("failed to get block since blockhash is empty")
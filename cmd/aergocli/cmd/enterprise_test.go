@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -96,3 +97,78 @@ func TestGetConfChangeWithMock(t *testing.T) {
 	assert.NoError(t, err, "should be success")
 	t.Log(output)
 }
+
+func TestEnterpriseHistoryWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	const testAdmin = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	const testTxHashString = "HB44gJvHhVoEfgiGq3VZmV9VUXfBXhHjcEvroBMkJGnY"
+	admin, _ := aergorpc.DecodeAddress(testAdmin)
+	txHash, _ := base58.Decode(testTxHashString)
+
+	mock.EXPECT().ListEnterpriseHistory(
+		gomock.Any(),
+		&aergorpc.EnterpriseHistoryQuery{Key: "p2pwhite", Admin: admin, FromBlock: 10, Limit: 5},
+	).Return(
+		&aergorpc.EnterpriseHistoryList{History: []*aergorpc.EnterpriseHistory{{
+			Seq: 3, Admin: admin, Action: "appendConf", Key: "P2PWHITE",
+			Added: []string{"b"}, BlockNo: 12, TxHash: txHash,
+		}}, Next: 3},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "enterprise", "history", "--key", "p2pwhite", "--admin", testAdmin, "--from", "10", "--limit", "5")
+	assert.NoError(t, err, "should be success")
+
+	var result struct {
+		History []map[string]interface{} `json:"history"`
+		Next    uint64                   `json:"next"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	if assert.Len(t, result.History, 1) {
+		assert.Equal(t, testAdmin, result.History[0]["admin"])
+		assert.Equal(t, "P2PWHITE", result.History[0]["key"])
+		assert.Equal(t, []interface{}{"b"}, result.History[0]["added"])
+		assert.Equal(t, testTxHashString, result.History[0]["txHash"])
+	}
+	assert.Equal(t, uint64(3), result.Next)
+}
+
+func TestEnterpriseProposalsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	const testAdmin = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	admin, _ := aergorpc.DecodeAddress(testAdmin)
+	call := `{"name":"removeAdmin","args":["AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7"]}`
+
+	mock.EXPECT().ListEnterpriseProposals(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&aergorpc.EnterpriseProposalList{Threshold: 2, Window: 100, Proposals: []*aergorpc.EnterpriseProposal{{
+			Id: 7, Proposer: admin, Call: call, BlockNo: 10, ExpireBlock: 110, Approvals: [][]byte{admin},
+		}}},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "enterprise", "proposals")
+	assert.NoError(t, err, "should be success")
+
+	var result struct {
+		Threshold uint64                   `json:"threshold"`
+		Proposals []map[string]interface{} `json:"proposals"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	assert.Equal(t, uint64(2), result.Threshold)
+	if assert.Len(t, result.Proposals, 1) {
+		assert.Equal(t, float64(7), result.Proposals[0]["id"])
+		assert.Equal(t, call, result.Proposals[0]["call"])
+		assert.Equal(t, []interface{}{testAdmin}, result.Proposals[0]["approvals"])
+	}
+}
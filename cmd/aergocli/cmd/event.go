@@ -7,6 +7,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -19,6 +20,7 @@ import (
 var contractAddress string
 var eventName string
 var argFilter string
+var topics []string
 var start uint64
 var end uint64
 var desc bool
@@ -44,6 +46,7 @@ func init() {
 	listCmd.Flags().StringVarP(&contractAddress, "address", "", "", "Contract Address")
 	listCmd.Flags().BoolVar(&desc, "desc", false, "descending order")
 	listCmd.Flags().StringVarP(&argFilter, "argfilter", "", "", "argument filter")
+	listCmd.Flags().StringArrayVar(&topics, "topic", nil, "indexed argument in JSON, in order (empty for any)")
 	listCmd.Flags().Int32Var(&recentBlockCnt, "recent", 0, "recent block count")
 	listCmd.MarkFlagRequired("address")
 
@@ -56,6 +59,7 @@ func init() {
 	streamCmd.Flags().StringVarP(&contractAddress, "address", "", "", "Contract Address")
 	streamCmd.Flags().StringVarP(&eventName, "event", "", "", "Event Name")
 	streamCmd.Flags().StringVarP(&argFilter, "argfilter", "", "", "argument filter")
+	streamCmd.Flags().StringArrayVar(&topics, "topic", nil, "indexed argument in JSON, in order (empty for any)")
 	streamCmd.Flags().IntVar(&maxEvents, "limit", 0, "maximum number of events to receive (0 for unlimited)")
 	streamCmd.Flags().IntVar(&eventTimeout, "timeout", 0, "maximum time to wait in seconds (0 for unlimited)")
 	streamCmd.MarkFlagRequired("address")
@@ -72,6 +76,11 @@ func execListEvent(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	topicFilter, err := parseTopics(topics)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
 	filter := &aergorpc.FilterInfo{
 		Blockfrom:       start,
 		Blockto:         end,
@@ -80,6 +89,7 @@ func execListEvent(cmd *cobra.Command, args []string) {
 		Desc:            desc,
 		ArgFilter:       []byte(argFilter),
 		RecentBlockCnt:  recentBlockCnt,
+		Topics:          topicFilter,
 	}
 
 	events, err := client.ListEvents(context.Background(), filter)
@@ -97,10 +107,16 @@ func execStreamEvent(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err)
 	}
+	topicFilter, err := parseTopics(topics)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		os.Exit(1)
+	}
 	filter := &aergorpc.FilterInfo{
 		ContractAddress: ba,
 		EventName:       eventName,
 		ArgFilter:       []byte(argFilter),
+		Topics:          topicFilter,
 	}
 
 	ctx := context.Background()
@@ -135,3 +151,20 @@ func execStreamEvent(cmd *cobra.Command, args []string) {
 		}
 	}
 }
+
+// parseTopics converts the indexed arguments given in JSON to the topics of the filter.
+func parseTopics(args []string) ([][]byte, error) {
+	var topics [][]byte
+	for _, arg := range args {
+		if len(arg) == 0 {
+			topics = append(topics, nil)
+			continue
+		}
+		topic, err := aergorpc.EventTopic([]byte(arg))
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic %s: %s", arg, err.Error())
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
@@ -27,6 +27,8 @@ func init() {
 	getstateCmd.Flags().BoolVar(&proof, "proof", false, "Get the proof for the state")
 	getstateCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 	getstateCmd.Flags().BoolVar(&staking, "staking", false, "Get the staking info from the address")
+	getstateCmd.Flags().BoolVar(&delegations, "delegations", false, "Get the stake delegations of the address")
+	getstateCmd.Flags().BoolVar(&keyRotations, "keyrotations", false, "Get the signing key and the key rotation history of the address")
 	getstateCmd.Flags().StringVar(&unit, "unit", "aergo", "display unit of balance")
 	rootCmd.AddCommand(getstateCmd)
 }
@@ -63,6 +65,26 @@ func execGetState(cmd *cobra.Command, args []string) {
 
 		return
 	}
+	if delegations {
+		msg, err := client.GetDelegations(context.Background(),
+			&types.AccountAddress{Value: addr})
+		if err != nil {
+			cmd.Printf("Failed: %s", err.Error())
+			return
+		}
+		cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvDelegations(msg)))
+		return
+	}
+	if keyRotations {
+		msg, err := client.GetKeyRotations(context.Background(),
+			&types.AccountAddress{Value: addr})
+		if err != nil {
+			cmd.Printf("Failed: %s", err.Error())
+			return
+		}
+		cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvKeyRotations(msg)))
+		return
+	}
 
 	if !proof {
 		// NOTE GetState first queries the statedb buffer.
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStateKeyRotationsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+	defer func() { keyRotations = false }()
+
+	const testAccount = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	const testKey = "AmNhXiU3s2BN26v5B5hT2bbEjvSjqyrBY7DGnD9UqVcwkTrDYyJN"
+	account, _ := types.DecodeAddress(testAccount)
+	key, _ := types.DecodeAddress(testKey)
+
+	mock.EXPECT().GetKeyRotations(
+		gomock.Any(),
+		&types.AccountAddress{Value: account},
+	).Return(
+		&types.KeyRotationList{
+			Account:    account,
+			SigningKey: key,
+			Rotations:  []*types.KeyRotation{{Pubkey: key, BlockNo: 10, TxHash: []byte{1}}},
+		},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "getstate", "--address", testAccount, "--keyrotations")
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	assert.Equal(t, testAccount, result["account"])
+	assert.Equal(t, testKey, result["signingKey"])
+	assert.Len(t, result["rotations"], 1)
+}
+
+func TestGetStateDelegationsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+	defer func() { delegations = false }()
+
+	const testAccount = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	const testDelegator = "AmNhXiU3s2BN26v5B5hT2bbEjvSjqyrBY7DGnD9UqVcwkTrDYyJN"
+	account, _ := types.DecodeAddress(testAccount)
+	delegator, _ := types.DecodeAddress(testDelegator)
+
+	mock.EXPECT().GetDelegations(
+		gomock.Any(),
+		&types.AccountAddress{Value: account},
+	).Return(
+		&types.DelegationList{
+			Account:    account,
+			Received:   types.StakingMinimum.Bytes(),
+			Delegators: []*types.Delegation{{Delegator: delegator, Amount: types.StakingMinimum.Bytes(), When: 10}},
+		},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "getstate", "--address", testAccount, "--delegations")
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	assert.Equal(t, testAccount, result["account"])
+	assert.Equal(t, types.StakingMinimum.String(), result["received"])
+	assert.Nil(t, result["delegation"])
+	if assert.Len(t, result["delegators"], 1) {
+		d := result["delegators"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, testDelegator, d["delegator"])
+	}
+}
@@ -376,6 +376,46 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetConsensusInfo(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsensusInfo", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetConsensusInfo), varargs...)
 }
 
+// GetContractSource mocks base method
+func (m *MockAergoRPCServiceClient) GetContractSource(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.ContractSource, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetContractSource", varargs...)
+	ret0, _ := ret[0].(*types.ContractSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractSource indicates an expected call of GetContractSource
+func (mr *MockAergoRPCServiceClientMockRecorder) GetContractSource(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractSource", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetContractSource), varargs...)
+}
+
+// GetDelegations mocks base method
+func (m *MockAergoRPCServiceClient) GetDelegations(arg0 context.Context, arg1 *types.AccountAddress, arg2 ...grpc.CallOption) (*types.DelegationList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDelegations", varargs...)
+	ret0, _ := ret[0].(*types.DelegationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelegations indicates an expected call of GetDelegations
+func (mr *MockAergoRPCServiceClientMockRecorder) GetDelegations(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegations", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetDelegations), varargs...)
+}
+
 // GetEnterpriseConfig mocks base method
 func (m *MockAergoRPCServiceClient) GetEnterpriseConfig(arg0 context.Context, arg1 *types.EnterpriseConfigKey, arg2 ...grpc.CallOption) (*types.EnterpriseConfig, error) {
 	m.ctrl.T.Helper()
@@ -396,6 +436,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetEnterpriseConfig(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseConfig", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetEnterpriseConfig), varargs...)
 }
 
+// GetKeyRotations mocks base method
+func (m *MockAergoRPCServiceClient) GetKeyRotations(arg0 context.Context, arg1 *types.AccountAddress, arg2 ...grpc.CallOption) (*types.KeyRotationList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetKeyRotations", varargs...)
+	ret0, _ := ret[0].(*types.KeyRotationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyRotations indicates an expected call of GetKeyRotations
+func (mr *MockAergoRPCServiceClientMockRecorder) GetKeyRotations(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyRotations", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetKeyRotations), varargs...)
+}
+
 // GetNameInfo mocks base method
 func (m *MockAergoRPCServiceClient) GetNameInfo(arg0 context.Context, arg1 *types.Name, arg2 ...grpc.CallOption) (*types.NameInfo, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +476,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetNameInfo(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNameInfo", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetNameInfo), varargs...)
 }
 
+// GetNameRecords mocks base method
+func (m *MockAergoRPCServiceClient) GetNameRecords(arg0 context.Context, arg1 *types.Name, arg2 ...grpc.CallOption) (*types.NameRecordList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetNameRecords", varargs...)
+	ret0, _ := ret[0].(*types.NameRecordList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNameRecords indicates an expected call of GetNameRecords
+func (mr *MockAergoRPCServiceClientMockRecorder) GetNameRecords(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNameRecords", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetNameRecords), varargs...)
+}
+
 // GetPeers mocks base method
 func (m *MockAergoRPCServiceClient) GetPeers(arg0 context.Context, arg1 *types.PeersParams, arg2 ...grpc.CallOption) (*types.PeerList, error) {
 	m.ctrl.T.Helper()
@@ -456,6 +536,46 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetReceipt(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetReceipt), varargs...)
 }
 
+// GetReverseName mocks base method
+func (m *MockAergoRPCServiceClient) GetReverseName(arg0 context.Context, arg1 *types.AccountAddress, arg2 ...grpc.CallOption) (*types.NameInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReverseName", varargs...)
+	ret0, _ := ret[0].(*types.NameInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReverseName indicates an expected call of GetReverseName
+func (mr *MockAergoRPCServiceClientMockRecorder) GetReverseName(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReverseName", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetReverseName), varargs...)
+}
+
+// GetScheduledCallReceipts mocks base method
+func (m *MockAergoRPCServiceClient) GetScheduledCallReceipts(arg0 context.Context, arg1 *types.BlockNumberParam, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetScheduledCallReceipts", varargs...)
+	ret0, _ := ret[0].(*types.SingleBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledCallReceipts indicates an expected call of GetScheduledCallReceipts
+func (mr *MockAergoRPCServiceClientMockRecorder) GetScheduledCallReceipts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledCallReceipts", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetScheduledCallReceipts), varargs...)
+}
+
 // GetServerInfo mocks base method
 func (m *MockAergoRPCServiceClient) GetServerInfo(arg0 context.Context, arg1 *types.KeyParams, arg2 ...grpc.CallOption) (*types.ServerInfo, error) {
 	m.ctrl.T.Helper()
@@ -536,6 +656,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) GetStateAndProof(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateAndProof", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetStateAndProof), varargs...)
 }
 
+// GetSystemParams mocks base method
+func (m *MockAergoRPCServiceClient) GetSystemParams(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.SystemParamList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSystemParams", varargs...)
+	ret0, _ := ret[0].(*types.SystemParamList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemParams indicates an expected call of GetSystemParams
+func (mr *MockAergoRPCServiceClientMockRecorder) GetSystemParams(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemParams", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetSystemParams), varargs...)
+}
+
 // GetTX mocks base method
 func (m *MockAergoRPCServiceClient) GetTX(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.Tx, error) {
 	m.ctrl.T.Helper()
@@ -676,6 +816,46 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ListBlockStream(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlockStream", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListBlockStream), varargs...)
 }
 
+// ListEnterpriseHistory mocks base method
+func (m *MockAergoRPCServiceClient) ListEnterpriseHistory(arg0 context.Context, arg1 *types.EnterpriseHistoryQuery, arg2 ...grpc.CallOption) (*types.EnterpriseHistoryList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEnterpriseHistory", varargs...)
+	ret0, _ := ret[0].(*types.EnterpriseHistoryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseHistory indicates an expected call of ListEnterpriseHistory
+func (mr *MockAergoRPCServiceClientMockRecorder) ListEnterpriseHistory(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseHistory", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEnterpriseHistory), varargs...)
+}
+
+// ListEnterpriseProposals mocks base method
+func (m *MockAergoRPCServiceClient) ListEnterpriseProposals(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.EnterpriseProposalList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEnterpriseProposals", varargs...)
+	ret0, _ := ret[0].(*types.EnterpriseProposalList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseProposals indicates an expected call of ListEnterpriseProposals
+func (mr *MockAergoRPCServiceClientMockRecorder) ListEnterpriseProposals(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseProposals", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEnterpriseProposals), varargs...)
+}
+
 // ListEventStream mocks base method
 func (m *MockAergoRPCServiceClient) ListEventStream(arg0 context.Context, arg1 *types.FilterInfo, arg2 ...grpc.CallOption) (types.AergoRPCService_ListEventStreamClient, error) {
 	m.ctrl.T.Helper()
@@ -716,6 +896,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) ListEvents(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEvents), varargs...)
 }
 
+// ListScheduledCalls mocks base method
+func (m *MockAergoRPCServiceClient) ListScheduledCalls(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListScheduledCalls", varargs...)
+	ret0, _ := ret[0].(*types.SingleBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledCalls indicates an expected call of ListScheduledCalls
+func (mr *MockAergoRPCServiceClientMockRecorder) ListScheduledCalls(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledCalls", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListScheduledCalls), varargs...)
+}
+
 // LockAccount mocks base method
 func (m *MockAergoRPCServiceClient) LockAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.Account, error) {
 	m.ctrl.T.Helper()
@@ -776,6 +976,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) NodeState(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeState", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).NodeState), varargs...)
 }
 
+// ProfileTx mocks base method
+func (m *MockAergoRPCServiceClient) ProfileTx(arg0 context.Context, arg1 *types.Tx, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProfileTx", varargs...)
+	ret0, _ := ret[0].(*types.SingleBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileTx indicates an expected call of ProfileTx
+func (mr *MockAergoRPCServiceClientMockRecorder) ProfileTx(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileTx", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ProfileTx), varargs...)
+}
+
 // QueryContract mocks base method
 func (m *MockAergoRPCServiceClient) QueryContract(arg0 context.Context, arg1 *types.Query, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
 	m.ctrl.T.Helper()
@@ -856,6 +1076,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) SignTX(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).SignTX), varargs...)
 }
 
+// TraceTx mocks base method
+func (m *MockAergoRPCServiceClient) TraceTx(arg0 context.Context, arg1 *types.SingleBytes, arg2 ...grpc.CallOption) (*types.SingleBytes, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TraceTx", varargs...)
+	ret0, _ := ret[0].(*types.SingleBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceTx indicates an expected call of TraceTx
+func (mr *MockAergoRPCServiceClientMockRecorder) TraceTx(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceTx", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).TraceTx), varargs...)
+}
+
 // UnlockAccount mocks base method
 func (m *MockAergoRPCServiceClient) UnlockAccount(arg0 context.Context, arg1 *types.Personal, arg2 ...grpc.CallOption) (*types.Account, error) {
 	m.ctrl.T.Helper()
@@ -876,6 +1116,26 @@ func (mr *MockAergoRPCServiceClientMockRecorder) UnlockAccount(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).UnlockAccount), varargs...)
 }
 
+// VerifyContractSource mocks base method
+func (m *MockAergoRPCServiceClient) VerifyContractSource(arg0 context.Context, arg1 *types.ContractSource, arg2 ...grpc.CallOption) (*types.ContractSource, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyContractSource", varargs...)
+	ret0, _ := ret[0].(*types.ContractSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyContractSource indicates an expected call of VerifyContractSource
+func (mr *MockAergoRPCServiceClientMockRecorder) VerifyContractSource(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyContractSource", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).VerifyContractSource), varargs...)
+}
+
 // VerifyTX mocks base method
 func (m *MockAergoRPCServiceClient) VerifyTX(arg0 context.Context, arg1 *types.Tx, arg2 ...grpc.CallOption) (*types.VerifyResult, error) {
 	m.ctrl.T.Helper()
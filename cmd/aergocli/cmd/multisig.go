@@ -0,0 +1,251 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aergoio/aergo/v2/account/key"
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/jsonrpc"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	multisigThreshold uint32
+	multisigKeys      []string
+)
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig [flags] subcommand",
+	Short: "Multisig account command",
+}
+
+var multisigCreateCmd = &cobra.Command{
+	Use:    "create",
+	Short:  "Create a multisig account with the public keys and the threshold",
+	RunE:   execMultisigCreate,
+	PreRun: connectAergo,
+}
+
+var multisigSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Add a signature to a transaction of a multisig account",
+	Long: "Add a signature to a transaction of a multisig account.\n" +
+		"The signers sign the same transaction offline, and the signed transactions are combined to submit.",
+	RunE: execMultisigSign,
+}
+
+var multisigCombineCmd = &cobra.Command{
+	Use:   "combine <tx_json_file>...",
+	Short: "Combine the signatures of the transactions signed by each signer",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  execMultisigCombine,
+}
+
+var multisigSubmitCmd = &cobra.Command{
+	Use:    "submit",
+	Short:  "Submit a transaction of a multisig account signed by the signers",
+	RunE:   execMultisigSubmit,
+	PreRun: connectAergo,
+}
+
+func init() {
+	multisigCreateCmd.Flags().StringVar(&address, "address", "", "account address of the sender")
+	multisigCreateCmd.MarkFlagRequired("address")
+	multisigCreateCmd.Flags().Uint32Var(&multisigThreshold, "threshold", 0, "number of signatures required to send a transaction")
+	multisigCreateCmd.MarkFlagRequired("threshold")
+	multisigCreateCmd.Flags().StringSliceVar(&multisigKeys, "keys", nil, "comma separated addresses of the public keys")
+	multisigCreateCmd.MarkFlagRequired("keys")
+	multisigCreateCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+
+	multisigSignCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to sign")
+	multisigSignCmd.Flags().StringVar(&jsonPath, "jsontxpath", "", "transaction json file path to sign")
+	multisigSignCmd.Flags().StringVar(&address, "address", "", "address of the key in the keystore to use for signing")
+	multisigSignCmd.Flags().StringVar(&pw, "password", "", "password (optional, will be asked on the terminal if not given)")
+	multisigSignCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
+
+	multisigSubmitCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to submit")
+	multisigSubmitCmd.Flags().StringVar(&jsonPath, "jsontxpath", "", "transaction json file path to submit")
+
+	multisigCmd.AddCommand(multisigCreateCmd, multisigSignCmd, multisigCombineCmd, multisigSubmitCmd)
+}
+
+func execMultisigCreate(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	ci := types.CallInfo{Name: types.OpcreateMultisig.Cmd(), Args: []interface{}{multisigThreshold}}
+	var pubkeys [][]byte
+	for _, k := range multisigKeys {
+		pubkey, err := types.DecodeAddress(k)
+		if err != nil {
+			return errors.New("Failed to parse --keys flag (" + k + ")\n" + err.Error())
+		}
+		pubkeys = append(pubkeys, pubkey)
+		ci.Args = append(ci.Args, k)
+	}
+	multisig, err := types.NewMultisigAccount(multisigThreshold, pubkeys)
+	if err != nil {
+		return errors.New("Failed to create multisig account\n" + err.Error())
+	}
+	payload, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   payload,
+			GasLimit:  0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	cmd.Println(types.EncodeAddress(multisig.Address()))
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
+
+func readMultisigTx(jsonTx, jsonPath string) (*types.Tx, error) {
+	if jsonTx == "" && jsonPath == "" {
+		return nil, errors.New("need to transaction json input")
+	}
+	if jsonTx == "" {
+		b, err := os.ReadFile(jsonPath)
+		if err != nil {
+			return nil, errors.New("Failed to read --jsontxpath\n" + err.Error())
+		}
+		jsonTx = string(b)
+	}
+	return parseMultisigTx([]byte(jsonTx))
+}
+
+func parseMultisigTx(jsonTx []byte) (*types.Tx, error) {
+	txs, err := jsonrpc.ParseBase58Tx(jsonTx)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) != 1 {
+		return nil, errors.New("need a single transaction")
+	}
+	tx := txs[0]
+	if !tx.HasMultisigAccount() {
+		return nil, fmt.Errorf("not a multisig account: %s", types.EncodeAddress(tx.GetBody().GetAccount()))
+	}
+	return tx, nil
+}
+
+func execMultisigSign(cmd *cobra.Command, args []string) error {
+	tx, err := readMultisigTx(jsonTx, jsonPath)
+	if err != nil {
+		return err
+	}
+	if privKey != "" {
+		rawKey, err := base58.Decode(privKey)
+		if err != nil {
+			return err
+		}
+		signKey, _ := btcec.PrivKeyFromBytes(rawKey)
+		if err = key.AddMultisigSign(tx, signKey); err != nil {
+			return err
+		}
+	} else if rootConfig.KeyStorePath != "" {
+		if address == "" {
+			return errors.New("required flag(s) \"address\" not set")
+		}
+		addr, err := types.DecodeAddress(address)
+		if err != nil {
+			return err
+		}
+		if pw == "" {
+			pw, err = getPasswd(cmd, false)
+			if err != nil {
+				return errors.New("Failed get password:" + err.Error())
+			}
+		}
+		if errStr := fillMultisigSign(tx, rootConfig.KeyStorePath, pw, addr); errStr != "" {
+			return errors.New(errStr)
+		}
+	} else {
+		return errors.New("need --key or the keystore to sign offline")
+	}
+	cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvTx(tx, jsonrpc.Base58)))
+	return nil
+}
+
+func fillMultisigSign(tx *types.Tx, dataDir, pw string, account []byte) string {
+	multiSign, err := types.DecodeMultisigSign(tx.Body.Sign)
+	if err != nil {
+		return fmt.Sprintf("Failed: %s\n", err.Error())
+	}
+	hash := key.CalculateHashWithoutSign(tx.Body)
+	ks := key.NewStore(os.ExpandEnv(dataDir), 0)
+	defer ks.CloseStore()
+	sign, err := ks.Sign(account, pw, hash)
+	if err != nil {
+		return fmt.Sprintf("Failed: %s\n", err.Error())
+	}
+	multiSign.Add(account, sign)
+	tx.Body.Sign = multiSign.Encode()
+	tx.Hash = tx.CalculateTxHash()
+	return ""
+}
+
+func execMultisigCombine(cmd *cobra.Command, args []string) error {
+	var combined *types.Tx
+	var multiSign *types.MultisigSign
+	for _, path := range args {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		tx, err := parseMultisigTx(b)
+		if err != nil {
+			return fmt.Errorf("%s: %s", path, err.Error())
+		}
+		signs, err := types.DecodeMultisigSign(tx.Body.Sign)
+		if err != nil {
+			return fmt.Errorf("%s: %s", path, err.Error())
+		}
+		if combined == nil {
+			combined, multiSign = tx, signs
+			continue
+		}
+		// the signatures are of the same tx only if the bodies without the sign are the same
+		if !bytes.Equal(key.CalculateHashWithoutSign(tx.Body), key.CalculateHashWithoutSign(combined.Body)) {
+			return fmt.Errorf("%s: the transaction differs from %s", path, args[0])
+		}
+		for i, pubkey := range signs.Pubkeys {
+			multiSign.Add(pubkey, signs.Signs[i])
+		}
+	}
+	combined.Body.Sign = multiSign.Encode()
+	combined.Hash = combined.CalculateTxHash()
+	cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvTx(combined, jsonrpc.Base58)))
+	return nil
+}
+
+func execMultisigSubmit(cmd *cobra.Command, args []string) error {
+	tx, err := readMultisigTx(jsonTx, jsonPath)
+	if err != nil {
+		return err
+	}
+	msgs, err := client.CommitTX(context.Background(), &types.TxList{Txs: []*types.Tx{tx}})
+	if err != nil {
+		return errors.New("Failed request to aergo server\n" + err.Error())
+	}
+	cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvCommitResult(msgs.Results[0])))
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/account/key"
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/jsonrpc"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisigSignAndCombineWithMock(t *testing.T) {
+	initMock(t)
+	defer deinitMock()
+	privKey, jsonTx, jsonPath = "", "", ""
+	defer func() {
+		privKey, jsonTx, jsonPath = "", "", ""
+		remoteKeystore = false
+	}()
+
+	var keys []*btcec.PrivateKey
+	var pubkeys [][]byte
+	for i := 0; i < 3; i++ {
+		k, err := btcec.NewPrivateKey()
+		assert.NoError(t, err)
+		keys = append(keys, k)
+		pubkeys = append(pubkeys, k.PubKey().SerializeCompressed())
+	}
+	multisig, err := types.NewMultisigAccount(2, pubkeys)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	unsigned := &types.Tx{Body: &types.TxBody{
+		Account:   multisig.Address(),
+		Recipient: pubkeys[0],
+		Amount:    []byte{10},
+		Nonce:     1,
+		Type:      types.TxType_TRANSFER,
+	}}
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	assert.NoError(t, os.WriteFile(unsignedPath, []byte(jsonrpc.MarshalJSON(jsonrpc.ConvTx(unsigned, jsonrpc.Base58))), 0644))
+
+	// each signer signs the unsigned tx offline
+	var signedPaths []string
+	for i, k := range keys[:2] {
+		output, err := executeCommand(rootCmd, "account", "multisig", "sign", "--jsontxpath", unsignedPath, "--key", base58.Encode(k.Serialize()))
+		assert.NoError(t, err, "should be success")
+		signed, err := parseMultisigTx([]byte(output))
+		if !assert.NoError(t, err, output) {
+			return
+		}
+		assert.Equal(t, types.ErrNotEnoughSignatures, key.VerifyMultisigTx(signed, multisig))
+
+		path := filepath.Join(dir, "signed"+string(rune('a'+i))+".json")
+		assert.NoError(t, os.WriteFile(path, []byte(output), 0644))
+		signedPaths = append(signedPaths, path)
+	}
+
+	output, err := executeCommand(rootCmd, append([]string{"account", "multisig", "combine"}, signedPaths...)...)
+	assert.NoError(t, err, "should be success")
+	combined, err := parseMultisigTx([]byte(output))
+	assert.NoError(t, err)
+	assert.NoError(t, key.VerifyMultisigTx(combined, multisig))
+
+	// the account command drops the client to use the local keystore, so set it
+	// again and submit with the node keystore
+	mock := initMock(t)
+	mock.EXPECT().CommitTX(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&types.CommitResultList{Results: []*types.CommitResult{{Hash: combined.Hash, Error: types.CommitStatus_TX_OK}}},
+		nil,
+	).Times(1)
+	_, err = executeCommand(rootCmd, "account", "multisig", "submit", "--jsontx", output, "--node-keystore")
+	assert.NoError(t, err, "should be success")
+
+	// a tx of another account can't be combined
+	other := filepath.Join(dir, "other.json")
+	unsigned.Body.Nonce = 2
+	assert.NoError(t, os.WriteFile(other, []byte(jsonrpc.MarshalJSON(jsonrpc.ConvTx(unsigned, jsonrpc.Base58))), 0644))
+	_, err = executeCommand(rootCmd, "account", "multisig", "combine", signedPaths[0], other)
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"strconv"
 
 	"github.com/aergoio/aergo/v2/types"
 	"github.com/aergoio/aergo/v2/types/jsonrpc"
@@ -24,6 +25,7 @@ var nameCmd = &cobra.Command{
 }
 var spending string
 var blockNo uint64
+var recordKey, recordValue string
 
 func init() {
 	rootCmd.AddCommand(nameCmd)
@@ -55,6 +57,19 @@ func init() {
 	updateCmd.Flags().StringVar(&spending, "amount", "20aergo", "spending for update name. Must be set to the current nameprice")
 	updateCmd.Flags().StringVar(&pw, "password", "", "password")
 
+	renewCmd := &cobra.Command{
+		Use:                   "renew",
+		Short:                 "Renew account name for a period. It spend at least an amount of aergo according to nameprice",
+		RunE:                  execNameRenew,
+		DisableFlagsInUseLine: true,
+	}
+	renewCmd.Flags().StringVar(&from, "from", "", "sender account address")
+	renewCmd.MarkFlagRequired("from")
+	renewCmd.Flags().StringVar(&name, "name", "", "name of account to renew")
+	renewCmd.MarkFlagRequired("name")
+	renewCmd.Flags().StringVar(&spending, "amount", "20aergo", "spending for renew name. Must be set to the current nameprice")
+	renewCmd.Flags().StringVar(&pw, "password", "", "password")
+
 	ownerCmd := &cobra.Command{
 		Use:                   "owner",
 		Short:                 "Owner of account name",
@@ -65,7 +80,52 @@ func init() {
 	ownerCmd.MarkFlagRequired("name")
 	ownerCmd.Flags().Uint64VarP(&blockNo, "blockno", "n", 0, "Block height")
 
-	nameCmd.AddCommand(newCmd, updateCmd, ownerCmd)
+	setReverseCmd := &cobra.Command{
+		Use:                   "setreverse",
+		Short:                 "Set the name of the sender address for reverse resolution. The name must resolve to the address",
+		RunE:                  execNameSetReverse,
+		DisableFlagsInUseLine: true,
+	}
+	setReverseCmd.Flags().StringVar(&from, "from", "", "sender account address")
+	setReverseCmd.MarkFlagRequired("from")
+	setReverseCmd.Flags().StringVar(&name, "name", "", "name of the address (clears the reverse record if empty)")
+	setReverseCmd.Flags().StringVar(&pw, "password", "", "password")
+
+	reverseCmd := &cobra.Command{
+		Use:                   "reverse",
+		Short:                 "Name of account address",
+		RunE:                  execNameReverse,
+		DisableFlagsInUseLine: true,
+	}
+	reverseCmd.Flags().StringVar(&address, "address", "", "account address")
+	reverseCmd.MarkFlagRequired("address")
+
+	setRecordCmd := &cobra.Command{
+		Use:                   "setrecord",
+		Short:                 "Set a text record (e.g. url, avatar) of account name",
+		RunE:                  execNameSetRecord,
+		DisableFlagsInUseLine: true,
+	}
+	setRecordCmd.Flags().StringVar(&from, "from", "", "sender account address")
+	setRecordCmd.MarkFlagRequired("from")
+	setRecordCmd.Flags().StringVar(&name, "name", "", "name of account")
+	setRecordCmd.MarkFlagRequired("name")
+	setRecordCmd.Flags().StringVar(&recordKey, "key", "", "key of the record")
+	setRecordCmd.MarkFlagRequired("key")
+	setRecordCmd.Flags().StringVar(&recordValue, "value", "", "value of the record (deletes the record if empty)")
+	setRecordCmd.Flags().StringVar(&pw, "password", "", "password")
+
+	recordsCmd := &cobra.Command{
+		Use:                   "records",
+		Short:                 "Text records of account name",
+		RunE:                  execNameRecords,
+		DisableFlagsInUseLine: true,
+	}
+	recordsCmd.Flags().StringVar(&name, "name", "", "name of account")
+	recordsCmd.MarkFlagRequired("name")
+	recordsCmd.Flags().Uint64VarP(&blockNo, "blockno", "n", 0, "Block height")
+
+	nameCmd.AddCommand(newCmd, updateCmd, renewCmd, ownerCmd, setReverseCmd, reverseCmd, setRecordCmd, recordsCmd)
 }
 
 func execNameNew(cmd *cobra.Command, args []string) error {
@@ -74,8 +134,8 @@ func execNameNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("wrong address in --from flag: %v", err.Error())
 	}
 
-	if len(name) != types.NameLength {
-		return errors.New("the name must be 12 alphabetic characters")
+	if len(name) != types.NameLength && !types.IsSubName([]byte(name)) {
+		return errors.New("the name must be 12 alphabetic characters or a subname of it")
 	}
 	amount, err := jsonrpc.ParseUnit(spending)
 	if err != nil {
@@ -128,8 +188,8 @@ func execNameUpdate(cmd *cobra.Command, args []string) error {
 		amount = big.NewInt(0)
 	} else {
 		ci.Name = types.NameUpdate
-		if len(name) != types.NameLength {
-			return errors.New("the name must be 12 alphabetic characters")
+		if len(name) != types.NameLength && !types.IsSubName([]byte(name)) {
+			return errors.New("the name must be 12 alphabetic characters or a subname of it")
 		}
 		err = json.Unmarshal([]byte("[\""+name+"\",\""+to+"\"]"), &ci.Args)
 		if err != nil {
@@ -155,6 +215,79 @@ func execNameUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func execNameRenew(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return fmt.Errorf("wrong address in --from flag: %v", err.Error())
+	}
+	if len(name) != types.NameLength {
+		return errors.New("the name must be 12 alphabetic characters")
+	}
+	amount, err := jsonrpc.ParseUnit(spending)
+	if err != nil {
+		return fmt.Errorf("wrong value in --amount flag: %v", err.Error())
+	}
+	return sendNameTx(cmd, account, amount, types.CallInfo{Name: types.NameRenew, Args: []interface{}{name}})
+}
+
+func execNameSetReverse(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return fmt.Errorf("wrong address in --from flag: %v", err.Error())
+	}
+	return sendNameTx(cmd, account, big.NewInt(0), types.CallInfo{Name: types.NameSetReverse, Args: []interface{}{name}})
+}
+
+func execNameSetRecord(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return fmt.Errorf("wrong address in --from flag: %v", err.Error())
+	}
+	ci := types.CallInfo{Name: types.NameSetRecord, Args: []interface{}{name, recordKey, recordValue}}
+	return sendNameTx(cmd, account, big.NewInt(0), ci)
+}
+
+func sendNameTx(cmd *cobra.Command, account []byte, amount *big.Int, ci types.CallInfo) error {
+	payload, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoName),
+			Amount:    amount.Bytes(),
+			Payload:   payload,
+			GasLimit:  0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
+
+func execNameReverse(cmd *cobra.Command, args []string) error {
+	addr, err := types.DecodeAddress(address)
+	if err != nil {
+		return fmt.Errorf("wrong address in --address flag: %v", err.Error())
+	}
+	msg, err := client.GetReverseName(context.Background(), &types.AccountAddress{Value: addr})
+	if err != nil {
+		return errors.New("Failed request to aergo server\n" + err.Error())
+	}
+	cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvNameInfo(msg)))
+	return nil
+}
+
+func execNameRecords(cmd *cobra.Command, args []string) error {
+	msg, err := client.GetNameRecords(context.Background(), &types.Name{Name: name, BlockNo: blockNo})
+	if err != nil {
+		return errors.New("Failed request to aergo server\n" + err.Error())
+	}
+	cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvNameRecords(msg)))
+	return nil
+}
+
 func execNameOwner(cmd *cobra.Command, args []string) {
 	msg, err := client.GetNameInfo(context.Background(), &types.Name{Name: name, BlockNo: blockNo})
 	if err != nil {
@@ -163,5 +296,7 @@ func execNameOwner(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println("{\n \"" + msg.Name.Name + "\": {\n  " +
 		"\"Owner\": \"" + types.EncodeAddress(msg.Owner) + "\",\n  " +
-		"\"Destination\": \"" + types.EncodeAddress(msg.Destination) + "\"\n  }\n}")
+		"\"Destination\": \"" + types.EncodeAddress(msg.Destination) + "\",\n  " +
+		"\"Expire\": " + strconv.FormatUint(msg.Expire, 10) + ",\n  " +
+		"\"Status\": \"" + msg.Status + "\"\n  }\n}")
 }
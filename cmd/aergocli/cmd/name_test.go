@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameReverseWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	const testAccount = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	const testName = "myname123456"
+	account, _ := types.DecodeAddress(testAccount)
+
+	mock.EXPECT().GetReverseName(
+		gomock.Any(),
+		&types.AccountAddress{Value: account},
+	).Return(
+		&types.NameInfo{Name: &types.Name{Name: testName}, Owner: account, Destination: account, Status: "active"},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "name", "reverse", "--address", testAccount)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	assert.Equal(t, testName, result["name"])
+	assert.Equal(t, testAccount, result["destination"])
+}
+
+func TestNameRecordsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	const testName = "myname123456"
+
+	mock.EXPECT().GetNameRecords(
+		gomock.Any(),
+		&types.Name{Name: testName},
+	).Return(
+		&types.NameRecordList{Name: testName, Records: []*types.NameRecord{{Key: "url", Value: "https://aergo.io"}}},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "name", "records", "--name", testName)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	assert.Equal(t, testName, result["name"])
+	assert.Equal(t, map[string]interface{}{"url": "https://aergo.io"}, result["records"])
+}
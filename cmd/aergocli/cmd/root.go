@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -38,6 +39,7 @@ var (
 	cacrtFile string
 	svrName   string
 	keyFile   string
+	apiToken  string
 	certPeer  string
 	privKey   string
 	pw        string
@@ -54,7 +56,9 @@ var (
 	proof      bool
 	compressed bool
 
-	staking bool
+	staking      bool
+	keyRotations bool
+	delegations  bool
 
 	remote         bool
 	importFormat   string
@@ -83,6 +87,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cacrtFile, "tlscacert", "", "aergosvr CA certification file for TLS ")
 	rootCmd.PersistentFlags().StringVar(&crtFile, "tlscert", "", "client certification file for TLS ")
 	rootCmd.PersistentFlags().StringVar(&keyFile, "tlskey", "", "client key file for TLS ")
+	rootCmd.PersistentFlags().StringVar(&apiToken, "apitoken", "", "API token to access aergo server")
 	rootCmd.PersistentFlags().StringVarP(&host, "host", "H", "localhost", "Host address to aergo server")
 	rootCmd.PersistentFlags().Int32VarP(&port, "port", "p", 7845, "Port number to aergo server")
 	rootCmd.PersistentFlags().StringVar(&dataDir, "keystore", "$HOME/.aergo", "Path to keystore")
@@ -157,6 +162,9 @@ func connectAergo(cmd *cobra.Command, args []string) {
 	} else {
 		opts = append(opts, grpc.WithInsecure())
 	}
+	if apiToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apiTokenCreds(apiToken)))
+	}
 	var ok bool
 	client, ok = GetClient(serverAddr, opts).(*ConnClient)
 	if !ok {
@@ -164,6 +172,17 @@ func connectAergo(cmd *cobra.Command, args []string) {
 	}
 }
 
+// apiTokenCreds attaches the api token to the requests.
+type apiTokenCreds string
+
+func (t apiTokenCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t apiTokenCreds) RequireTransportSecurity() bool {
+	return false
+}
+
 func disconnectAergo(cmd *cobra.Command, args []string) {
 	if test {
 		return
@@ -0,0 +1,51 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/spf13/cobra"
+)
+
+var signingKey string
+
+var rotateKeyCmd = &cobra.Command{
+	Use:   "rotatekey",
+	Short: "Rotate the signing key of an account",
+	Long: "Rotate the signing key of an account.\n" +
+		"The transactions of the account must be signed by the new key after the rotation, while the address is kept.",
+	RunE:   execRotateKey,
+	PreRun: connectAergo,
+}
+
+func execRotateKey(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	if _, err := types.DecodeAddress(signingKey); err != nil {
+		return errors.New("Failed to parse --key flag (" + signingKey + ")\n" + err.Error())
+	}
+	ci := types.CallInfo{Name: types.OprotateKey.Cmd(), Args: []interface{}{signingKey}}
+	payload, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   payload,
+			GasLimit:  0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
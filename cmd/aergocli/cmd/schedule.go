@@ -0,0 +1,87 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	aergorpc "github.com/aergoio/aergo/v2/types"
+	"github.com/spf13/cobra"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule [flags] subcommand",
+	Short: "Show the calls scheduled by contracts",
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(
+		&cobra.Command{
+			Use:   "list [contract]",
+			Short: "List the queued scheduled calls of a contract, or all of them",
+			Args:  cobra.MaximumNArgs(1),
+			Run:   execListScheduledCalls,
+		},
+		&cobra.Command{
+			Use:   "receipts block_no",
+			Short: "Show the receipts of the scheduled calls executed at the start of a block",
+			Args:  cobra.MinimumNArgs(1),
+			Run:   execGetScheduledCallReceipts,
+		},
+	)
+}
+
+func execListScheduledCalls(cmd *cobra.Command, args []string) {
+	var contract []byte
+	if len(args) > 0 {
+		if len(args[0]) == aergorpc.NameLength {
+			contract = []byte(args[0])
+		} else {
+			var err error
+			if contract, err = aergorpc.DecodeAddress(args[0]); err != nil {
+				cmd.Printf("Failed: invalid contract address: %s\n", err.Error())
+				return
+			}
+		}
+	}
+	msg, err := client.ListScheduledCalls(context.Background(), &aergorpc.SingleBytes{Value: contract})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	printIndentedJSON(cmd, msg.Value)
+}
+
+func execGetScheduledCallReceipts(cmd *cobra.Command, args []string) {
+	blockNo, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Printf("Failed: invalid block number: %s\n", err.Error())
+		return
+	}
+	msg, err := client.GetScheduledCallReceipts(context.Background(), &aergorpc.BlockNumberParam{BlockNo: blockNo})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	if len(msg.Value) == 0 {
+		cmd.Println("[]")
+		return
+	}
+	printIndentedJSON(cmd, msg.Value)
+}
+
+func printIndentedJSON(cmd *cobra.Command, data []byte) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(out.String())
+}
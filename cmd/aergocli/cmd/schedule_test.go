@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListScheduledCallsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testContract := "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	contract, _ := types.DecodeAddress(testContract)
+	calls := `[{"id":3,"contract":"AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4","function":"release","args":[1],"blockNo":100,"gasLimit":0,"fee":"1000"}]`
+
+	mock.EXPECT().ListScheduledCalls(
+		gomock.Any(),
+		&types.SingleBytes{Value: contract},
+	).Return(
+		&types.SingleBytes{Value: []byte(calls)},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "schedule", "list", testContract)
+	assert.NoError(t, err, "should be success")
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, result, 1)
+	assert.Equal(t, "release", result[0]["function"])
+}
+
+func TestGetScheduledCallReceiptsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	receipts := `[{"id":3,"contract":"AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4","function":"release","status":"SUCCESS","gasUsed":100,"feeUsed":"1000"}]`
+
+	mock.EXPECT().GetScheduledCallReceipts(
+		gomock.Any(),
+		&types.BlockNumberParam{BlockNo: 100},
+	).Return(
+		&types.SingleBytes{Value: []byte(receipts)},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "schedule", "receipts", "100")
+	assert.NoError(t, err, "should be success")
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, result, 1)
+	assert.Equal(t, "SUCCESS", result[0]["status"])
+}
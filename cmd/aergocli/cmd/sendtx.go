@@ -8,6 +8,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/types"
@@ -22,6 +23,7 @@ var sendtxCmd = &cobra.Command{
 	RunE:  execSendTX,
 }
 var chainIdHash string
+var expiresIn uint64
 
 func init() {
 	rootCmd.AddCommand(sendtxCmd)
@@ -35,6 +37,7 @@ func init() {
 	sendtxCmd.Flags().StringVar(&chainIdHash, "chainidhash", "", "hash value of chain id in the block")
 	sendtxCmd.Flags().Uint64VarP(&gas, "gaslimit", "g", 0, "Gas limit")
 	sendtxCmd.Flags().StringVar(&pw, "password", "", "Password")
+	sendtxCmd.Flags().Uint64Var(&expiresIn, "expires-in", 0, "number of blocks after the best block until which the tx can be included (0 = no expiry)")
 }
 
 func execSendTX(cmd *cobra.Command, args []string) error {
@@ -65,11 +68,27 @@ func execSendTX(cmd *cobra.Command, args []string) error {
 		}
 		tx.GetBody().ChainIdHash = cid
 	}
+	if errStr := fillExpiry(tx, expiresIn); errStr != "" {
+		return errors.New(errStr)
+	}
 
 	cmd.Println(sendTX(cmd, tx, account))
 	return nil
 }
 
+// fillExpiry sets the last block number in which the tx can be included to expiresIn blocks after the best block.
+func fillExpiry(tx *types.Tx, expiresIn uint64) string {
+	if expiresIn == 0 {
+		return ""
+	}
+	msg, err := client.Blockchain(context.Background(), &types.Empty{})
+	if err != nil {
+		return fmt.Sprintf("Failed: %s\n", err.Error())
+	}
+	tx.GetBody().ValidUntilBlock = msg.GetBestHeight() + expiresIn
+	return ""
+}
+
 func sendTX(cmd *cobra.Command, tx *types.Tx, account []byte) string {
 	if rootConfig.KeyStorePath != "" {
 		var err error
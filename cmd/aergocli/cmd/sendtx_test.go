@@ -45,3 +45,32 @@ func TestSendTxFromToValidation(t *testing.T) {
 	_, err = executeCommand(rootCmd, "sendtx", "--from", "AmNL5neKQS2ZwRuBeqfcfHMLg3aSmGoefEh5bW8ozWxrtmxaGHZ3", "--to", "AmNfacq5A3orqn3MhgkHSncufXEP8gVJgqDy8jTgBphXQInvalid", "--amount", "1000", "--keystore", "")
 	assert.Error(t, err, "should error when wrong --to flag")
 }
+
+func TestSendTxExpiresInWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+	defer func() { expiresIn = 0 }()
+
+	mock.EXPECT().Blockchain(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&types.BlockchainStatus{BestHeight: 100},
+		nil,
+	).Times(1)
+	var sent *types.Tx
+	mock.EXPECT().SendTX(
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(_ interface{}, tx *types.Tx, _ ...interface{}) (*types.CommitResult, error) {
+		sent = tx
+		return &types.CommitResult{Error: types.CommitStatus_TX_OK}, nil
+	}).Times(1)
+
+	_, err := executeCommand(rootCmd, "sendtx", "--from", "AmNL5neKQS2ZwRuBeqfcfHMLg3aSmGoefEh5bW8ozWxrtmxaGHZ3", "--to", "AmNfacq5A3orqn3MhgkHSncufXEP8gVJgqDy8jTgBphXQeuuaHHF", "--amount", "1000", "--keystore", "", "--expires-in", "10")
+	assert.NoError(t, err, "should no error")
+	if assert.NotNil(t, sent) {
+		assert.Equal(t, uint64(0), sent.GetBody().GetValidAfterBlock())
+		assert.Equal(t, uint64(110), sent.GetBody().GetValidUntilBlock())
+	}
+}
@@ -21,6 +21,7 @@ func init() {
 	signCmd.Flags().StringVar(&address, "address", "1", "address of account to use for signing")
 	signCmd.Flags().StringVar(&pw, "password", "", "local account password")
 	signCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
+	signCmd.Flags().Uint64Var(&expiresIn, "expires-in", 0, "number of blocks after the best block until which the tx can be included (0 = no expiry)")
 	rootCmd.AddCommand(verifyCmd)
 	verifyCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction list json to verify")
 	verifyCmd.Flags().StringVar(&jsonPath, "jsontxpath", "", "transaction json file path to verify")
@@ -51,6 +52,16 @@ var signCmd = &cobra.Command{
 			cmd.Printf("Failed: %s\n", err.Error())
 			return
 		}
+		if expiresIn != 0 {
+			// the local keystore doesn't need the connection except to get the best block
+			if client == nil {
+				connectAergo(cmd, args)
+			}
+			if errStr := fillExpiry(&types.Tx{Body: param}, expiresIn); errStr != "" {
+				cmd.Print(errStr)
+				return
+			}
+		}
 
 		var msg *types.Tx
 		if privKey != "" {
@@ -36,6 +36,59 @@ func execUnstake(cmd *cobra.Command, args []string) error {
 	return sendStake(cmd, false)
 }
 
+var delegateCmd = &cobra.Command{
+	Use:    "delegate",
+	Short:  "Delegate staked balance to a delegate account voting with it",
+	RunE:   execDelegate,
+	PreRun: connectAergo,
+}
+
+func execDelegate(cmd *cobra.Command, args []string) error {
+	if _, err := types.DecodeAddress(to); err != nil {
+		return errors.New("Failed to parse --to flag (" + to + ")\n" + err.Error())
+	}
+	return sendDelegate(cmd, types.CallInfo{Name: types.Opdelegate.Cmd(), Args: []interface{}{to}})
+}
+
+var undelegateCmd = &cobra.Command{
+	Use:    "undelegate",
+	Short:  "Take back the delegated staked balance",
+	RunE:   execUndelegate,
+	PreRun: connectAergo,
+}
+
+func execUndelegate(cmd *cobra.Command, args []string) error {
+	return sendDelegate(cmd, types.CallInfo{Name: types.Opundelegate.Cmd()})
+}
+
+func sendDelegate(cmd *cobra.Command, ci types.CallInfo) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	amountBigInt, err := jsonrpc.ParseUnit(amount)
+	if err != nil {
+		return errors.New("Failed to parse --amount flag\n" + err.Error())
+	}
+	payload, err := json.Marshal(ci)
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Amount:    amountBigInt.Bytes(),
+			Payload:   payload,
+			GasLimit:  0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+
+	cmd.Println(sendTX(cmd, tx, account))
+	return nil
+}
+
 func sendStake(cmd *cobra.Command, s bool) error {
 	account, err := types.DecodeAddress(address)
 	if err != nil {
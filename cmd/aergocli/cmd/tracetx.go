@@ -0,0 +1,48 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	aergorpc "github.com/aergoio/aergo/v2/types"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "tracetx tx_hash",
+		Short: "Re-execute a transaction on the state of its parent block and show the execution trace",
+		Long: "Re-execute a transaction on the state of its parent block and show the call tree, with the gas, " +
+			"state accesses, sql statements and events of each call, and where the error was raised.\n" +
+			"SQL statements which change the database fail in the trace, since the database is opened read-only.\n" +
+			"It needs the permission to control the node.",
+		Args: cobra.MinimumNArgs(1),
+		Run:  execTraceTx,
+	})
+}
+
+func execTraceTx(cmd *cobra.Command, args []string) {
+	txHash, err := base58.Decode(args[0])
+	if err != nil {
+		cmd.Printf("Failed: invalid tx hash: %s\n", err.Error())
+		return
+	}
+	msg, err := client.TraceTx(context.Background(), &aergorpc.SingleBytes{Value: txHash})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	var out bytes.Buffer
+	if err = json.Indent(&out, msg.Value, "", "  "); err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	cmd.Println(out.String())
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceTxWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	testTxHashString := "HB44gJvHhVoEfgiGq3VZmV9VUXfBXhHjcEvroBMkJGnY"
+	testTxHash, _ := base58.Decode(testTxHashString)
+	trace := `{"txhash":"HB44gJvHhVoEfgiGq3VZmV9VUXfBXhHjcEvroBMkJGnY","status":"ERROR","call":{"type":"call","contract":"AmgExqUu6J4ZRYQ6Uxhr9xNVC1SqMyvtsUWtiMCPtoWt1RfHBzSW","gasUsed":100}}`
+
+	mock.EXPECT().TraceTx(
+		gomock.Any(),
+		&types.SingleBytes{Value: testTxHash},
+	).Return(
+		&types.SingleBytes{Value: []byte(trace)},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "tracetx", testTxHashString)
+	assert.NoError(t, err, "should be success")
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testTxHashString, result["txhash"])
+	assert.Equal(t, "ERROR", result["status"])
+}
@@ -22,11 +22,13 @@ var revert bool
 var voteId string
 var voteStatId string
 var voteVersion string
+var voteStatParams bool
 
 func init() {
 	rootCmd.AddCommand(voteStatCmd)
 	voteStatCmd.Flags().StringVar(&address, "address", "", "address of account")
-	voteStatCmd.Flags().StringVar(&voteStatId, "id", "bpcount", "id of vote (e.g. bpcount, stakingmin, gasprice, nameprice)")
+	voteStatCmd.Flags().StringVar(&voteStatId, "id", "bpcount", "id of vote (e.g. bpcount, stakingmin, gasprice, nameprice, maxblocksize, maxcalldepth, sqlmaxdbsize, mempoolfadeout, ed25519verifygas, sha256gas and the other gas costs of the crypto module)")
+	voteStatCmd.Flags().BoolVar(&voteStatParams, "params", false, "show the system params with their pending values")
 	voteStatCmd.Flags().Uint64Var(&number, "count", 0, "the number of elected")
 	rootCmd.AddCommand(bpCmd)
 	bpCmd.Flags().Uint64Var(&number, "count", 0, "the number of elected")
@@ -159,8 +161,16 @@ func execVoteStat(cmd *cobra.Command, args []string) {
 		}
 		cmd.Println("]")
 		return
+	} else if voteStatParams {
+		msg, err := client.GetSystemParams(context.Background(), &types.Empty{})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(jsonrpc.MarshalJSON(jsonrpc.ConvSystemParams(msg)))
+		return
 	}
-	cmd.Println("no --address or --id specified")
+	cmd.Println("no --address, --id or --params specified")
 	cmd.Usage()
 	return
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoteStatParamsWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+	defer func() { voteStatParams = false }()
+
+	mock.EXPECT().GetSystemParams(
+		gomock.Any(),
+		&types.Empty{},
+	).Return(
+		&types.SystemParamList{Params: []*types.SystemParam{
+			{Id: "BPCOUNT", Type: "number", Value: big.NewInt(13).Bytes(), Min: big.NewInt(1).Bytes(), Max: big.NewInt(100).Bytes()},
+			{Id: "MAXCALLDEPTH", Type: "number", Value: big.NewInt(64).Bytes(), Min: big.NewInt(5).Bytes(), Max: big.NewInt(64).Bytes(),
+				ActivationDelay: 3600, PendingValue: big.NewInt(32).Bytes(), ActivationBlock: 3700},
+		}},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "votestat", "--params")
+	assert.NoError(t, err, "should be success")
+
+	var result struct {
+		Params []map[string]interface{} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err, output)
+	}
+	if assert.Len(t, result.Params, 2) {
+		assert.Equal(t, "13", result.Params[0]["value"])
+		assert.Nil(t, result.Params[0]["pendingValue"])
+		assert.Equal(t, "32", result.Params[1]["pendingValue"])
+		assert.Equal(t, float64(3700), result.Params[1]["activationBlock"])
+	}
+}
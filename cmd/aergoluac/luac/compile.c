@@ -7,6 +7,49 @@
 #include "state_module.h"
 #include "_cgo_export.h"
 
+#define ABI_TYPES_KEY "_abi_types"
+
+/* abi.types(fn, {arg types}, {return types}) annotates the types of a registered function. The chunk returns a
+   function which lists the annotations as lines of "name<TAB>arg;types<TAB>return;types" */
+static const char *abi_types_lib =
+	"local types = {}\n"
+	"abi.types = function(fn, args, returns)\n"
+	"  if type(fn) ~= 'function' then error('abi.types: the first argument must be a function', 2) end\n"
+	"  local t = {}\n"
+	"  for i, l in ipairs({args or {}, returns or {}}) do\n"
+	"    if type(l) ~= 'table' then error('abi.types: the types must be a table of strings', 2) end\n"
+	"    for _, s in ipairs(l) do\n"
+	"      if type(s) ~= 'string' or s:find('[\\t\\n;]') then error('abi.types: invalid type ' .. tostring(s), 2) end\n"
+	"    end\n"
+	"    t[i] = table.concat(l, ';')\n"
+	"  end\n"
+	"  types[fn] = t\n"
+	"end\n"
+	"return function()\n"
+	"  local list, found = {}, {}\n"
+	"  for name, fn in pairs(_G) do\n"
+	"    local t = types[fn]\n"
+	"    if t ~= nil then\n"
+	"      list[#list + 1] = name .. '\\t' .. t[1] .. '\\t' .. t[2]\n"
+	"      found[fn] = true\n"
+	"    end\n"
+	"  end\n"
+	"  for fn in pairs(types) do\n"
+	"    if not found[fn] then error('abi.types: the function is not a global function') end\n"
+	"  end\n"
+	"  table.sort(list)\n"
+	"  return table.concat(list, '\\n')\n"
+	"end\n";
+
+static void luac_open_abi_types(lua_State *L) {
+	if (luaL_loadbuffer(L, abi_types_lib, strlen(abi_types_lib), "abi_types") != 0 ||
+		lua_pcall(L, 0, 1, 0) != 0) {
+		lua_pop(L, 1);
+		return;
+	}
+	lua_setfield(L, LUA_REGISTRYINDEX, ABI_TYPES_KEY);
+}
+
 lua_State *luac_vm_newstate() {
 	lua_State *L = luaL_newstate(3);
 	if (L == NULL) {
@@ -14,9 +57,33 @@ lua_State *luac_vm_newstate() {
 	}
 	luaL_openlibs(L);
 	luac_open_state(L);
+	luac_open_abi_types(L);
 	return L;
 }
 
+void luac_close_abi_types(lua_State *L) {
+	lua_getfield(L, LUA_GLOBALSINDEX, "abi");
+	lua_pushnil(L);
+	lua_setfield(L, -2, "types");
+	lua_pop(L, 1);
+	lua_pushnil(L);
+	lua_setfield(L, LUA_REGISTRYINDEX, ABI_TYPES_KEY);
+}
+
+/* pushes the list of the type annotations, or the error message if it fails */
+const char *luac_abi_types(lua_State *L) {
+	lua_getfield(L, LUA_REGISTRYINDEX, ABI_TYPES_KEY);
+	if (!lua_isfunction(L, -1)) {
+		lua_pop(L, 1);
+		lua_pushstring(L, "");
+		return NULL;
+	}
+	if (lua_pcall(L, 0, 1, 0) != 0) {
+		return lua_tostring(L, -1);
+	}
+	return NULL;
+}
+
 void luac_vm_close(lua_State *L) {
 	if (L != NULL) {
 		lua_close(L);
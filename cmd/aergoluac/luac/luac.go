@@ -12,15 +12,18 @@ import "C"
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"runtime"
+	"strings"
 	"unsafe"
 
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/encoding"
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
+	"github.com/aergoio/aergo/v2/types"
 )
 
 func NewLState() *C.lua_State {
@@ -38,6 +41,11 @@ func CloseLState(L *C.lua_State) {
 	}
 }
 
+// DisableABITypes removes abi.types from the state, so the contract is compiled as before the typed ABI.
+func DisableABITypes(L *C.lua_State) {
+	C.luac_close_abi_types(L)
+}
+
 func Compile(L *C.lua_State, code string) (util.LuaCode, error) {
 	cStr := C.CString(code)
 	defer C.free(unsafe.Pointer(cStr))
@@ -47,23 +55,38 @@ func Compile(L *C.lua_State, code string) (util.LuaCode, error) {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return nil, errors.New(C.GoString(errMsg))
 	}
-	return dumpToBytes(L), nil
+	return addABITypes(L, dumpToBytes(L))
 }
 
 func CompileFromFile(srcFileName, outFileName, abiFileName string) error {
 	cSrcFileName := C.CString(srcFileName)
-	cOutFileName := C.CString(outFileName)
-	cAbiFileName := C.CString(abiFileName)
 	L := C.luac_vm_newstate()
 	defer C.free(unsafe.Pointer(cSrcFileName))
-	defer C.free(unsafe.Pointer(cOutFileName))
-	defer C.free(unsafe.Pointer(cAbiFileName))
 	defer C.luac_vm_close(L)
 
-	if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName, cAbiFileName); errMsg != nil {
+	if len(abiFileName) == 0 {
+		cOutFileName := C.CString(outFileName)
+		defer C.free(unsafe.Pointer(cOutFileName))
+		if errMsg := C.vm_compile(L, cSrcFileName, cOutFileName, nil); errMsg != nil {
+			return errors.New(C.GoString(errMsg))
+		}
+		return nil
+	}
+
+	if errMsg := C.vm_loadfile(L, cSrcFileName); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	return nil
+	if errMsg := C.vm_stringdump(L); errMsg != nil {
+		return errors.New(C.GoString(errMsg))
+	}
+	code, err := addABITypes(L, dumpToBytes(L))
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(outFileName, code.ByteCode(), 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(abiFileName, code.ABI(), 0644)
 }
 
 func DumpFromFile(srcFileName string) error {
@@ -79,7 +102,11 @@ func DumpFromFile(srcFileName string) error {
 		return errors.New(C.GoString(errMsg))
 	}
 
-	fmt.Println(encoding.EncodeCode(dumpToBytes(L)))
+	code, err := addABITypes(L, dumpToBytes(L))
+	if err != nil {
+		return err
+	}
+	fmt.Println(encoding.EncodeCode(code))
 	return nil
 }
 
@@ -116,7 +143,11 @@ func DumpFromStdin() error {
 	if errMsg := C.vm_stringdump(L); errMsg != nil {
 		return errors.New(C.GoString(errMsg))
 	}
-	fmt.Println(encoding.EncodeCode(dumpToBytes(L)))
+	code, err := addABITypes(L, dumpToBytes(L))
+	if err != nil {
+		return err
+	}
+	fmt.Println(encoding.EncodeCode(code))
 	return nil
 }
 
@@ -129,3 +160,44 @@ func dumpToBytes(L *C.lua_State) util.LuaCode {
 	a = C.lua_tolstring(L, -1, &la)
 	return util.NewLuaCode(C.GoBytes(unsafe.Pointer(c), C.int(lc)), C.GoBytes(unsafe.Pointer(a), C.int(la)))
 }
+
+// addABITypes adds the type annotations given by abi.types to the generated ABI. The ABI of a contract without
+// any annotation is kept as it is.
+func addABITypes(L *C.lua_State, code util.LuaCode) (util.LuaCode, error) {
+	if errMsg := C.luac_abi_types(L); errMsg != nil {
+		return nil, errors.New(C.GoString(errMsg))
+	}
+	var l C.size_t
+	s := C.lua_tolstring(L, -1, &l)
+	list := C.GoStringN(s, C.int(l))
+	C.lua_settop(L, -2)
+	if len(list) == 0 {
+		return code, nil
+	}
+
+	var abi types.ABI
+	if err := json.Unmarshal(code.ABI(), &abi); err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("abi.types: invalid annotation %q", line)
+		}
+		if err := abi.SetTypes(fields[0], splitTypes(fields[1]), splitTypes(fields[2])); err != nil {
+			return nil, err
+		}
+	}
+	b, err := json.Marshal(&abi)
+	if err != nil {
+		return nil, err
+	}
+	return util.NewLuaCode(code.ByteCode(), b), nil
+}
+
+func splitTypes(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, ";")
+}
@@ -86,11 +86,13 @@ func main() {
 		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p]\n", os.Args[0])
 		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] [-V] [-w] <filename>\n", os.Args[0])
 		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] pack <input_file> [<output_file>]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "  %s [-p] [-V] [-junit <report_file>] test <dir_or_file>...\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	verbose := flag.Bool("V", false, "verbose output (only batch)")
 	watch := flag.Bool("w", false, "enable watch (only batch)")
 	private := flag.Bool("p", false, "enable private features")
+	junit := flag.String("junit", "", "write the report in the JUnit XML format (only test)")
 
 	flag.Parse()
 
@@ -131,6 +133,19 @@ func main() {
 
 		// Call the pack function with the input and output files
 		exitCode = exec.ExecutePack(inputFile, outputFile)
+	} else if flag.Arg(0) == "test" {
+		// test command
+		if flag.NArg() < 2 {
+			fmt.Fprintln(os.Stderr, "Error: test command requires a directory or files to test")
+			exitCode = 1
+			return
+		}
+
+		if *verbose {
+			exec.EnableVerbose()
+		}
+
+		exitCode = exec.RunTests(flag.Args()[1:], *junit)
 	} else {
 		// call batch executor
 		cmd := "batch"
@@ -9,10 +9,14 @@
 package context
 
 import (
+	"bytes"
+	"fmt"
 	"log"
+	"sort"
 	"strconv"
 
 	"github.com/aergoio/aergo/v2/contract/vm_dummy"
+	"github.com/aergoio/aergo/v2/types"
 )
 
 var (
@@ -22,7 +26,8 @@ var (
 )
 
 type context struct {
-	chain *vm_dummy.DummyChain
+	chain     *vm_dummy.DummyChain
+	snapshots map[string]*types.Block
 }
 
 func Open(private bool) {
@@ -40,7 +45,8 @@ func Open(private bool) {
 		panic(err)
 	}
 	currentCtx = &context{
-		chain: chain,
+		chain:     chain,
+		snapshots: make(map[string]*types.Block),
 	}
 }
 
@@ -66,3 +72,59 @@ func Get() *vm_dummy.DummyChain {
 	}
 	return currentCtx.chain
 }
+
+// SaveSnapshot names the best block of the chain, so that the chain can be restored to it later.
+func SaveSnapshot(name string) error {
+	block, err := Get().GetBlockByNo(Get().BestBlockNo())
+	if err != nil {
+		return err
+	}
+	currentCtx.snapshots[name] = block
+	return nil
+}
+
+// RestoreSnapshot disconnects the blocks connected after the named snapshot. The snapshot must still be on the
+// chain, so it fails if the block of the snapshot has been undone or replaced.
+func RestoreSnapshot(name string) error {
+	block, ok := currentCtx.snapshots[name]
+	if !ok {
+		return fmt.Errorf("snapshot not found: %s", name)
+	}
+	chain := Get()
+	height := block.BlockNo()
+	var onChain *types.Block
+	if height <= chain.BestBlockNo() {
+		onChain, _ = chain.GetBlockByNo(height)
+	}
+	if err := checkSnapshot(name, block, chain.BestBlockNo(), onChain); err != nil {
+		return err
+	}
+	for chain.BestBlockNo() > height {
+		if err := chain.DisConnectBlock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSnapshot compares the block of the snapshot with the block of the same number on the chain, which is nil if
+// the chain is shorter than the snapshot.
+func checkSnapshot(name string, block *types.Block, best types.BlockNo, onChain *types.Block) error {
+	if block.BlockNo() > best || onChain == nil {
+		return fmt.Errorf("the block of snapshot %s has been undone", name)
+	}
+	if !bytes.Equal(onChain.BlockHash(), block.BlockHash()) {
+		return fmt.Errorf("the block of snapshot %s has been replaced", name)
+	}
+	return nil
+}
+
+// Snapshots returns the names of the saved snapshots.
+func Snapshots() []string {
+	names := make([]string, 0, len(currentCtx.snapshots))
+	for name := range currentCtx.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,42 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSnapshot(t *testing.T) {
+	newBlock := func(no types.BlockNo, ts int64) *types.Block {
+		return &types.Block{Header: &types.BlockHeader{BlockNo: no, Timestamp: ts}}
+	}
+	snapshot := newBlock(3, 100)
+
+	tests := []struct {
+		name    string
+		best    types.BlockNo
+		onChain *types.Block
+		wantErr string
+	}{
+		{"best", 3, snapshot, ""},
+		{"behind best", 5, snapshot, ""},
+		{"same header", 5, newBlock(3, 100), ""},
+		{"undone", 2, nil, "has been undone"},
+		{"missing", 3, nil, "has been undone"},
+		{"replaced", 3, newBlock(3, 101), "has been replaced"},
+		{"replaced behind best", 4, newBlock(3, 101), "has been replaced"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSnapshot("s1", snapshot, tt.best, tt.onChain)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "snapshot s1 "+tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ var (
 	TimestampSymbol    = "<value_or_increment>"
 	HardforkSymbol     = "<version>"
 	CommandSymbol      = "[command]"
+	JSONPathSymbol     = "<json_path>"
+	IndexSymbol        = "<index>"
+	EventSymbol        = "<event>"
+	SnapshotSymbol     = "<snapshot>"
+	TestCaseSymbol     = "<test_case>"
 )
 
 // reprenestation and description map of all symbols
@@ -31,4 +36,9 @@ func init() {
 	Symbols[FunctionSymbol] = "smart contract function name"
 	Symbols[TimestampSymbol] = "timestamp value or +increment"
 	Symbols[HardforkSymbol] = "hardfork version"
+	Symbols[JSONPathSymbol] = "json path, such as $.events[0].args"
+	Symbols[IndexSymbol] = "index starting from 0"
+	Symbols[EventSymbol] = "event name"
+	Symbols[SnapshotSymbol] = "snapshot name"
+	Symbols[TestCaseSymbol] = "test case name"
 }
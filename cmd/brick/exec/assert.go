@@ -0,0 +1,177 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+// lastTxHash is the hash of the last transaction executed by a command, whose receipt is checked by the assertions.
+var lastTxHash []byte
+
+func setLastTx(txHash []byte) {
+	lastTxHash = txHash
+}
+
+func clearLastTx() {
+	lastTxHash = nil
+}
+
+// lastReceipt returns the receipt of the last transaction as a json document, so that it can be checked by a path.
+func lastReceipt() (interface{}, error) {
+	if lastTxHash == nil {
+		return nil, fmt.Errorf("there is no transaction to check")
+	}
+	receipt := context.Get().GetReceipt(lastTxHash)
+
+	events := make([]interface{}, 0, len(receipt.Events))
+	for _, event := range receipt.Events {
+		events = append(events, eventDoc(event))
+	}
+	doc := map[string]interface{}{
+		"status":  receipt.Status,
+		"ret":     parseJSONOrString(receipt.Ret),
+		"gasUsed": json.Number(strconv.FormatUint(receipt.GasUsed, 10)),
+		"feeUsed": json.Number(new(big.Int).SetBytes(receipt.FeeUsed).String()),
+		"events":  events,
+	}
+	if len(receipt.ContractAddress) > 0 {
+		doc["contractAddress"] = types.EncodeAddress(receipt.ContractAddress)
+	}
+	return doc, nil
+}
+
+func eventDoc(event *types.Event) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     event.GetEventName(),
+		"args":     parseJSONOrString(event.GetJsonArgs()),
+		"contract": types.EncodeAddress(event.GetContractAddress()),
+		"txHash":   base58.Encode(event.GetTxHash()),
+	}
+}
+
+// parseJSON decodes a json text keeping the numbers as they are, so that big numbers are compared exactly.
+func parseJSON(text string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid json %s: %s", text, err.Error())
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid json %s: trailing data", text)
+	}
+	return v, nil
+}
+
+func parseJSONOrString(text string) interface{} {
+	if text == "" {
+		return nil
+	}
+	if v, err := parseJSON(text); err == nil {
+		return v
+	}
+	return text
+}
+
+// selectJSONPath returns the value at a path such as `$.events[0].args`. The leading `$` is optional.
+func selectJSONPath(doc interface{}, path string) (interface{}, error) {
+	rest := strings.TrimPrefix(path, "$")
+	cur := doc
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			obj, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("path %s: not an object at .%s", path, key)
+			}
+			if cur, ok = obj[key]; !ok {
+				return nil, fmt.Errorf("path %s: key not found: %s", path, key)
+			}
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %s: unclosed bracket", path)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("path %s: invalid index %s", path, rest[1:end])
+			}
+			arr, ok := cur.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("path %s: not an array at [%d]", path, i)
+			}
+			if i < 0 || i >= len(arr) {
+				return nil, fmt.Errorf("path %s: index out of range: %d", path, i)
+			}
+			cur = arr[i]
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %s: invalid path", path)
+		}
+	}
+	return cur, nil
+}
+
+// compareJSON checks the value against the expected json text.
+func compareJSON(path string, actual interface{}, expected string) error {
+	want, err := parseJSON(expected)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(normalizeJSON(actual), normalizeJSON(want)) {
+		return fmt.Errorf("%s: expected: %s, but got: %s", path, expected, toJSON(actual))
+	}
+	return nil
+}
+
+// exactNumber is a json number in the canonical form of a fraction.
+type exactNumber string
+
+// normalizeJSON makes the numbers comparable, since 1 and 1.0 are the same number in json.
+func normalizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return exactNumber(r.RatString())
+		}
+		return exactNumber(v)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = normalizeJSON(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = normalizeJSON(e)
+		}
+		return a
+	}
+	return v
+}
+
+func toJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(buf.String())
+}
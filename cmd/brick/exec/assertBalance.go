@@ -0,0 +1,71 @@
+package exec
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&assertBalance{})
+}
+
+type assertBalance struct{}
+
+func (c *assertBalance) Command() string {
+	return "assertbalance"
+}
+
+func (c *assertBalance) Syntax() string {
+	return fmt.Sprintf("%s %s", context.AccountSymbol, context.AmountSymbol)
+}
+
+func (c *assertBalance) Usage() string {
+	return "assertbalance <account_name> <expected_balance>"
+}
+
+func (c *assertBalance) Describe() string {
+	return "assert the balance of an account"
+}
+
+func (c *assertBalance) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *assertBalance) parse(args string) (string, *big.Int, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 2 {
+		return "", nil, fmt.Errorf("need 2 arguments. usage: %s", c.Usage())
+	}
+
+	amountStr := context.ParseDecimalAmount(splitArgs[1].Text, 18)
+	amount, success := new(big.Int).SetString(amountStr, 10)
+	if success == false {
+		return "", nil, fmt.Errorf("fail to parse number: %s", splitArgs[1].Text)
+	}
+
+	return splitArgs[0].Text, amount, nil
+}
+
+func (c *assertBalance) Run(args string) (string, uint64, []*types.Event, error) {
+	accountName, expected, _ := c.parse(args)
+
+	state, err := context.Get().GetAccountState(accountName)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if balance := state.GetBalanceBigInt(); balance.Cmp(expected) != 0 {
+		return "", 0, nil, fmt.Errorf("balance of %s: expected: %s, but got: %s", accountName, expected, balance)
+	}
+	return "assert balance successfully", 0, nil, nil
+}
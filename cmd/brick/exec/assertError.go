@@ -0,0 +1,92 @@
+package exec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/rs/zerolog"
+)
+
+func init() {
+	registerExec(&assertError{})
+}
+
+type assertError struct{}
+
+func (c *assertError) Command() string {
+	return "asserterror"
+}
+
+func (c *assertError) Syntax() string {
+	return fmt.Sprintf("%s %s", context.ExpectedErrSymbol, context.CommandSymbol)
+}
+
+func (c *assertError) Usage() string {
+	return "asserterror `<expected_error>` <command> [command_args...]"
+}
+
+func (c *assertError) Describe() string {
+	return "run a command and assert that it fails with the expected error"
+}
+
+func (c *assertError) Validate(args string) error {
+	_, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *assertError) parse(args string) (string, Executor, string, error) {
+	expectedError, cmdLine := splitFirstChunk(args)
+	if expectedError == "" || cmdLine == "" {
+		return "", nil, "", fmt.Errorf("need an expected error and a command. usage: %s", c.Usage())
+	}
+
+	cmd, cmdArgs := context.ParseFirstWord(cmdLine)
+	executor := GetExecutor(cmd)
+	if executor == nil {
+		return "", nil, "", fmt.Errorf("command not found: %s", cmd)
+	} else if executor == GetExecutor(c.Command()) {
+		return "", nil, "", fmt.Errorf("cannot nest %s", c.Command())
+	}
+
+	return expectedError, executor, cmdArgs, nil
+}
+
+// splitFirstChunk splits the first word, or the first text surrounded by accents, from the rest of the args.
+func splitFirstChunk(args string) (string, string) {
+	args = strings.TrimSpace(args)
+	if strings.HasPrefix(args, "`") {
+		if end := strings.Index(args[1:], "`"); end >= 0 {
+			return args[1 : end+1], strings.TrimSpace(args[end+2:])
+		}
+	}
+	first, rest, _ := strings.Cut(args, " ")
+	return first, strings.TrimSpace(rest)
+}
+
+func (c *assertError) Run(args string) (string, uint64, []*types.Event, error) {
+	expectedError, executor, cmdArgs, _ := c.parse(args)
+
+	logLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel) // turn off log
+
+	err := executor.Validate(cmdArgs)
+	if err == nil {
+		_, _, _, err = executor.Run(cmdArgs)
+	}
+
+	zerolog.SetGlobalLevel(logLevel) // restore log level
+
+	if err == nil {
+		return "", 0, nil, fmt.Errorf("no error, expected: %s", expectedError)
+	}
+	if !strings.Contains(err.Error(), expectedError) {
+		return "", 0, nil, fmt.Errorf("expected error: %s, but got: %s", expectedError, err.Error())
+	}
+
+	Index(context.ExpectedErrSymbol, expectedError)
+
+	return "assert error successfully", 0, nil, nil
+}
@@ -0,0 +1,88 @@
+package exec
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&assertEvent{})
+}
+
+type assertEvent struct{}
+
+func (c *assertEvent) Command() string {
+	return "assertevent"
+}
+
+func (c *assertEvent) Syntax() string {
+	return fmt.Sprintf("%s %s %s", context.IndexSymbol, context.EventSymbol, context.ExpectedSymbol)
+}
+
+func (c *assertEvent) Usage() string {
+	return "assertevent <event_index> <event_name> `[expected_args_json]`"
+}
+
+func (c *assertEvent) Describe() string {
+	return "assert an event of the last transaction"
+}
+
+func (c *assertEvent) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *assertEvent) parse(args string) (int, string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 2 {
+		return 0, "", "", fmt.Errorf("need at least 2 arguments. usage: %s", c.Usage())
+	} else if len(splitArgs) > 3 {
+		return 0, "", "", fmt.Errorf("too many arguments. usage: %s", c.Usage())
+	}
+
+	index, err := strconv.Atoi(splitArgs[0].Text)
+	if err != nil || index < 0 {
+		return 0, "", "", fmt.Errorf("fail to parse event index: %s", splitArgs[0].Text)
+	}
+
+	expectedArgs := ""
+	if len(splitArgs) == 3 {
+		expectedArgs = splitArgs[2].Text
+	}
+
+	return index, splitArgs[1].Text, expectedArgs, nil
+}
+
+func (c *assertEvent) Run(args string) (string, uint64, []*types.Event, error) {
+	index, eventName, expectedArgs, _ := c.parse(args)
+
+	if lastTxHash == nil {
+		return "", 0, nil, fmt.Errorf("there is no transaction to check")
+	}
+	events := context.Get().GetEvents(lastTxHash)
+	if index >= len(events) {
+		return "", 0, nil, fmt.Errorf("event %d not found: the transaction has %d events", index, len(events))
+	}
+	event := events[index]
+	if event.GetEventName() != eventName {
+		return "", 0, nil, fmt.Errorf("event %d: expected: %s, but got: %s", index, eventName, event.GetEventName())
+	}
+	if expectedArgs != "" {
+		path := fmt.Sprintf("event %d args", index)
+		if err := compareJSON(path, parseJSONOrString(event.GetJsonArgs()), expectedArgs); err != nil {
+			return "", 0, nil, err
+		}
+	}
+
+	return "assert event successfully", 0, nil, nil
+}
@@ -0,0 +1,83 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&assertQuery{})
+}
+
+type assertQuery struct{}
+
+func (c *assertQuery) Command() string {
+	return "assertquery"
+}
+
+func (c *assertQuery) Syntax() string {
+	return fmt.Sprintf("%s %s %s %s %s", context.ContractSymbol, context.FunctionSymbol,
+		context.ContractArgsSymbol, context.JSONPathSymbol, context.ExpectedSymbol)
+}
+
+func (c *assertQuery) Usage() string {
+	return "assertquery <contract_name> <func_name> `<query_json_str>` <json_path> `<expected_json>`"
+}
+
+func (c *assertQuery) Describe() string {
+	return "query a smart contract and assert a value of the result at a json path, such as $.balance or $[0]"
+}
+
+func (c *assertQuery) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, _, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *assertQuery) parse(args string) (string, string, string, string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 5 {
+		return "", "", "", "", "", fmt.Errorf("need 5 arguments. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, // contractName
+		splitArgs[1].Text, // funcName
+		splitArgs[2].Text, // queryCode
+		splitArgs[3].Text, // jsonPath
+		splitArgs[4].Text, // expected
+		nil
+}
+
+func (c *assertQuery) Run(args string) (string, uint64, []*types.Event, error) {
+	contractName, funcName, queryCode, jsonPath, expected, _ := c.parse(args)
+
+	formattedQuery := fmt.Sprintf("{\"name\":\"%s\",\"args\":%s}", funcName, queryCode)
+
+	_, result, err := context.Get().QueryOnly(contractName, formattedQuery, "")
+	if err != nil {
+		return "", 0, nil, err
+	}
+	doc, err := parseJSON(result)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	actual, err := selectJSONPath(doc, jsonPath)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if err := compareJSON(jsonPath, actual, expected); err != nil {
+		return "", 0, nil, err
+	}
+
+	Index(context.ExpectedSymbol, expected)
+
+	return "assert query successfully", 0, nil, nil
+}
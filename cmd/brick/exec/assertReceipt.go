@@ -0,0 +1,69 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&assertReceipt{})
+}
+
+type assertReceipt struct{}
+
+func (c *assertReceipt) Command() string {
+	return "assertreceipt"
+}
+
+func (c *assertReceipt) Syntax() string {
+	return fmt.Sprintf("%s %s", context.JSONPathSymbol, context.ExpectedSymbol)
+}
+
+func (c *assertReceipt) Usage() string {
+	return "assertreceipt <json_path> `<expected_json>`"
+}
+
+func (c *assertReceipt) Describe() string {
+	return "assert a value of the receipt of the last transaction at a json path. the receipt has status, ret, gasUsed, feeUsed, contractAddress and events"
+}
+
+func (c *assertReceipt) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *assertReceipt) parse(args string) (string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 2 {
+		return "", "", fmt.Errorf("need 2 arguments. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, splitArgs[1].Text, nil
+}
+
+func (c *assertReceipt) Run(args string) (string, uint64, []*types.Event, error) {
+	jsonPath, expected, _ := c.parse(args)
+
+	doc, err := lastReceipt()
+	if err != nil {
+		return "", 0, nil, err
+	}
+	actual, err := selectJSONPath(doc, jsonPath)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if err := compareJSON(jsonPath, actual, expected); err != nil {
+		return "", 0, nil, err
+	}
+
+	return "assert receipt successfully", 0, nil, nil
+}
@@ -0,0 +1,83 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectJSONPath(t *testing.T) {
+	doc, err := parseJSON(`{"status":"SUCCESS","events":[{"name":"set","args":["a",1]}],"a.b":{"c":true}}`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{"$", toJSON(doc), ""},
+		{"", toJSON(doc), ""},
+		{"$.status", `"SUCCESS"`, ""},
+		{".status", `"SUCCESS"`, ""},
+		{"$.events[0].name", `"set"`, ""},
+		{"$.events[0].args[1]", `1`, ""},
+		{"$.events[0].args", `["a",1]`, ""},
+		{"$.missing", "", "key not found: missing"},
+		{"$.status.name", "", "not an object at .name"},
+		{"$.status[0]", "", "not an array at [0]"},
+		{"$.events[1]", "", "index out of range: 1"},
+		{"$.events[-1]", "", "index out of range: -1"},
+		{"$.events[x]", "", "invalid index x"},
+		{"$.events[0", "", "unclosed bracket"},
+		{"$events", "", "invalid path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := selectJSONPath(doc, tt.path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toJSON(got))
+		})
+	}
+}
+
+func TestCompareJSON(t *testing.T) {
+	tests := []struct {
+		actual   string
+		expected string
+		wantErr  bool
+	}{
+		{`1`, `1`, false},
+		{`1`, `1.0`, false},
+		{`1.5`, `1.50`, false},
+		{`100`, `1e2`, false},
+		{`1`, `2`, true},
+		{`1`, `"1"`, true},
+		{`123456789012345678901234567890`, `123456789012345678901234567890`, false},
+		{`123456789012345678901234567890`, `123456789012345678901234567891`, true},
+		{`{"a":[1,2],"b":null}`, `{"b":null,"a":[1.0,2]}`, false},
+		{`{"a":[1,2]}`, `{"a":[2,1]}`, true},
+		{`{"a":1}`, `{"a":1,"b":2}`, true},
+		{`"abc"`, `"abc"`, false},
+		{`true`, `false`, true},
+		{`1`, `1 2`, true},
+		{`1`, `{`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.actual+" "+tt.expected, func(t *testing.T) {
+			actual, err := parseJSON(tt.actual)
+			require.NoError(t, err)
+			err = compareJSON("$", actual, tt.expected)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -108,6 +108,7 @@ func (c *callContract) Run(args string) (string, uint64, []*types.Event, error)
 	if err != nil {
 		return "", 0, nil, err
 	}
+	setLastTx(callTx.Hash())
 
 	if expectedError != "" {
 		Index(context.ExpectedErrSymbol, expectedError)
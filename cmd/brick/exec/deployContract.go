@@ -100,6 +100,7 @@ func (c *deployContract) Run(args string) (string, uint64, []*types.Event, error
 	if err != nil {
 		return "", 0, nil, err
 	}
+	setLastTx(tx.Hash())
 
 	Index(context.ContractSymbol, contractName)
 	Index(context.AccountSymbol, contractName)
@@ -92,6 +92,7 @@ func (c *multicall) Run(args string) (string, uint64, []*types.Event, error) {
 	if err != nil {
 		return "", 0, nil, err
 	}
+	setLastTx(multicallTx.Hash())
 
 	if expectedError != "" {
 		Index(context.ExpectedErrSymbol, expectedError)
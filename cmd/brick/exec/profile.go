@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/contract"
+	"github.com/aergoio/aergo/v2/contract/vm_dummy"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&profileContract{})
+}
+
+type profileContract struct{}
+
+func (c *profileContract) Command() string {
+	return "profile"
+}
+
+func (c *profileContract) Syntax() string {
+	return fmt.Sprintf("%s %s %s %s %s %s", context.AccountSymbol,
+		context.AmountSymbol, context.ContractSymbol,
+		context.FunctionSymbol, context.ContractArgsSymbol, context.PathSymbol)
+}
+
+func (c *profileContract) Usage() string {
+	return fmt.Sprintf("profile <sender_name> <amount> <contract_name> <func_name> `[call_json_str]` `[output_file_path]`")
+}
+
+func (c *profileContract) Describe() string {
+	return "call a smart contract and show where the gas is consumed, in the folded stack format of flame graphs"
+}
+
+func (c *profileContract) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, _, _, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *profileContract) parse(args string) (string, *big.Int, string, string, string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 4 {
+		return "", nil, "", "", "", "", fmt.Errorf("need at least 4 arguments. usage: %s", c.Usage())
+	}
+
+	amountStr := context.ParseDecimalAmount(splitArgs[1].Text, 18)
+	amount, success := new(big.Int).SetString(amountStr, 10)
+	if success == false {
+		return "", nil, "", "", "", "", fmt.Errorf("fail to parse number %s", splitArgs[1].Text)
+	}
+
+	callCode := "[]"
+	if len(splitArgs) >= 5 {
+		callCode = splitArgs[4].Text
+	}
+
+	outPath := ""
+	if len(splitArgs) == 6 {
+		outPath = splitArgs[5].Text
+	} else if len(splitArgs) > 6 {
+		return "", nil, "", "", "", "", fmt.Errorf("too many arguments. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, //accountName
+		amount, //amount
+		splitArgs[2].Text, //contractName
+		splitArgs[3].Text, //funcName
+		callCode, //callCode
+		outPath, //outPath
+		nil
+}
+
+func (c *profileContract) Run(args string) (string, uint64, []*types.Event, error) {
+
+	accountName, amount, contractName, funcName, callCode, outPath, _ := c.parse(args)
+
+	formattedQuery := fmt.Sprintf("{\"name\":\"%s\",\"args\":%s}", funcName, callCode)
+
+	callTx := vm_dummy.NewLuaTxCallBig(accountName, contractName, amount, formattedQuery)
+
+	profiler := contract.NewProfiler(callTx.Hash())
+	if err := context.Get().ConnectBlockWithProfiler(profiler, callTx); err != nil {
+		return "", 0, nil, err
+	}
+	setLastTx(callTx.Hash())
+
+	receipt := context.Get().GetReceipt(callTx.Hash())
+	folded := profiler.Folded()
+
+	if outPath == "" {
+		return fmt.Sprintf("%s\n%s", receipt.Status, folded), receipt.GasUsed, context.Get().GetEvents(callTx.Hash()), nil
+	}
+	if err := os.WriteFile(outPath, []byte(folded), 0644); err != nil {
+		return "", 0, nil, err
+	}
+	if storage := profiler.FoldedStorage(); storage != "" {
+		if err := os.WriteFile(outPath+".storage", []byte(storage), 0644); err != nil {
+			return "", 0, nil, err
+		}
+	}
+	return fmt.Sprintf("%s: the profile is written to %s", receipt.Status, outPath), receipt.GasUsed, context.Get().GetEvents(callTx.Hash()), nil
+}
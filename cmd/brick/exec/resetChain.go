@@ -34,5 +34,6 @@ func (c *resetChain) Validate(args string) error {
 func (c *resetChain) Run(args string) (string, uint64, []*types.Event, error) {
 	context.Reset()
 	resetContractInfoInterface()
+	clearLastTx()
 	return "reset a dummy chain successfully", 0, nil, nil
 }
@@ -0,0 +1,62 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&restoreChain{})
+}
+
+type restoreChain struct{}
+
+func (c *restoreChain) Command() string {
+	return "restore"
+}
+
+func (c *restoreChain) Syntax() string {
+	return context.SnapshotSymbol
+}
+
+func (c *restoreChain) Usage() string {
+	return "restore <snapshot_name>"
+}
+
+func (c *restoreChain) Describe() string {
+	return "restore the chain to a snapshot by undoing the blocks after it"
+}
+
+func (c *restoreChain) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, err := c.parse(args)
+
+	return err
+}
+
+func (c *restoreChain) parse(args string) (string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return "", fmt.Errorf("invalid format. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, nil
+}
+
+func (c *restoreChain) Run(args string) (string, uint64, []*types.Event, error) {
+	name, _ := c.parse(args)
+
+	if err := context.RestoreSnapshot(name); err != nil {
+		return "", 0, nil, err
+	}
+	clearLastTx()
+
+	return fmt.Sprintf("restore snapshot %s at block %d", name, context.Get().BestBlockNo()), 0, nil, nil
+}
@@ -81,6 +81,7 @@ func (c *sendCoin) Run(args string) (string, uint64, []*types.Event, error) {
 	} else if err != nil {
 		return "", 0, nil, err
 	}
+	setLastTx(tx.Hash())
 
 	Index(context.AccountSymbol, receiverName)
 
@@ -0,0 +1,63 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&snapshotChain{})
+}
+
+type snapshotChain struct{}
+
+func (c *snapshotChain) Command() string {
+	return "snapshot"
+}
+
+func (c *snapshotChain) Syntax() string {
+	return context.SnapshotSymbol
+}
+
+func (c *snapshotChain) Usage() string {
+	return "snapshot <snapshot_name>"
+}
+
+func (c *snapshotChain) Describe() string {
+	return "save the current state of the chain with a name, to restore it later"
+}
+
+func (c *snapshotChain) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, err := c.parse(args)
+
+	return err
+}
+
+func (c *snapshotChain) parse(args string) (string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return "", fmt.Errorf("invalid format. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, nil
+}
+
+func (c *snapshotChain) Run(args string) (string, uint64, []*types.Event, error) {
+	name, _ := c.parse(args)
+
+	if err := context.SaveSnapshot(name); err != nil {
+		return "", 0, nil, err
+	}
+
+	Index(context.SnapshotSymbol, name)
+
+	return fmt.Sprintf("save snapshot %s at block %d", name, context.Get().BestBlockNo()), 0, nil, nil
+}
@@ -0,0 +1,51 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func init() {
+	registerExec(&testCase{})
+}
+
+type testCase struct{}
+
+func (c *testCase) Command() string {
+	return "case"
+}
+
+func (c *testCase) Syntax() string {
+	return context.TestCaseSymbol
+}
+
+func (c *testCase) Usage() string {
+	return "case `<test_case_name>`"
+}
+
+func (c *testCase) Describe() string {
+	return "start a test case. the following commands belong to the case, when the file is run by brick test"
+}
+
+func (c *testCase) Validate(args string) error {
+	_, err := c.parse(args)
+
+	return err
+}
+
+func (c *testCase) parse(args string) (string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return "", fmt.Errorf("invalid format. usage: %s", c.Usage())
+	}
+
+	return splitArgs[0].Text, nil
+}
+
+func (c *testCase) Run(args string) (string, uint64, []*types.Event, error) {
+	name, _ := c.parse(args)
+
+	return fmt.Sprintf("test case: %s", name), 0, nil, nil
+}
@@ -0,0 +1,249 @@
+package exec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/v2/cmd/brick/context"
+	"github.com/mattn/go-colorable"
+	"github.com/rs/zerolog"
+)
+
+// junitTestSuites is the report of brick test in the JUnit XML format, which is read by most CI services.
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+	elapsed  time.Duration
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// RunTests runs the brick files as tests, and writes the report to junitPath in the JUnit XML format, if it is not
+// empty. A directory is searched for the files with the .brick extension. Each file is run on a new chain, and is
+// split into the test cases by the case command; a file without the case command is a single test case. The cases of
+// a file share the chain, so use snapshot and restore for the fixtures. A case fails at the first failed command, and
+// the rest of the case is skipped. It returns the exit code, which is 1 if any case fails.
+func RunTests(paths []string, junitPath string) int {
+	stdOut := colorable.NewColorableStdout()
+
+	files, err := findTestFiles(paths)
+	if err != nil {
+		fmt.Fprintf(stdOut, "\x1B[31;1m%s\x1B[0m\n", err.Error())
+		return 1
+	} else if len(files) == 0 {
+		fmt.Fprintf(stdOut, "\x1B[31;1mno brick file to test\x1B[0m\n")
+		return 1
+	}
+
+	// run batch commands in the files as nested batches, so that their errors are counted for the test
+	runner := GetExecutor("batch").(*batch)
+	runner.level++
+	defer func() {
+		runner.level--
+		batchErrorCount = 0
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	}()
+
+	report := &junitTestSuites{}
+	var elapsed time.Duration
+	for _, file := range files {
+		suite := runTestFile(stdOut, file)
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		elapsed += suite.elapsed
+	}
+	report.Time = junitTime(elapsed)
+
+	if report.Failures == 0 {
+		fmt.Fprintf(stdOut, "\x1B[32;1mPASS: %d test cases\x1B[0m\n", report.Tests)
+	} else {
+		fmt.Fprintf(stdOut, "\x1B[31;1mFAIL: %d of %d test cases\x1B[0m\n", report.Failures, report.Tests)
+	}
+
+	if junitPath != "" {
+		if err := writeJUnitFile(junitPath, report); err != nil {
+			fmt.Fprintf(stdOut, "\x1B[31;1mfail to write the junit report %s: %s\x1B[0m\n", junitPath, err.Error())
+			return 1
+		}
+	}
+
+	if report.Failures > 0 {
+		return 1
+	}
+	return 0
+}
+
+func writeJUnitFile(path string, report *junitTestSuites) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = writeJUnit(f, report); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeJUnit writes the report as an XML document.
+func writeJUnit(w io.Writer, report *junitTestSuites) error {
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append([]byte(xml.Header), append(out, '\n')...))
+	return err
+}
+
+func findTestFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var found []string
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(d.Name(), ".brick") {
+				found = append(found, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+func runTestFile(stdOut io.Writer, file string) *junitTestSuite {
+	suite := &junitTestSuite{Name: file}
+
+	var (
+		tc       *junitTestCase
+		started  time.Time
+		skipCase bool
+	)
+	finish := func() {
+		if tc == nil {
+			return
+		}
+		d := time.Since(started)
+		tc.Time = junitTime(d)
+		suite.elapsed += d
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+			fmt.Fprintf(stdOut, "\x1B[31;1mFAIL\x1B[0m %s: %s\n%s\n", file, tc.Name, tc.Failure.Text)
+		} else if verboseBatch {
+			fmt.Fprintf(stdOut, "\x1B[32;1mPASS\x1B[0m %s: %s\n", file, tc.Name)
+		}
+		suite.Cases = append(suite.Cases, tc)
+		tc = nil
+	}
+	start := func(name string) {
+		finish()
+		tc = &junitTestCase{Name: name, ClassName: file}
+		started = time.Now()
+		skipCase = false
+	}
+
+	// each file is tested on a new chain
+	context.Reset()
+	resetContractInfoInterface()
+	clearLastTx()
+	if !verboseBatch {
+		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	}
+
+	cmdLines, err := GetExecutor("batch").(*batch).readBatchFile(file)
+	if err != nil {
+		start(filepath.Base(file))
+		tc.Failure = &junitFailure{Message: err.Error(), Text: err.Error()}
+		finish()
+		return suite
+	}
+
+	for i, line := range cmdLines {
+		cmd, args := context.ParseFirstWord(line)
+		if len(cmd) == 0 || context.Comment == cmd {
+			continue
+		}
+		if cmd == "case" {
+			name, err := (&testCase{}).parse(args)
+			if err != nil {
+				name = args
+			}
+			start(name)
+			continue
+		}
+		if tc == nil {
+			start(filepath.Base(file))
+		}
+		if skipCase {
+			continue
+		}
+
+		errorCount := batchErrorCount
+		letBatchKnowErr = nil
+		Broker(line)
+		if batchErrorCount > errorCount {
+			msg := "fail to run the command"
+			if letBatchKnowErr != nil {
+				msg = letBatchKnowErr.Error()
+			}
+			tc.Failure = &junitFailure{
+				Message: msg,
+				Text:    fmt.Sprintf("%s:%d %s %s\n%s", file, i+1, cmd, args, msg),
+			}
+			letBatchKnowErr = nil
+			// skip the rest of the case
+			skipCase = true
+		}
+	}
+	finish()
+
+	return suite
+}
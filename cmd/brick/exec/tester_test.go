@@ -0,0 +1,70 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *junitTestSuites
+		want   []string
+	}{
+		{
+			"empty",
+			&junitTestSuites{Time: "0.000"},
+			[]string{`<testsuites tests="0" failures="0" time="0.000"></testsuites>`},
+		},
+		{
+			"pass",
+			&junitTestSuites{Tests: 1, Time: "0.100", Suites: []*junitTestSuite{{
+				Name: "token.brick", Tests: 1, Time: "0.100",
+				Cases: []*junitTestCase{{Name: "transfer", ClassName: "token.brick", Time: "0.100"}},
+			}}},
+			[]string{
+				`<testsuite name="token.brick" tests="1" failures="0" time="0.100">`,
+				`<testcase name="transfer" classname="token.brick" time="0.100"></testcase>`,
+			},
+		},
+		{
+			"fail",
+			&junitTestSuites{Tests: 2, Failures: 1, Time: "1.500", Suites: []*junitTestSuite{{
+				Name: "token.brick", Tests: 2, Failures: 1, Time: "1.500",
+				Cases: []*junitTestCase{
+					{Name: "transfer", ClassName: "token.brick", Time: "0.500"},
+					{Name: "burn", ClassName: "token.brick", Time: "1.000",
+						Failure: &junitFailure{Message: "line 7: expected: 1, but got: 2", Text: "assert & <fail>"}},
+				},
+			}}},
+			[]string{
+				`<testsuites tests="2" failures="1" time="1.500">`,
+				`<testcase name="transfer" classname="token.brick" time="0.500"></testcase>`,
+				`<failure message="line 7: expected: 1, but got: 2">assert &amp; &lt;fail&gt;</failure>`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeJUnit(&buf, tt.report))
+			out := buf.String()
+			assert.True(t, strings.HasPrefix(out, xml.Header))
+			assert.True(t, strings.HasSuffix(out, "\n"))
+			for _, w := range tt.want {
+				assert.Contains(t, out, w)
+			}
+
+			var read junitTestSuites
+			require.NoError(t, xml.Unmarshal(buf.Bytes(), &read))
+			assert.Equal(t, tt.report.Tests, read.Tests)
+			assert.Equal(t, tt.report.Failures, read.Failures)
+			assert.Equal(t, len(tt.report.Suites), len(read.Suites))
+		})
+	}
+}
@@ -42,5 +42,6 @@ func (c *undoCommit) Run(args string) (string, uint64, []*types.Event, error) {
 	if err != nil {
 		return "", 0, nil, err
 	}
+	clearLastTx()
 	return "Undo, Succesfully", 0, nil, nil
 }
@@ -71,12 +71,18 @@ type JSONPolarisPeer struct {
 	*PolarisPeerAlias
 	Connected time.Time `json:"connected"`
 	LastCheck time.Time `json:"lastCheck"`
+	// Source is base58 encoded id of sibling polaris which the peer is synced from.
+	Source string `json:"source,omitempty"`
 }
 
 func NewJSONPolarisPeer(pp *types.PolarisPeer) JSONPolarisPeer {
-	return JSONPolarisPeer{
-		(*PolarisPeerAlias)(pp),
-		time.Unix(0, pp.Connected),
-		time.Unix(0, pp.LastCheck),
+	jp := JSONPolarisPeer{
+		PolarisPeerAlias: (*PolarisPeerAlias)(pp),
+		Connected:        time.Unix(0, pp.Connected),
+		LastCheck:        time.Unix(0, pp.LastCheck),
 	}
+	if len(pp.Source) > 0 {
+		jp.Source = base58.Encode(pp.Source)
+	}
+	return jp
 }
@@ -0,0 +1,112 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/jsonrpc"
+	"github.com/spf13/cobra"
+)
+
+// polarisSvcName is the component name of peer map service in polaris
+const polarisSvcName = "polarisSvc"
+
+var federationCmd = &cobra.Command{
+	Use:   "federation <subcommand>",
+	Short: "commands for federation with sibling polarises",
+}
+
+var fedStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show sync status of sibling polarises",
+	Run:   execFederationStatus,
+}
+
+var fedPeersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "Show peers synced from sibling polarises",
+	Run:   execFederationPeers,
+}
+
+var fedSibling string
+
+func init() {
+	rootCmd.AddCommand(federationCmd)
+	federationCmd.AddCommand(fedStatusCmd)
+	federationCmd.AddCommand(fedPeersCmd)
+
+	fedPeersCmd.Flags().StringVar(&fedSibling, "sibling", "", "Show only peers synced from this sibling polaris (base58 peer id)")
+}
+
+// FederationStatus is federation part of polaris statistics.
+type FederationStatus struct {
+	Peers       int `json:"peers"`
+	DirectPeers int `json:"direct_peers"`
+	SyncedPeers int `json:"synced_peers"`
+	Siblings    []struct {
+		PeerID           string    `json:"peerID"`
+		Address          string    `json:"address"`
+		LastTrial        time.Time `json:"lastTrial"`
+		LastSync         time.Time `json:"lastSync"`
+		LastError        string    `json:"lastError,omitempty"`
+		ConsecutiveFails int       `json:"consecutiveFails"`
+		SyncedPeers      int       `json:"syncedPeers"`
+	} `json:"siblings"`
+}
+
+func execFederationStatus(cmd *cobra.Command, args []string) {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(3))
+	msg, err := client.NodeState(context.Background(), &types.NodeReq{Component: []byte(polarisSvcName), Timeout: b})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	var stats map[string]struct {
+		Status string           `json:"status"`
+		Actor  FederationStatus `json:"actor"`
+	}
+	if err = json.Unmarshal(msg.Value, &stats); err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	stat, found := stats[polarisSvcName]
+	if !found {
+		cmd.Printf("Failed: no status of %s\n", polarisSvcName)
+		return
+	}
+	cmd.Println(jsonrpc.MarshalJSON(stat.Actor))
+}
+
+func execFederationPeers(cmd *cobra.Command, args []string) {
+	var sibling []byte
+	if len(fedSibling) > 0 {
+		var err error
+		if sibling, err = base58.Decode(fedSibling); err != nil {
+			cmd.Printf("Failed: invalid sibling id %s\n", err.Error())
+			return
+		}
+	}
+	msg, err := client.CurrentList(context.Background(), &types.Paginations{Size: 500})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	ppList := make([]JSONPolarisPeer, 0, len(msg.Peers))
+	for _, p := range msg.Peers {
+		if len(p.Source) == 0 || (sibling != nil && types.PeerID(p.Source) != types.PeerID(sibling)) {
+			continue
+		}
+		ppList = append(ppList, NewJSONPolarisPeer(p))
+	}
+	cmd.Println(jsonrpc.MarshalJSON(ppList))
+}
@@ -0,0 +1,238 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/aergoio/aergo/v2/cmd/polaris/common"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/p2p/p2pkey"
+	"github.com/aergoio/aergo/v2/p2p/p2putil"
+	v030 "github.com/aergoio/aergo/v2/p2p/v030"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+// SiblingSyncInterval is interval to pull registered peers from sibling polarises
+const SiblingSyncInterval = time.Minute
+
+// siblingState is the sync status of a sibling polaris. Sibling polarises pull peers which are registered directly to
+// each other by map query, so that a node can get the whole peer list from any one of polarises in the federation.
+type siblingState struct {
+	meta p2pcommon.PeerMeta
+
+	lastTrial time.Time
+	lastSync  time.Time
+	lastErr   error
+	contFail  int
+	peerCnt   int
+}
+
+// SiblingStatus is exported form of sibling polaris status
+type SiblingStatus struct {
+	PeerID           string    `json:"peerID"`
+	Address          string    `json:"address"`
+	LastTrial        time.Time `json:"lastTrial"`
+	LastSync         time.Time `json:"lastSync"`
+	LastError        string    `json:"lastError,omitempty"`
+	ConsecutiveFails int       `json:"consecutiveFails"`
+	SyncedPeers      int       `json:"syncedPeers"`
+}
+
+func (pms *PeerMapService) initSiblings(addrs []string) {
+	selfID := pms.ntc.SelfMeta().ID
+	for _, addrStr := range addrs {
+		meta, err := p2putil.FromMultiAddrString(addrStr)
+		if err != nil {
+			pms.Logger.Warn().Err(err).Str("addr_str", addrStr).Msg("invalid sibling polaris address in config file")
+			continue
+		}
+		if meta.ID == selfID {
+			continue
+		}
+		pms.siblings[meta.ID] = &siblingState{meta: meta}
+	}
+	if len(pms.siblings) > 0 {
+		metas := make([]p2pcommon.PeerMeta, 0, len(pms.siblings))
+		for _, s := range pms.siblings {
+			metas = append(metas, s.meta)
+		}
+		pms.Logger.Info().Array("siblings", p2putil.NewLogPeerMetasMarshaller(metas, 10)).Msg("federating with sibling polarises")
+	}
+}
+
+func (pms *PeerMapService) isSibling(id types.PeerID) bool {
+	_, found := pms.siblings[id]
+	return found
+}
+
+func (pms *PeerMapService) runBackground() {
+	syncTicker := time.NewTicker(SiblingSyncInterval)
+	saveTicker := time.NewTicker(PeerStoreSaveInterval)
+	defer syncTicker.Stop()
+	defer saveTicker.Stop()
+
+	// sync first without waiting, to fill registry quickly after restart
+	pms.syncSiblings()
+	for {
+		select {
+		case <-syncTicker.C:
+			pms.syncSiblings()
+		case <-saveTicker.C:
+			if pms.storePeers {
+				pms.savePeerStore()
+			}
+		case <-pms.finish:
+			return
+		}
+	}
+}
+
+func (pms *PeerMapService) syncSiblings() {
+	for id, sibling := range pms.siblings {
+		addrs, err := pms.querySibling(sibling.meta)
+		pms.sibMutex.Lock()
+		sibling.lastTrial = time.Now()
+		sibling.lastErr = err
+		if err != nil {
+			sibling.contFail++
+			pms.sibMutex.Unlock()
+			pms.Logger.Info().Err(err).Stringer("polarisID", types.LogPeerShort(id)).Msg("failed to sync with sibling polaris")
+			continue
+		}
+		sibling.contFail = 0
+		sibling.lastSync = sibling.lastTrial
+		sibling.peerCnt = len(addrs)
+		pms.sibMutex.Unlock()
+
+		added, removed := pms.mergeSiblingPeers(id, addrs)
+		pms.Logger.Debug().Stringer("polarisID", types.LogPeerShort(id)).Int("peer_cnt", len(addrs)).Int("added", added).Int("removed", removed).Msg("synced with sibling polaris")
+	}
+}
+
+// querySibling sends map query to sibling polaris without registering itself, and returns peers registered to sibling.
+func (pms *PeerMapService) querySibling(meta p2pcommon.PeerMeta) ([]*types.PeerAddress, error) {
+	s, err := pms.nt.GetOrCreateStreamWithTTL(meta, common.PolarisConnectionTTL, common.PolarisMapSub)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	rw := v030.NewV030ReadWriter(bufio.NewReader(s), bufio.NewWriter(s), nil)
+
+	selfAddr := pms.ntc.SelfMeta().ToPeerAddress()
+	chainBytes, _ := pms.ntc.GenesisChainID().Bytes()
+	status := &types.Status{Sender: &selfAddr, ChainID: chainBytes, Version: p2pkey.NodeVersion(), NoExpose: true}
+	query := &types.MapQuery{Status: status, Size: ResponseMaxPeerLimit, AddMe: false}
+	bytes, err := p2putil.MarshalMessageBody(query)
+	if err != nil {
+		return nil, err
+	}
+	if err = rw.WriteMsg(common.NewPolarisMessage(p2pcommon.NewMsgID(), common.MapQuery, bytes)); err != nil {
+		return nil, err
+	}
+
+	data, err := rw.ReadMsg()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.MapResponse{}
+	if err = p2putil.UnmarshalMessageBody(data.Payload(), resp); err != nil {
+		return nil, err
+	}
+	if resp.Status != types.ResultStatus_OK {
+		return nil, fmt.Errorf("remote error %s %s", resp.Status.String(), resp.Message)
+	}
+	return resp.Addresses, nil
+}
+
+// handleSiblingQuery returns only peers which are registered directly to this polaris, so that synced peers are not
+// echoed back to sibling polarises.
+func (pms *PeerMapService) handleSiblingQuery(query *types.MapQuery) (*types.MapResponse, error) {
+	if query.Status == nil {
+		return nil, fmt.Errorf("malformed query %v", query)
+	}
+	resp := &types.MapResponse{}
+	sameChain, err := pms.checkChain(query.Status.ChainID)
+	if err != nil || !sameChain {
+		resp.Status = types.ResultStatus_UNAUTHENTICATED
+		resp.Message = "different chain"
+		return resp, nil
+	}
+	maxPeers := int(query.Size)
+	if maxPeers <= 0 || maxPeers > ResponseMaxPeerLimit {
+		maxPeers = ResponseMaxPeerLimit
+	}
+	resp.Addresses = pms.collectPeers(maxPeers, func(ps *peerState) bool {
+		return len(ps.source) == 0
+	})
+	resp.Status = types.ResultStatus_OK
+	return resp, nil
+}
+
+// mergeSiblingPeers adds or updates peers received from sibling, and removes peers which were synced from the same
+// sibling but are not in the sibling anymore. Peers registered directly to this polaris take precedence.
+func (pms *PeerMapService) mergeSiblingPeers(sibling types.PeerID, addrs []*types.PeerAddress) (added, removed int) {
+	selfID := pms.ntc.SelfMeta().ID
+	received := make(map[types.PeerID]bool, len(addrs))
+	now := time.Now()
+
+	pms.rwmutex.Lock()
+	defer pms.rwmutex.Unlock()
+	for _, pa := range addrs {
+		meta := p2pcommon.FromPeerAddress(pa)
+		if meta.ID == selfID || len(meta.Addresses) == 0 || pms.isSibling(meta.ID) {
+			continue
+		}
+		// ban is checked only by ip address, since resolving domain names of many peers is too heavy.
+		if ip := net.ParseIP(meta.PrimaryAddress()); ip != nil {
+			if banned, _ := pms.lm.IsBanned(ip.String(), meta.ID); banned {
+				continue
+			}
+		}
+		received[meta.ID] = true
+		prev, found := pms.peerRegistry[meta.ID]
+		if !found {
+			pms.peerRegistry[meta.ID] = &peerState{PeerMapService: pms, meta: meta, addr: meta.ToPeerAddress(), connected: now, lCheckTime: now, source: sibling}
+			added++
+		} else if len(prev.source) > 0 && !isEqualMeta(prev.meta, meta) {
+			prev.meta = meta
+			prev.addr = meta.ToPeerAddress()
+			prev.source = sibling
+		}
+	}
+	// the response was clipped, so absence of a peer doesn't mean it was removed in sibling
+	if len(addrs) >= ResponseMaxPeerLimit {
+		return
+	}
+	for id, ps := range pms.peerRegistry {
+		if ps.source == sibling && !received[id] {
+			delete(pms.peerRegistry, id)
+			removed++
+		}
+	}
+	return
+}
+
+func (pms *PeerMapService) siblingStatuses() []SiblingStatus {
+	pms.sibMutex.Lock()
+	defer pms.sibMutex.Unlock()
+	statuses := make([]SiblingStatus, 0, len(pms.siblings))
+	for id, s := range pms.siblings {
+		st := SiblingStatus{PeerID: types.IDB58Encode(id), LastTrial: s.lastTrial, LastSync: s.lastSync,
+			ConsecutiveFails: s.contFail, SyncedPeers: s.peerCnt}
+		if len(s.meta.Addresses) > 0 {
+			st.Address = s.meta.Addresses[0].String()
+		}
+		if s.lastErr != nil {
+			st.LastError = s.lastErr.Error()
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
@@ -0,0 +1,119 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/v2/cmd/polaris/common"
+	"github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func TestPeerMapService_mergeSiblingPeers(t *testing.T) {
+	sibling := types.RandomPeerID()
+	ad1, _ := types.ParseMultiaddr("/ip4/123.45.67.89/tcp/7846")
+	ad2, _ := types.ParseMultiaddr("/ip4/222.8.8.8/tcp/7846")
+	direct := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad1}, ID: types.RandomPeerID()}
+	synced := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad2}, ID: types.RandomPeerID()}
+	newOne := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad2}, ID: types.RandomPeerID()}
+
+	tests := []struct {
+		name string
+		recv []p2pcommon.PeerMeta
+
+		wantAdded   int
+		wantRemoved int
+		wantSynced  []types.PeerID
+	}{
+		{"TKeep", mm(synced), 0, 0, []types.PeerID{synced.ID}},
+		{"TAdd", mm(synced, newOne), 1, 0, []types.PeerID{synced.ID, newOne.ID}},
+		{"TRemove", mm(newOne), 1, 1, []types.PeerID{newOne.ID}},
+		{"TDirectFirst", mm(direct), 0, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pms := NewPolarisService(pmapDummyCfg, pmapDummyNTC)
+			pms.peerRegistry[direct.ID] = &peerState{PeerMapService: pms, meta: direct, addr: direct.ToPeerAddress(), lCheckTime: time.Now()}
+			pms.peerRegistry[synced.ID] = &peerState{PeerMapService: pms, meta: synced, addr: synced.ToPeerAddress(), lCheckTime: time.Now(), source: sibling}
+
+			addrs := make([]*types.PeerAddress, len(tt.recv))
+			for i, m := range tt.recv {
+				pa := m.ToPeerAddress()
+				addrs[i] = &pa
+			}
+			added, removed := pms.mergeSiblingPeers(sibling, addrs)
+			if added != tt.wantAdded || removed != tt.wantRemoved {
+				t.Errorf("mergeSiblingPeers() = (%v, %v), want (%v, %v)", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+			if ps, found := pms.peerRegistry[direct.ID]; !found || len(ps.source) > 0 {
+				t.Errorf("mergeSiblingPeers() changed directly registered peer")
+			}
+			if len(pms.peerRegistry) != len(tt.wantSynced)+1 {
+				t.Errorf("mergeSiblingPeers() registry size = %v, want %v", len(pms.peerRegistry), len(tt.wantSynced)+1)
+			}
+			for _, id := range tt.wantSynced {
+				if ps, found := pms.peerRegistry[id]; !found || ps.source != sibling {
+					t.Errorf("mergeSiblingPeers() peer %v is not synced from sibling", id)
+				}
+			}
+		})
+	}
+}
+
+func TestPeerMapService_handleSiblingQuery(t *testing.T) {
+	mainnetbytes, _ := common.ONEMainNet.Bytes()
+	pmapDummyNTC.chainID = &common.ONEMainNet
+	ad1, _ := types.ParseMultiaddr("/ip4/123.45.67.89/tcp/7846")
+	direct := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad1}, ID: types.RandomPeerID()}
+	synced := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad1}, ID: types.RandomPeerID()}
+
+	tests := []struct {
+		name    string
+		query   *types.MapQuery
+		wantErr bool
+
+		wantStatus types.ResultStatus
+		wantCnt    int
+	}{
+		{"TMissingStat", &types.MapQuery{Size: 10}, true, types.ResultStatus_OK, 0},
+		{"TDiffChain", &types.MapQuery{Status: &types.Status{ChainID: []byte{0x01, 0x02}}, Size: 10}, false, types.ResultStatus_UNAUTHENTICATED, 0},
+		{"TOnlyDirect", &types.MapQuery{Status: &types.Status{ChainID: mainnetbytes}, Size: 10}, false, types.ResultStatus_OK, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pms := NewPolarisService(pmapDummyCfg, pmapDummyNTC)
+			pms.peerRegistry[direct.ID] = &peerState{PeerMapService: pms, meta: direct, addr: direct.ToPeerAddress()}
+			pms.peerRegistry[synced.ID] = &peerState{PeerMapService: pms, meta: synced, addr: synced.ToPeerAddress(), source: types.RandomPeerID()}
+
+			got, err := pms.handleSiblingQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handleSiblingQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != tt.wantStatus || len(got.Addresses) != tt.wantCnt {
+				t.Errorf("handleSiblingQuery() = (%v, %v), want (%v, %v)", got.Status, len(got.Addresses), tt.wantStatus, tt.wantCnt)
+			}
+		})
+	}
+}
+
+func TestPeerMapService_initSiblings(t *testing.T) {
+	conf := &config.Config{P2P: &config.P2PConfig{}, Auth: &config.AuthConfig{},
+		Polaris: &config.PolarisConfig{Siblings: []string{
+			"/ip4/211.34.56.78/tcp/8916/p2p/16Uiu2HAmFqptXPfcdaCdwipB2fhHATgKGVFVPehDAPZsDKSU7jRm",
+			"/ip4/211.34.56.79/tcp/8916", // no peer id
+		}}}
+	pms := NewPolarisService(conf, pmapDummyNTC)
+	sibID, _ := types.IDB58Decode("16Uiu2HAmFqptXPfcdaCdwipB2fhHATgKGVFVPehDAPZsDKSU7jRm")
+	if len(pms.siblings) != 1 || !pms.isSibling(sibID) {
+		t.Errorf("initSiblings() siblings = %v, want only %v", pms.siblingStatuses(), sibID)
+	}
+}
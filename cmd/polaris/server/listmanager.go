@@ -83,9 +83,12 @@ func (lm *polarisListManager) loadListFile() {
 }
 
 func (lm *polarisListManager) saveListFile() {
+	if !lm.enabled {
+		return
+	}
 	blFile := filepath.Join(lm.authDir, localListFile)
 	lm.logger.Debug().Str("file", blFile).Msg("Saving local blacklist file")
-	jsonFile, err := os.OpenFile(blFile, os.O_WRONLY|os.O_CREATE, 0644)
+	jsonFile, err := os.OpenFile(blFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		lm.logger.Info().Err(err).Str("file", blFile).Msg("Failed to open blacklist file for writing")
 		return
@@ -144,9 +147,7 @@ func (lm *polarisListManager) Start() {
 
 func (lm *polarisListManager) Stop() {
 	lm.logger.Debug().Msg("stopping list manager")
-	if lm.enabled {
-		lm.saveListFile()
-	}
+	lm.saveListFile()
 }
 
 func (lm *polarisListManager) IsBanned(addr string, pid types.PeerID) (bool, time.Time) {
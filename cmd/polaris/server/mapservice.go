@@ -70,6 +70,13 @@ type PeerMapService struct {
 
 	rwmutex      *sync.RWMutex
 	peerRegistry map[types.PeerID]*peerState
+
+	storePeers bool
+	dataDir    string
+
+	sibMutex sync.Mutex
+	siblings map[types.PeerID]*siblingState
+	finish   chan struct{}
 }
 
 func NewPolarisService(cfg *config.Config, ntc p2pcommon.NTContainer) *PeerMapService {
@@ -77,6 +84,10 @@ func NewPolarisService(cfg *config.Config, ntc p2pcommon.NTContainer) *PeerMapSe
 		rwmutex:      &sync.RWMutex{},
 		peerRegistry: make(map[types.PeerID]*peerState),
 		allowPrivate: cfg.Polaris.AllowPrivate,
+		storePeers:   cfg.Polaris.EnablePeerStore,
+		dataDir:      cfg.BaseConfig.DataDir,
+		siblings:     make(map[types.PeerID]*siblingState),
+		finish:       make(chan struct{}),
 	}
 
 	pms.BaseComponent = component.NewBaseComponent(PolarisSvc, pms, log.NewLogger("polaris"))
@@ -87,6 +98,7 @@ func NewPolarisService(cfg *config.Config, ntc p2pcommon.NTContainer) *PeerMapSe
 	pms.PrivateNet = !ntc.GenesisChainID().MainNet
 
 	pms.lm = NewPolarisListManager(cfg.Polaris, cfg.BaseConfig.AuthDir, pms.Logger)
+	pms.initSiblings(cfg.Polaris.Siblings)
 	// initialize map Servers
 	return pms
 }
@@ -100,23 +112,45 @@ func (pms *PeerMapService) BeforeStart() {}
 func (pms *PeerMapService) AfterStart() {
 	pms.nt = pms.ntc.GetNetworkTransport()
 	pms.lm.Start()
+	if pms.storePeers {
+		pms.loadPeerStore()
+	}
 	pms.Logger.Info().Str("minAergoVer", p2pcommon.MinimumAergoVersion).Str("maxAergoVer", p2pcommon.MaximumAergoVersion).Str("version", string(common.PolarisMapSub)).Msg("Starting polaris listening")
 	pms.nt.AddStreamHandler(common.PolarisMapSub, pms.onConnect)
 	pms.hc.Start()
+	if pms.storePeers || len(pms.siblings) > 0 {
+		go pms.runBackground()
+	}
 }
 
 func (pms *PeerMapService) BeforeStop() {
 	if pms.nt != nil {
 		pms.hc.Stop()
 		pms.nt.RemoveStreamHandler(common.PolarisMapSub)
+		close(pms.finish)
+		if pms.storePeers {
+			pms.savePeerStore()
+		}
 	}
 	pms.lm.Stop()
 }
 
 func (pms *PeerMapService) Statistics() *map[string]interface{} {
-	return nil
-	//dummy := make(map[string]interface{})
-	//return &dummy
+	pms.rwmutex.RLock()
+	total, synced := len(pms.peerRegistry), 0
+	for _, ps := range pms.peerRegistry {
+		if len(ps.source) > 0 {
+			synced++
+		}
+	}
+	pms.rwmutex.RUnlock()
+	stat := map[string]interface{}{
+		"peers":        total,
+		"direct_peers": total - synced,
+		"synced_peers": synced,
+		"siblings":     pms.siblingStatuses(),
+	}
+	return &stat
 }
 
 func (pms *PeerMapService) onConnect(s types.Stream) {
@@ -146,7 +180,12 @@ func (pms *PeerMapService) onConnect(s types.Stream) {
 		pms.Logger.Info().Str("address", remoteIP.String()).Stringer(p2putil.LogPeerID, types.LogPeerShort(peerID)).Msg("close soon banned peer")
 		return
 	}
-	resp, err := pms.handleQuery(conn, container, query)
+	var resp *types.MapResponse
+	if pms.isSibling(peerID) {
+		resp, err = pms.handleSiblingQuery(query)
+	} else {
+		resp, err = pms.handleQuery(conn, container, query)
+	}
 	if err != nil {
 		pms.Logger.Info().Err(err).Stringer(p2putil.LogPeerID, types.LogPeerShort(peerID)).Msg("failed to handle query")
 		return
@@ -257,11 +296,18 @@ func (pms *PeerMapService) handleQuery(conn p2pcommon.RemoteConn, container p2pc
 }
 
 func (pms *PeerMapService) retrieveList(maxPeers int, exclude types.PeerID) []*types.PeerAddress {
+	return pms.collectPeers(maxPeers, func(ps *peerState) bool {
+		return ps.meta.ID != exclude
+	})
+}
+
+// collectPeers returns at most maxPeers addresses of registered peers which filter returns true.
+func (pms *PeerMapService) collectPeers(maxPeers int, filter func(ps *peerState) bool) []*types.PeerAddress {
 	list := make([]*types.PeerAddress, 0, maxPeers)
 	pms.rwmutex.RLock()
 	defer pms.rwmutex.RUnlock()
-	for id, ps := range pms.peerRegistry {
-		if id == exclude {
+	for _, ps := range pms.peerRegistry {
+		if !filter(ps) {
 			continue
 		}
 		list = append(list, &ps.addr)
@@ -289,6 +335,8 @@ func (pms *PeerMapService) registerPeer(receivedMeta p2pcommon.PeerMeta, conn p2
 			prev.meta = receivedMeta
 			prev.addr = receivedMeta.ToPeerAddress()
 		}
+		// peer registered directly is not managed by sibling anymore
+		prev.source = ""
 		prev.lCheckTime = now
 	}
 	return nil
@@ -421,10 +469,15 @@ func (pms *PeerMapService) Receive(context actor.Context) {
 			context.Respond(types.RPCErrInvalidArgument)
 		}
 		pms.lm.AddEntry(entry)
+		pms.lm.saveListFile()
 		context.Respond(nil)
 		go pms.applyNewBLEntry(entry)
 	case *types.RmEntryParams:
-		context.Respond(pms.lm.RemoveEntry(int(msg.Index)))
+		removed := pms.lm.RemoveEntry(int(msg.Index))
+		if removed {
+			pms.lm.saveListFile()
+		}
+		context.Respond(removed)
 	default:
 		pms.Logger.Debug().Interface("msg", msg)
 	}
@@ -470,7 +523,7 @@ func (pms *PeerMapService) getCurrentPeers(param *CurrentListMsg) *types.Polaris
 	pms.rwmutex.Lock()
 	pms.rwmutex.Unlock()
 	for _, rPeer := range pms.peerRegistry {
-		pList[addSize] = &types.PolarisPeer{Address: &rPeer.addr, Connected: rPeer.connected.UnixNano(), LastCheck: rPeer.lastCheck().UnixNano(), Verion: rPeer.meta.Version}
+		pList[addSize] = &types.PolarisPeer{Address: &rPeer.addr, Connected: rPeer.connected.UnixNano(), LastCheck: rPeer.lastCheck().UnixNano(), Verion: rPeer.meta.Version, Source: []byte(rPeer.source)}
 		addSize++
 		if addSize >= listSize {
 			break
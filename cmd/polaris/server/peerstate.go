@@ -35,6 +35,8 @@ type peerState struct {
 	meta      p2pcommon.PeerMeta
 	addr      types.PeerAddress
 	connected time.Time
+	// source is id of sibling polaris from which this peer is synced. it is empty if peer is registered to this polaris.
+	source types.PeerID
 
 	// temporary means it does not affect current peer registry. TODO refactor more pretty way
 	temporary bool
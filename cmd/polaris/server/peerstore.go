@@ -0,0 +1,144 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+const (
+	localPeerStoreFile = "polaris_peers.json"
+
+	PeerStoreSaveInterval = time.Minute * 5
+)
+
+// storedPeer is the form of registered peer in the peer store file
+type storedPeer struct {
+	PeerID    string    `json:"peerID"`
+	Addresses []string  `json:"addresses"`
+	Role      string    `json:"role,omitempty"`
+	Version   string    `json:"version,omitempty"`
+	Producers []string  `json:"producers,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	Port      uint32    `json:"port,omitempty"`
+	Connected time.Time `json:"connected"`
+	// Source is the sibling polaris from which the peer is synced
+	Source string `json:"source,omitempty"`
+}
+
+func newStoredPeer(ps *peerState) storedPeer {
+	sp := storedPeer{PeerID: types.IDB58Encode(ps.meta.ID), Role: ps.meta.Role.String(), Version: ps.meta.Version,
+		Port: ps.conn.Port, Connected: ps.connected}
+	for _, a := range ps.meta.Addresses {
+		sp.Addresses = append(sp.Addresses, a.String())
+	}
+	for _, pid := range ps.meta.ProducerIDs {
+		sp.Producers = append(sp.Producers, types.IDB58Encode(pid))
+	}
+	if ps.conn.IP != nil {
+		sp.IP = ps.conn.IP.String()
+	}
+	if len(ps.source) > 0 {
+		sp.Source = types.IDB58Encode(ps.source)
+	}
+	return sp
+}
+
+func (sp storedPeer) toPeerState(pms *PeerMapService) (*peerState, error) {
+	id, err := types.IDB58Decode(sp.PeerID)
+	if err != nil {
+		return nil, err
+	}
+	meta := p2pcommon.PeerMeta{ID: id, Role: types.PeerRole(types.PeerRole_value[sp.Role]), Version: sp.Version}
+	for _, a := range sp.Addresses {
+		ma, err := types.ParseMultiaddr(a)
+		if err != nil {
+			return nil, err
+		}
+		meta.Addresses = append(meta.Addresses, ma)
+	}
+	for _, p := range sp.Producers {
+		pid, err := types.IDB58Decode(p)
+		if err != nil {
+			return nil, err
+		}
+		meta.ProducerIDs = append(meta.ProducerIDs, pid)
+	}
+	ps := &peerState{PeerMapService: pms, meta: meta, addr: meta.ToPeerAddress(), connected: sp.Connected,
+		conn: p2pcommon.RemoteConn{IP: net.ParseIP(sp.IP), Port: sp.Port}}
+	if len(sp.Source) > 0 {
+		if ps.source, err = types.IDB58Decode(sp.Source); err != nil {
+			return nil, err
+		}
+	}
+	return ps, nil
+}
+
+// loadPeerStore restores peer registry from the file. Restored peers are not verified yet, and bad ones will be removed
+// by next health check.
+func (pms *PeerMapService) loadPeerStore() {
+	file := filepath.Join(pms.dataDir, localPeerStoreFile)
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		pms.Logger.Info().Err(err).Str("file", file).Msg("Failed to read peer store file")
+		return
+	}
+	var stored []storedPeer
+	if err = json.Unmarshal(bytes, &stored); err != nil {
+		pms.Logger.Warn().Err(err).Str("file", file).Msg("Failed to parse peer store file")
+		return
+	}
+
+	pms.rwmutex.Lock()
+	defer pms.rwmutex.Unlock()
+	for _, sp := range stored {
+		ps, err := sp.toPeerState(pms)
+		if err != nil {
+			pms.Logger.Debug().Err(err).Str("peerID", sp.PeerID).Msg("Skipping invalid peer in peer store file")
+			continue
+		}
+		if ps.conn.IP != nil {
+			if banned, _ := pms.lm.IsBanned(ps.conn.IP.String(), ps.meta.ID); banned {
+				continue
+			}
+		}
+		pms.peerRegistry[ps.meta.ID] = ps
+	}
+	pms.Logger.Info().Str("file", file).Int("peer_cnt", len(pms.peerRegistry)).Msg("Loaded peer store file")
+}
+
+func (pms *PeerMapService) savePeerStore() {
+	file := filepath.Join(pms.dataDir, localPeerStoreFile)
+	pms.rwmutex.RLock()
+	stored := make([]storedPeer, 0, len(pms.peerRegistry))
+	for _, ps := range pms.peerRegistry {
+		stored = append(stored, newStoredPeer(ps))
+	}
+	pms.rwmutex.RUnlock()
+
+	bytes, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		pms.Logger.Warn().Err(err).Msg("Failed to marshal peer store")
+		return
+	}
+	// write to temporary file and rename it, not to corrupt existing file on failure
+	tmpFile := file + ".tmp"
+	if err = os.WriteFile(tmpFile, bytes, 0644); err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+	if err != nil {
+		pms.Logger.Warn().Err(err).Str("file", file).Msg("Failed to write peer store file")
+		return
+	}
+	pms.Logger.Debug().Str("file", file).Int("peer_cnt", len(stored)).Msg("Saved peer store file")
+}
@@ -0,0 +1,49 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+func TestPeerMapService_savePeerStore(t *testing.T) {
+	conf := &config.Config{BaseConfig: config.BaseConfig{DataDir: t.TempDir()}, P2P: &config.P2PConfig{}, Auth: &config.AuthConfig{},
+		Polaris: &config.PolarisConfig{EnablePeerStore: true}}
+	ad1, _ := types.ParseMultiaddr("/ip4/123.45.67.89/tcp/7846")
+	ad2, _ := types.ParseMultiaddr("/dns4/node.aergo.io/tcp/7846")
+	direct := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad1}, ID: types.RandomPeerID(), Role: types.PeerRole_Agent,
+		ProducerIDs: []types.PeerID{types.RandomPeerID()}, Version: "v2.4.0"}
+	synced := p2pcommon.PeerMeta{Addresses: []types.Multiaddr{ad2}, ID: types.RandomPeerID(), Role: types.PeerRole_Watcher, Version: "v2.4.0"}
+	sibling := types.RandomPeerID()
+	connected := time.Now().Add(-time.Hour).Round(time.Second)
+
+	pms := NewPolarisService(conf, pmapDummyNTC)
+	pms.peerRegistry[direct.ID] = &peerState{PeerMapService: pms, meta: direct, addr: direct.ToPeerAddress(), connected: connected,
+		conn: p2pcommon.RemoteConn{IP: net.ParseIP("123.45.67.89"), Port: 51234}}
+	pms.peerRegistry[synced.ID] = &peerState{PeerMapService: pms, meta: synced, addr: synced.ToPeerAddress(), connected: connected, source: sibling}
+	pms.savePeerStore()
+
+	loaded := NewPolarisService(conf, pmapDummyNTC)
+	loaded.loadPeerStore()
+	if len(loaded.peerRegistry) != 2 {
+		t.Fatalf("loadPeerStore() size = %v, want %v", len(loaded.peerRegistry), 2)
+	}
+	for _, want := range pms.peerRegistry {
+		got, found := loaded.peerRegistry[want.meta.ID]
+		if !found {
+			t.Fatalf("loadPeerStore() peer %v not found", want.meta.ID)
+		}
+		if !isEqualMeta(got.meta, want.meta) || got.source != want.source || !got.connected.Equal(want.connected) || !got.conn.IP.Equal(want.conn.IP) {
+			t.Errorf("loadPeerStore() peer = %v, want %v", got.meta, want.meta)
+		}
+	}
+}
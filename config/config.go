@@ -102,8 +102,18 @@ func (ctx *ServerContext) GetDefaultP2PConfig() *P2PConfig {
 		NPPeerPool:      100,
 		NPUsePolaris:    true,
 		NPExposeSelf:    true,
+		NPAddrBook:      true,
 		PeerRole:        "",
 		AllowLegacy:     true,
+
+		NPCompression:       "snappy",
+		NPCompressThreshold: 1024,
+
+		NPBlockReqRate:   20,
+		NPHashReqRate:    20,
+		NPTxReqRate:      50,
+		NPPeerUploadRate: 0,
+		NPUploadRate:     0,
 	}
 }
 
@@ -118,6 +128,7 @@ func (ctx *ServerContext) GetDefaultPolarisConfig() *PolarisConfig {
 		GenesisFile:     "",
 		AllowPrivate:    false,
 		EnableBlacklist: true,
+		EnablePeerStore: true,
 	}
 }
 
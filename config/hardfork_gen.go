@@ -15,18 +15,21 @@ var (
 		V3: types.BlockNo(111499715),
 		V4: types.BlockNo(173677571),
 		V5: types.BlockNo(196150000),
+		V6: types.BlockNo(18446744073709551615),
 	}
 	TestNetHardforkConfig = &HardforkConfig{
 		V2: types.BlockNo(18714241),
 		V3: types.BlockNo(100360545),
 		V4: types.BlockNo(140020000),
 		V5: types.BlockNo(155300000),
+		V6: types.BlockNo(18446744073709551615),
 	}
 	AllEnabledHardforkConfig = &HardforkConfig{
 		V2: types.BlockNo(0),
 		V3: types.BlockNo(0),
 		V4: types.BlockNo(0),
 		V5: types.BlockNo(0),
+		V6: types.BlockNo(0),
 	}
 )
 
@@ -35,6 +38,7 @@ v2 = "{{.Hardfork.V2}}"
 v3 = "{{.Hardfork.V3}}"
 v4 = "{{.Hardfork.V4}}"
 v5 = "{{.Hardfork.V5}}"
+v6 = "{{.Hardfork.V6}}"
 `
 
 type HardforkConfig struct {
@@ -42,6 +46,7 @@ type HardforkConfig struct {
 	V3 types.BlockNo `mapstructure:"v3" description:"a block number of the hardfork version 3"`
 	V4 types.BlockNo `mapstructure:"v4" description:"a block number of the hardfork version 4"`
 	V5 types.BlockNo `mapstructure:"v5" description:"a block number of the hardfork version 5"`
+	V6 types.BlockNo `mapstructure:"v6" description:"a block number of the hardfork version 6"`
 }
 
 type HardforkDbConfig map[string]types.BlockNo
@@ -74,6 +79,10 @@ func (c *HardforkConfig) IsV5Fork(h types.BlockNo) bool {
 	return isFork(c.V5, h)
 }
 
+func (c *HardforkConfig) IsV6Fork(h types.BlockNo) bool {
+	return isFork(c.V6, h)
+}
+
 func (c *HardforkConfig) CheckCompatibility(dbCfg HardforkDbConfig, h types.BlockNo) error {
 	if err := c.validate(); err != nil {
 		return err
@@ -90,7 +99,10 @@ func (c *HardforkConfig) CheckCompatibility(dbCfg HardforkDbConfig, h types.Bloc
 	if (isFork(c.V5, h) || isFork(dbCfg["V5"], h)) && c.V5 != dbCfg["V5"] {
 		return newForkError("V5", h, c.V5, dbCfg["V5"])
 	}
-	return checkOlderNode(5, h, dbCfg)
+	if (isFork(c.V6, h) || isFork(dbCfg["V6"], h)) && c.V6 != dbCfg["V6"] {
+		return newForkError("V6", h, c.V6, dbCfg["V6"])
+	}
+	return checkOlderNode(6, h, dbCfg)
 }
 
 func (c *HardforkConfig) Version(h types.BlockNo) int32 {
@@ -88,6 +88,7 @@ v2 = "9223"
 v3 = "11000"
 v4 = "14000"
 v5 = "15000"
+v6 = "16000"
 `,
 	)
 	dbCfg, _ := readDbConfig(`
@@ -95,7 +96,8 @@ v5 = "15000"
 	"V2": 18446744073709551315,
 	"V3": 18446744073709551415,
 	"V4": 18446744073709551515,
-	"V5": 18446744073709551615
+	"V5": 18446744073709551615,
+	"V6": 18446744073709551615
 }`,
 	)
 	err := cfg.CheckCompatibility(dbCfg, 10)
@@ -108,7 +110,8 @@ v5 = "15000"
 	"V2": 9223,
 	"V3": 10000,
 	"V4": 14000,
-	"V5": 15000
+	"V5": 15000,
+	"V6": 16000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10)
@@ -121,7 +124,8 @@ v5 = "15000"
 	"V2": 9223,
 	"V3": 10000,
 	"V4": 14000,
-	"V5": 15000
+	"V5": 15000,
+	"V6": 16000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 9500)
@@ -134,7 +138,8 @@ v5 = "15000"
 	"V2": 9221,
 	"V3": 10000,
 	"V4": 14000,
-	"V5": 15000
+	"V5": 15000,
+	"V6": 16000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 9500)
@@ -147,7 +152,8 @@ v5 = "15000"
 	"V2": 9223,
 	"V3": 10000,
 	"V4": 14000,
-	"V5": 15000
+	"V5": 15000,
+	"V6": 16000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10000)
@@ -172,7 +178,8 @@ v5 = "15000"
 	"VV": 10000,
 	"V3": 11000,
 	"V4": 12000,
-	"V5": 15000
+	"V5": 15000,
+	"V6": 16000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 9000)
@@ -191,6 +198,7 @@ v2 = "9223"
 v3 = "10000"
 v4 = "14000"
 v5 = "20000"
+v6 = "30000"
 `,
 	)
 	tests := []struct {
@@ -263,6 +271,21 @@ v5 = "20000"
 			21001,
 			5,
 		},
+		{
+			"before v6",
+			29999,
+			5,
+		},
+		{
+			"equal v6",
+			30000,
+			6,
+		},
+		{
+			"greater v6",
+			30001,
+			6,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -280,6 +303,7 @@ v2 = "9223"
 v3 = "10000"
 v4 = "14000"
 v5 = "20000"
+v6 = "30000"
 `,
 	)
 	dbConfig, _ := readDbConfig(`
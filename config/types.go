@@ -56,6 +56,9 @@ type RPCConfig struct {
 	NSAllowCORS bool   `mapstructure:"nsallowcors" description:"Allow CORS to RPC or REST API"`
 	// Internal operations
 	LogInternalOperations bool `mapstructure:"log_internal_operations" description:"Log internal operations"`
+	// API tokens
+	APITokens       []string `mapstructure:"apitokens" description:"API tokens with allowed methods and rate limit, in the form of <sha256 hex of token>:<comma separated methods or *>:<requests per second, 0 for no limit>"`
+	RequireAPIToken bool     `mapstructure:"requireapitoken" description:"Reject requests without a valid API token. The requests with an API token are checked by its allowed methods instead of the RPC permissions of client certificates"`
 }
 
 // Web3Config defines configurations for web3 service
@@ -85,6 +88,7 @@ type P2PConfig struct {
 	NPExposeSelf   bool     `mapstructure:"npexposeself" description:"Whether to request expose self to polaris and other connected node"`
 	NPUsePolaris   bool     `mapstructure:"npusepolaris" description:"Whether to connect and get node list from polaris"`
 	NPAddPolarises []string `mapstructure:"npaddpolarises" description:"Add addresses of polarises if default polaris is not sufficient"`
+	NPAddrBook     bool     `mapstructure:"npaddrbook" description:"Whether to keep addresses of known peers in data directory and reconnect to them after restart"`
 
 	LogFullPeerID bool `mapstructure:"logfullpeerid" description:"Whether to use full legnth peerID or short form"`
 
@@ -94,6 +98,15 @@ type P2PConfig struct {
 	Agent         string   `mapstructure:"agent" description:"Peer id of agent that delegates this producer, only available when local peer is producer"`
 	AllowLegacy   bool     `mapstructure:"allowlegacy" description:"Whether to allow legacy security protocols"`
 	MsgBufSize    int      `mapstructure:"msgbufsize" description:"Size of message buffer for each peer"`
+
+	NPCompression       string `mapstructure:"npcompression" description:"Compression algorithm of message payload sent to peers which support it. none, snappy or zstd"`
+	NPCompressThreshold int    `mapstructure:"npcompressthreshold" description:"Minimum payload size in bytes to be compressed"`
+
+	NPBlockReqRate   int   `mapstructure:"npblockreqrate" description:"Maximum number of block requests per second accepted from a peer. 0 means unlimited"`
+	NPHashReqRate    int   `mapstructure:"nphashreqrate" description:"Maximum number of block hash requests per second accepted from a peer. 0 means unlimited"`
+	NPTxReqRate      int   `mapstructure:"nptxreqrate" description:"Maximum number of tx requests per second accepted from a peer. 0 means unlimited"`
+	NPPeerUploadRate int64 `mapstructure:"nppeeruploadrate" description:"Maximum bytes per second sent to a peer. Requests from the peer are throttled while exceeded. 0 means unlimited"`
+	NPUploadRate     int64 `mapstructure:"npuploadrate" description:"Maximum bytes per second sent to all peers. Requests from peers are throttled while exceeded. 0 means unlimited"`
 }
 
 // AuthConfig defines configuration for auditing
@@ -103,9 +116,11 @@ type AuthConfig struct {
 
 // PolarisConfig defines configuration for polaris server and client (i.e. polarisConnect)
 type PolarisConfig struct {
-	AllowPrivate    bool   `mapstructure:"allowprivate" description:"allow peer to have private address. for private network and test"`
-	GenesisFile     string `mapstructure:"genesisfile" description:"json file containing informations of genesisblock to which polaris refer "`
-	EnableBlacklist bool   `mapstructure:"enableblacklist" description:"allow peer to have private address. for private network and test"`
+	AllowPrivate    bool     `mapstructure:"allowprivate" description:"allow peer to have private address. for private network and test"`
+	GenesisFile     string   `mapstructure:"genesisfile" description:"json file containing informations of genesisblock to which polaris refer "`
+	EnableBlacklist bool     `mapstructure:"enableblacklist" description:"allow peer to have private address. for private network and test"`
+	EnablePeerStore bool     `mapstructure:"enablepeerstore" description:"Whether to save registered peers to data directory and restore them after restart"`
+	Siblings        []string `mapstructure:"siblings" description:"Addresses of sibling polaris servers which share registered peers with this polaris"`
 }
 
 // BlockchainConfig defines configurations for blockchain service
@@ -122,6 +137,7 @@ type BlockchainConfig struct {
 	NumWorkers       int    `mapstructure:"numworkers" description:"maximum worker count for chainservice"`
 	NumLStateClosers int    `mapstructure:"numclosers" description:"maximum LuaVM state closer count for chainservice"`
 	CloseLimit       int    `mapstructure:"closelimit" description:"number of LuaVM states which a LuaVM state closer closes at one time"`
+	ParallelExec     bool   `mapstructure:"parallelexec" description:"execute the txs of a received block speculatively in parallel"`
 }
 
 // DBConfig defines configurations for db modnitoring
@@ -219,6 +235,10 @@ nskey = "{{.RPC.NSKey}}"
 nscacert = "{{.RPC.NSCACert}}"
 nsallowcors = {{.RPC.NSAllowCORS}}
 log_internal_operations = {{.RPC.LogInternalOperations}}
+apitokens = [{{range .RPC.APITokens}}
+"{{.}}", {{end}}
+]
+requireapitoken = {{.RPC.RequireAPIToken}}
 
 [p2p]
 # Set address and port to which the inbound peers connect, and don't set loopback address or private network unless used in local network 
@@ -242,8 +262,16 @@ npusepolaris = {{.P2P.NPUsePolaris}}
 npaddpolarises = [{{range .P2P.NPAddPolarises}}
 "{{.}}", {{end}}
 ]
+npaddrbook = {{.P2P.NPAddrBook}}
 peerrole = "{{.P2P.PeerRole}}"
 allowlegacy = "{{.P2P.AllowLegacy}}"
+npcompression = "{{.P2P.NPCompression}}"
+npcompressthreshold = {{.P2P.NPCompressThreshold}}
+npblockreqrate = {{.P2P.NPBlockReqRate}}
+nphashreqrate = {{.P2P.NPHashReqRate}}
+nptxreqrate = {{.P2P.NPTxReqRate}}
+nppeeruploadrate = {{.P2P.NPPeerUploadRate}}
+npuploadrate = {{.P2P.NPUploadRate}}
 
 [web3]
 netserviceport = {{.Web3.NetServicePort}}
@@ -255,6 +283,10 @@ enable = {{.Web3.Enable}}
 allowprivate = {{.Polaris.AllowPrivate}}
 genesisfile = "{{.Polaris.GenesisFile}}"
 enableblacklist = "{{.Polaris.EnableBlacklist}}"
+enablepeerstore = {{.Polaris.EnablePeerStore}}
+siblings = [{{range .Polaris.Siblings}}
+"{{.}}", {{end}}
+]
 
 [blockchain]
 # blockchain configurations
@@ -266,6 +298,7 @@ forceresetheight = "{{.Blockchain.ForceResetHeight}}"
 numworkers = "{{.Blockchain.NumWorkers}}"
 numclosers = "{{.Blockchain.NumLStateClosers}}"
 closelimit = "{{.Blockchain.CloseLimit}}"
+parallelexec = {{.Blockchain.ParallelExec}}
 
 [db]
 controlcompaction = "{{.DB.ControlCompaction}}"
@@ -76,6 +76,8 @@ type BlockGenerator struct {
 	bi               *types.BlockHeaderInfo
 	txOp             TxOp
 	fetchTXs         func(component.ICompSyncRequester, uint32) []types.Transaction
+	execScheduled    chain.ScheduledCallExecFn
+	nScheduled       int
 	skipEmpty        bool
 	maxBlockBodySize uint32
 }
@@ -137,7 +139,7 @@ func (g *BlockGenerator) GenerateBlock() (*types.Block, error) {
 		return nil, err
 	}
 	n := len(transactions)
-	if n == 0 && g.skipEmpty {
+	if n == 0 && g.nScheduled == 0 && g.skipEmpty {
 		logger.Debug().Msg("BF: empty block is skipped")
 		return nil, ErrBlockEmpty
 	}
@@ -166,6 +168,12 @@ func (g *BlockGenerator) WithDeco(fn FetchDeco) *BlockGenerator {
 	return g
 }
 
+// WithScheduledCalls sets the function executing the scheduled calls at the start of the block.
+func (g *BlockGenerator) WithScheduledCalls(fn chain.ScheduledCallExecFn) *BlockGenerator {
+	g.execScheduled = fn
+	return g
+}
+
 func (g *BlockGenerator) SetNoTTE(noTTE bool) *BlockGenerator {
 	g.noTTE = noTTE
 	return g
@@ -136,6 +136,23 @@ func (g *BlockGenerator) GatherTXs() ([]types.Transaction, error) {
 		contract.CloseDatabase()
 	}()
 
+	// the voted system params are activated at the start of the block, as in
+	// the block execution of the chain service
+	if _, err := contract.ActivateParams(context.Background(), bState, g.bi); err != nil {
+		return nil, err
+	}
+
+	// the scheduled calls due at the block are executed before the txs, under
+	// the same lock as the txs
+	g.nScheduled = 0
+	if g.execScheduled != nil {
+		n, err := g.execScheduled(bState)
+		if err != nil {
+			return nil, err
+		}
+		g.nScheduled = n
+	}
+
 	// block generation timeout check. this function works like BlockFactory#checkBpTimeout()
 	checkBGTimeout := NewCompTxOp(
 		TxOpFn(func(bState *state.BlockState, txIn types.Transaction) error {
@@ -245,6 +245,7 @@ func (bf *BlockFactory) generateBlock(execCtx context.Context, bpi *bpInfo, lpbN
 	bGen := chain.NewBlockGenerator(
 		bf, execCtx, bi, bs, newTxExec(execCtx, bpi.ChainDB, bi), false).
 		WithDeco(bf.deco()).
+		WithScheduledCalls(bc.NewScheduledCallExecutor(execCtx, bpi.ChainDB, bi, contract.BlockFactory)).
 		SetNoTTE(bf.noTTE)
 
 	begT := time.Now()
@@ -526,7 +526,9 @@ func (bf *BlockFactory) generateBlock(work *Work) (*types.Block, *state.BlockSta
 	blockState.SetGasPrice(system.GetGasPrice())
 	blockState.Receipts().SetHardFork(bf.bv, bi.No)
 
-	block, err := chain.NewBlockGenerator(bf, work.execCtx, bi, blockState, txOp, RaftSkipEmptyBlock).GenerateBlock()
+	block, err := chain.NewBlockGenerator(bf, work.execCtx, bi, blockState, txOp, RaftSkipEmptyBlock).
+		WithScheduledCalls(bc.NewScheduledCallExecutor(work.execCtx, bf.ChainWAL, bi, contract.BlockFactory)).
+		GenerateBlock()
 	if err == chain.ErrBlockEmpty {
 		//need reset previous work
 		return nil, nil, chain.ErrBlockEmpty
@@ -194,7 +194,9 @@ func (s *SimpleBlockFactory) Start() {
 				blockState.Receipts().SetHardFork(s.bv, bi.No)
 				txOp := chain.NewCompTxOp(s.txOp, newTxExec(s.ChainDB, bi))
 
-				block, err := chain.NewBlockGenerator(s, context.Background(), bi, blockState, txOp, false).GenerateBlock()
+				block, err := chain.NewBlockGenerator(s, context.Background(), bi, blockState, txOp, false).
+					WithScheduledCalls(bc.NewScheduledCallExecutor(context.Background(), s.ChainDB, bi, contract.BlockFactory)).
+					GenerateBlock()
 				if err == chain.ErrQuit {
 					return
 				} else if err != nil {
@@ -104,12 +104,28 @@ func Execute(
 	// create a new context
 	ctx := NewVmContext(execCtx, bs, cdb, sender, receiver, contractState, sender.ID(), tx.GetHash(), bi, "", true, false, receiver.RP(), executionMode, txAmount, gasLimit, isFeeDelegation, isMultiCall)
 
+	// the replay must not use the context slot of the block factory or the chain service, which run concurrently
+	if ctx.isReplay {
+		allocContextSlot(ctx)
+		defer freeContextSlot(ctx)
+	}
+
 	// execute the transaction
 	if receiver.IsDeploy() {
+		traceEnter(ctx, "deploy", types.EncodeAddress(receiver.ID()), txAmount.String(), gasLimit)
 		rv, events, internalOps, ctrFee, err = Create(contractState, txPayload, receiver.ID(), ctx)
 	} else {
+		traceEnter(ctx, "call", types.EncodeAddress(receiver.ID()), txAmount.String(), gasLimit)
 		rv, events, internalOps, ctrFee, err = Call(contractState, txPayload, receiver.ID(), ctx)
 	}
+	if ctx.tracer != nil {
+		traceReturn(ctx, rv)
+		var errMsg string
+		if err != nil {
+			errMsg = err.Error()
+		}
+		traceExit(ctx, errMsg, gasLimit-ctx.usedGas())
+	}
 
 	// close the trace file
 	if ctx.traceFile != nil {
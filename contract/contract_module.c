@@ -389,7 +389,7 @@ static int moduleDeploy(lua_State *L) {
 	return ret.r0;
 }
 
-static int moduleEvent(lua_State *L) {
+static int emitEvent(lua_State *L, int start, int indexed) {
 	char *event_name;
 	char *json_args;
 	int service = getLuaExecContext(L);
@@ -399,14 +399,14 @@ static int moduleEvent(lua_State *L) {
 
 	event_name = (char *) luaL_checkstring(L, 1);
 	if (vm_is_hardfork(L, 2)) {
-		json_args = lua_util_get_json_array_from_stack(L, 2, lua_gettop(L), true);
+		json_args = lua_util_get_json_array_from_stack(L, start, lua_gettop(L), true);
 	} else {
-		json_args = lua_util_get_json_from_stack(L, 2, lua_gettop(L), false);
+		json_args = lua_util_get_json_from_stack(L, start, lua_gettop(L), false);
 	}
 	if (json_args == NULL) {
 		luaL_throwerror(L);
 	}
-	errStr = luaEvent(L, service, event_name, json_args);
+	errStr = luaEvent(L, service, event_name, json_args, indexed);
 	if (errStr != NULL) {
 		strPushAndRelease(L, errStr);
 		luaL_throwerror(L);
@@ -415,6 +415,19 @@ static int moduleEvent(lua_State *L) {
 	return 0;
 }
 
+static int moduleEvent(lua_State *L) {
+	return emitEvent(L, 2, 0);
+}
+
+static int moduleIndexedEvent(lua_State *L) {
+	int indexed = luaL_checkint(L, 2);
+
+	if (indexed < 0 || indexed > lua_gettop(L) - 2) {
+		luaL_error(L, "invalid number of indexed args");
+	}
+	return emitEvent(L, 3, indexed);
+}
+
 static int governance(lua_State *L, char type) {
 	char *ret;
 	int service = getLuaExecContext(L);
@@ -482,6 +495,103 @@ static int moduleVoteDao(lua_State *L) {
 	return governance(L, 'D');
 }
 
+static int moduleSchedule(lua_State *L) {
+	int service = getLuaExecContext(L);
+	lua_Integer block_no = 0;
+	lua_Integer ts = 0;
+	lua_Integer gas;
+	char *fname;
+	char *json_args;
+	char *fee;
+	bool needfree = false;
+	int i, n, top;
+	struct luaScheduleCall_return ret;
+
+	lua_gasuse(L, 2000);
+
+	switch(lua_type(L, 1)) {
+	case LUA_TNUMBER:
+		block_no = luaL_checkinteger(L, 1);
+		break;
+	case LUA_TTABLE:
+		lua_getfield(L, 1, "block");
+		if (!lua_isnil(L, -1)) {
+			block_no = luaL_checkinteger(L, -1);
+		}
+		lua_getfield(L, 1, "time");
+		if (!lua_isnil(L, -1)) {
+			ts = luaL_checkinteger(L, -1);
+		}
+		lua_pop(L, 2);
+		break;
+	default:
+		luaL_error(L, "invalid schedule time");
+	}
+	fname = (char *)luaL_checkstring(L, 2);
+	if (lua_isnoneornil(L, 3)) {
+		n = 0;
+	} else {
+		luaL_checktype(L, 3, LUA_TTABLE);
+		n = lua_objlen(L, 3);
+	}
+	gas = luaL_optinteger(L, 4, 0);
+
+	switch(lua_type(L, 5)) {
+	case LUA_TNUMBER:
+	case LUA_TSTRING:
+		fee = (char *) lua_tostring(L, 5);
+		break;
+	case LUA_TUSERDATA:
+		fee = lua_get_bignum_str(L, 5);
+		if (fee == NULL) {
+			luaL_error(L, "not enough memory");
+		}
+		needfree = true;
+		break;
+	default:
+		luaL_error(L, "invalid fee");
+	}
+
+	top = lua_gettop(L);
+	for (i = 1; i <= n; ++i) {
+		lua_rawgeti(L, 3, i);
+	}
+	json_args = lua_util_get_json_array_from_stack(L, top + 1, top + n, false);
+	lua_pop(L, n);
+	if (json_args == NULL) {
+		if (needfree) {
+			free(fee);
+		}
+		luaL_throwerror(L);
+	}
+
+	ret = luaScheduleCall(L, service, block_no, ts, fname, json_args, gas, fee);
+	free(json_args);
+	if (needfree) {
+		free(fee);
+	}
+	if (ret.r1 != NULL) {
+		strPushAndRelease(L, ret.r1);
+		luaL_throwerror(L);
+	}
+	lua_pushinteger(L, ret.r0);
+	return 1;
+}
+
+static int moduleCancelSchedule(lua_State *L) {
+	int service = getLuaExecContext(L);
+	char *errStr;
+
+	lua_gasuse(L, 500);
+
+	errStr = luaCancelScheduledCall(L, service, luaL_checkinteger(L, 1));
+	if (errStr != NULL) {
+		strPushAndRelease(L, errStr);
+		luaL_throwerror(L);
+	}
+	return 0;
+}
+
 static const luaL_Reg call_methods[] = {
 	{"value", call_value},
 	{"amount", call_value},
@@ -527,9 +637,19 @@ static const luaL_Reg contract_lib[] = {
 	{NULL, NULL}
 };
 
+static const luaL_Reg contract_lib_v6[] = {
+	{"schedule", moduleSchedule},
+	{"cancelSchedule", moduleCancelSchedule},
+	{"indexedEvent", moduleIndexedEvent},
+	{NULL, NULL}
+};
+
 int luaopen_contract(lua_State *L) {
 
 	luaL_register(L, contract_str, contract_lib);
+	if (vm_is_hardfork(L, 6)) {
+		luaL_register(L, NULL, contract_lib_v6);
+	}
 
 	lua_createtable(L, 0, 3);
 	luaL_register(L, NULL, call_methods);
@@ -0,0 +1,159 @@
+package contract
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/cloudflare/circl/ecc/bls12381"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ripemd160"
+)
+
+const maxBlsPubKeys = 100
+
+var (
+	errInvalidPubKey    = errors.New("invalid public key")
+	errInvalidSignature = errors.New("invalid signature")
+
+	// the domain separation tags of the proof of possession ciphersuite of the IETF BLS signature, with the public
+	// keys in G1 and the signatures in G2
+	blsSigDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	blsPopDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+func ripemd160Hash(data []byte) []byte {
+	h := ripemd160.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func blake2bHash(data []byte, size int) ([]byte, error) {
+	h, err := blake2b.New(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// verifyEd25519 verifies the ed25519 signature of the message.
+func verifyEd25519(msg, sig, pubKey []byte) (bool, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false, errInvalidPubKey
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return false, errInvalidSignature
+	}
+	return ed25519.Verify(pubKey, msg, sig), nil
+}
+
+// verifyP256 verifies the ecdsa signature of the hash on the secp256r1 curve, as used by the passkeys. The signature
+// is either r || s of 64 bytes or the ASN.1 DER encoding, and the public key is either compressed or uncompressed.
+func verifyP256(hash, sig, pubKey []byte) (bool, error) {
+	curve := elliptic.P256()
+	var x, y *big.Int
+	if len(pubKey) == 33 {
+		x, y = elliptic.UnmarshalCompressed(curve, pubKey)
+	} else {
+		x, y = elliptic.Unmarshal(curve, pubKey)
+	}
+	if x == nil {
+		return false, errInvalidPubKey
+	}
+	pub := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	if len(sig) == 64 {
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(pub, hash, r, s), nil
+	}
+	return ecdsa.VerifyASN1(pub, hash, sig), nil
+}
+
+// blsHashToG2 maps the message to a point of G2 by the hash_to_curve of RFC 9380 with the suite
+// BLS12381G2_XMD:SHA-256_SSWU_RO_.
+func blsHashToG2(msg, dst []byte) *bls12381.G2 {
+	p := new(bls12381.G2)
+	p.Hash(msg, dst)
+	return p
+}
+
+// blsUnmarshal decodes the compressed signature and public keys, which must be in the prime order subgroup and not
+// the identity.
+func blsUnmarshal(sig []byte, pubKeys [][]byte) (*bls12381.G2, []*bls12381.G1, error) {
+	s := new(bls12381.G2)
+	if len(sig) != bls12381.G2SizeCompressed || s.SetBytes(sig) != nil || s.IsIdentity() {
+		return nil, nil, errInvalidSignature
+	}
+	if len(pubKeys) == 0 || len(pubKeys) > maxBlsPubKeys {
+		return nil, nil, errInvalidPubKey
+	}
+	pks := make([]*bls12381.G1, len(pubKeys))
+	for i, b := range pubKeys {
+		pk := new(bls12381.G1)
+		if len(b) != bls12381.G1SizeCompressed || pk.SetBytes(b) != nil || pk.IsIdentity() {
+			return nil, nil, errInvalidPubKey
+		}
+		pks[i] = pk
+	}
+	return s, pks, nil
+}
+
+// blsPairingCheck reports whether the product of e(p[i], q[i]) equals e(g1, sig).
+func blsPairingCheck(p []*bls12381.G1, q []*bls12381.G2, sig *bls12381.G2) bool {
+	signs := make([]int, len(p), len(p)+1)
+	for i := range signs {
+		signs[i] = 1
+	}
+	p = append(p, bls12381.G1Generator())
+	q = append(q, sig)
+	signs = append(signs, -1)
+	return bls12381.ProdPairFrac(p, q, signs).IsIdentity()
+}
+
+// verifyBls verifies the BLS signature of the IETF draft, with the compressed public key of 48 bytes in G1 and the
+// compressed signature of 96 bytes in G2 of BLS12-381, in the proof of possession ciphersuite used by Ethereum.
+func verifyBls(msg, sig, pubKey []byte) (bool, error) {
+	return verifyBlsAggregate([][]byte{msg}, sig, [][]byte{pubKey})
+}
+
+// verifyBlsAggregate verifies the aggregated BLS signature of the messages signed by each public key, as
+// AggregateVerify of the IETF draft. If only one message is given, it is signed by all the public keys, as
+// FastAggregateVerify, so the contract must check the proofs of possession of the public keys by verifyBlsPop to
+// prevent the rogue key attack.
+func verifyBlsAggregate(msgs [][]byte, sig []byte, pubKeys [][]byte) (bool, error) {
+	s, pks, err := blsUnmarshal(sig, pubKeys)
+	if err != nil {
+		return false, err
+	}
+	if len(msgs) == 1 {
+		aggPk := new(bls12381.G1)
+		aggPk.SetIdentity()
+		for _, pk := range pks {
+			aggPk.Add(aggPk, pk)
+		}
+		if aggPk.IsIdentity() {
+			return false, nil
+		}
+		return blsPairingCheck([]*bls12381.G1{aggPk}, []*bls12381.G2{blsHashToG2(msgs[0], blsSigDST)}, s), nil
+	}
+	if len(msgs) != len(pks) {
+		return false, errors.New("the number of the messages and the public keys are different")
+	}
+	hashes := make([]*bls12381.G2, len(msgs))
+	for i, msg := range msgs {
+		hashes[i] = blsHashToG2(msg, blsSigDST)
+	}
+	return blsPairingCheck(pks, hashes, s), nil
+}
+
+// verifyBlsPop verifies the proof of possession of the public key, as PopVerify of the IETF draft.
+func verifyBlsPop(pubKey, proof []byte) (bool, error) {
+	s, pks, err := blsUnmarshal(proof, [][]byte{pubKey})
+	if err != nil {
+		return false, err
+	}
+	return blsPairingCheck(pks, []*bls12381.G2{blsHashToG2(pubKey, blsPopDST)}, s), nil
+}
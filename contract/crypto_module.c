@@ -1,14 +1,19 @@
 #include "_cgo_export.h"
 #include "util.h"
+#include "crypto_module.h"
 
 extern int getLuaExecContext(lua_State *L);
 
+#define MAX_BLS_PUBKEYS     100
+
+/* the gas costs of the functions are system params, returned by luaCryptoGas */
+
 static int crypto_sha256(lua_State *L) {
 	size_t len;
 	char *arg;
 	struct luaCryptoSha256_return ret;
 
-	lua_gasuse(L, 500);
+	lua_gasuse(L, luaCryptoGas(GAS_SHA256));
 
 	luaL_checktype(L, 1, LUA_TSTRING);
 	arg = (char *) lua_tolstring(L, 1, &len);
@@ -27,7 +32,7 @@ static int crypto_ecverify(lua_State *L) {
 	struct luaECVerify_return ret;
 	int service = getLuaExecContext(L);
 
-	lua_gasuse(L, 5000);
+	lua_gasuse(L, luaCryptoGas(GAS_ECVERIFY));
 
 	luaL_checktype(L, 1, LUA_TSTRING);
 	luaL_checktype(L, 2, LUA_TSTRING);
@@ -104,7 +109,7 @@ static int crypto_verifyProof(lua_State *L) {
 	int i, b;
 	const int proofIndex = 4;
 
-	lua_gasuse(L, 5000);
+	lua_gasuse(L, luaCryptoGas(GAS_VERIFY_PROOF));
 
 	if (argc < proofIndex) {
 		lua_pushboolean(L, 0);
@@ -142,7 +147,7 @@ static int crypto_keccak256(lua_State *L) {
 	char *arg;
 	struct luaCryptoKeccak256_return ret;
 
-	lua_gasuse(L, 500);
+	lua_gasuse(L, luaCryptoGas(GAS_KECCAK256));
 
 	luaL_checktype(L, 1, LUA_TSTRING);
 	arg = (char *) lua_tolstring(L, 1, &len);
@@ -153,6 +158,220 @@ static int crypto_keccak256(lua_State *L) {
 	return 1;
 }
 
+static int crypto_ripemd160(lua_State *L) {
+	size_t len;
+	char *arg;
+	struct luaCryptoRipemd160_return ret;
+
+	luaL_checktype(L, 1, LUA_TSTRING);
+	arg = (char *) lua_tolstring(L, 1, &len);
+
+	lua_gasuse(L, luaCryptoGas(GAS_RIPEMD160));
+	lua_gasuse_mul(L, luaCryptoGas(GAS_HASH_WORD), (len + 31) / 32);
+
+	ret = luaCryptoRipemd160(arg, len);
+	lua_pushlstring(L, ret.r0, ret.r1);
+	free(ret.r0);
+	return 1;
+}
+
+static int crypto_blake2b(lua_State *L) {
+	size_t len;
+	char *arg;
+	int size;
+	struct luaCryptoBlake2b_return ret;
+
+	luaL_checktype(L, 1, LUA_TSTRING);
+	arg = (char *) lua_tolstring(L, 1, &len);
+	size = luaL_optinteger(L, 2, 32);
+
+	lua_gasuse(L, luaCryptoGas(GAS_BLAKE2B));
+	lua_gasuse_mul(L, luaCryptoGas(GAS_HASH_WORD), (len + 31) / 32);
+
+	ret = luaCryptoBlake2b(arg, len, size);
+	if (ret.r2 != NULL) {
+		strPushAndRelease(L, ret.r2);
+		lua_error(L);
+	}
+	lua_pushlstring(L, ret.r0, ret.r1);
+	free(ret.r0);
+	return 1;
+}
+
+static void push_verify_result(lua_State *L, int result, char *errMsg) {
+	if (errMsg != NULL) {
+		strPushAndRelease(L, errMsg);
+		lua_error(L);
+	}
+	lua_pushboolean(L, result);
+}
+
+static int crypto_ed25519_verify(lua_State *L) {
+	char *msg, *sig, *pubkey;
+	size_t msgLen, sigLen, pubkeyLen;
+	struct luaCryptoEd25519Verify_return ret;
+	int service = getLuaExecContext(L);
+
+	lua_gasuse(L, luaCryptoGas(GAS_ED25519_VERIFY));
+
+	luaL_checktype(L, 1, LUA_TSTRING);
+	luaL_checktype(L, 2, LUA_TSTRING);
+	luaL_checktype(L, 3, LUA_TSTRING);
+	msg    = (char *) lua_tolstring(L, 1, &msgLen);
+	sig    = (char *) lua_tolstring(L, 2, &sigLen);
+	pubkey = (char *) lua_tolstring(L, 3, &pubkeyLen);
+
+	/* the message is hashed with sha512 */
+	lua_gasuse_mul(L, luaCryptoGas(GAS_HASH_WORD), (msgLen + 31) / 32);
+
+	ret = luaCryptoEd25519Verify(L, service, msg, sig, pubkey, msgLen, sigLen, pubkeyLen);
+	push_verify_result(L, ret.r0, ret.r1);
+	return 1;
+}
+
+static int crypto_p256_verify(lua_State *L) {
+	char *hash, *sig, *pubkey;
+	size_t hashLen, sigLen, pubkeyLen;
+	struct luaCryptoP256Verify_return ret;
+	int service = getLuaExecContext(L);
+
+	lua_gasuse(L, luaCryptoGas(GAS_P256_VERIFY));
+
+	luaL_checktype(L, 1, LUA_TSTRING);
+	luaL_checktype(L, 2, LUA_TSTRING);
+	luaL_checktype(L, 3, LUA_TSTRING);
+	hash   = (char *) lua_tolstring(L, 1, &hashLen);
+	sig    = (char *) lua_tolstring(L, 2, &sigLen);
+	pubkey = (char *) lua_tolstring(L, 3, &pubkeyLen);
+
+	ret = luaCryptoP256Verify(L, service, hash, sig, pubkey, hashLen, sigLen, pubkeyLen);
+	push_verify_result(L, ret.r0, ret.r1);
+	return 1;
+}
+
+/* checks the argument n is a string or a table of strings, and returns the number of the strings */
+static int check_blobs(lua_State *L, int n) {
+	int i, count;
+
+	if (lua_isstring(L, n)) {
+		return 1;
+	}
+	luaL_checktype(L, n, LUA_TTABLE);
+	count = (int) lua_objlen(L, n);
+	if (count < 1 || count > MAX_BLS_PUBKEYS) {
+		luaL_argerror(L, n, "invalid number of elements");
+	}
+	for (i = 1; i <= count; i++) {
+		lua_rawgeti(L, n, i);
+		if (!lua_isstring(L, -1)) {
+			luaL_argerror(L, n, "string expected");
+		}
+		lua_pop(L, 1);
+	}
+	return count;
+}
+
+/* returns the total length of the strings of the argument n, checked by check_blobs */
+static size_t blobs_len(lua_State *L, int n, int count) {
+	size_t len = 0;
+	int i;
+
+	if (lua_isstring(L, n)) {
+		return lua_objlen(L, n);
+	}
+	for (i = 1; i <= count; i++) {
+		lua_rawgeti(L, n, i);
+		len += lua_objlen(L, -1);
+		lua_pop(L, 1);
+	}
+	return len;
+}
+
+static struct blob *make_blobs(lua_State *L, int n, int count) {
+	struct blob *blobs = (struct blob *) malloc(sizeof(struct blob) * count);
+	int i;
+
+	if (lua_isstring(L, n)) {
+		blobs[0].data = (char *) lua_tolstring(L, n, &blobs[0].len);
+		return blobs;
+	}
+	for (i = 0; i < count; i++) {
+		/* the strings are kept alive by the table */
+		lua_rawgeti(L, n, i+1);
+		blobs[i].data = (char *) lua_tolstring(L, -1, &blobs[i].len);
+		lua_pop(L, 1);
+	}
+	return blobs;
+}
+
+static int bls_verify(lua_State *L, int nMsgs, int nPubKeys) {
+	char *sig;
+	size_t sigLen;
+	struct blob *msgs, *pubkeys;
+	struct luaCryptoBlsVerify_return ret;
+	int service = getLuaExecContext(L);
+
+	lua_gasuse(L, luaCryptoGas(GAS_BLS_VERIFY));
+	lua_gasuse_mul(L, luaCryptoGas(GAS_BLS_PAIRING), nMsgs > nPubKeys ? nMsgs : nPubKeys);
+	/* each message is hashed to the curve */
+	lua_gasuse_mul(L, luaCryptoGas(GAS_BLS_HASH_TO_CURVE), nMsgs);
+	lua_gasuse_mul(L, luaCryptoGas(GAS_HASH_WORD), (blobs_len(L, 1, nMsgs) + 31) / 32);
+
+	sig = (char *) lua_tolstring(L, 2, &sigLen);
+	msgs = make_blobs(L, 1, nMsgs);
+	pubkeys = make_blobs(L, 3, nPubKeys);
+
+	ret = luaCryptoBlsVerify(L, service, msgs, nMsgs, sig, sigLen, pubkeys, nPubKeys);
+	free(msgs);
+	free(pubkeys);
+	push_verify_result(L, ret.r0, ret.r1);
+	return 1;
+}
+
+static int crypto_bls_verify(lua_State *L) {
+	luaL_checktype(L, 1, LUA_TSTRING);
+	luaL_checktype(L, 2, LUA_TSTRING);
+	luaL_checktype(L, 3, LUA_TSTRING);
+	return bls_verify(L, 1, 1);
+}
+
+/* bls_aggregate_verify(msgs, sig, pubkeys) verifies the aggregated signature of the messages signed by each public key,
+   or of a message signed by all the public keys */
+static int crypto_bls_aggregate_verify(lua_State *L) {
+	int nMsgs, nPubKeys;
+
+	nMsgs = check_blobs(L, 1);
+	luaL_checktype(L, 2, LUA_TSTRING);
+	if (lua_isstring(L, 3)) {
+		luaL_argerror(L, 3, "table expected");
+	}
+	nPubKeys = check_blobs(L, 3);
+	return bls_verify(L, nMsgs, nPubKeys);
+}
+
+/* bls_pop_verify(pubkey, proof) verifies the proof of possession of the public key, which is required for the public
+   keys signing the same message in bls_aggregate_verify */
+static int crypto_bls_pop_verify(lua_State *L) {
+	char *pubkey, *proof;
+	size_t pubkeyLen, proofLen;
+	struct luaCryptoBlsPopVerify_return ret;
+	int service = getLuaExecContext(L);
+
+	lua_gasuse(L, luaCryptoGas(GAS_BLS_VERIFY));
+	lua_gasuse(L, luaCryptoGas(GAS_BLS_PAIRING));
+	/* the public key is hashed to the curve */
+	lua_gasuse(L, luaCryptoGas(GAS_BLS_HASH_TO_CURVE));
+
+	luaL_checktype(L, 1, LUA_TSTRING);
+	luaL_checktype(L, 2, LUA_TSTRING);
+	pubkey = (char *) lua_tolstring(L, 1, &pubkeyLen);
+	proof  = (char *) lua_tolstring(L, 2, &proofLen);
+
+	ret = luaCryptoBlsPopVerify(L, service, pubkey, proof, pubkeyLen, proofLen);
+	push_verify_result(L, ret.r0, ret.r1);
+	return 1;
+}
+
 static const luaL_Reg crypto_lib[] = {
 	{"sha256", crypto_sha256},
 	{"ecverify", crypto_ecverify},
@@ -161,8 +380,22 @@ static const luaL_Reg crypto_lib[] = {
 	{NULL, NULL}
 };
 
+static const luaL_Reg crypto_lib_v6[] = {
+	{"ripemd160", crypto_ripemd160},
+	{"blake2b", crypto_blake2b},
+	{"ed25519_verify", crypto_ed25519_verify},
+	{"p256_verify", crypto_p256_verify},
+	{"bls_verify", crypto_bls_verify},
+	{"bls_aggregate_verify", crypto_bls_aggregate_verify},
+	{"bls_pop_verify", crypto_bls_pop_verify},
+	{NULL, NULL}
+};
+
 int luaopen_crypto(lua_State *L) {
 	luaL_register(L, "crypto", crypto_lib);
+	if (vm_is_hardfork(L, 6)) {
+		luaL_register(L, NULL, crypto_lib_v6);
+	}
 	lua_pop(L, 1);
 	return 1;
 }
@@ -3,6 +3,23 @@
 
 #include "lua.h"
 
+/* the gas costs of the crypto module, in the order of cryptoGasParams in
+   contract/system/param.go */
+enum crypto_gas {
+	GAS_SHA256,
+	GAS_KECCAK256,
+	GAS_RIPEMD160,
+	GAS_BLAKE2B,
+	GAS_HASH_WORD,        /* per 32 bytes of the hashed data */
+	GAS_ECVERIFY,
+	GAS_VERIFY_PROOF,
+	GAS_ED25519_VERIFY,
+	GAS_P256_VERIFY,
+	GAS_BLS_VERIFY,
+	GAS_BLS_PAIRING,      /* per pairing */
+	GAS_BLS_HASH_TO_CURVE /* per message hashed to the curve */
+};
+
 extern int luaopen_crypto(lua_State *L);
 
 #endif /* _CRYPTO_MODULE_H */
\ No newline at end of file
@@ -0,0 +1,170 @@
+package contract
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoHash(t *testing.T) {
+	assert.Equal(t, "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc", hex.EncodeToString(ripemd160Hash([]byte("abc"))))
+
+	h, err := blake2bHash([]byte("abc"), 32)
+	require.NoError(t, err)
+	assert.Equal(t, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319", hex.EncodeToString(h))
+	h, err = blake2bHash([]byte("abc"), 64)
+	require.NoError(t, err)
+	assert.Len(t, h, 64)
+	_, err = blake2bHash([]byte("abc"), 65)
+	assert.Error(t, err)
+}
+
+func TestVerifyEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("hello")
+	sig := ed25519.Sign(priv, msg)
+
+	ok, err := verifyEd25519(msg, sig, pub)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = verifyEd25519([]byte("other"), sig, pub)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, err = verifyEd25519(msg, sig, pub[1:])
+	assert.Equal(t, errInvalidPubKey, err)
+	_, err = verifyEd25519(msg, sig[1:], pub)
+	assert.Equal(t, errInvalidSignature, err)
+}
+
+func TestVerifyP256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("hello"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+	rs := make([]byte, 64)
+	r.FillBytes(rs[:32])
+	s.FillBytes(rs[32:])
+	der, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+	uncompressed := elliptic.Marshal(elliptic.P256(), key.X, key.Y)
+	compressed := elliptic.MarshalCompressed(elliptic.P256(), key.X, key.Y)
+
+	for _, sig := range [][]byte{rs, der} {
+		for _, pub := range [][]byte{uncompressed, compressed} {
+			ok, err := verifyP256(hash[:], sig, pub)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}
+	}
+	other := sha256.Sum256([]byte("other"))
+	ok, err := verifyP256(other[:], rs, compressed)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, err = verifyP256(hash[:], rs, compressed[1:])
+	assert.Equal(t, errInvalidPubKey, err)
+}
+
+func TestBlsHashToG2(t *testing.T) {
+	// the test vectors of BLS12381G2_XMD:SHA-256_SSWU_RO_ in RFC 9380, J.10.1. The point is x.c1 || x.c0 || y.c1 ||
+	// y.c0 in the serialization of BLS12-381
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"", "05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d" +
+			"0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a" +
+			"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6" +
+			"0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92"},
+		{"abc", "139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8" +
+			"02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6" +
+			"00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16" +
+			"1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			assert.Equal(t, tt.want, hex.EncodeToString(blsHashToG2([]byte(tt.msg), dst).Bytes()))
+		})
+	}
+}
+
+func TestVerifyBls(t *testing.T) {
+	// the test vectors of the BLS signature in the consensus spec tests of Ethereum
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+	pubs := [][]byte{
+		unhex("a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"),
+		unhex("b301803f8b5ac4a1133581fc676dfedc60d891dd5fa99028805e5ea5b08d3491af75d0707adab3b70c6a6a580217bf81"),
+		unhex("b53d21a4cfd562c469cc81514d4ce5a6b577d8403d32a394dc265dd190b47fa9f829fdd7963afdf972e5e77854051f6f"),
+	}
+	msgs := [][]byte{bytes.Repeat([]byte{0x00}, 32), bytes.Repeat([]byte{0x56}, 32), bytes.Repeat([]byte{0xab}, 32)}
+	sigs := [][]byte{
+		unhex("b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"),
+		unhex("af1390c3c47acdb37131a51216da683c509fce0e954328a59f93aebda7e4ff974ba208d9a4a2a2389f892a9d418d618418dd7f7a6bc7aa0da999a9d3a5b815bc085e14fd001f6a1948768a3f4afefc8b8240dda329f984cb345c6363272ba4fe"),
+		unhex("ae82747ddeefe4fd64cf9cedb9b04ae3e8a43420cd255e3c7cd06a8d88b7c7f8638543719981c5d16fa3527c468c25f0026704a6951bde891360c7e8d12ddee0559004ccdbe6046b55bae1b257ee97f7cdb955773d7cf29adf3ccbb9975e4eb9"),
+	}
+	// the signatures of msgs[2] by all the keys, and of msgs[i] by pubs[i]
+	fastAgg := unhex("9712c3edd73a209c742b8250759db12549b3eaf43b5ca61376d9f30e2747dbcf842d8b2ac0901d2a093713e20284a7670fcf6954e9ab93de991bb9b313e664785a075fc285806fa5224c82bde146561b446ccfc706a64b8579513cfc4ff1d930")
+	agg := unhex("9104e74b9dfd3ad502f25d6a5ef57db0ed7d9a0e00f3500586d8ce44231212542fcfaf87840539b398bf07626705cf1105d246ca1062c6c2e1a53029a0f790ed5e3cb1f52f8234dc5144c45fc847c0cd37a92d68e7c5ba7c648a8a339f171244")
+	infinity := append([]byte{0xc0}, make([]byte, 95)...)
+
+	tests := []struct {
+		name    string
+		msgs    [][]byte
+		sig     []byte
+		pubs    [][]byte
+		want    bool
+		wantErr error
+	}{
+		{"verify", msgs[:1], sigs[0], pubs[:1], true, nil},
+		{"verify other key", msgs[1:2], sigs[1], pubs[1:2], true, nil},
+		{"wrong message", msgs[1:2], sigs[0], pubs[:1], false, nil},
+		{"wrong key", msgs[:1], sigs[0], pubs[1:2], false, nil},
+		{"short signature", msgs[:1], sigs[0][1:], pubs[:1], false, errInvalidSignature},
+		{"infinity signature", msgs[:1], infinity, pubs[:1], false, errInvalidSignature},
+		{"uncompressed key", msgs[:1], sigs[0], [][]byte{append(pubs[0], make([]byte, 48)...)}, false, errInvalidPubKey},
+		{"fast aggregate", msgs[2:], fastAgg, pubs, true, nil},
+		{"fast aggregate missing key", msgs[2:], fastAgg, pubs[:2], false, nil},
+		{"aggregate", msgs, agg, pubs, true, nil},
+		{"aggregate swapped", [][]byte{msgs[1], msgs[0], msgs[2]}, agg, pubs, false, nil},
+		{"no key", msgs[:1], sigs[0], nil, false, errInvalidPubKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := verifyBlsAggregate(tt.msgs, tt.sig, tt.pubs)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+	_, err := verifyBlsAggregate(msgs[:2], agg, pubs)
+	assert.Error(t, err, "the number of the messages and the keys are different")
+
+	ok, err := verifyBls(msgs[0], sigs[0], pubs[0])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// the proof of possession is the signature of the public key with the other tag, so a signature is not a proof
+	pop := unhex("b803eb0ed93ea10224a73b6b9c725796be9f5fefd215ef7a5b97234cc956cf6870db6127b7e4d824ec62276078e787db05584ce1adbf076bc0808ca0f15b73d59060254b25393d95dfc7abe3cda566842aaedf50bbb062aae1bbb6ef3b1f77e1")
+	ok, err = verifyBlsPop(pubs[0], pop)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = verifyBlsPop(pubs[1], pop)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = verifyBls(pubs[0], pop, pubs[0])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -14,6 +14,7 @@ const (
 	P2PWhite       = "P2PWHITE"
 	P2PBlack       = "P2PBLACK"
 	AccountWhite   = "ACCOUNTWHITE"
+	RPCTokens      = "RPCTOKENS"
 )
 
 // EnterpriseKeyDict is represent allowed key list and used when validate tx, int values are meaningless.
@@ -22,6 +23,7 @@ var enterpriseKeyDict = map[string]int{
 	P2PWhite:       2,
 	P2PBlack:       3,
 	AccountWhite:   4,
+	RPCTokens:      5,
 }
 
 type Conf struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aergoio/aergo-lib/log"
@@ -12,6 +13,7 @@ import (
 	"github.com/aergoio/aergo/v2/state"
 	"github.com/aergoio/aergo/v2/state/statedb"
 	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
 )
 
 var (
@@ -54,21 +56,123 @@ func (e *EnterpriseContext) HasConfValue(value string) bool {
 }
 
 func ExecuteEnterpriseTx(bs *state.BlockState, ccc consensus.ChainConsensusCluster, scs *statedb.ContractState, txBody *types.TxBody,
-	sender, receiver *state.AccountState, blockNo types.BlockNo) ([]*types.Event, error) {
+	txHash []byte, sender, receiver *state.AccountState, blockInfo *types.BlockHeaderInfo) ([]*types.Event, error) {
 
-	context, err := ValidateEnterpriseTx(txBody, sender, scs, blockNo)
+	context, err := ValidateEnterpriseTx(txBody, sender, scs, blockInfo)
 	if err != nil {
 		return nil, err
 	}
+	if blockInfo.ForkVersion < 6 {
+		return applyEnterpriseTx(bs, ccc, scs, context, txHash, sender.ID(), receiver, blockInfo)
+	}
+	events, err := expireProposals(scs, receiver.ID(), blockInfo.No)
+	if err != nil {
+		return nil, err
+	}
+	var applied []*types.Event
+	switch context.Call.Name {
+	case ApproveProposal, RejectProposal:
+		voted, approved, err := vote(scs, receiver.ID(), context.ArgsAny[0].(*types.EnterpriseProposal),
+			context.Call.Name == ApproveProposal, sender.ID(), context.Admins)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, voted...)
+		if approved == nil {
+			break
+		}
+		// the call is validated again, since the state may be changed after it
+		// was proposed, by the proposer who is the admin applying it
+		context, err = validateEnterpriseTx(&types.TxBody{Payload: []byte(approved.Call)}, approved.Proposer, scs, blockInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply proposal %d: %s", approved.Id, err.Error())
+		}
+		applied, err = applyEnterpriseTx(bs, ccc, scs, context, txHash, approved.Proposer, receiver, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		policy, err := getApprovalPolicy(scs)
+		if err != nil {
+			return nil, err
+		}
+		if policy.isOn() {
+			event, err := propose(scs, receiver.ID(), txBody.Payload, sender.ID(), policy, blockInfo.No)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+			break
+		}
+		applied, err = applyEnterpriseTx(bs, ccc, scs, context, txHash, sender.ID(), receiver, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	events = append(events, applied...)
+	for i, event := range events {
+		event.EventIdx = int32(i)
+	}
+	return events, nil
+}
+
+// applyEnterpriseTx applies the call validated. The admin is the sender of the
+// call or the proposer of the call approved.
+func applyEnterpriseTx(bs *state.BlockState, ccc consensus.ChainConsensusCluster, scs *statedb.ContractState, context *EnterpriseContext,
+	txHash []byte, admin []byte, receiver *state.AccountState, blockInfo *types.BlockHeaderInfo) ([]*types.Event, error) {
+	var err error
+	history := &types.EnterpriseHistory{
+		Admin:   admin,
+		Action:  context.Call.Name,
+		BlockNo: blockInfo.No,
+		TxHash:  txHash,
+	}
+	var oldValues, newValues []string
 	var events []*types.Event
 	switch context.Call.Name {
+	case AppendAdmin, RemoveAdmin:
+		history.Key = string(dbkey.EnterpriseAdmins())
+		oldValues = encodeAdmins(context.Admins)
+	case SetConf, AppendConf, RemoveConf, EnableConf:
+		history.Key = strings.ToUpper(context.Args[0])
+		old, err := getConf(scs, []byte(context.Args[0]))
+		if err != nil {
+			return nil, err
+		}
+		if old == nil {
+			old = &Conf{}
+		}
+		if context.Call.Name == EnableConf {
+			oldValues = []string{strconv.FormatBool(old.On)}
+			newValues = []string{strconv.FormatBool(context.Conf.On)}
+		} else {
+			oldValues = old.Values
+			newValues = context.Conf.Values
+		}
+	case SetApprovalPolicy:
+		history.Key = keyApprovals
+		old, err := getApprovalPolicy(scs)
+		if err != nil {
+			return nil, err
+		}
+		oldValues = old.values()
+	case ChangeCluster:
+		history.Key = keyCluster
+		jsonArgs, err := json.Marshal(context.Call.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		newValues = []string{string(jsonArgs)}
+	}
+	switch context.Call.Name {
 	case AppendAdmin:
 		requestAddress := types.ToAddress(context.Args[0])
-		err := setAdmins(scs,
-			append(context.Admins, requestAddress))
+		admins := append(context.Admins, requestAddress)
+		err := setAdmins(scs, admins)
 		if err != nil {
 			return nil, err
 		}
+		newValues = encodeAdmins(admins)
 		jsonArgs, err := json.Marshal(context.Args[0])
 		if err != nil {
 			return nil, err
@@ -90,6 +194,7 @@ func ExecuteEnterpriseTx(bs *state.BlockState, ccc consensus.ChainConsensusClust
 		if err != nil {
 			return nil, err
 		}
+		newValues = encodeAdmins(context.Admins)
 		jsonArgs, err := json.Marshal(context.Args[0])
 		if err != nil {
 			return nil, err
@@ -126,6 +231,16 @@ func ExecuteEnterpriseTx(bs *state.BlockState, ccc consensus.ChainConsensusClust
 			EventIdx:        0,
 			JsonArgs:        string(jsonArgs),
 		})
+	case SetApprovalPolicy:
+		policy := context.ArgsAny[0].(*approvalPolicy)
+		if err = setApprovalPolicy(scs, policy); err != nil {
+			return nil, err
+		}
+		newValues = policy.values()
+		events, err = createSetEvent(receiver.ID(), keyApprovals, policy.values())
+		if err != nil {
+			return nil, err
+		}
 	case ChangeCluster:
 		if bs.CCProposal != nil {
 			return nil, ErrTxEnterpriseAlreadyIncludeChangeCluster
@@ -170,9 +285,34 @@ func ExecuteEnterpriseTx(bs *state.BlockState, ccc consensus.ChainConsensusClust
 	default:
 		return nil, fmt.Errorf("unsupported call in enterprise contract")
 	}
+	if blockInfo.ForkVersion >= 6 {
+		// the lists are recorded by their changes, so a record doesn't grow with them
+		switch context.Call.Name {
+		case AppendAdmin, RemoveAdmin, SetConf, AppendConf, RemoveConf:
+			history.Removed, history.Added = diffValues(oldValues, newValues)
+		default:
+			history.Removed, history.Added = oldValues, newValues
+		}
+		if err = addHistory(scs, history); err != nil {
+			return nil, err
+		}
+		event, err := createHistoryEvent(receiver.ID(), history, len(events))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
 	return events, nil
 }
 
+func encodeAdmins(admins [][]byte) []string {
+	var ret []string
+	for _, admin := range admins {
+		ret = append(ret, types.EncodeAddress(admin))
+	}
+	return ret
+}
+
 func createSetEvent(addr []byte, name string, v []string) ([]*types.Event, error) {
 	jsonArgs, err := json.Marshal(v)
 	if err != nil {
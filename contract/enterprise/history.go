@@ -0,0 +1,169 @@
+package enterprise
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+// The history is the audit log of the enterprise contract. Every change of
+// the admins or the configs is recorded with a sequence number from 1, so
+// the records are ordered by block. A change of a list records the values
+// removed from and added to it rather than the whole list.
+const (
+	historyDefaultLimit = 100
+	historyMaxLimit     = 1000
+)
+
+// historyMaxScan is the maximum number of the records read by a query,
+// whether they match it or not.
+var historyMaxScan = 10000
+
+// keyCluster is the key of the history of the cluster changes.
+const keyCluster = "CLUSTER"
+
+type historyEvent struct {
+	Seq     uint64   `json:"seq"`
+	Admin   string   `json:"admin"`
+	Action  string   `json:"action"`
+	Key     string   `json:"key"`
+	Removed []string `json:"removed"`
+	Added   []string `json:"added"`
+}
+
+func addHistory(scs *statedb.ContractState, h *types.EnterpriseHistory) error {
+	count, err := getHistoryCount(scs)
+	if err != nil {
+		return err
+	}
+	h.Seq = count + 1
+	data, err := proto.Encode(h)
+	if err != nil {
+		return err
+	}
+	if err = scs.SetData(dbkey.EnterpriseHistory(h.Seq), data); err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.EnterpriseHistoryCount(), types.Uint64ToBytes(h.Seq))
+}
+
+func getHistoryCount(scs *statedb.ContractState) (uint64, error) {
+	data, err := scs.GetData(dbkey.EnterpriseHistoryCount())
+	if err != nil || len(data) == 0 {
+		return 0, err
+	}
+	return types.BytesToUint64(data), nil
+}
+
+func getHistory(scs *statedb.ContractState, seq uint64) (*types.EnterpriseHistory, error) {
+	data, err := scs.GetData(dbkey.EnterpriseHistory(seq))
+	if err != nil {
+		return nil, err
+	}
+	h := &types.EnterpriseHistory{}
+	if err := proto.Decode(data, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// diffValues returns the values of old missing in new and the values of new
+// missing in old, keeping their order.
+func diffValues(old, new []string) (removed, added []string) {
+	return missingValues(old, new), missingValues(new, old)
+}
+
+func missingValues(values, in []string) []string {
+	count := make(map[string]int, len(in))
+	for _, v := range in {
+		count[v]++
+	}
+	var ret []string
+	for _, v := range values {
+		if count[v] > 0 {
+			count[v]--
+		} else {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// createHistoryEvent returns the structured event of the history, which is
+// emitted in addition to the event of the action.
+func createHistoryEvent(addr []byte, h *types.EnterpriseHistory, idx int) (*types.Event, error) {
+	jsonArgs, err := json.Marshal(&historyEvent{
+		Seq:     h.Seq,
+		Admin:   types.EncodeAddress(h.Admin),
+		Action:  h.Action,
+		Key:     h.Key,
+		Removed: h.Removed,
+		Added:   h.Added,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: addr,
+		EventName:       "Audit " + h.Action,
+		EventIdx:        int32(idx),
+		JsonArgs:        string(jsonArgs),
+	}, nil
+}
+
+// ListHistory returns the history matching the query, latest first. The
+// empty fields of the query match all the records. The records before the
+// sequence number are returned if the query has one, which is used to page
+// through the history. A query reads at most historyMaxScan records, so the
+// list can be short of the limit. Its next is the sequence number to query
+// the records before to continue, or 0 if no record is left.
+func ListHistory(ecs *statedb.ContractState, q *types.EnterpriseHistoryQuery) (*types.EnterpriseHistoryList, error) {
+	count, err := getHistoryCount(ecs)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(q.GetLimit())
+	if limit == 0 {
+		limit = historyDefaultLimit
+	} else if limit > historyMaxLimit {
+		limit = historyMaxLimit
+	}
+	seq := count
+	if q.GetBefore() != 0 && q.GetBefore() <= count {
+		seq = q.GetBefore() - 1
+	}
+	ret := &types.EnterpriseHistoryList{}
+	for scanned := 0; seq > 0 && len(ret.History) < limit && scanned < historyMaxScan; seq-- {
+		scanned++
+		h, err := getHistory(ecs, seq)
+		if err != nil {
+			return nil, err
+		}
+		if h.BlockNo < q.GetFromBlock() {
+			seq = 0
+			break
+		}
+		if q.GetToBlock() != 0 && h.BlockNo > q.GetToBlock() {
+			continue
+		}
+		if q.GetKey() != "" && !strings.EqualFold(h.Key, q.GetKey()) {
+			continue
+		}
+		if q.GetAction() != "" && !strings.EqualFold(h.Action, q.GetAction()) {
+			continue
+		}
+		if len(q.GetAdmin()) != 0 && !bytes.Equal(h.Admin, q.GetAdmin()) {
+			continue
+		}
+		ret.History = append(ret.History, h)
+	}
+	if seq > 0 {
+		ret.Next = seq + 1
+	}
+	return ret, nil
+}
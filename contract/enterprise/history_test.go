@@ -0,0 +1,155 @@
+package enterprise
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnterpriseHistory(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	const admin2 = "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7"
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 5}
+	peers := []string{
+		`{"peerid":"16Uiu2HAmAokYAtLbZxJAPRgp2jCc4bD35cJD921trqUANh59Rc4n"}`,
+		`{"peerid":"16Uiu2HAm4xYtGsqk7WGKUxr8prfVpJ25hD23AQ3Be6anEL9Kxkgw"}`,
+		`{"peerid":"16Uiu2HAmGiJ2QgVAWHMUtzLKKNM5eFUJ3Ds3FN7nYJq1mHN5ZPj9"}`,
+	}
+	execute := func(txHash string, name string, args ...interface{}) []*types.Event {
+		payload, err := json.Marshal(&types.CallInfo{Name: name, Args: args})
+		assert.NoError(t, err)
+		tx := &types.TxBody{Payload: payload}
+		events, err := ExecuteEnterpriseTx(nil, ccc, scs, tx, []byte(txHash), sender, receiver, blockInfo)
+		assert.NoError(t, err, string(payload))
+		return events
+	}
+
+	events := execute("tx0", AppendAdmin, types.EncodeAddress(sender.ID()))
+	assert.Len(t, events, 1, "no history before the hardfork")
+	list, err := ListHistory(scs, &types.EnterpriseHistoryQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, list.History)
+
+	blockInfo.ForkVersion = 6
+	blockInfo.No = 2
+	events = execute("tx1", AppendAdmin, admin2)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "Append ADMIN", events[0].EventName)
+		assert.Equal(t, "Audit appendAdmin", events[1].EventName)
+		assert.Equal(t, int32(1), events[1].EventIdx)
+		var args historyEvent
+		assert.NoError(t, json.Unmarshal([]byte(events[1].JsonArgs), &args))
+		assert.Equal(t, historyEvent{
+			Seq:    1,
+			Admin:  types.EncodeAddress(sender.ID()),
+			Action: AppendAdmin,
+			Key:    "ADMINS",
+			Added:  []string{admin2},
+		}, args)
+	}
+
+	blockInfo.No = 3
+	execute("tx2", SetConf, "p2pwhite", peers[0], peers[1])
+	execute("tx3", AppendConf, "p2pwhite", peers[2])
+	blockInfo.No = 4
+	events = execute("tx4", EnableConf, "p2pwhite", true)
+	assert.Equal(t, "Enable P2PWHITE", events[0].EventName, "the event of the action is kept")
+	execute("tx5", RemoveConf, "p2pwhite", peers[0])
+
+	list, err = ListHistory(scs, &types.EnterpriseHistoryQuery{})
+	assert.NoError(t, err)
+	if assert.Len(t, list.History, 5) {
+		h := list.History[0]
+		assert.Equal(t, uint64(5), h.Seq, "latest first")
+		assert.Equal(t, RemoveConf, h.Action)
+		assert.Equal(t, P2PWhite, h.Key)
+		assert.Equal(t, peers[:1], h.Removed, "the change of the list only")
+		assert.Empty(t, h.Added)
+		assert.Equal(t, types.BlockNo(4), h.BlockNo)
+		assert.Equal(t, []byte("tx5"), h.TxHash)
+		assert.Equal(t, sender.ID(), h.Admin)
+
+		h = list.History[1]
+		assert.Equal(t, EnableConf, h.Action)
+		assert.Equal(t, []string{"false"}, h.Removed)
+		assert.Equal(t, []string{"true"}, h.Added)
+
+		h = list.History[2]
+		assert.Equal(t, AppendConf, h.Action)
+		assert.Empty(t, h.Removed)
+		assert.Equal(t, peers[2:], h.Added)
+
+		h = list.History[3]
+		assert.Equal(t, SetConf, h.Action)
+		assert.Empty(t, h.Removed)
+		assert.Equal(t, peers[:2], h.Added)
+	}
+
+	seqs := func(q *types.EnterpriseHistoryQuery) []uint64 {
+		list, err := ListHistory(scs, q)
+		assert.NoError(t, err)
+		var ret []uint64
+		for _, h := range list.History {
+			ret = append(ret, h.Seq)
+		}
+		return ret
+	}
+	assert.Equal(t, []uint64{5, 4, 3, 2}, seqs(&types.EnterpriseHistoryQuery{Key: "P2PWhite"}))
+	assert.Equal(t, []uint64{1}, seqs(&types.EnterpriseHistoryQuery{Key: "admins"}))
+	assert.Equal(t, []uint64{4}, seqs(&types.EnterpriseHistoryQuery{Action: "enableconf"}))
+	assert.Equal(t, []uint64{3, 2}, seqs(&types.EnterpriseHistoryQuery{FromBlock: 3, ToBlock: 3}))
+	assert.Equal(t, []uint64{5, 4}, seqs(&types.EnterpriseHistoryQuery{Limit: 2}))
+	assert.Equal(t, []uint64{3, 2}, seqs(&types.EnterpriseHistoryQuery{Before: 4, Limit: 2}))
+	assert.Equal(t, []uint64{5, 4, 3, 2, 1}, seqs(&types.EnterpriseHistoryQuery{Admin: sender.ID()}))
+	assert.Nil(t, seqs(&types.EnterpriseHistoryQuery{Admin: types.ToAddress(admin2)}))
+
+	// a query reads a limited number of records and returns where to continue
+	defer func(maxScan int) { historyMaxScan = maxScan }(historyMaxScan)
+	historyMaxScan = 2
+	tests := []struct {
+		query    *types.EnterpriseHistoryQuery
+		wantSeqs []uint64
+		wantNext uint64
+	}{
+		{&types.EnterpriseHistoryQuery{}, []uint64{5, 4}, 4},
+		{&types.EnterpriseHistoryQuery{Before: 4}, []uint64{3, 2}, 2},
+		{&types.EnterpriseHistoryQuery{Before: 2}, []uint64{1}, 0},
+		{&types.EnterpriseHistoryQuery{Key: "admins"}, nil, 4},
+		{&types.EnterpriseHistoryQuery{Key: "admins", Before: 2}, []uint64{1}, 0},
+		{&types.EnterpriseHistoryQuery{Limit: 1}, []uint64{5}, 5},
+		{&types.EnterpriseHistoryQuery{FromBlock: 4}, []uint64{5, 4}, 4},
+		{&types.EnterpriseHistoryQuery{FromBlock: 4, Before: 4}, nil, 0},
+	}
+	for _, tt := range tests {
+		list, err := ListHistory(scs, tt.query)
+		assert.NoError(t, err)
+		var got []uint64
+		for _, h := range list.History {
+			got = append(got, h.Seq)
+		}
+		assert.Equal(t, tt.wantSeqs, got, tt.query.String())
+		assert.Equal(t, tt.wantNext, list.Next, tt.query.String())
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	tests := []struct {
+		old, new               []string
+		wantRemoved, wantAdded []string
+	}{
+		{nil, []string{"a", "b"}, nil, []string{"a", "b"}},
+		{[]string{"a", "b"}, nil, []string{"a", "b"}, nil},
+		{[]string{"a", "b", "c"}, []string{"c", "a", "d"}, []string{"b"}, []string{"d"}},
+		{[]string{"a", "a"}, []string{"a"}, []string{"a"}, nil},
+		{[]string{"a"}, []string{"a"}, nil, nil},
+	}
+	for _, tt := range tests {
+		removed, added := diffValues(tt.old, tt.new)
+		assert.Equal(t, tt.wantRemoved, removed)
+		assert.Equal(t, tt.wantAdded, added)
+	}
+}
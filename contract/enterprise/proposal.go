@@ -0,0 +1,412 @@
+package enterprise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+const SetApprovalPolicy = "setApprovalPolicy"
+const ApproveProposal = "approveProposal"
+const RejectProposal = "rejectProposal"
+
+// keyApprovals is the key of the history of the approval policy.
+const keyApprovals = "APPROVALS"
+
+const (
+	// approvalWindowMax is the maximum number of blocks in which a proposal
+	// can be approved.
+	approvalWindowMax = 30 * 24 * 60 * 60
+	// proposalMax is the maximum number of the pending proposals.
+	proposalMax = 64
+	// proposalMaxPerAdmin is the maximum number of the pending proposals of
+	// an admin, so an admin cannot take all the slots of the others.
+	proposalMaxPerAdmin = 8
+)
+
+const (
+	proposalPending  = "pending"
+	proposalApproved = "approved"
+	proposalRejected = "rejected"
+	proposalExpired  = "expired"
+)
+
+// approvalPolicy is the governance mode of the enterprise contract. If the
+// threshold is more than 1, a change by an admin is a proposal, which is
+// applied when the threshold number of the admins approve it within the
+// window blocks. It is rejected when so many admins reject it that it cannot
+// be approved.
+type approvalPolicy struct {
+	Threshold uint64
+	Window    uint64
+}
+
+func (p *approvalPolicy) isOn() bool {
+	return p.Threshold > 1
+}
+
+type proposalEvent struct {
+	ID         uint64   `json:"id"`
+	Proposer   string   `json:"proposer"`
+	Call       string   `json:"call"`
+	Approvals  []string `json:"approvals"`
+	Rejections []string `json:"rejections"`
+	Threshold  uint64   `json:"threshold"`
+	Expire     uint64   `json:"expire"`
+	Status     string   `json:"status"`
+}
+
+func isApprovalCall(name string) bool {
+	return name == SetApprovalPolicy || name == ApproveProposal || name == RejectProposal
+}
+
+func getApprovalPolicy(scs *statedb.ContractState) (*approvalPolicy, error) {
+	data, err := scs.GetData(dbkey.EnterprisePolicy())
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 16 {
+		return &approvalPolicy{}, nil
+	}
+	return &approvalPolicy{
+		Threshold: types.BytesToUint64(data[:8]),
+		Window:    types.BytesToUint64(data[8:]),
+	}, nil
+}
+
+func setApprovalPolicy(scs *statedb.ContractState, p *approvalPolicy) error {
+	return scs.SetData(dbkey.EnterprisePolicy(), append(types.Uint64ToBytes(p.Threshold), types.Uint64ToBytes(p.Window)...))
+}
+
+func (p *approvalPolicy) values() []string {
+	return []string{strconv.FormatUint(p.Threshold, 10), strconv.FormatUint(p.Window, 10)}
+}
+
+func getProposals(scs *statedb.ContractState) (*types.EnterpriseProposalList, error) {
+	data, err := scs.GetData(dbkey.EnterpriseProposals())
+	if err != nil {
+		return nil, err
+	}
+	list := &types.EnterpriseProposalList{}
+	if len(data) != 0 {
+		if err := proto.Decode(data, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func setProposals(scs *statedb.ContractState, list *types.EnterpriseProposalList) error {
+	if len(list.Proposals) == 0 {
+		return scs.DeleteData(dbkey.EnterpriseProposals())
+	}
+	data, err := proto.Encode(list)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.EnterpriseProposals(), data)
+}
+
+func getProposal(scs *statedb.ContractState, id uint64) (*types.EnterpriseProposal, error) {
+	list, err := getProposals(scs)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range list.Proposals {
+		if p.Id == id {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func nextProposalID(scs *statedb.ContractState) (uint64, error) {
+	data, err := scs.GetData(dbkey.EnterpriseProposalSeq())
+	if err != nil {
+		return 0, err
+	}
+	var id uint64 = 1
+	if len(data) != 0 {
+		id = types.BytesToUint64(data) + 1
+	}
+	return id, scs.SetData(dbkey.EnterpriseProposalSeq(), types.Uint64ToBytes(id))
+}
+
+// argUint64 returns the unsigned integer of a json number or a decimal string.
+func argUint64(v interface{}) (uint64, error) {
+	switch arg := v.(type) {
+	case float64:
+		if arg < 0 || arg > math.MaxUint64 || arg != math.Trunc(arg) {
+			return 0, fmt.Errorf("not unsigned integer : %v", v)
+		}
+		return uint64(arg), nil
+	case string:
+		return strconv.ParseUint(arg, 10, 64)
+	}
+	return 0, fmt.Errorf("not number : %v", v)
+}
+
+func validateApprovalPolicy(context *EnterpriseContext, ci *types.CallInfo) error {
+	if len(ci.Args) != 2 { //args[0] : threshold, args[1] : window
+		return fmt.Errorf("invalid arguments in payload for %s : %s", ci.Name, ci.Args)
+	}
+	threshold, err := argUint64(ci.Args[0])
+	if err != nil {
+		return err
+	}
+	window, err := argUint64(ci.Args[1])
+	if err != nil {
+		return err
+	}
+	policy := &approvalPolicy{Threshold: threshold, Window: window}
+	if policy.isOn() {
+		if threshold > uint64(len(context.Admins)) {
+			return fmt.Errorf("the threshold %d is more than the number of admins", threshold)
+		}
+		if window == 0 || window > approvalWindowMax {
+			return fmt.Errorf("the window should be in 1 to %d blocks", approvalWindowMax)
+		}
+	}
+	context.ArgsAny = append(context.ArgsAny, policy)
+	return nil
+}
+
+func validateVote(scs *statedb.ContractState, context *EnterpriseContext, ci *types.CallInfo,
+	voter []byte, blockNo types.BlockNo) error {
+	if len(ci.Args) != 1 { //args[0] : proposal id
+		return fmt.Errorf("invalid arguments in payload for %s : %s", ci.Name, ci.Args)
+	}
+	id, err := argUint64(ci.Args[0])
+	if err != nil {
+		return err
+	}
+	proposal, err := getProposal(scs, id)
+	if err != nil {
+		return err
+	}
+	if proposal == nil {
+		return fmt.Errorf("proposal not found : %d", id)
+	}
+	if proposal.ExpireBlock < blockNo {
+		return fmt.Errorf("proposal expired : %d", id)
+	}
+	if containsAddress(proposal.Approvals, voter) || containsAddress(proposal.Rejections, voter) {
+		return fmt.Errorf("already voted for the proposal : %d", id)
+	}
+	context.ArgsAny = append(context.ArgsAny, proposal)
+	return nil
+}
+
+// validateAdminCount checks that the admins are not less than the threshold
+// of approvals after removing an admin.
+func validateAdminCount(scs *statedb.ContractState, admins [][]byte) error {
+	policy, err := getApprovalPolicy(scs)
+	if err != nil {
+		return err
+	}
+	if policy.isOn() && uint64(len(admins)-1) < policy.Threshold {
+		return fmt.Errorf("the admins cannot be less than the threshold of approvals %d", policy.Threshold)
+	}
+	return nil
+}
+
+// propose adds the call as a proposal approved by the proposer.
+func propose(scs *statedb.ContractState, addr []byte, call []byte, proposer []byte,
+	policy *approvalPolicy, blockNo types.BlockNo) (*types.Event, error) {
+	list, err := getProposals(scs)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Proposals) >= proposalMax {
+		return nil, fmt.Errorf("too many pending proposals")
+	}
+	if countProposals(list, proposer) >= proposalMaxPerAdmin {
+		return nil, fmt.Errorf("too many pending proposals of the admin, at most %d", proposalMaxPerAdmin)
+	}
+	id, err := nextProposalID(scs)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &types.EnterpriseProposal{
+		Id:          id,
+		Proposer:    proposer,
+		Call:        string(call),
+		BlockNo:     blockNo,
+		ExpireBlock: blockNo + policy.Window,
+		Approvals:   [][]byte{proposer},
+	}
+	list.Proposals = append(list.Proposals, proposal)
+	if err = setProposals(scs, list); err != nil {
+		return nil, err
+	}
+	return createProposalEvent(addr, "Propose PROPOSAL", proposal, policy, proposalPending)
+}
+
+// vote adds the approval or the rejection of the voter to the proposal. It
+// returns the proposal if it is approved, which should be applied by the
+// caller. Only the votes of the current admins are counted.
+func vote(scs *statedb.ContractState, addr []byte, proposal *types.EnterpriseProposal, approve bool,
+	voter []byte, admins [][]byte) ([]*types.Event, *types.EnterpriseProposal, error) {
+	policy, err := getApprovalPolicy(scs)
+	if err != nil {
+		return nil, nil, err
+	}
+	threshold := policy.Threshold
+	if threshold == 0 {
+		threshold = 1
+	}
+	eventName := "Approve PROPOSAL"
+	if approve {
+		proposal.Approvals = append(proposal.Approvals, voter)
+	} else {
+		proposal.Rejections = append(proposal.Rejections, voter)
+		eventName = "Reject PROPOSAL"
+	}
+
+	status := proposalPending
+	if countAdmins(proposal.Approvals, admins) >= threshold {
+		status = proposalApproved
+	} else if countAdmins(proposal.Rejections, admins)+threshold > uint64(len(admins)) {
+		status = proposalRejected
+	}
+
+	list, err := getProposals(scs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, p := range list.Proposals {
+		if p.Id != proposal.Id {
+			continue
+		}
+		if status == proposalPending {
+			list.Proposals[i] = proposal
+		} else {
+			list.Proposals = append(list.Proposals[:i], list.Proposals[i+1:]...)
+		}
+		break
+	}
+	if err = setProposals(scs, list); err != nil {
+		return nil, nil, err
+	}
+	event, err := createProposalEvent(addr, eventName, proposal, policy, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status == proposalApproved {
+		return []*types.Event{event}, proposal, nil
+	}
+	return []*types.Event{event}, nil, nil
+}
+
+// expireProposals removes the proposals which are not approved within the
+// window. The expiry is evaluated on access: no tx runs at the expire block,
+// so the proposals are removed by the next enterprise tx, which has their
+// expire events in its receipt, possibly many blocks later. The expire block
+// in the event tells when a proposal expired. An expired proposal cannot be
+// voted for and is not listed by ListProposals, even before it is removed.
+func expireProposals(scs *statedb.ContractState, addr []byte, blockNo types.BlockNo) ([]*types.Event, error) {
+	list, err := getProposals(scs)
+	if err != nil || len(list.Proposals) == 0 {
+		return nil, err
+	}
+	policy, err := getApprovalPolicy(scs)
+	if err != nil {
+		return nil, err
+	}
+	var events []*types.Event
+	var pending []*types.EnterpriseProposal
+	for _, p := range list.Proposals {
+		if p.ExpireBlock >= blockNo {
+			pending = append(pending, p)
+			continue
+		}
+		event, err := createProposalEvent(addr, "Expire PROPOSAL", p, policy, proposalExpired)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	list.Proposals = pending
+	return events, setProposals(scs, list)
+}
+
+func createProposalEvent(addr []byte, name string, p *types.EnterpriseProposal, policy *approvalPolicy,
+	status string) (*types.Event, error) {
+	jsonArgs, err := json.Marshal(&proposalEvent{
+		ID:         p.Id,
+		Proposer:   types.EncodeAddress(p.Proposer),
+		Call:       p.Call,
+		Approvals:  encodeAdmins(p.Approvals),
+		Rejections: encodeAdmins(p.Rejections),
+		Threshold:  policy.Threshold,
+		Expire:     p.ExpireBlock,
+		Status:     status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: addr,
+		EventName:       name,
+		JsonArgs:        string(jsonArgs),
+	}, nil
+}
+
+func containsAddress(addresses [][]byte, addr []byte) bool {
+	for _, a := range addresses {
+		if bytes.Equal(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func countProposals(list *types.EnterpriseProposalList, proposer []byte) int {
+	var n int
+	for _, p := range list.Proposals {
+		if bytes.Equal(p.Proposer, proposer) {
+			n++
+		}
+	}
+	return n
+}
+
+func countAdmins(addresses [][]byte, admins [][]byte) uint64 {
+	var n uint64
+	for _, a := range addresses {
+		if containsAddress(admins, a) {
+			n++
+		}
+	}
+	return n
+}
+
+// ListProposals returns the approval policy and the proposals which are not
+// expired at the block.
+func ListProposals(ecs *statedb.ContractState, blockNo types.BlockNo) (*types.EnterpriseProposalList, error) {
+	policy, err := getApprovalPolicy(ecs)
+	if err != nil {
+		return nil, err
+	}
+	list, err := getProposals(ecs)
+	if err != nil {
+		return nil, err
+	}
+	ret := &types.EnterpriseProposalList{Threshold: policy.Threshold, Window: policy.Window}
+	for _, p := range list.Proposals {
+		if p.ExpireBlock >= blockNo {
+			ret.Proposals = append(ret.Proposals, p)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,234 @@
+package enterprise
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnterpriseApproval(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	getAccount := func(address string) *state.AccountState {
+		account, err := state.GetAccountState(types.ToAddress(address), sdb)
+		assert.NoError(t, err)
+		return account
+	}
+	admin2 := getAccount("AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	admin3 := getAccount("AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+	peer := `{"peerid":"16Uiu2HAmAokYAtLbZxJAPRgp2jCc4bD35cJD921trqUANh59Rc4n"}`
+
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 5}
+	execute := func(account *state.AccountState, name string, args ...interface{}) ([]*types.Event, error) {
+		payload, err := json.Marshal(&types.CallInfo{Name: name, Args: args})
+		assert.NoError(t, err)
+		tx := &types.TxBody{Payload: payload}
+		return ExecuteEnterpriseTx(nil, ccc, scs, tx, nil, account, receiver, blockInfo)
+	}
+	eventNames := func(events []*types.Event) []string {
+		var ret []string
+		for i, e := range events {
+			assert.Equal(t, int32(i), e.EventIdx)
+			ret = append(ret, e.EventName)
+		}
+		return ret
+	}
+	status := func(event *types.Event) string {
+		var args proposalEvent
+		assert.NoError(t, json.Unmarshal([]byte(event.JsonArgs), &args))
+		return args.Status
+	}
+	pending := func() []uint64 {
+		list, err := ListProposals(scs, blockInfo.No)
+		assert.NoError(t, err)
+		var ret []uint64
+		for _, p := range list.Proposals {
+			ret = append(ret, p.Id)
+		}
+		return ret
+	}
+
+	for _, account := range []*state.AccountState{sender, admin2, admin3} {
+		_, err := execute(sender, AppendAdmin, types.EncodeAddress(account.ID()))
+		assert.NoError(t, err)
+	}
+	_, err := execute(sender, SetApprovalPolicy, 2, 10)
+	assert.Error(t, err, "not supported before the hardfork")
+	blockInfo.ForkVersion = 6
+
+	_, err = execute(sender, SetApprovalPolicy, 4, 10)
+	assert.Error(t, err, "threshold more than the admins")
+	_, err = execute(sender, SetApprovalPolicy, 2, 0)
+	assert.Error(t, err, "empty window")
+	_, err = execute(sender, SetApprovalPolicy, "two", 10)
+	assert.Error(t, err, "invalid threshold")
+	events, err := execute(sender, SetApprovalPolicy, 2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Set APPROVALS", "Audit setApprovalPolicy"}, eventNames(events), "applied without approvals")
+
+	// a change is proposed and applied by the approval of the other admin
+	events, err = execute(sender, SetConf, "p2pwhite", peer)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Propose PROPOSAL"}, eventNames(events))
+	conf, err := getConf(scs, []byte(P2PWhite))
+	assert.NoError(t, err)
+	assert.Nil(t, conf, "not applied before the approval")
+	assert.Equal(t, []uint64{1}, pending())
+
+	_, err = execute(sender, ApproveProposal, 1)
+	assert.Error(t, err, "the proposer already approved")
+	_, err = execute(admin2, ApproveProposal, 2)
+	assert.Error(t, err, "proposal not found")
+
+	blockInfo.No = 2
+	events, err = execute(admin2, ApproveProposal, 1)
+	assert.NoError(t, err)
+	if assert.Equal(t, []string{"Approve PROPOSAL", "Set P2PWHITE", "Audit setConf"}, eventNames(events)) {
+		assert.Equal(t, proposalApproved, status(events[0]))
+	}
+	conf, err = getConf(scs, []byte(P2PWhite))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{peer}, conf.Values)
+	assert.Empty(t, pending())
+	history, err := ListHistory(scs, &types.EnterpriseHistoryQuery{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, sender.ID(), history.History[0].Admin, "the change is made by the proposer")
+
+	// rejected when it cannot reach the threshold
+	_, err = execute(sender, RemoveAdmin, types.EncodeAddress(admin3.ID()))
+	assert.NoError(t, err)
+	events, err = execute(admin2, RejectProposal, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, proposalPending, status(events[0]))
+	events, err = execute(admin3, RejectProposal, 2)
+	assert.NoError(t, err)
+	if assert.Equal(t, []string{"Reject PROPOSAL"}, eventNames(events)) {
+		assert.Equal(t, proposalRejected, status(events[0]))
+	}
+	assert.Empty(t, pending())
+	admins, err := getAdmins(scs)
+	assert.NoError(t, err)
+	assert.Len(t, admins, 3)
+
+	// expired after the window, which is evaluated on access
+	_, err = execute(sender, EnableConf, "p2pwhite", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3}, pending())
+	blockInfo.No += 11
+	assert.Empty(t, pending(), "not listed before it is removed")
+	_, err = execute(admin2, ApproveProposal, 3)
+	assert.Error(t, err, "proposal expired")
+	proposal, err := getProposal(scs, 3)
+	assert.NoError(t, err)
+	assert.NotNil(t, proposal, "removed by the next enterprise tx only")
+	blockInfo.No += 100
+	events, err = execute(sender, RemoveAdmin, types.EncodeAddress(admin3.ID()))
+	assert.NoError(t, err)
+	if assert.Equal(t, []string{"Expire PROPOSAL", "Propose PROPOSAL"}, eventNames(events)) {
+		var args proposalEvent
+		assert.NoError(t, json.Unmarshal([]byte(events[0].JsonArgs), &args))
+		assert.Equal(t, proposalExpired, args.Status)
+		assert.Equal(t, uint64(12), args.Expire, "the expire block, not the block of the tx")
+	}
+	proposal, err = getProposal(scs, 3)
+	assert.NoError(t, err)
+	assert.Nil(t, proposal)
+	assert.Equal(t, []uint64{4}, pending())
+
+	// the admins cannot be less than the threshold
+	events, err = execute(admin3, ApproveProposal, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Approve PROPOSAL", "Remove ADMIN", "Audit removeAdmin"}, eventNames(events))
+	_, err = execute(sender, RemoveAdmin, types.EncodeAddress(admin2.ID()))
+	assert.Error(t, err, "the admins would be less than the threshold")
+
+	// the policy is changed by a proposal
+	_, err = execute(admin2, SetApprovalPolicy, 0, 0)
+	assert.NoError(t, err)
+	_, err = execute(sender, ApproveProposal, 5)
+	assert.NoError(t, err)
+	events, err = execute(sender, RemoveAdmin, types.EncodeAddress(admin2.ID()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Remove ADMIN", "Audit removeAdmin"}, eventNames(events), "applied without approvals")
+}
+
+func TestEnterpriseProposalFlood(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	admin2, err := state.GetAccountState(types.ToAddress("AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7"), sdb)
+	assert.NoError(t, err)
+	peer := `{"peerid":"16Uiu2HAmAokYAtLbZxJAPRgp2jCc4bD35cJD921trqUANh59Rc4n"}`
+
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 6}
+	execute := func(account *state.AccountState, name string, args ...interface{}) error {
+		payload, err := json.Marshal(&types.CallInfo{Name: name, Args: args})
+		assert.NoError(t, err)
+		tx := &types.TxBody{Payload: payload}
+		_, err = ExecuteEnterpriseTx(nil, ccc, scs, tx, nil, account, receiver, blockInfo)
+		return err
+	}
+	for _, account := range []*state.AccountState{sender, admin2} {
+		assert.NoError(t, execute(sender, AppendAdmin, types.EncodeAddress(account.ID())))
+	}
+	assert.NoError(t, execute(sender, SetApprovalPolicy, 2, approvalWindowMax))
+
+	// an admin floods the queue with the proposals of the maximum window
+	for i := 0; i < proposalMaxPerAdmin; i++ {
+		assert.NoError(t, execute(sender, SetConf, "p2pwhite", peer))
+	}
+	assert.Error(t, execute(sender, SetConf, "p2pwhite", peer), "too many proposals of the admin")
+
+	// the other admin can still propose
+	assert.NoError(t, execute(admin2, SetConf, "p2pwhite", peer))
+	list, err := ListProposals(scs, blockInfo.No)
+	assert.NoError(t, err)
+	assert.Len(t, list.Proposals, proposalMaxPerAdmin+1)
+	assert.Equal(t, admin2.ID(), list.Proposals[proposalMaxPerAdmin].Proposer)
+}
+
+func TestEnterpriseProposalProposer(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	getAccount := func(address string) *state.AccountState {
+		account, err := state.GetAccountState(types.ToAddress(address), sdb)
+		assert.NoError(t, err)
+		return account
+	}
+	admin2 := getAccount("AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	admin3 := getAccount("AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+	peer := `{"peerid":"16Uiu2HAmAokYAtLbZxJAPRgp2jCc4bD35cJD921trqUANh59Rc4n"}`
+
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 6}
+	execute := func(account *state.AccountState, name string, args ...interface{}) error {
+		payload, err := json.Marshal(&types.CallInfo{Name: name, Args: args})
+		assert.NoError(t, err)
+		tx := &types.TxBody{Payload: payload}
+		_, err = ExecuteEnterpriseTx(nil, ccc, scs, tx, nil, account, receiver, blockInfo)
+		return err
+	}
+	for _, account := range []*state.AccountState{sender, admin2, admin3} {
+		assert.NoError(t, execute(sender, AppendAdmin, types.EncodeAddress(account.ID())))
+	}
+	assert.NoError(t, execute(sender, SetApprovalPolicy, 2, 10))
+
+	// the proposer is removed from the admins before its call is approved
+	assert.NoError(t, execute(admin3, SetConf, "p2pwhite", peer))
+	assert.NoError(t, execute(sender, RemoveAdmin, types.EncodeAddress(admin3.ID())))
+	assert.NoError(t, execute(admin2, ApproveProposal, 2))
+	assert.NoError(t, execute(admin2, ApproveProposal, 1), "the approval of the removed proposer is not counted")
+
+	// the call is validated as the proposer who would apply it
+	err := execute(sender, ApproveProposal, 1)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to apply proposal 1")
+	}
+	conf, err := getConf(scs, []byte(P2PWhite))
+	assert.NoError(t, err)
+	assert.Nil(t, conf)
+}
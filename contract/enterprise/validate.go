@@ -26,11 +26,21 @@ const ChangeCluster = "changeCluster"
 var ErrTxEnterpriseAdminIsNotSet = errors.New("admin is not set")
 
 func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
-	scs *statedb.ContractState, blockNo types.BlockNo) (*EnterpriseContext, error) {
+	scs *statedb.ContractState, blockInfo *types.BlockHeaderInfo) (*EnterpriseContext, error) {
+	return validateEnterpriseTx(tx, sender.ID(), scs, blockInfo)
+}
+
+// validateEnterpriseTx validates the call made by the admin, which is the
+// sender of the tx or the proposer of the call approved.
+func validateEnterpriseTx(tx *types.TxBody, admin []byte,
+	scs *statedb.ContractState, blockInfo *types.BlockHeaderInfo) (*EnterpriseContext, error) {
 	var ci types.CallInfo
 	if err := json.Unmarshal(tx.Payload, &ci); err != nil {
 		return nil, err
 	}
+	if isApprovalCall(ci.Name) && blockInfo.ForkVersion < 6 {
+		return nil, fmt.Errorf("unsupported call %s", ci.Name)
+	}
 	context := &EnterpriseContext{Call: &ci}
 	switch ci.Name {
 	case AppendAdmin, RemoveAdmin:
@@ -44,7 +54,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 		if len(address) == 0 {
 			return nil, fmt.Errorf("invalid arguments[0]: %s", ci.Args[0])
 		}
-		admins, err := checkAdmin(scs, sender.ID())
+		admins, err := checkAdmin(scs, admin)
 		if err != nil &&
 			err != ErrTxEnterpriseAdminIsNotSet {
 			return nil, err
@@ -56,6 +66,9 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 			if !context.IsAdminExist(address) {
 				return nil, fmt.Errorf("admins is not exist : %s", ci.Args[0])
 			}
+			if err := validateAdminCount(scs, admins); err != nil {
+				return nil, err
+			}
 			conf, err := getConf(scs, []byte(AccountWhite))
 			if err != nil {
 				return nil, err
@@ -77,7 +90,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 			return nil, err
 		}
 		key := []byte(context.Args[0])
-		admins, err := checkAdmin(scs, sender.ID())
+		admins, err := checkAdmin(scs, admin)
 		if err != nil {
 			return nil, err
 		}
@@ -99,7 +112,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 		if err := checkArgs(context, &ci); err != nil {
 			return nil, err
 		}
-		admins, err := checkAdmin(scs, sender.ID())
+		admins, err := checkAdmin(scs, admin)
 		if err != nil {
 			return nil, err
 		}
@@ -147,7 +160,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 		if !ok {
 			return nil, fmt.Errorf("not bool in payload for enableConf : %s", ci.Args)
 		}
-		admins, err := checkAdmin(scs, sender.ID())
+		admins, err := checkAdmin(scs, admin)
 		if err != nil {
 			return nil, err
 		}
@@ -168,18 +181,38 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.AccountState,
 			return nil, ErrNotSupportedMethod
 		}
 
-		cc, err := ValidateChangeCluster(ci, blockNo)
+		cc, err := ValidateChangeCluster(ci, blockInfo.No)
 		if err != nil {
 			return nil, err
 		}
 
 		context.ArgsAny = append(context.ArgsAny, cc)
 
-		admins, err := checkAdmin(scs, sender.ID())
+		admins, err := checkAdmin(scs, admin)
+		if err != nil {
+			return nil, err
+		}
+		context.Admins = admins
+
+	case SetApprovalPolicy:
+		admins, err := checkAdmin(scs, admin)
+		if err != nil {
+			return nil, err
+		}
+		context.Admins = admins
+		if err := validateApprovalPolicy(context, &ci); err != nil {
+			return nil, err
+		}
+
+	case ApproveProposal, RejectProposal:
+		admins, err := checkAdmin(scs, admin)
 		if err != nil {
 			return nil, err
 		}
 		context.Admins = admins
+		if err := validateVote(scs, context, &ci, admin, blockInfo.No); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported call %s", ci.Name)
 	}
@@ -217,6 +250,8 @@ func checkArgs(context *EnterpriseContext, ci *types.CallInfo) error {
 		op = checkAccountWhite
 	case RPCPermissions:
 		op = checkRPCPermissions
+	case RPCTokens:
+		op = checkRPCTokens
 	default:
 		op = checkNone
 	}
@@ -273,6 +308,13 @@ func checkRPCPermissions(v string) error {
 	return nil
 }
 
+func checkRPCTokens(v string) error {
+	if _, err := types.ParseAPIToken(v); err != nil {
+		return fmt.Errorf("invalid RPC token %s: %s", v, err.Error())
+	}
+	return nil
+}
+
 func checkNone(v string) error {
 	return nil
 }
@@ -9,6 +9,11 @@ package contract
 import "C"
 
 func (ce *executor) setCountHook(limit C.int) {
+	// the line hook of the profiler checks the timeout as well
+	if ce != nil && ce.L != nil && ce.err == nil && ce.ctx.profiler != nil && ce.ctx.IsGasSystem() {
+		C.vm_set_profile_hook(ce.L)
+		return
+	}
 	if ce == nil ||
 		ce.L == nil ||
 		ce.err != nil ||
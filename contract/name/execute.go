@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/state"
@@ -15,7 +16,7 @@ import (
 func ExecuteNameTx(bs *state.BlockState, scs *statedb.ContractState, txBody *types.TxBody,
 	sender, receiver *state.AccountState, blockInfo *types.BlockHeaderInfo) ([]*types.Event, error) {
 
-	ci, err := ValidateNameTx(txBody, sender, scs)
+	ci, err := ValidateNameTx(txBody, sender, scs, blockInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +39,12 @@ func ExecuteNameTx(bs *state.BlockState, scs *statedb.ContractState, txBody *typ
 	switch ci.Name {
 	case types.NameCreate:
 		nameArg := ci.Args[0].(string)
-		if err = CreateName(scs, txBody, sender, nameState, nameArg); err != nil {
+		if blockInfo.ForkVersion < 6 {
+			err = CreateName(scs, txBody, sender, nameState, nameArg)
+		} else {
+			err = RegisterName(scs, txBody, sender, nameState, nameArg, blockInfo.No)
+		}
+		if err != nil {
 			return nil, err
 		}
 		jsonArgs := ""
@@ -71,6 +77,46 @@ func ExecuteNameTx(bs *state.BlockState, scs *statedb.ContractState, txBody *typ
 			EventName:       "update name",
 			JsonArgs:        jsonArgs,
 		})
+	case types.NameRenew:
+		nameArg := ci.Args[0].(string)
+		expire, err := RenewName(scs, txBody, sender, nameState, nameArg)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "renew name",
+			JsonArgs:        `["` + nameArg + `",` + strconv.FormatUint(expire, 10) + `]`,
+		})
+	case types.NameSetReverse:
+		nameArg := ci.Args[0].(string)
+		if err = SetReverseName(scs, sender.ID(), nameArg); err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "set reverse name",
+			JsonArgs:        `["` + types.EncodeAddress(sender.ID()) + `","` + nameArg + `"]`,
+		})
+	case types.NameSetRecord:
+		nameArg := ci.Args[0].(string)
+		keyArg := ci.Args[1].(string)
+		valueArg := ci.Args[2].(string)
+		if err = SetNameRecord(scs, nameArg, keyArg, valueArg); err != nil {
+			return nil, err
+		}
+		jsonArgs, err := json.Marshal([]string{nameArg, keyArg, valueArg})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "set name record",
+			JsonArgs:        string(jsonArgs),
+		})
 	case types.SetContractOwner:
 		ownerArg := ci.Args[0].(string)
 		ownerState, err := SetContractOwner(bs, scs, ownerArg, nameState)
@@ -85,7 +131,8 @@ func ExecuteNameTx(bs *state.BlockState, scs *statedb.ContractState, txBody *typ
 	return events, nil
 }
 
-func ValidateNameTx(tx *types.TxBody, sender *state.AccountState, scs *statedb.ContractState) (*types.CallInfo, error) {
+func ValidateNameTx(tx *types.TxBody, sender *state.AccountState, scs *statedb.ContractState,
+	blockInfo *types.BlockHeaderInfo) (*types.CallInfo, error) {
 	if sender != nil && sender.Balance().Cmp(tx.GetAmountBigInt()) < 0 {
 		return nil, types.ErrInsufficientBalance
 	}
@@ -96,12 +143,27 @@ func ValidateNameTx(tx *types.TxBody, sender *state.AccountState, scs *statedb.C
 	}
 
 	nameArg := ci.Args[0].(string)
+	isSubName := types.IsSubName([]byte(nameArg))
+	if (isSubName || ci.Name == types.NameRenew || ci.Name == types.NameSetReverse || ci.Name == types.NameSetRecord) &&
+		blockInfo.ForkVersion < 6 {
+		return nil, fmt.Errorf("not supported operation")
+	}
 	switch ci.Name {
 	case types.NameCreate:
+		if isSubName {
+			if err := validateForSubName(tx, scs, nameArg, blockInfo.No); err != nil {
+				return nil, err
+			}
+			break
+		}
 		if system.GetNamePrice().Cmp(tx.GetAmountBigInt()) > 0 {
 			return nil, types.ErrTooSmallAmount
 		}
-		if owner := getOwner(scs, []byte(nameArg), false); owner != nil {
+		if blockInfo.ForkVersion >= 6 {
+			if getActiveNameMap(scs, []byte(nameArg), blockInfo.No, false) != nil {
+				return nil, fmt.Errorf("aleady occupied %s", string(nameArg))
+			}
+		} else if owner := getOwner(scs, []byte(nameArg), false); owner != nil {
 			return nil, fmt.Errorf("aleady occupied %s", string(nameArg))
 		}
 	case types.NameUpdate:
@@ -112,6 +174,51 @@ func ValidateNameTx(tx *types.TxBody, sender *state.AccountState, scs *statedb.C
 			(!bytes.Equal(tx.Account, getOwner(scs, []byte(nameArg), false))) {
 			return nil, fmt.Errorf("owner not matched : %s", nameArg)
 		}
+		if blockInfo.ForkVersion >= 6 && getActiveNameMap(scs, []byte(nameArg), blockInfo.No, false) == nil {
+			return nil, fmt.Errorf("%s is not created yet", nameArg)
+		}
+	case types.NameRenew:
+		if isSubName {
+			return nil, fmt.Errorf("subname is renewed with its root name")
+		}
+		if system.GetNamePrice().Cmp(tx.GetAmountBigInt()) > 0 {
+			return nil, types.ErrTooSmallAmount
+		}
+		nameMap := getActiveNameMap(scs, []byte(nameArg), blockInfo.No, false)
+		if nameMap == nil {
+			return nil, fmt.Errorf("%s is not created yet", nameArg)
+		}
+		if !bytes.Equal(tx.Account, nameMap.Owner) {
+			return nil, fmt.Errorf("owner not matched : %s", nameArg)
+		}
+		if nameMap.Expire == 0 {
+			return nil, fmt.Errorf("%s does not expire", nameArg)
+		}
+	case types.NameSetReverse:
+		if tx.GetAmountBigInt().Sign() != 0 {
+			return nil, types.ErrTxInvalidAmount
+		}
+		if nameArg == "" {
+			break
+		}
+		nameMap := getActiveNameMap(scs, []byte(nameArg), blockInfo.No, false)
+		if nameMap == nil {
+			return nil, fmt.Errorf("%s is not created yet", nameArg)
+		}
+		if !bytes.Equal(tx.Account, nameMap.Destination) {
+			return nil, fmt.Errorf("%s is not resolved to the sender", nameArg)
+		}
+	case types.NameSetRecord:
+		if tx.GetAmountBigInt().Sign() != 0 {
+			return nil, types.ErrTxInvalidAmount
+		}
+		nameMap := getActiveNameMap(scs, []byte(nameArg), blockInfo.No, false)
+		if nameMap == nil {
+			return nil, fmt.Errorf("%s is not created yet", nameArg)
+		}
+		if !bytes.Equal(tx.Account, nameMap.Owner) {
+			return nil, fmt.Errorf("owner not matched : %s", nameArg)
+		}
 	case types.SetContractOwner:
 		if owner := getOwner(scs, []byte(types.AergoName), false); owner != nil {
 			return nil, fmt.Errorf("owner aleady set to %s", types.EncodeAddress(owner))
@@ -123,6 +230,23 @@ func ValidateNameTx(tx *types.TxBody, sender *state.AccountState, scs *statedb.C
 	return &ci, nil
 }
 
+// validateForSubName checks that the sender owns the name above the subname,
+// and the subname is not taken.
+func validateForSubName(tx *types.TxBody, scs *statedb.ContractState, name string, blockNo types.BlockNo) error {
+	parent := parentName([]byte(name))
+	parentMap := getActiveNameMap(scs, parent, blockNo, false)
+	if parentMap == nil || parentMap.isExpired(blockNo) {
+		return fmt.Errorf("%s is not created yet", string(parent))
+	}
+	if !bytes.Equal(tx.Account, parentMap.Owner) {
+		return fmt.Errorf("owner not matched : %s", string(parent))
+	}
+	if getActiveNameMap(scs, []byte(name), blockNo, false) != nil {
+		return fmt.Errorf("aleady occupied %s", name)
+	}
+	return nil
+}
+
 func SetContractOwner(bs *state.BlockState, scs *statedb.ContractState,
 	address string, nameState *state.AccountState) (*state.AccountState, error) {
 
@@ -1,6 +1,7 @@
 package name
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/aergoio/aergo/v2/state"
@@ -136,3 +137,103 @@ func nextBlockContractState(t *testing.T, bs *state.BlockState, scs *statedb.Con
 	commitContractState(t, bs, scs)
 	return openContractState(t, bs)
 }
+
+func TestExcuteNameExpiry(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	owner := types.ToAddress("AmMXVdJ8DnEFysN58cox9RADC74dF1CLrQimKCMdB4XXMkJeuQgL")
+	other := types.ToAddress("AmMSMkVHQ6qRVA7G7rqwjvv2NBwB48tTekJ2jFMrjfZrsofePgay")
+	name := "AB1234567890"
+	subname := "pay." + name
+
+	sender, _ := state.GetAccountState(owner, sdb.GetStateDB())
+	sender.AddBalance(types.MaxAER)
+	sender2, _ := state.GetAccountState(other, sdb.GetStateDB())
+	sender2.AddBalance(types.MaxAER)
+	receiver, _ := state.GetAccountState([]byte(types.AergoName), sdb.GetStateDB())
+	bs := sdb.NewBlockState(sdb.GetRoot())
+	scs := openContractState(t, bs)
+
+	blockInfo := &types.BlockHeaderInfo{No: 10, ForkVersion: 6}
+	execute := func(account *state.AccountState, payload []byte) ([]*types.Event, error) {
+		txBody := &types.TxBody{
+			Account:   account.ID(),
+			Recipient: []byte(types.AergoName),
+			Amount:    types.NewAmount(1, types.Aergo).Bytes(),
+			Payload:   payload,
+		}
+		return ExecuteNameTx(bs, scs, txBody, account, receiver, blockInfo)
+	}
+	resolve := func(name string) []byte {
+		scs = nextBlockContractState(t, bs, scs)
+		addr, err := Resolve(bs, []byte(name), false, blockInfo.No)
+		assert.NoError(t, err)
+		return addr
+	}
+	nameInfo := func(name string) *types.NameInfo {
+		scs = nextBlockContractState(t, bs, scs)
+		info, err := GetNameInfo(scs, name, blockInfo.No)
+		assert.NoError(t, err)
+		return info
+	}
+
+	blockInfo.ForkVersion = 5
+	_, err := execute(sender, buildNamePayload(name, types.NameRenew, ""))
+	assert.Error(t, err, "not supported before the hardfork")
+	blockInfo.ForkVersion = 6
+
+	_, err = execute(sender, buildNamePayload(name, types.NameCreate, ""))
+	assert.NoError(t, err, "register name")
+	expire := blockInfo.No + NamePeriod
+	assert.Equal(t, owner, resolve(name))
+	info := nameInfo(name)
+	assert.Equal(t, expire, info.Expire)
+	assert.Equal(t, NameActive, info.Status)
+
+	blockInfo.No++
+	_, err = execute(sender2, buildNamePayload(subname, types.NameCreate, ""))
+	assert.Error(t, err, "only the owner of the name creates a subname")
+	_, err = execute(sender, buildNamePayload("x.y."+name, types.NameCreate, ""))
+	assert.Error(t, err, "the parent is not created")
+	_, err = execute(sender, buildNamePayload(subname, types.NameCreate, ""))
+	assert.NoError(t, err, "create subname")
+	assert.Equal(t, owner, resolve(subname))
+	assert.Equal(t, owner, GetActiveAddress(scs, []byte(subname), blockInfo.No))
+	assert.Equal(t, expire, nameInfo(subname).Expire, "the subname follows its root")
+
+	// in the grace period, the name is still resolved and only the owner renews it
+	blockInfo.No = expire + 1
+	assert.Equal(t, NameExpired, nameInfo(name).Status)
+	assert.Equal(t, owner, resolve(name))
+	_, err = execute(sender2, buildNamePayload(name, types.NameCreate, ""))
+	assert.Error(t, err, "the expired name is not released yet")
+	_, err = execute(sender2, buildNamePayload(name, types.NameRenew, ""))
+	assert.Error(t, err, "renew by other")
+	_, err = execute(sender, buildNamePayload(subname, types.NameRenew, ""))
+	assert.Error(t, err, "renew subname")
+	event, err := execute(sender, buildNamePayload(name, types.NameRenew, ""))
+	assert.NoError(t, err, "renew name")
+	expire += NamePeriod
+	assert.Equal(t, "renew name", event[0].EventName)
+	assert.Equal(t, `["AB1234567890",`+strconv.FormatUint(expire, 10)+`]`, event[0].JsonArgs)
+	assert.Equal(t, NameActive, nameInfo(name).Status)
+
+	// after the grace period, the name and its subnames are released
+	blockInfo.No = expire + NameGracePeriod + 1
+	assert.Nil(t, resolve(name))
+	assert.Nil(t, resolve(subname))
+	assert.Nil(t, GetActiveAddress(scs, []byte(name), blockInfo.No), "the mempool resolves as the chain")
+	assert.Nil(t, GetActiveAddress(scs, []byte(subname), blockInfo.No), "the mempool resolves as the chain")
+	assert.Equal(t, NameReleased, nameInfo(name).Status)
+	_, err = execute(sender, buildNamePayload(name, types.NameUpdate, types.EncodeAddress(other)))
+	assert.Error(t, err, "update released name")
+
+	_, err = execute(sender2, buildNamePayload(name, types.NameCreate, ""))
+	assert.NoError(t, err, "register released name")
+	assert.Equal(t, other, resolve(name))
+	assert.Nil(t, resolve(subname), "the subname of the previous registration")
+	blockInfo.No++
+	_, err = execute(sender2, buildNamePayload(subname, types.NameCreate, ""))
+	assert.NoError(t, err, "create subname again")
+	assert.Equal(t, other, resolve(subname))
+}
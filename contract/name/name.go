@@ -1,6 +1,7 @@
 package name
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"strings"
@@ -11,10 +12,46 @@ import (
 	"github.com/aergoio/aergo/v2/types/dbkey"
 )
 
+const (
+	// NamePeriod is the blocks for which a name is registered or renewed, about a year.
+	NamePeriod = 365 * 24 * 60 * 60
+	// NameGracePeriod is the blocks after the expiry in which only the owner can renew the name, about 30 days.
+	// The name is released after it.
+	NameGracePeriod = 30 * 24 * 60 * 60
+)
+
+const (
+	NameActive   = "active"
+	NameExpired  = "expired"
+	NameReleased = "released"
+)
+
+// NameMap is the record of a name. The names registered from the hardfork
+// version 6 are of version 2, which have the expiry. The names registered
+// before are permanent.
 type NameMap struct {
 	Version     byte
 	Owner       []byte
 	Destination []byte
+	Expire      types.BlockNo // the last block of the registration, 0 for a subname which follows its root
+	Registered  types.BlockNo // the block at which the root name is registered
+}
+
+func (n *NameMap) isExpired(blockNo types.BlockNo) bool {
+	return n.Expire != 0 && blockNo > n.Expire
+}
+
+func (n *NameMap) isReleased(blockNo types.BlockNo) bool {
+	return n.Expire != 0 && blockNo > n.Expire+NameGracePeriod
+}
+
+func (n *NameMap) status(blockNo types.BlockNo) string {
+	if n.isReleased(blockNo) {
+		return NameReleased
+	} else if n.isExpired(blockNo) {
+		return NameExpired
+	}
+	return NameActive
 }
 
 func CreateName(scs *statedb.ContractState, tx *types.TxBody, sender, receiver *state.AccountState, name string) error {
@@ -30,6 +67,47 @@ func createName(scs *statedb.ContractState, name []byte, owner []byte) error {
 	return registerOwner(scs, name, owner, owner)
 }
 
+// RegisterName registers the name for NamePeriod blocks, or the subname
+// under its root name.
+func RegisterName(scs *statedb.ContractState, tx *types.TxBody, sender, receiver *state.AccountState, name string,
+	blockNo types.BlockNo) error {
+	amount := tx.GetAmountBigInt()
+	if err := state.SendBalance(sender, receiver, amount); err != nil {
+		return err
+	}
+	nameMap := &NameMap{Version: 2, Owner: sender.ID(), Destination: sender.ID()}
+	if root := rootName([]byte(name)); root != nil {
+		rootMap := getNameMap(scs, root, false)
+		if rootMap == nil {
+			return fmt.Errorf("%s is not created yet", string(root))
+		}
+		nameMap.Registered = rootMap.Registered
+	} else {
+		nameMap.Expire = blockNo + NamePeriod
+		nameMap.Registered = blockNo
+	}
+	// the records of the previous registration are not inherited
+	if err := scs.DeleteData(dbkey.NameRecords([]byte(name))); err != nil {
+		return err
+	}
+	return setNameMap(scs, []byte(name), nameMap)
+}
+
+// RenewName extends the registration of the name for NamePeriod blocks.
+func RenewName(scs *statedb.ContractState, tx *types.TxBody, sender, receiver *state.AccountState,
+	name string) (types.BlockNo, error) {
+	amount := tx.GetAmountBigInt()
+	if err := state.SendBalance(sender, receiver, amount); err != nil {
+		return 0, err
+	}
+	nameMap := getNameMap(scs, []byte(name), false)
+	if nameMap == nil {
+		return 0, fmt.Errorf("%s is not created yet", name)
+	}
+	nameMap.Expire += NamePeriod
+	return nameMap.Expire, setNameMap(scs, []byte(name), nameMap)
+}
+
 // UpdateName is avaliable after bid implement
 func UpdateName(bs *state.BlockState, scs *statedb.ContractState, tx *types.TxBody,
 	sender, receiver *state.AccountState, name, to string) error {
@@ -63,7 +141,14 @@ func UpdateName(bs *state.BlockState, scs *statedb.ContractState, tx *types.TxBo
 
 func updateName(scs *statedb.ContractState, name []byte, owner []byte, to []byte) error {
 	//return setAddress(scs, name, to)
-	return registerOwner(scs, name, owner, to)
+	nameMap := getNameMap(scs, name, false)
+	if nameMap == nil || nameMap.Version < 2 {
+		return registerOwner(scs, name, owner, to)
+	}
+	// keep the registration
+	nameMap.Owner = owner
+	nameMap.Destination = to
+	return setNameMap(scs, name, nameMap)
 }
 
 func isPredefined(name []byte, legacy bool) bool {
@@ -73,8 +158,9 @@ func isPredefined(name []byte, legacy bool) bool {
 	return len(name) == types.AddressLength || types.IsSpecialAccount(name)
 }
 
-// Resolve is resolve name for chain
-func Resolve(bs *state.BlockState, name []byte, legacy bool) ([]byte, error) {
+// Resolve is resolve name for chain. The name released at the block is not
+// resolved.
+func Resolve(bs *state.BlockState, name []byte, legacy bool, blockNo types.BlockNo) ([]byte, error) {
 	if isPredefined(name, legacy) {
 		return name, nil
 	}
@@ -82,7 +168,10 @@ func Resolve(bs *state.BlockState, name []byte, legacy bool) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return getAddress(scs, name), nil
+	if nameMap := getActiveNameMap(scs, name, blockNo, true); nameMap != nil {
+		return nameMap.Destination, nil
+	}
+	return nil, nil
 }
 
 func openContract(bs *state.BlockState) (*statedb.ContractState, error) {
@@ -97,7 +186,7 @@ func openContract(bs *state.BlockState) (*statedb.ContractState, error) {
 	return scs, nil
 }
 
-// GetAddress is resolve name for mempool
+// GetAddress is resolve name for mempool, regardless of the expiry of the name
 func GetAddress(scs *statedb.ContractState, name []byte) []byte {
 	if len(name) == types.AddressLength || types.IsSpecialAccount(name) {
 		return name
@@ -105,6 +194,18 @@ func GetAddress(scs *statedb.ContractState, name []byte) []byte {
 	return getAddress(scs, name)
 }
 
+// GetActiveAddress is resolve name for mempool, as Resolve does for the chain
+// at the block. The name released at the block is not resolved.
+func GetActiveAddress(scs *statedb.ContractState, name []byte, blockNo types.BlockNo) []byte {
+	if len(name) == types.AddressLength || types.IsSpecialAccount(name) {
+		return name
+	}
+	if nameMap := getActiveNameMap(scs, name, blockNo, true); nameMap != nil {
+		return nameMap.Destination
+	}
+	return nil
+}
+
 // GetAddressLegacy is resolve name for mempool by buggy logic, leaved for backward compatibility
 func GetAddressLegacy(scs *statedb.ContractState, name []byte) []byte {
 	if len(name) == types.AddressLength || strings.Contains(string(name), ".") {
@@ -147,9 +248,51 @@ func getNameMap(scs *statedb.ContractState, name []byte, useInitial bool) *NameM
 	return deserializeNameMap(ownerdata)
 }
 
-func GetNameInfo(ncs *statedb.ContractState, name string) (*types.NameInfo, error) {
-	owner := getOwner(ncs, []byte(name), true)
-	return &types.NameInfo{Name: &types.Name{Name: string(name)}, Owner: owner, Destination: GetAddress(ncs, []byte(name))}, nil
+// getActiveNameMap returns the record of the name if it is not released at
+// the block. A subname is active while the root name registered at the time
+// of its creation is.
+func getActiveNameMap(scs *statedb.ContractState, name []byte, blockNo types.BlockNo, useInitial bool) *NameMap {
+	nameMap := getNameMap(scs, name, useInitial)
+	if nameMap == nil || nameMap.isReleased(blockNo) {
+		return nil
+	}
+	if root := rootName(name); root != nil {
+		rootMap := getNameMap(scs, root, useInitial)
+		if rootMap == nil || rootMap.isReleased(blockNo) || rootMap.Registered != nameMap.Registered {
+			return nil
+		}
+		nameMap.Expire = rootMap.Expire
+	}
+	return nameMap
+}
+
+// rootName returns the root name of the subname, or nil if it is not a subname.
+func rootName(name []byte) []byte {
+	if !types.IsSubName(name) {
+		return nil
+	}
+	return name[bytes.LastIndexByte(name, '.')+1:]
+}
+
+// parentName returns the name right above the subname.
+func parentName(name []byte) []byte {
+	return name[bytes.IndexByte(name, '.')+1:]
+}
+
+func GetNameInfo(ncs *statedb.ContractState, name string, blockNo types.BlockNo) (*types.NameInfo, error) {
+	info := &types.NameInfo{Name: &types.Name{Name: string(name)}}
+	nameMap := getActiveNameMap(ncs, []byte(name), blockNo, true)
+	if nameMap == nil && getNameMap(ncs, []byte(name), true) != nil {
+		info.Status = NameReleased
+		return info, nil
+	}
+	if nameMap != nil {
+		info.Owner = nameMap.Owner
+		info.Expire = nameMap.Expire
+		info.Status = nameMap.status(blockNo)
+	}
+	info.Destination = GetAddress(ncs, []byte(name))
+	return info, nil
 }
 
 func registerOwner(scs *statedb.ContractState, name, owner, destination []byte) error {
@@ -172,6 +315,12 @@ func serializeNameMap(n *NameMap) []byte {
 		binary.LittleEndian.PutUint64(buf, uint64(len(n.Destination)))
 		ret = append(ret, buf...)
 		ret = append(ret, n.Destination...)
+		if n.Version >= 2 {
+			binary.LittleEndian.PutUint64(buf, n.Expire)
+			ret = append(ret, buf...)
+			binary.LittleEndian.PutUint64(buf, n.Registered)
+			ret = append(ret, buf...)
+		}
 	}
 	return ret
 }
@@ -179,7 +328,7 @@ func serializeNameMap(n *NameMap) []byte {
 func deserializeNameMap(data []byte) *NameMap {
 	if data != nil {
 		version := data[0]
-		if version != 1 {
+		if version != 1 && version != 2 {
 			panic("could not deserializeOwner, not supported version")
 		}
 		offset := 1
@@ -197,11 +346,17 @@ func deserializeNameMap(data []byte) *NameMap {
 		offset = next
 		next = offset + int(sizeOfDest)
 		destination := data[offset:next]
-		return &NameMap{
+		nameMap := &NameMap{
 			Version:     version,
 			Owner:       owner,
 			Destination: destination,
 		}
+		if version >= 2 {
+			offset = next
+			nameMap.Expire = binary.LittleEndian.Uint64(data[offset : offset+8])
+			nameMap.Registered = binary.LittleEndian.Uint64(data[offset+8 : offset+16])
+		}
+		return nameMap
 	}
 	return nil
 }
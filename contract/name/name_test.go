@@ -51,7 +51,7 @@ func TestName(t *testing.T) {
 	assert.NoError(t, err, "create name")
 
 	scs = nextBlockContractState(t, bs, scs)
-	_, err = ValidateNameTx(tx, sender, scs)
+	_, err = ValidateNameTx(tx, sender, scs, &types.BlockHeaderInfo{})
 	assert.Error(t, err, "same name")
 
 	ret := getAddress(scs, []byte(name))
@@ -0,0 +1,131 @@
+package name
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+// SetReverseName sets the name of the address for the reverse resolution, or
+// clears it with an empty name. The reverse record is valid only while the
+// name resolves to the address.
+func SetReverseName(scs *statedb.ContractState, address []byte, name string) error {
+	if name == "" {
+		return scs.DeleteData(dbkey.NameReverse(address))
+	}
+	return scs.SetData(dbkey.NameReverse(address), []byte(name))
+}
+
+// Reverse is reverse resolution for chain. It returns nil if the address has
+// no reverse record or the name does not resolve to the address.
+func Reverse(bs *state.BlockState, address []byte, blockNo types.BlockNo) ([]byte, error) {
+	scs, err := openContract(bs)
+	if err != nil {
+		return nil, err
+	}
+	return getReverseName(scs, address, blockNo, true), nil
+}
+
+func getReverseName(scs *statedb.ContractState, address []byte, blockNo types.BlockNo, useInitial bool) []byte {
+	var err error
+	var name []byte
+	if useInitial {
+		name, err = scs.GetInitialData(dbkey.NameReverse(address))
+	} else {
+		name, err = scs.GetData(dbkey.NameReverse(address))
+	}
+	if err != nil || len(name) == 0 {
+		return nil
+	}
+	// verify against the forward record
+	nameMap := getActiveNameMap(scs, name, blockNo, useInitial)
+	if nameMap == nil || !bytes.Equal(nameMap.Destination, address) {
+		return nil
+	}
+	return name
+}
+
+// GetReverseName returns the info of the name of the address, whose name is
+// empty if the address has no valid reverse record.
+func GetReverseName(ncs *statedb.ContractState, address []byte, blockNo types.BlockNo) (*types.NameInfo, error) {
+	name := getReverseName(ncs, address, blockNo, true)
+	if name == nil {
+		return &types.NameInfo{Name: &types.Name{}, Destination: address}, nil
+	}
+	return GetNameInfo(ncs, string(name), blockNo)
+}
+
+// SetNameRecord sets the text record of the name with the key, or deletes it
+// with an empty value.
+func SetNameRecord(scs *statedb.ContractState, name, key, value string) error {
+	records, err := getNameRecords(scs, []byte(name), false)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, r := range records.Records {
+		if r.Key != key {
+			continue
+		}
+		found = true
+		if value == "" {
+			records.Records = append(records.Records[:i], records.Records[i+1:]...)
+		} else {
+			r.Value = value
+		}
+		break
+	}
+	if !found && value != "" {
+		if len(records.Records) >= types.NameRecordMax {
+			return fmt.Errorf("too many records of %s", name)
+		}
+		records.Records = append(records.Records, &types.NameRecord{Key: key, Value: value})
+	}
+	return setNameRecords(scs, []byte(name), records)
+}
+
+func setNameRecords(scs *statedb.ContractState, name []byte, records *types.NameRecordList) error {
+	if len(records.Records) == 0 {
+		return scs.DeleteData(dbkey.NameRecords(name))
+	}
+	data, err := proto.Encode(records)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.NameRecords(name), data)
+}
+
+func getNameRecords(scs *statedb.ContractState, name []byte, useInitial bool) (*types.NameRecordList, error) {
+	var err error
+	var data []byte
+	if useInitial {
+		data, err = scs.GetInitialData(dbkey.NameRecords(name))
+	} else {
+		data, err = scs.GetData(dbkey.NameRecords(name))
+	}
+	if err != nil {
+		return nil, err
+	}
+	records := &types.NameRecordList{}
+	if len(data) != 0 {
+		if err := proto.Decode(data, records); err != nil {
+			return nil, err
+		}
+	}
+	records.Name = string(name)
+	return records, nil
+}
+
+// GetNameRecords returns the text records of the name. A name which is not
+// registered at the block has no records.
+func GetNameRecords(ncs *statedb.ContractState, name string, blockNo types.BlockNo) (*types.NameRecordList, error) {
+	if getActiveNameMap(ncs, []byte(name), blockNo, true) == nil {
+		return &types.NameRecordList{Name: name}, nil
+	}
+	return getNameRecords(ncs, []byte(name), true)
+}
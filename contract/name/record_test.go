@@ -0,0 +1,121 @@
+package name
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcuteNameReverseAndRecords(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	owner := types.ToAddress("AmMXVdJ8DnEFysN58cox9RADC74dF1CLrQimKCMdB4XXMkJeuQgL")
+	other := types.ToAddress("AmMSMkVHQ6qRVA7G7rqwjvv2NBwB48tTekJ2jFMrjfZrsofePgay")
+	name := "AB1234567890"
+
+	sender, _ := state.GetAccountState(owner, sdb.GetStateDB())
+	sender.AddBalance(types.MaxAER)
+	sender2, _ := state.GetAccountState(other, sdb.GetStateDB())
+	sender2.AddBalance(types.MaxAER)
+	receiver, _ := state.GetAccountState([]byte(types.AergoName), sdb.GetStateDB())
+	bs := sdb.NewBlockState(sdb.GetRoot())
+	scs := openContractState(t, bs)
+
+	blockInfo := &types.BlockHeaderInfo{No: 10, ForkVersion: 6}
+	execute := func(account *state.AccountState, amount *big.Int, payload string) ([]*types.Event, error) {
+		txBody := &types.TxBody{
+			Account:   account.ID(),
+			Recipient: []byte(types.AergoName),
+			Amount:    amount.Bytes(),
+			Payload:   []byte(payload),
+		}
+		return ExecuteNameTx(bs, scs, txBody, account, receiver, blockInfo)
+	}
+	reverse := func(address []byte) string {
+		scs = nextBlockContractState(t, bs, scs)
+		reversed, err := Reverse(bs, address, blockInfo.No)
+		assert.NoError(t, err)
+		info, err := GetReverseName(scs, address, blockInfo.No)
+		assert.NoError(t, err)
+		assert.Equal(t, string(reversed), info.Name.Name)
+		return string(reversed)
+	}
+	records := func(name string) map[string]string {
+		scs = nextBlockContractState(t, bs, scs)
+		list, err := GetNameRecords(scs, name, blockInfo.No)
+		assert.NoError(t, err)
+		ret := make(map[string]string)
+		for _, r := range list.Records {
+			ret[r.Key] = r.Value
+		}
+		return ret
+	}
+	zero := big.NewInt(0)
+	setReverse := func(name string) string {
+		return fmt.Sprintf(`{"Name":"v1setReverseName","Args":["%s"]}`, name)
+	}
+	setRecord := func(name, key, value string) string {
+		return fmt.Sprintf(`{"Name":"v1setNameRecord","Args":["%s","%s","%s"]}`, name, key, value)
+	}
+
+	_, err := execute(sender, types.NewAmount(1, types.Aergo), string(buildNamePayload(name, types.NameCreate, "")))
+	assert.NoError(t, err, "register name")
+
+	blockInfo.ForkVersion = 5
+	_, err = execute(sender, zero, setReverse(name))
+	assert.Error(t, err, "not supported before the hardfork")
+	blockInfo.ForkVersion = 6
+
+	_, err = execute(sender2, zero, setReverse(name))
+	assert.Error(t, err, "the name is not resolved to the sender")
+	_, err = execute(sender, big.NewInt(1), setReverse(name))
+	assert.Equal(t, types.ErrTxInvalidAmount, err)
+	event, err := execute(sender, zero, setReverse(name))
+	assert.NoError(t, err, "set reverse name")
+	assert.Equal(t, "set reverse name", event[0].EventName)
+	assert.Equal(t, name, reverse(owner))
+	assert.Equal(t, "", reverse(other))
+
+	_, err = execute(sender2, zero, setRecord(name, "url", "https://aergo.io"))
+	assert.Error(t, err, "set record by other")
+	event, err = execute(sender, zero, setRecord(name, "url", "https://aergo.io"))
+	assert.NoError(t, err, "set record")
+	assert.Equal(t, `["AB1234567890","url","https://aergo.io"]`, event[0].JsonArgs)
+	_, err = execute(sender, zero, setRecord(name, "avatar", "ipfs://avatar"))
+	assert.NoError(t, err, "set record")
+	_, err = execute(sender, zero, setRecord(name, "url", "https://docs.aergo.io"))
+	assert.NoError(t, err, "update record")
+	assert.Equal(t, map[string]string{"url": "https://docs.aergo.io", "avatar": "ipfs://avatar"}, records(name))
+	_, err = execute(sender, zero, setRecord(name, "avatar", ""))
+	assert.NoError(t, err, "delete record")
+	assert.Equal(t, map[string]string{"url": "https://docs.aergo.io"}, records(name))
+
+	for i := 1; i < types.NameRecordMax; i++ {
+		_, err = execute(sender, zero, setRecord(name, fmt.Sprintf("key%d", i), "v"))
+		assert.NoError(t, err)
+	}
+	_, err = execute(sender, zero, setRecord(name, "onemore", "v"))
+	assert.Error(t, err, "too many records")
+
+	// the reverse record is not valid once the name resolves to other address
+	blockInfo.No++
+	_, err = execute(sender, types.NewAmount(1, types.Aergo), string(buildNamePayload(name, types.NameUpdate, types.EncodeAddress(other))))
+	assert.NoError(t, err, "update name")
+	assert.Equal(t, "", reverse(owner))
+
+	// the records are not inherited by a new registration
+	blockInfo.No += NamePeriod + NameGracePeriod
+	assert.Empty(t, records(name))
+	_, err = execute(sender, types.NewAmount(1, types.Aergo), string(buildNamePayload(name, types.NameCreate, "")))
+	assert.NoError(t, err, "register released name")
+	assert.Empty(t, records(name))
+	assert.Equal(t, name, reverse(owner), "the reverse record is valid again")
+
+	_, err = execute(sender, zero, setReverse(""))
+	assert.NoError(t, err, "clear reverse name")
+	assert.Equal(t, "", reverse(owner))
+}
@@ -30,13 +30,45 @@ static int resolve(lua_State *L) {
 	return 1;
 }
 
+static int reverse(lua_State *L) {
+	char *address, *ret;
+	int service = getLuaExecContext(L);
+
+	lua_gasuse(L, 100);
+
+	address = (char *)luaL_checkstring(L, 1);
+	ret = luaNameReverse(L, service, address);
+
+	if (ret == NULL) {
+		lua_pushnil(L);
+	} else {
+		// if the returned string starts with `[`, it's an error
+		if (ret[0] == '[') {
+			strPushAndRelease(L, ret);
+			luaL_throwerror(L);
+		} else {
+			strPushAndRelease(L, ret);
+		}
+	}
+
+	return 1;
+}
+
 static const luaL_Reg name_service_lib[] = {
 	{"resolve", resolve},
 	{NULL, NULL}
 };
 
+static const luaL_Reg name_service_lib_v6[] = {
+	{"reverse", reverse},
+	{NULL, NULL}
+};
+
 int luaopen_name(lua_State *L) {
 	luaL_register(L, "name_service", name_service_lib);
+	if (vm_is_hardfork(L, 6)) {
+		luaL_register(L, NULL, name_service_lib_v6);
+	}
 	lua_pop(L, 1);
 	return 1;
 }
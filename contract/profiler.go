@@ -0,0 +1,216 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TxProfile is the result of a dry run of a transaction with the profiler.
+type TxProfile struct {
+	Status  string `json:"status"`
+	Ret     string `json:"ret,omitempty"`
+	GasUsed uint64 `json:"gasUsed"`
+	FeeUsed string `json:"feeUsed"`
+	Gas     string `json:"gas"`               // the consumed gas in the folded stack format
+	Storage string `json:"storage,omitempty"` // the size of the state updates in bytes, in the folded stack format
+}
+
+// Profiler attributes the gas consumed by the vm to the call stacks of a transaction. A stack is made of the called
+// contracts, the lua functions with the current source line and the callbacks of the vm, such as state.set or
+// crypto.ecverify. The gas of the instructions is sampled by the line hook which is set instead of the count hook,
+// so the execution is much slower than usual. It is passed to the vm by the execution context, see WithProfiler.
+type Profiler struct {
+	txHash []byte
+	gas    map[string]uint64
+	size   map[string]uint64
+	levels []*profileLevel
+}
+
+// profileLevel is the execution of a contract function on its own LState.
+type profileLevel struct {
+	L       *LState
+	prefix  string // the stack of the caller and the called contract
+	fname   string
+	cur     string // the stack of the current source line
+	pending string // the callback which the next sampled gas is charged to
+	lastGas uint64
+}
+
+type profilerKey struct{}
+
+func NewProfiler(txHash []byte) *Profiler {
+	return &Profiler{
+		txHash: txHash,
+		gas:    make(map[string]uint64),
+		size:   make(map[string]uint64),
+	}
+}
+
+// WithProfiler returns the execution context to profile the transaction. The sql databases are written as usual, so
+// roll them back by CloseDatabase if the transaction must not change them.
+func WithProfiler(execCtx context.Context, p *Profiler) context.Context {
+	return context.WithValue(execCtx, profilerKey{}, p)
+}
+
+func profilerOf(execCtx context.Context) *Profiler {
+	if execCtx == nil {
+		return nil
+	}
+	p, _ := execCtx.Value(profilerKey{}).(*Profiler)
+	return p
+}
+
+// Folded returns the consumed gas in the folded stack format, which is read by the flame graph tools.
+func (p *Profiler) Folded() string {
+	return folded(p.gas)
+}
+
+// FoldedStorage returns the size of the state updates in the folded stack format.
+func (p *Profiler) FoldedStorage() string {
+	return folded(p.size)
+}
+
+func folded(samples map[string]uint64) string {
+	stacks := make([]string, 0, len(samples))
+	for stack, n := range samples {
+		if n > 0 {
+			stacks = append(stacks, stack)
+		}
+	}
+	sort.Strings(stacks)
+	var buf bytes.Buffer
+	for _, stack := range stacks {
+		fmt.Fprintf(&buf, "%s %d\n", stack, samples[stack])
+	}
+	return buf.String()
+}
+
+func (p *Profiler) current() *profileLevel {
+	if len(p.levels) == 0 {
+		return nil
+	}
+	return p.levels[len(p.levels)-1]
+}
+
+// charge attributes the gas consumed since the last sample to the current stack. The gas is continued from the
+// caller to the called contract, so the levels share a single gas counter.
+func (p *Profiler) charge(ctx *vmContext, suffix string) {
+	l := p.current()
+	if l == nil {
+		return
+	}
+	gas := ctx.gasOf(l.L)
+	stack := l.cur
+	if l.pending != "" {
+		stack += ";" + l.pending
+		l.pending = ""
+	}
+	if suffix != "" {
+		stack += ";" + suffix
+	}
+	if l.lastGas > gas {
+		p.gas[stack] += l.lastGas - gas
+	}
+	l.lastGas = gas
+}
+
+func (p *Profiler) push(ctx *vmContext, L *LState, contract string, fname string) {
+	prefix := contract
+	if parent := p.current(); parent != nil {
+		p.charge(ctx, "")
+		prefix = parent.cur + ";" + contract
+	}
+	p.levels = append(p.levels, &profileLevel{
+		L:       L,
+		prefix:  prefix,
+		fname:   fname,
+		cur:     prefix + ";" + fname,
+		lastGas: ctx.gasOf(L),
+	})
+}
+
+func (p *Profiler) pop(ctx *vmContext) {
+	l := p.current()
+	if l == nil {
+		return
+	}
+	p.charge(ctx, "")
+	p.levels = p.levels[:len(p.levels)-1]
+	if parent := p.current(); parent != nil {
+		parent.lastGas = l.lastGas
+	}
+}
+
+// setProfiler attaches the profiler in the execution context to the vm context, if the transaction is profiled.
+func setProfiler(ctx *vmContext, execCtx context.Context) {
+	p := profilerOf(execCtx)
+	if p == nil || !bytes.Equal(ctx.txHash, p.txHash) {
+		return
+	}
+	ctx.profiler = p
+}
+
+func profileEnter(ctx *vmContext, L *LState, contract string, fname string) {
+	if ctx.profiler == nil {
+		return
+	}
+	ctx.profiler.push(ctx, L, contract, fname)
+}
+
+func profileExit(ctx *vmContext) {
+	if ctx.profiler == nil {
+		return
+	}
+	ctx.profiler.pop(ctx)
+}
+
+// profileLine charges the gas consumed until the line hook, and moves to the stack of the new line. The stack of the
+// lua functions is given by the hook from the outermost function, whose name is unknown since it is called by the vm.
+func profileLine(ctx *vmContext, stack string) {
+	if ctx.profiler == nil {
+		return
+	}
+	ctx.profiler.charge(ctx, "")
+	if stack == "" {
+		return
+	}
+	if l := ctx.profiler.current(); l != nil {
+		if strings.HasPrefix(stack, "?") {
+			stack = l.fname + stack[1:]
+		}
+		l.cur = l.prefix + ";" + stack
+	}
+}
+
+// profileCallback charges the gas consumed since the last sample to the callback, since the gas of the callbacks is
+// charged before they are called.
+func profileCallback(ctx *vmContext, name string) {
+	if ctx == nil || ctx.profiler == nil {
+		return
+	}
+	ctx.profiler.charge(ctx, name)
+}
+
+// profileSql charges the gas consumed until the next sample to the sql statement being executed.
+func profileSql(ctx *vmContext) {
+	if ctx == nil || ctx.profiler == nil {
+		return
+	}
+	ctx.profiler.charge(ctx, "")
+	if l := ctx.profiler.current(); l != nil {
+		l.pending = "sql"
+	}
+}
+
+// profileUpdateSize records the size of a state update at the current stack.
+func profileUpdateSize(ctx *vmContext, updateSize int64) {
+	if ctx.profiler == nil || updateSize <= 0 {
+		return
+	}
+	if l := ctx.profiler.current(); l != nil {
+		ctx.profiler.size[l.cur] += uint64(updateSize)
+	}
+}
@@ -0,0 +1,52 @@
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiler_stacks(t *testing.T) {
+	txHash := []byte("profiled")
+	p := NewProfiler(txHash)
+	execCtx := WithProfiler(context.Background(), p)
+
+	other := &vmContext{txHash: []byte("other"), blockInfo: &types.BlockHeaderInfo{}}
+	setProfiler(other, execCtx)
+	assert.Nil(t, other.profiler)
+	assert.False(t, other.isReplay, "the profiler alone should not replay")
+
+	ctx := &vmContext{txHash: txHash, blockInfo: &types.BlockHeaderInfo{}}
+	setProfiler(ctx, WithReplay(execCtx))
+	setTracer(ctx, WithReplay(execCtx))
+	assert.Equal(t, p, ctx.profiler)
+	assert.True(t, ctx.isReplay)
+
+	profileEnter(ctx, nil, "A", "run")
+	profileLine(ctx, "?;add:3")
+	assert.Equal(t, "A;run;add:3", p.current().cur)
+	profileUpdateSize(ctx, 36)
+
+	// a call from the line keeps the stack of the caller
+	profileEnter(ctx, nil, "B", "get")
+	profileLine(ctx, "?:7")
+	assert.Equal(t, "A;run;add:3;B;get:7", p.current().cur)
+	profileUpdateSize(ctx, 4)
+	profileExit(ctx)
+
+	profileSql(ctx)
+	assert.Equal(t, "sql", p.current().pending)
+	profileLine(ctx, "?:4")
+	assert.Empty(t, p.current().pending)
+	profileExit(ctx)
+	assert.Nil(t, p.current())
+
+	assert.Equal(t, "A;run;add:3 36\nA;run;add:3;B;get:7 4\n", p.FoldedStorage())
+
+	p.gas["A;run:4"] = 30
+	p.gas["A;run:2;state.set"] = 120
+	p.gas["A;run:5"] = 0
+	assert.Equal(t, "A;run:2;state.set 120\nA;run:4 30\n", p.Folded())
+}
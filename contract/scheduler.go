@@ -0,0 +1,189 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/aergoio/aergo/v2/contract/system"
+	"github.com/aergoio/aergo/v2/fee"
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+var errNotEnoughPrepaidFee = errors.New("not enough prepaid fee")
+
+// ScheduledCallReceipt is the result of a scheduled call executed at the start of a block. It is kept apart from the
+// receipts of the txs, which are not changed by the scheduled calls.
+type ScheduledCallReceipt struct {
+	Id       uint64               `json:"id"`
+	Contract string               `json:"contract"`
+	Function string               `json:"function"`
+	TxHash   string               `json:"txhash"`
+	Status   string               `json:"status"`
+	Ret      string               `json:"ret,omitempty"`
+	GasUsed  uint64               `json:"gasUsed"`
+	FeeUsed  string               `json:"feeUsed"`
+	Events   []ScheduledCallEvent `json:"events,omitempty"`
+}
+
+type ScheduledCallEvent struct {
+	Contract string `json:"contract"`
+	Name     string `json:"name"`
+	Args     string `json:"args"`
+}
+
+// ExecuteScheduledCalls executes the scheduled calls which are due at the block, before the txs of the block. It
+// returns the number of the executed calls.
+func ExecuteScheduledCalls(execCtx context.Context, bs *state.BlockState, cdb ChainAccessor, bi *types.BlockHeaderInfo, executionMode int) (int, error) {
+	if bi.ForkVersion < 6 {
+		return 0, nil
+	}
+	n := 0
+	for ; n < system.MaxScheduledCallsPerBlock; n++ {
+		call, err := popScheduledCall(bs, bi)
+		if err != nil {
+			return n, err
+		}
+		if call == nil {
+			break
+		}
+		if err = executeScheduledCall(execCtx, bs, cdb, bi, executionMode, call); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// popScheduledCall removes the first due call from the queue and returns its prepaid fee to the contract.
+func popScheduledCall(bs *state.BlockState, bi *types.BlockHeaderInfo) (*system.ScheduledCall, error) {
+	sysState, err := state.GetAccountState([]byte(types.AergoSystem), bs.StateDB)
+	if err != nil {
+		return nil, err
+	}
+	scs, err := statedb.OpenContractState(sysState.IDNoPadding(), sysState.State(), bs.StateDB)
+	if err != nil {
+		return nil, err
+	}
+	call, err := system.PopDueScheduledCall(scs, bi.No, bi.Ts/1e9)
+	if err != nil || call == nil {
+		return nil, err
+	}
+	contractId, err := types.DecodeAddress(call.Contract)
+	if err != nil {
+		return nil, err
+	}
+	ctrState, err := state.GetAccountState(contractId, bs.StateDB)
+	if err != nil {
+		return nil, err
+	}
+	if err = state.SendBalance(sysState, ctrState, call.GetFee()); err != nil {
+		return nil, err
+	}
+	if err = statedb.StageContractState(scs, bs.StateDB); err != nil {
+		return nil, err
+	}
+	if err = sysState.PutState(); err != nil {
+		return nil, err
+	}
+	return call, ctrState.PutState()
+}
+
+// executeScheduledCall executes the call as a tx which the contract sends to itself, with the gas limited by the
+// prepaid fee. The fee used is paid by the contract to the block producer.
+func executeScheduledCall(execCtx context.Context, bs *state.BlockState, cdb ChainAccessor, bi *types.BlockHeaderInfo, executionMode int, call *system.ScheduledCall) error {
+	contractId, err := types.DecodeAddress(call.Contract)
+	if err != nil {
+		return err
+	}
+	ctrState, err := state.GetAccountState(contractId, bs.StateDB)
+	if err != nil {
+		return err
+	}
+
+	payload := call.Payload()
+	prepaid := call.GetFee()
+	baseFee := fee.TxBaseFee(bi.ForkVersion, bs.GasPrice, len(payload))
+	gasLimit := call.GasLimit
+	if fee.GasEnabled(bi.ForkVersion) {
+		var maxGas uint64
+		if prepaid.Cmp(baseFee) > 0 {
+			maxGas = fee.MaxGasLimit(new(big.Int).Sub(prepaid, baseFee), bs.GasPrice)
+		}
+		if gasLimit == 0 || gasLimit > maxGas {
+			gasLimit = maxGas
+		}
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Nonce:       call.Id,
+			Account:     contractId,
+			Recipient:   contractId,
+			Payload:     payload,
+			GasLimit:    gasLimit,
+			Type:        types.TxType_CALL,
+			ChainIdHash: bi.ChainIdHash(),
+		},
+	}
+	tx.Hash = tx.CalculateTxHash()
+
+	var (
+		status  = "SUCCESS"
+		rv      string
+		events  []*types.Event
+		usedFee *big.Int
+	)
+	if fee.GasEnabled(bi.ForkVersion) && gasLimit == 0 {
+		usedFee = prepaid
+		if usedFee.Cmp(baseFee) > 0 {
+			usedFee = baseFee
+		}
+		err = newVmError(errNotEnoughPrepaidFee)
+	} else {
+		rv, events, _, usedFee, err = Execute(execCtx, bs, cdb, tx, ctrState, ctrState, bi, executionMode, false)
+	}
+	if err != nil {
+		if !IsRuntimeError(err) {
+			return err
+		}
+		ctrState.Reset()
+		if ctrState.Balance().Cmp(usedFee) < 0 {
+			return &types.InternalError{Reason: "fee is greater than balance"}
+		}
+		events = nil
+		status = "ERROR"
+		rv = err.Error()
+	}
+	ctrState.SubBalance(usedFee)
+	if err = ctrState.PutState(); err != nil {
+		return err
+	}
+	bs.BpReward.Add(&bs.BpReward, usedFee)
+
+	receipt := &ScheduledCallReceipt{
+		Id:       call.Id,
+		Contract: call.Contract,
+		Function: call.Function,
+		TxHash:   base58.Encode(tx.Hash),
+		Status:   status,
+		Ret:      rv,
+		GasUsed:  fee.ReceiptGasUsed(bi.ForkVersion, false, usedFee, bs.GasPrice),
+		FeeUsed:  usedFee.String(),
+	}
+	for _, e := range events {
+		receipt.Events = append(receipt.Events, ScheduledCallEvent{
+			Contract: types.EncodeAddress(e.ContractAddress),
+			Name:     e.EventName,
+			Args:     e.JsonArgs,
+		})
+	}
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	bs.AddScheduledCallReceipt(string(data))
+	return nil
+}
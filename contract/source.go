@@ -0,0 +1,48 @@
+package contract
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+var (
+	ErrNotContract    = errors.New("not a contract")
+	ErrSourceMismatch = errors.New("the source does not match the deployed code")
+	ErrSourceTooLarge = errors.New("the source is larger than a transaction")
+)
+
+// VerifySource compiles the source and compares the bytecode and the ABI with the deployed code of the contract. The
+// source is compiled as before the hardfork version 6 first, and then with the typed ABI. It returns whether the
+// typed ABI was used for the match. The source cannot be larger than a transaction which could have deployed it, and
+// the instructions run to generate its ABI are limited.
+func VerifySource(contractState *statedb.ContractState, source string) (bool, error) {
+	if len(source) > types.TxMaxSize {
+		return false, ErrSourceTooLarge
+	}
+	code, err := contractState.GetCode()
+	if err != nil {
+		return false, err
+	}
+	deployed := util.LuaCode(code)
+	if !deployed.IsValidFormat() {
+		return false, ErrNotContract
+	}
+	err = ErrSourceMismatch
+	for _, typedABI := range []bool{false, true} {
+		compiled, compileErr := compileLimited(source, typedABI)
+		if compileErr != nil {
+			// abi.types is not available without the typed ABI
+			err = compileErr
+			continue
+		}
+		if bytes.Equal(compiled.ByteCode(), deployed.ByteCode()) && bytes.Equal(compiled.ABI(), deployed.ABI()) {
+			return typedABI, nil
+		}
+		err = ErrSourceMismatch
+	}
+	return false, err
+}
@@ -15,6 +15,7 @@ import (
 	"sync"
 
 	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/state"
 	"github.com/aergoio/aergo/v2/types"
@@ -214,7 +215,19 @@ func dataSrc(dbName string) string {
 		"file:%s/%s.db?branches=on&max_db_size=%d",
 		database.DataDir,
 		dbName,
-		maxSQLDBSize*1024*1024)
+		sqlMaxDBSize()*1024*1024)
+}
+
+// sqlMaxDBSize returns the max database size (MB) voted as a system param, or
+// the one of the node config. The size is read only when a database is opened.
+// The databases are closed at the end of each block by SaveRecoveryPoint or
+// CloseDatabase and the query connections are opened per query, so a voted
+// size applies from the first block where it is active, without a restart.
+func sqlMaxDBSize() uint64 {
+	if size := system.GetSQLMaxDBSize(); size != 0 {
+		return size
+	}
+	return maxSQLDBSize
 }
 
 func readOnlyConn(dbName string) (*litetree, error) {
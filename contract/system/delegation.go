@@ -0,0 +1,310 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+type delegateCmd struct {
+	*SystemContext
+	amount *big.Int
+}
+
+func newDelegateCmd(ctx *SystemContext) (sysCmd, error) {
+	return &delegateCmd{SystemContext: ctx, amount: ctx.txBody.GetAmountBigInt()}, nil
+}
+
+func (c *delegateCmd) run() (*types.Event, error) {
+	delegation := c.Delegation
+	delegation.Amount = new(big.Int).Add(delegation.GetAmountBigInt(), c.amount).Bytes()
+	delegation.When = c.BlockInfo.No
+	if err := setDelegation(c.scs, delegation); err != nil {
+		return nil, err
+	}
+	if err := updateDelegators(c.scs, delegation, c.amount); err != nil {
+		return nil, err
+	}
+	// the delegator loses the voting power, and the delegate votes with it
+	if err := refreshAllVote(c.SystemContext); err != nil {
+		return nil, err
+	}
+	if err := syncDelegateVote(c.SystemContext, delegation.Delegate); err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "delegate",
+		JsonArgs: `["` + types.EncodeAddress(delegation.Delegator) + `", "` +
+			types.EncodeAddress(delegation.Delegate) + `", {"_bignum":"` + c.amount.String() + `"}]`,
+	}, nil
+}
+
+type undelegateCmd struct {
+	*SystemContext
+	amount *big.Int
+}
+
+func newUndelegateCmd(ctx *SystemContext) (sysCmd, error) {
+	return &undelegateCmd{SystemContext: ctx, amount: ctx.txBody.GetAmountBigInt()}, nil
+}
+
+func (c *undelegateCmd) run() (*types.Event, error) {
+	delegation := c.Delegation
+	delegation.Amount = new(big.Int).Sub(delegation.GetAmountBigInt(), c.amount).Bytes()
+	delegation.When = c.BlockInfo.No
+	if err := setDelegation(c.scs, delegation); err != nil {
+		return nil, err
+	}
+	if err := updateDelegators(c.scs, delegation, new(big.Int).Neg(c.amount)); err != nil {
+		return nil, err
+	}
+	// like unstaking, the votes of the delegator are not increased until it
+	// votes again.
+	if err := syncDelegateVote(c.SystemContext, delegation.Delegate); err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "undelegate",
+		JsonArgs: `["` + types.EncodeAddress(delegation.Delegator) + `", "` +
+			types.EncodeAddress(delegation.Delegate) + `", {"_bignum":"` + c.amount.String() + `"}]`,
+	}, nil
+}
+
+func validateForDelegate(account []byte, ci *types.CallInfo, txBody *types.TxBody, scs *statedb.ContractState) (*types.Staking, *types.Delegation, error) {
+	delegate, err := types.ParseDelegate(ci)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bytes.Equal(delegate, account) {
+		return nil, nil, fmt.Errorf("cannot delegate to oneself")
+	}
+	staked, err := checkStakingBefore(account, scs)
+	if err != nil {
+		return nil, nil, err
+	}
+	delegation, err := getDelegation(scs, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(delegation.Delegate) != 0 && !bytes.Equal(delegation.Delegate, delegate) {
+		return nil, nil, fmt.Errorf("already delegated to %s", types.EncodeAddress(delegation.Delegate))
+	}
+	toBe := new(big.Int).Add(delegation.GetAmountBigInt(), txBody.GetAmountBigInt())
+	if staked.GetAmountBigInt().Cmp(toBe) < 0 {
+		return nil, nil, types.ErrExceedAmount
+	}
+	if _, err := checkStakingBefore(delegate, scs); err != nil {
+		return nil, nil, fmt.Errorf("the delegate is not staking: %s", types.EncodeAddress(delegate))
+	}
+	delegation.Delegator = account
+	delegation.Delegate = delegate
+	return staked, delegation, nil
+}
+
+func validateForUndelegate(account []byte, txBody *types.TxBody, scs *statedb.ContractState, blockNo uint64) (*types.Delegation, error) {
+	delegation, err := getDelegation(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	if delegation.GetAmountBigInt().Sign() == 0 {
+		return nil, fmt.Errorf("not delegating before")
+	}
+	if delegation.GetAmountBigInt().Cmp(txBody.GetAmountBigInt()) < 0 {
+		return nil, types.ErrExceedAmount
+	}
+	if delegation.GetWhen()+StakingDelay > blockNo {
+		return nil, types.ErrLessTimeHasPassed
+	}
+	return delegation, nil
+}
+
+// syncDelegateVote updates the votes of the delegate to its voting power changed by a delegation.
+func syncDelegateVote(context *SystemContext, delegate []byte) error {
+	staked, err := getStaking(context.scs, delegate)
+	if err != nil {
+		return err
+	}
+	power, err := accountVotingPower(context.scs, delegate, staked)
+	if err != nil {
+		return err
+	}
+	return refreshVotes(context, delegate, types.ToAccountID(delegate), power, true)
+}
+
+// accountVotingPower returns the voting power of the account: its own stake except the stake delegated to other account,
+// and the stake delegated to the account.
+func accountVotingPower(scs *statedb.ContractState, account []byte, staked *types.Staking) (*big.Int, error) {
+	delegation, err := getDelegation(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	_, received, err := getDelegators(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	power := new(big.Int).Sub(staked.GetAmountBigInt(), delegation.GetAmountBigInt())
+	return power.Add(power, received), nil
+}
+
+// delegatedAmount returns the stake of the account delegated to other account.
+func delegatedAmount(scs *statedb.ContractState, account []byte) (*big.Int, error) {
+	delegation, err := getDelegation(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	return delegation.GetAmountBigInt(), nil
+}
+
+func setDelegation(scs *statedb.ContractState, delegation *types.Delegation) error {
+	if delegation.GetAmountBigInt().Sign() == 0 {
+		return scs.DeleteData(dbkey.SystemDelegation(delegation.Delegator))
+	}
+	data, err := proto.Encode(delegation)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.SystemDelegation(delegation.Delegator), data)
+}
+
+func getDelegation(scs *statedb.ContractState, account []byte) (*types.Delegation, error) {
+	delegation := &types.Delegation{}
+	data, err := scs.GetData(dbkey.SystemDelegation(account))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 0 {
+		if err := proto.Decode(data, delegation); err != nil {
+			return nil, err
+		}
+	}
+	return delegation, nil
+}
+
+// The delegations received by a delegate are kept by one key for each
+// delegator, indexed from 0 to the count of its delegators, so a delegation
+// updates a few keys regardless of the number of the delegators. The count
+// and the sum of the delegations are kept in the delegators key.
+
+// updateDelegators applies the delegation changed by delta to the delegations the delegate received.
+func updateDelegators(scs *statedb.ContractState, delegation *types.Delegation, delta *big.Int) error {
+	count, received, err := getDelegators(scs, delegation.Delegate)
+	if err != nil {
+		return err
+	}
+	received.Add(received, delta)
+
+	idx, found, err := getDelegatorIdx(scs, delegation.Delegator)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if err = setDelegator(scs, delegation.Delegate, count, delegation.Delegator); err != nil {
+			return err
+		}
+		count++
+	} else if delegation.GetAmountBigInt().Sign() == 0 {
+		// the last delegator takes the index of the removed one
+		count--
+		if idx != count {
+			last, err := scs.GetData(dbkey.SystemDelegator(delegation.Delegate, count))
+			if err != nil {
+				return err
+			}
+			if err = setDelegator(scs, delegation.Delegate, idx, last); err != nil {
+				return err
+			}
+		}
+		if err = scs.DeleteData(dbkey.SystemDelegator(delegation.Delegate, count)); err != nil {
+			return err
+		}
+		if err = scs.DeleteData(dbkey.SystemDelegatorIdx(delegation.Delegator)); err != nil {
+			return err
+		}
+	}
+
+	if count == 0 {
+		return scs.DeleteData(dbkey.SystemDelegators(delegation.Delegate))
+	}
+	return scs.SetData(dbkey.SystemDelegators(delegation.Delegate), append(types.Uint64ToBytes(count), received.Bytes()...))
+}
+
+// getDelegators returns the number of the delegators of the delegate and the sum of their delegations.
+func getDelegators(scs *statedb.ContractState, delegate []byte) (uint64, *big.Int, error) {
+	data, err := scs.GetData(dbkey.SystemDelegators(delegate))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 8 {
+		return 0, new(big.Int), nil
+	}
+	return types.BytesToUint64(data[:8]), new(big.Int).SetBytes(data[8:]), nil
+}
+
+func setDelegator(scs *statedb.ContractState, delegate []byte, idx uint64, delegator []byte) error {
+	if err := scs.SetData(dbkey.SystemDelegator(delegate, idx), delegator); err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.SystemDelegatorIdx(delegator), types.Uint64ToBytes(idx))
+}
+
+func getDelegatorIdx(scs *statedb.ContractState, delegator []byte) (uint64, bool, error) {
+	data, err := scs.GetData(dbkey.SystemDelegatorIdx(delegator))
+	if err != nil || len(data) == 0 {
+		return 0, false, err
+	}
+	return types.BytesToUint64(data), true, nil
+}
+
+// listDelegators returns the delegations received by the delegate.
+func listDelegators(scs *statedb.ContractState, delegate []byte) ([]*types.Delegation, error) {
+	count, _, err := getDelegators(scs, delegate)
+	if err != nil {
+		return nil, err
+	}
+	var delegators []*types.Delegation
+	for idx := uint64(0); idx < count; idx++ {
+		delegator, err := scs.GetData(dbkey.SystemDelegator(delegate, idx))
+		if err != nil {
+			return nil, err
+		}
+		delegation, err := getDelegation(scs, delegator)
+		if err != nil {
+			return nil, err
+		}
+		delegators = append(delegators, &types.Delegation{Delegator: delegator, Amount: delegation.Amount, When: delegation.When})
+	}
+	return delegators, nil
+}
+
+// GetDelegations returns the delegation of the stake of an account and the delegations received by the account.
+func GetDelegations(scs *statedb.ContractState, account []byte) (*types.DelegationList, error) {
+	_, received, err := getDelegators(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	delegators, err := listDelegators(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	delegations := &types.DelegationList{Account: account, Delegators: delegators}
+	if received.Sign() != 0 {
+		delegations.Received = received.Bytes()
+	}
+	delegation, err := getDelegation(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	if len(delegation.Delegate) != 0 {
+		delegations.Delegation = delegation
+	}
+	return delegations, nil
+}
@@ -0,0 +1,225 @@
+package system
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegateUndelegate(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+	defer func() { votingPowerRank = nil }()
+
+	delegate := getSender(t, "AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+	other := getSender(t, "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	for _, account := range []*state.AccountState{sender, delegate, other} {
+		account.AddBalance(types.MaxAER)
+	}
+	minplusmin := new(big.Int).Add(types.StakingMinimum, types.StakingMinimum)
+
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 6}
+	execute := func(account *state.AccountState, amount *big.Int, payload string) (*types.Event, error) {
+		txBody := &types.TxBody{Account: account.ID(), Recipient: []byte(types.AergoSystem), Amount: amount.Bytes(), Payload: []byte(payload)}
+		if err := types.ValidateSystemTx(txBody); err != nil {
+			return nil, err
+		}
+		events, err := ExecuteSystemTx(scs, txBody, account, receiver, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+		return events[0], nil
+	}
+	voted := func(account *state.AccountState) *big.Int {
+		vote, err := GetVote(scs, account.ID(), defaultVoteKey)
+		assert.NoError(t, err)
+		return new(big.Int).SetBytes(vote.Amount)
+	}
+	delegatePayload := func(to *state.AccountState) string {
+		return fmt.Sprintf(`{"Name":"v1delegate","Args":["%s"]}`, types.EncodeAddress(to.ID()))
+	}
+	const undelegatePayload = `{"Name":"v1undelegate"}`
+
+	_, err := execute(delegate, types.StakingMinimum, `{"Name":"v1stake"}`)
+	assert.NoError(t, err)
+	_, err = execute(delegate, big.NewInt(0), string(buildVotingPayloadEx(1, types.OpvoteBP.Cmd())))
+	assert.NoError(t, err)
+	assert.Equal(t, types.StakingMinimum, voted(delegate))
+
+	_, err = execute(sender, types.StakingMinimum, delegatePayload(delegate))
+	assert.Error(t, err, "not staking before")
+	_, err = execute(sender, minplusmin, `{"Name":"v1stake"}`)
+	assert.NoError(t, err)
+
+	_, err = execute(sender, types.StakingMinimum, delegatePayload(other))
+	assert.Error(t, err, "the delegate is not staking")
+	_, err = execute(sender, types.StakingMinimum, delegatePayload(sender))
+	assert.Error(t, err, "delegate to oneself")
+
+	blockInfo.ForkVersion = 5
+	_, err = execute(sender, types.StakingMinimum, delegatePayload(delegate))
+	assert.Error(t, err, "not supported before the hardfork")
+	blockInfo.ForkVersion = 6
+
+	event, err := execute(sender, types.StakingMinimum, delegatePayload(delegate))
+	assert.NoError(t, err)
+	assert.Equal(t, "delegate", event.EventName)
+	assert.Equal(t, minplusmin, voted(delegate), "the delegate votes with the combined power")
+	assert.Equal(t, minplusmin, votingPowerRank.votingPowerOf(delegate.AccountID()))
+
+	delegations, err := GetDelegations(scs, delegate.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, delegations.Delegation)
+	assert.Equal(t, types.StakingMinimum, delegations.GetReceivedBigInt())
+	if assert.Len(t, delegations.Delegators, 1) {
+		assert.Equal(t, sender.ID(), delegations.Delegators[0].Delegator)
+	}
+	delegations, err = GetDelegations(scs, sender.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, delegate.ID(), delegations.Delegation.Delegate)
+	assert.Equal(t, types.StakingMinimum, delegations.Delegation.GetAmountBigInt())
+
+	_, err = execute(sender, big.NewInt(1), delegatePayload(other))
+	assert.Error(t, err, "already delegated to the other")
+	_, err = execute(sender, minplusmin, delegatePayload(delegate))
+	assert.Equal(t, types.ErrExceedAmount, err)
+
+	// the delegator votes only with the stake not delegated
+	_, err = execute(sender, big.NewInt(0), string(buildVotingPayloadEx(2, types.OpvoteBP.Cmd())))
+	assert.NoError(t, err)
+	assert.Equal(t, types.StakingMinimum, voted(sender))
+
+	blockInfo.No += StakingDelay - 1
+	_, err = execute(sender, minplusmin, `{"Name":"v1unstake"}`)
+	assert.EqualError(t, err, "the delegated stake cannot be unstaked")
+
+	_, err = execute(sender, types.StakingMinimum, undelegatePayload)
+	assert.Equal(t, types.ErrLessTimeHasPassed, err)
+	blockInfo.No++
+	_, err = execute(sender, minplusmin, undelegatePayload)
+	assert.Equal(t, types.ErrExceedAmount, err)
+	event, err = execute(sender, types.StakingMinimum, undelegatePayload)
+	assert.NoError(t, err)
+	assert.Equal(t, "undelegate", event.EventName)
+	assert.Equal(t, types.StakingMinimum, voted(delegate))
+	assert.Equal(t, types.StakingMinimum, votingPowerRank.votingPowerOf(delegate.AccountID()))
+	assert.Equal(t, types.StakingMinimum, voted(sender), "the votes are not increased until voting again")
+
+	delegations, err = GetDelegations(scs, delegate.ID())
+	assert.NoError(t, err)
+	assert.Zero(t, delegations.GetReceivedBigInt().Sign())
+	assert.Empty(t, delegations.Delegators)
+	delegations, err = GetDelegations(scs, sender.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, delegations.Delegation)
+
+	_, err = execute(sender, types.StakingMinimum, undelegatePayload)
+	assert.Error(t, err, "not delegating before")
+	_, err = execute(sender, types.StakingMinimum, `{"Name":"v1undelegate","Args":["x"]}`)
+	assert.Error(t, err)
+	_, err = execute(sender, big.NewInt(0), delegatePayload(delegate))
+	assert.Equal(t, types.ErrTxInvalidAmount, err)
+}
+
+func TestDelegateVoteWithoutStake(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+	defer func() { votingPowerRank = nil }()
+
+	delegate := getSender(t, "AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+	for _, account := range []*state.AccountState{sender, delegate} {
+		account.AddBalance(types.MaxAER)
+	}
+
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 6}
+	execute := func(account *state.AccountState, amount *big.Int, payload string) error {
+		txBody := &types.TxBody{Account: account.ID(), Recipient: []byte(types.AergoSystem), Amount: amount.Bytes(), Payload: []byte(payload)}
+		if err := types.ValidateSystemTx(txBody); err != nil {
+			return err
+		}
+		_, err := ExecuteSystemTx(scs, txBody, account, receiver, blockInfo)
+		return err
+	}
+	vote := string(buildVotingPayloadEx(1, types.OpvoteBP.Cmd()))
+
+	assert.NoError(t, execute(delegate, types.StakingMinimum, `{"Name":"v1stake"}`))
+	assert.NoError(t, execute(sender, types.StakingMinimum, `{"Name":"v1stake"}`))
+	assert.NoError(t, execute(sender, types.StakingMinimum, fmt.Sprintf(`{"Name":"v1delegate","Args":["%s"]}`, types.EncodeAddress(delegate.ID()))))
+
+	// the delegate unstakes all its own stake, and still votes with the delegated stake
+	blockInfo.No += StakingDelay
+	assert.NoError(t, execute(delegate, types.StakingMinimum, `{"Name":"v1unstake"}`))
+	staking, err := GetStaking(scs, delegate.ID())
+	assert.NoError(t, err)
+	assert.Zero(t, staking.GetAmountBigInt().Sign())
+	assert.NoError(t, execute(delegate, big.NewInt(0), vote))
+	v, err := GetVote(scs, delegate.ID(), defaultVoteKey)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StakingMinimum, new(big.Int).SetBytes(v.Amount))
+
+	// the delegator has no power left to vote
+	assert.Equal(t, types.ErrMustStakeBeforeVote, execute(sender, big.NewInt(0), vote))
+}
+
+func TestUpdateDelegators(t *testing.T) {
+	scs, _, _ := initTest(t)
+	defer deinitTest()
+
+	delegate := []byte("delegate")
+	delegators := [][]byte{[]byte("delegator1"), []byte("delegator2"), []byte("delegator3")}
+	update := func(delegator []byte, amount int64, delta int64) {
+		delegation := &types.Delegation{Delegator: delegator, Delegate: delegate, Amount: big.NewInt(amount).Bytes(), When: 1}
+		assert.NoError(t, setDelegation(scs, delegation))
+		assert.NoError(t, updateDelegators(scs, delegation, big.NewInt(delta)))
+	}
+	listed := func() map[string]int64 {
+		list, err := listDelegators(scs, delegate)
+		assert.NoError(t, err)
+		ret := make(map[string]int64)
+		for _, d := range list {
+			ret[string(d.Delegator)] = d.GetAmountBigInt().Int64()
+		}
+		return ret
+	}
+	received := func() (uint64, int64) {
+		count, received, err := getDelegators(scs, delegate)
+		assert.NoError(t, err)
+		return count, received.Int64()
+	}
+
+	for i, delegator := range delegators {
+		update(delegator, int64(i+1), int64(i+1))
+	}
+	update(delegators[0], 11, 10)
+	assert.Equal(t, map[string]int64{"delegator1": 11, "delegator2": 2, "delegator3": 3}, listed())
+	count, sum := received()
+	assert.Equal(t, uint64(3), count, "a delegator is counted once")
+	assert.Equal(t, int64(16), sum)
+
+	// the last delegator takes the index of the removed one
+	update(delegators[0], 0, -11)
+	assert.Equal(t, map[string]int64{"delegator2": 2, "delegator3": 3}, listed())
+	idx, found, err := getDelegatorIdx(scs, delegators[2])
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint64(0), idx)
+	_, found, err = getDelegatorIdx(scs, delegators[0])
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	update(delegators[0], 1, 1)
+	update(delegators[1], 0, -2)
+	update(delegators[2], 0, -3)
+	assert.Equal(t, map[string]int64{"delegator1": 1}, listed())
+	update(delegators[0], 0, -1)
+	assert.Empty(t, listed())
+	count, sum = received()
+	assert.Zero(t, count)
+	assert.Zero(t, sum)
+}
@@ -18,14 +18,17 @@ import (
 
 // SystemContext is context of executing aergo.system transaction and filled after validation.
 type SystemContext struct {
-	BlockInfo *types.BlockHeaderInfo
-	Call      *types.CallInfo
-	Args      []string
-	Staked    *types.Staking
-	Vote      *types.Vote // voting
-	Proposal  *Proposal   // voting
-	Sender    *state.AccountState
-	Receiver  *state.AccountState
+	BlockInfo  *types.BlockHeaderInfo
+	Call       *types.CallInfo
+	Args       []string
+	Staked     *types.Staking
+	Vote       *types.Vote // voting
+	Proposal   *Proposal   // voting
+	Multisig   *types.MultisigAccount
+	SigningKey []byte // key rotation
+	Delegation *types.Delegation
+	Sender     *state.AccountState
+	Receiver   *state.AccountState
 
 	op     types.OpSysTx
 	scs    *statedb.ContractState
@@ -63,10 +66,14 @@ func newSysCmd(account []byte, txBody *types.TxBody, sender, receiver *state.Acc
 	scs *statedb.ContractState, blockInfo *types.BlockHeaderInfo) (sysCmd, error) {
 
 	cmds := map[types.OpSysTx]sysCmdCtor{
-		types.OpvoteBP:  newVoteCmd,
-		types.OpvoteDAO: newVoteCmd,
-		types.Opstake:   newStakeCmd,
-		types.Opunstake: newUnstakeCmd,
+		types.OpvoteBP:         newVoteCmd,
+		types.OpvoteDAO:        newVoteCmd,
+		types.Opstake:          newStakeCmd,
+		types.Opunstake:        newUnstakeCmd,
+		types.OpcreateMultisig: newCreateMultisigCmd,
+		types.OprotateKey:      newRotateKeyCmd,
+		types.Opdelegate:       newDelegateCmd,
+		types.Opundelegate:     newUndelegateCmd,
 	}
 
 	context, err := newSystemContext(account, txBody, sender, receiver, scs, blockInfo)
@@ -0,0 +1,87 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+type rotateKeyCmd struct {
+	*SystemContext
+}
+
+func newRotateKeyCmd(ctx *SystemContext) (sysCmd, error) {
+	return &rotateKeyCmd{SystemContext: ctx}, nil
+}
+
+func (c *rotateKeyCmd) run() (*types.Event, error) {
+	account := c.Sender.ID()
+	rotations, err := GetKeyRotations(c.scs, account)
+	if err != nil {
+		return nil, err
+	}
+	// the signing key is kept even if it's rotated back to the address, to tell the account has rotated the key
+	c.Sender.SetSigningKey(c.SigningKey)
+	rotations.SigningKey = c.SigningKey
+	rotations.Rotations = append(rotations.Rotations, &types.KeyRotation{
+		Pubkey:  c.SigningKey,
+		BlockNo: c.BlockInfo.No,
+		TxHash:  (&types.Tx{Body: c.txBody}).CalculateTxHash(),
+	})
+	if err := setKeyRotations(c.scs, account, rotations); err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "rotateKey",
+		JsonArgs: `["` + types.EncodeAddress(account) + `", "` +
+			types.EncodeAddress(c.SigningKey) + `"]`,
+	}, nil
+}
+
+func validateForRotateKey(ci *types.CallInfo, txBody *types.TxBody, sender *state.AccountState) ([]byte, error) {
+	signingKey, err := types.ParseRotateKey(ci)
+	if err != nil {
+		return nil, err
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, types.ErrTxInvalidAmount
+	}
+	if types.IsMultisigAddress(sender.ID()) {
+		return nil, fmt.Errorf("multisig account has no signing key to rotate")
+	}
+	if bytes.Equal(sender.State().SigningKeyOf(sender.ID()), signingKey) {
+		return nil, fmt.Errorf("already the signing key: %s", types.EncodeAddress(signingKey))
+	}
+	return signingKey, nil
+}
+
+func setKeyRotations(scs *statedb.ContractState, account []byte, rotations *types.KeyRotationList) error {
+	data, err := proto.Encode(rotations)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.SystemKeyRotation(account), data)
+}
+
+// GetKeyRotations returns the current signing key of an account and the history of the key rotations. The signing key
+// is empty if the account has never rotated the key.
+func GetKeyRotations(scs *statedb.ContractState, account []byte) (*types.KeyRotationList, error) {
+	rotations := &types.KeyRotationList{Account: account}
+	data, err := scs.GetData(dbkey.SystemKeyRotation(account))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 0 {
+		if err := proto.Decode(data, rotations); err != nil {
+			return nil, err
+		}
+	}
+	return rotations, nil
+}
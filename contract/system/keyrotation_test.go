@@ -0,0 +1,78 @@
+package system
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateKey(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	var keys [][]byte
+	for i := 0; i < 2; i++ {
+		key, err := btcec.NewPrivateKey()
+		assert.NoError(t, err)
+		keys = append(keys, key.PubKey().SerializeCompressed())
+	}
+	rotateTx := func(key []byte) *types.TxBody {
+		payload := fmt.Sprintf(`{"Name":"v1rotateKey","Args":["%s"]}`, types.EncodeAddress(key))
+		return &types.TxBody{Account: sender.ID(), Recipient: []byte(types.AergoSystem), Payload: []byte(payload)}
+	}
+
+	txBody := rotateTx(keys[0])
+	assert.NoError(t, types.ValidateSystemTx(txBody))
+	_, err := newSysCmd(sender.ID(), txBody, sender, receiver, scs, &types.BlockHeaderInfo{ForkVersion: 5})
+	assert.Error(t, err, "not supported before the hardfork")
+
+	rotations, err := GetKeyRotations(scs, sender.ID())
+	assert.NoError(t, err)
+	assert.Empty(t, rotations.SigningKey, "never rotated")
+	assert.Empty(t, rotations.Rotations)
+
+	for i, key := range append(keys, sender.ID()) {
+		blockInfo := &types.BlockHeaderInfo{No: uint64(10 + i), ForkVersion: 6}
+		cmd, err := newSysCmd(sender.ID(), rotateTx(key), sender, receiver, scs, blockInfo)
+		if !assert.NoError(t, err) {
+			return
+		}
+		event, err := cmd.run()
+		assert.NoError(t, err)
+		assert.Equal(t, "rotateKey", event.EventName)
+		assert.Equal(t, key, sender.SigningKey())
+		assert.Equal(t, key, sender.State().SigningKeyOf(sender.ID()))
+
+		_, err = newSysCmd(sender.ID(), rotateTx(key), sender, receiver, scs, blockInfo)
+		assert.Error(t, err, "already the signing key")
+	}
+
+	rotations, err = GetKeyRotations(scs, sender.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, sender.ID(), rotations.SigningKey, "rotated back to the address")
+	if assert.Len(t, rotations.Rotations, 3) {
+		assert.Equal(t, keys[0], rotations.Rotations[0].Pubkey)
+		assert.Equal(t, uint64(10), rotations.Rotations[0].BlockNo)
+		assert.Equal(t, (&types.Tx{Body: rotateTx(keys[0])}).CalculateTxHash(), rotations.Rotations[0].TxHash)
+		assert.Equal(t, keys[1], rotations.Rotations[1].Pubkey)
+		assert.Equal(t, sender.ID(), rotations.Rotations[2].Pubkey)
+	}
+
+	txBody = rotateTx(keys[0])
+	txBody.Amount = types.NewAmount(1, types.Aergo).Bytes()
+	_, err = newSysCmd(sender.ID(), txBody, sender, receiver, scs, &types.BlockHeaderInfo{ForkVersion: 6})
+	assert.Equal(t, types.ErrTxInvalidAmount, err)
+
+	for _, args := range []string{
+		`[]`,
+		`[1]`,
+		`["aergo.name"]`,
+		fmt.Sprintf(`["%s","%s"]`, types.EncodeAddress(keys[0]), types.EncodeAddress(keys[1])),
+	} {
+		txBody.Payload = []byte(`{"Name":"v1rotateKey","Args":` + args + `}`)
+		assert.Error(t, types.ValidateSystemTx(txBody), args)
+	}
+}
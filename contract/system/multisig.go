@@ -0,0 +1,76 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+type createMultisigCmd struct {
+	*SystemContext
+}
+
+func newCreateMultisigCmd(ctx *SystemContext) (sysCmd, error) {
+	return &createMultisigCmd{SystemContext: ctx}, nil
+}
+
+func (c *createMultisigCmd) run() (*types.Event, error) {
+	multisig := c.Multisig
+	if err := setMultisig(c.scs, multisig); err != nil {
+		return nil, err
+	}
+	jsonArgs := fmt.Sprintf(`["%s", %d`, types.EncodeAddress(multisig.Address()), multisig.Threshold)
+	for _, pubkey := range multisig.Pubkeys {
+		jsonArgs += `, "` + types.EncodeAddress(pubkey) + `"`
+	}
+	jsonArgs += "]"
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "createMultisig",
+		JsonArgs:        jsonArgs,
+	}, nil
+}
+
+func validateForCreateMultisig(ci *types.CallInfo, txBody *types.TxBody, scs *statedb.ContractState) (*types.MultisigAccount, error) {
+	multisig, err := types.ParseMultisigCreate(ci)
+	if err != nil {
+		return nil, err
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, types.ErrTxInvalidAmount
+	}
+	if _, err := GetMultisig(scs, multisig.Address()); err == nil {
+		return nil, fmt.Errorf("multisig account already exists: %s", types.EncodeAddress(multisig.Address()))
+	} else if err != types.ErrMultisigNotFound {
+		return nil, err
+	}
+	return multisig, nil
+}
+
+func setMultisig(scs *statedb.ContractState, multisig *types.MultisigAccount) error {
+	data, err := proto.Encode(multisig)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.SystemMultisig(multisig.Address()), data)
+}
+
+// GetMultisig returns the public keys and the threshold of a multisig account.
+func GetMultisig(scs *statedb.ContractState, address []byte) (*types.MultisigAccount, error) {
+	data, err := scs.GetData(dbkey.SystemMultisig(address))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, types.ErrMultisigNotFound
+	}
+	var multisig types.MultisigAccount
+	if err := proto.Decode(data, &multisig); err != nil {
+		return nil, err
+	}
+	return &multisig, nil
+}
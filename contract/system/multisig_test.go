@@ -0,0 +1,61 @@
+package system
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMultisig(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	var keys []string
+	for i := 0; i < 3; i++ {
+		key, err := btcec.NewPrivateKey()
+		assert.NoError(t, err)
+		keys = append(keys, types.EncodeAddress(key.PubKey().SerializeCompressed()))
+	}
+	payload := fmt.Sprintf(`{"Name":"v1createMultisig","Args":[2,"%s","%s","%s"]}`, keys[2], keys[0], keys[1])
+	txBody := &types.TxBody{Account: sender.ID(), Recipient: []byte(types.AergoSystem), Payload: []byte(payload)}
+	assert.NoError(t, types.ValidateSystemTx(txBody))
+
+	_, err := newSysCmd(sender.ID(), txBody, sender, receiver, scs, &types.BlockHeaderInfo{ForkVersion: 5})
+	assert.Error(t, err, "not supported before the hardfork")
+
+	blockInfo := &types.BlockHeaderInfo{ForkVersion: 6}
+	cmd, err := newSysCmd(sender.ID(), txBody, sender, receiver, scs, blockInfo)
+	assert.NoError(t, err)
+	event, err := cmd.run()
+	assert.NoError(t, err)
+	assert.Equal(t, "createMultisig", event.EventName)
+
+	multisig, err := types.NewMultisigAccount(2, [][]byte{
+		types.ToAddress(keys[0]), types.ToAddress(keys[1]), types.ToAddress(keys[2]),
+	})
+	assert.NoError(t, err)
+	saved, err := GetMultisig(scs, multisig.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), saved.Threshold)
+	assert.Equal(t, multisig.Pubkeys, saved.Pubkeys, "the keys are sorted")
+
+	_, err = newSysCmd(sender.ID(), txBody, sender, receiver, scs, blockInfo)
+	assert.Error(t, err, "the same keys and threshold make the same account")
+
+	_, err = GetMultisig(scs, sender.ID())
+	assert.Equal(t, types.ErrMultisigNotFound, err)
+
+	for _, args := range []string{
+		fmt.Sprintf(`[0,"%s"]`, keys[0]),
+		fmt.Sprintf(`[2,"%s"]`, keys[0]),
+		fmt.Sprintf(`[1,"%s","%s"]`, keys[0], keys[0]),
+		fmt.Sprintf(`["x","%s"]`, keys[0]),
+		`[1,"aergo.name"]`,
+	} {
+		txBody.Payload = []byte(`{"Name":"v1createMultisig","Args":` + args + `}`)
+		assert.Error(t, types.ValidateSystemTx(txBody), args)
+	}
+}
@@ -66,6 +66,22 @@ const (
 	stakingMin
 	gasPrice
 	namePrice
+	maxBlockSize
+	maxCallDepth
+	sqlMaxDbSize
+	mempoolFadeout
+	ed25519VerifyGas
+	p256VerifyGas
+	blsPairingGas
+	sha256Gas
+	keccak256Gas
+	ripemd160Gas
+	blake2bGas
+	hashWordGas
+	ecVerifyGas
+	verifyProofGas
+	blsVerifyGas
+	blsHashToCurveGas
 	sysParamMax
 )
 
@@ -80,6 +96,38 @@ var (
 		stakingMin.ID(): types.StakingMinimum,
 		gasPrice.ID():   types.NewAmount(50, types.Gaer), // 50 gaer
 		namePrice.ID():  types.NewAmount(1, types.Aergo), // 1 aergo
+		// the params without default value use the node config
+		maxCallDepth.ID(): big.NewInt(64),
+		// the gas costs of the crypto module of the vm
+		ed25519VerifyGas.ID():  big.NewInt(3000),
+		p256VerifyGas.ID():     big.NewInt(5000),
+		blsPairingGas.ID():     big.NewInt(30000),
+		sha256Gas.ID():         big.NewInt(500),
+		keccak256Gas.ID():      big.NewInt(500),
+		ripemd160Gas.ID():      big.NewInt(500),
+		blake2bGas.ID():        big.NewInt(300),
+		hashWordGas.ID():       big.NewInt(10),
+		ecVerifyGas.ID():       big.NewInt(5000),
+		verifyProofGas.ID():    big.NewInt(5000),
+		blsVerifyGas.ID():      big.NewInt(30000),
+		blsHashToCurveGas.ID(): big.NewInt(5000),
+	}
+
+	// cryptoGasParams are the gas costs of the crypto module, in the order of
+	// enum crypto_gas in contract/crypto_module.h
+	cryptoGasParams = [...]sysParamIndex{
+		sha256Gas,
+		keccak256Gas,
+		ripemd160Gas,
+		blake2bGas,
+		hashWordGas,
+		ecVerifyGas,
+		verifyProofGas,
+		ed25519VerifyGas,
+		p256VerifyGas,
+		blsVerifyGas,
+		blsPairingGas,
+		blsHashToCurveGas,
 	}
 )
 
@@ -188,6 +236,37 @@ func GetBpCount() int {
 	return int(GetParam(bpCount.ID()).Uint64())
 }
 
+// these functions return 0 if the param is not voted, to use the node config
+
+func GetMaxBlockBodySize() uint32 {
+	return uint32(getVotedParam(maxBlockSize))
+}
+
+func GetSQLMaxDBSize() uint64 {
+	return getVotedParam(sqlMaxDbSize)
+}
+
+func GetMempoolFadeout() uint64 {
+	return getVotedParam(mempoolFadeout)
+}
+
+func GetMaxCallDepth() int32 {
+	return int32(GetParam(maxCallDepth.ID()).Int64())
+}
+
+// GetCryptoGas returns the gas cost of the crypto module, indexed by enum
+// crypto_gas in contract/crypto_module.h.
+func GetCryptoGas(op int) uint64 {
+	return GetParam(cryptoGasParams[op].ID()).Uint64()
+}
+
+func getVotedParam(id sysParamIndex) uint64 {
+	if val := GetParam(id.ID()); val != nil {
+		return val.Uint64()
+	}
+	return 0
+}
+
 // these functions are reading the param value directly from the state
 
 func GetNamePriceFromState(scs *statedb.ContractState) *big.Int {
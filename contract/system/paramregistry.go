@@ -0,0 +1,367 @@
+package system
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+// paramType is the type of the value of a system param.
+type paramType int
+
+const (
+	paramNumber paramType = iota // a count or a size
+	paramAmount                  // an amount of aer
+)
+
+func (t paramType) String() string {
+	if t == paramAmount {
+		return "amount"
+	}
+	return "number"
+}
+
+// paramSpec describes a governable system param. A voted value must be in
+// [min, max], and it is activated after delay blocks from the block where the
+// voting reached the threshold. The param can be voted from the hardfork
+// version.
+type paramSpec struct {
+	description string
+	typ         paramType
+	min         *big.Int
+	max         *big.Int // nil for types.MaxAER, which is set by the genesis
+	delay       uint64
+	version     int32
+}
+
+// paramActivationDelay is the activation delay of the params which changes
+// the behavior of the nodes, so that the node operators can prepare for it.
+const paramActivationDelay = 3600
+
+// paramRegistry is the registry of the governable system params. To add a
+// param, append it to sysParamIndex and register its spec here. Among the gas
+// costs of the vm operations, the costs of the crypto module are governable,
+// since they depend on the performance of the crypto libraries. The other
+// costs are built in the vm and can't be changed without a hardfork.
+var paramRegistry = [sysParamMax]paramSpec{
+	bpCount: {
+		description: "number of block producers",
+		typ:         paramNumber,
+		min:         big.NewInt(1),
+		max:         big.NewInt(100),
+	},
+	stakingMin: {
+		description: "minimum amount of staking",
+		typ:         paramAmount,
+		min:         big.NewInt(1),
+	},
+	gasPrice: {
+		description: "price of gas",
+		typ:         paramAmount,
+		min:         big.NewInt(1),
+	},
+	namePrice: {
+		description: "price of name creation",
+		typ:         paramAmount,
+		min:         big.NewInt(1),
+	},
+	maxBlockSize: {
+		description: "maximum size of block body in bytes (default: node config)",
+		typ:         paramNumber,
+		min:         big.NewInt(64 * 1024),
+		max:         big.NewInt(int64(types.BlockSizeHardLimit())),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	maxCallDepth: {
+		description: "maximum depth of contract calls",
+		typ:         paramNumber,
+		min:         big.NewInt(5),
+		max:         big.NewInt(64),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	sqlMaxDbSize: {
+		description: "maximum size of contract database in MB (default: node config)",
+		typ:         paramNumber,
+		min:         big.NewInt(10),
+		max:         big.NewInt(4 * 1024 * 1024),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	mempoolFadeout: {
+		description: "hours for which mempool keeps transactions (default: node config)",
+		typ:         paramNumber,
+		min:         big.NewInt(1),
+		max:         big.NewInt(24 * 30),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	ed25519VerifyGas: {
+		description: "gas of crypto.ed25519_verify",
+		typ:         paramNumber,
+		min:         big.NewInt(100),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	p256VerifyGas: {
+		description: "gas of crypto.p256_verify",
+		typ:         paramNumber,
+		min:         big.NewInt(100),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	blsPairingGas: {
+		description: "gas per pairing of the bls signature verifications",
+		typ:         paramNumber,
+		min:         big.NewInt(1000),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	sha256Gas: {
+		description: "gas of crypto.sha256",
+		typ:         paramNumber,
+		min:         big.NewInt(10),
+		max:         big.NewInt(100000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	keccak256Gas: {
+		description: "gas of crypto.keccak256",
+		typ:         paramNumber,
+		min:         big.NewInt(10),
+		max:         big.NewInt(100000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	ripemd160Gas: {
+		description: "gas of crypto.ripemd160",
+		typ:         paramNumber,
+		min:         big.NewInt(10),
+		max:         big.NewInt(100000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	blake2bGas: {
+		description: "gas of crypto.blake2b",
+		typ:         paramNumber,
+		min:         big.NewInt(10),
+		max:         big.NewInt(100000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	hashWordGas: {
+		description: "gas per 32 bytes of the data hashed by the crypto functions",
+		typ:         paramNumber,
+		min:         big.NewInt(1),
+		max:         big.NewInt(10000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	ecVerifyGas: {
+		description: "gas of crypto.ecverify",
+		typ:         paramNumber,
+		min:         big.NewInt(100),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	verifyProofGas: {
+		description: "gas of crypto.verifyProof",
+		typ:         paramNumber,
+		min:         big.NewInt(100),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	blsVerifyGas: {
+		description: "gas of the bls signature verifications besides the pairings",
+		typ:         paramNumber,
+		min:         big.NewInt(1000),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+	blsHashToCurveGas: {
+		description: "gas per message hashed to the curve by the bls signature verifications",
+		typ:         paramNumber,
+		min:         big.NewInt(100),
+		max:         big.NewInt(1000000),
+		delay:       paramActivationDelay,
+		version:     6,
+	},
+}
+
+func (i sysParamIndex) spec() *paramSpec {
+	return &paramRegistry[i]
+}
+
+func (s *paramSpec) maxValue() *big.Int {
+	if s.max == nil {
+		return types.MaxAER
+	}
+	return s.max
+}
+
+func (s *paramSpec) inBounds(value *big.Int) bool {
+	return s.min.Cmp(value) <= 0 && s.maxValue().Cmp(value) >= 0
+}
+
+// lookupParam returns the index of the param with the id.
+func lookupParam(id string) (sysParamIndex, bool) {
+	for i := sysParamIndex(0); i < sysParamMax; i++ {
+		if strings.ToUpper(id) == i.ID() {
+			return i, true
+		}
+	}
+	return sysParamMax, false
+}
+
+// voteParam applies the value which won the voting of the param. The value
+// of a param without activation delay is active from the next block, or it
+// is pending until the activation block.
+func voteParam(scs *statedb.ContractState, id string, value *big.Int, blockNo types.BlockNo) error {
+	i, ok := lookupParam(id)
+	if !ok || i.spec().delay == 0 {
+		return updateParam(scs, id, value)
+	}
+	pending, _, err := getPendingParam(scs, i)
+	if err != nil {
+		return err
+	}
+	current, err := getCurrentParam(scs, i)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.Cmp(value) == 0 {
+		// the winner is back to the current value
+		if pending == nil {
+			return nil
+		}
+		return scs.DeleteData(dbkey.SystemParamPending(i.ID()))
+	}
+	if pending != nil && pending.Cmp(value) == 0 {
+		return nil
+	}
+	return setPendingParam(scs, i, value, blockNo+i.spec().delay)
+}
+
+// getCurrentParam returns the value of the param in the state, which may be
+// updated by the current block, or the default value.
+func getCurrentParam(scs *statedb.ContractState, i sysParamIndex) (*big.Int, error) {
+	data, err := scs.GetData(dbkey.SystemParam(i.ID()))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return DefaultParams[i.ID()], nil
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func setPendingParam(scs *statedb.ContractState, i sysParamIndex, value *big.Int, activation types.BlockNo) error {
+	data := append(types.Uint64ToBytes(activation), value.Bytes()...)
+	return scs.SetData(dbkey.SystemParamPending(i.ID()), data)
+}
+
+// getPendingParam returns the value of the param voted and its activation
+// block, or nil if there is no pending value.
+func getPendingParam(scs *statedb.ContractState, i sysParamIndex) (*big.Int, types.BlockNo, error) {
+	data, err := scs.GetData(dbkey.SystemParamPending(i.ID()))
+	if err != nil || len(data) < 8 {
+		return nil, 0, err
+	}
+	return new(big.Int).SetBytes(data[8:]), types.BlockNo(types.BytesToUint64(data[:8])), nil
+}
+
+// ActivateParams activates the pending values of the params whose activation
+// block is reached, and returns the activated values by id. The values are
+// written to the state only, so that a replay of the block doesn't change the
+// params of the node; see SetNextBlockParams.
+func ActivateParams(scs *statedb.ContractState, blockNo types.BlockNo) (map[string]*big.Int, error) {
+	var activated map[string]*big.Int
+	for i := sysParamIndex(0); i < sysParamMax; i++ {
+		if i.spec().delay == 0 {
+			continue
+		}
+		value, activation, err := getPendingParam(scs, i)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil || activation > blockNo {
+			continue
+		}
+		if err = scs.SetData(dbkey.SystemParam(i.ID()), value.Bytes()); err != nil {
+			return nil, err
+		}
+		if err = scs.DeleteData(dbkey.SystemParamPending(i.ID())); err != nil {
+			return nil, err
+		}
+		if activated == nil {
+			activated = map[string]*big.Int{}
+		}
+		activated[i.ID()] = value
+	}
+	return activated, nil
+}
+
+// SetNextBlockParams makes the values activated by ActivateParams active on
+// the next block, like the values updated by the voting.
+func SetNextBlockParams(params map[string]*big.Int) {
+	for id, value := range params {
+		systemParams.setNextBlockParam(id, value)
+	}
+}
+
+// GetSystemParam returns the value of the param for the next block with its
+// spec and the pending value if any. It returns nil for an unknown id.
+func GetSystemParam(scs *statedb.ContractState, id string) (*types.SystemParam, error) {
+	i, ok := lookupParam(id)
+	if !ok {
+		return nil, nil
+	}
+	spec := i.spec()
+	param := &types.SystemParam{
+		Id:              i.ID(),
+		Description:     spec.description,
+		Type:            spec.typ.String(),
+		Min:             spec.min.Bytes(),
+		Max:             spec.maxValue().Bytes(),
+		ActivationDelay: spec.delay,
+	}
+	value, err := getCurrentParam(scs, i)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		param.Value = value.Bytes()
+	}
+	pending, activation, err := getPendingParam(scs, i)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		param.PendingValue = pending.Bytes()
+		param.ActivationBlock = activation
+	}
+	return param, nil
+}
+
+// GetSystemParams returns all the governable params.
+func GetSystemParams(scs *statedb.ContractState) (*types.SystemParamList, error) {
+	list := &types.SystemParamList{}
+	for i := sysParamIndex(0); i < sysParamMax; i++ {
+		param, err := GetSystemParam(scs, i.ID())
+		if err != nil {
+			return nil, err
+		}
+		list.Params = append(list.Params, param)
+	}
+	return list, nil
+}
@@ -0,0 +1,102 @@
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoteDelayedParam(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+	defer func() { votingPowerRank = nil }()
+
+	sender.AddBalance(types.MaxAER)
+	blockInfo := &types.BlockHeaderInfo{No: 1, ForkVersion: 6}
+	execute := func(payload string) error {
+		txBody := &types.TxBody{Account: sender.ID(), Recipient: []byte(types.AergoSystem), Payload: []byte(payload)}
+		_, err := ExecuteSystemTx(scs, txBody, sender, receiver, blockInfo)
+		return err
+	}
+	_, err := ExecuteSystemTx(scs, &types.TxBody{Account: sender.ID(), Amount: types.StakingMinimum.Bytes(),
+		Payload: buildStakingPayload(true)}, sender, receiver, blockInfo)
+	assert.NoError(t, err)
+
+	blockInfo.ForkVersion = 5
+	assert.EqualError(t, execute(`{"Name":"v1voteDAO", "Args":["maxcalldepth", "32"]}`), "args[0] invalid id")
+	blockInfo.ForkVersion = 6
+	assert.EqualError(t, execute(`{"Name":"v1voteDAO", "Args":["maxcalldepth", "65"]}`), "include invalid number range")
+	assert.EqualError(t, execute(`{"Name":"v1voteDAO", "Args":["maxcalldepth", "4"]}`), "include invalid number range")
+	assert.NoError(t, execute(`{"Name":"v1voteDAO", "Args":["maxcalldepth", "32"]}`))
+
+	// the value voted is pending until the activation block
+	CommitParams(true)
+	assert.Equal(t, int32(64), GetMaxCallDepth())
+	param, err := GetSystemParam(scs, "maxcalldepth")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(64).Bytes(), param.Value)
+	assert.Equal(t, big.NewInt(32).Bytes(), param.PendingValue)
+	assert.Equal(t, 1+uint64(paramActivationDelay), param.ActivationBlock)
+
+	activated, err := ActivateParams(scs, paramActivationDelay)
+	assert.NoError(t, err)
+	assert.Empty(t, activated)
+	activated, err = ActivateParams(scs, 1+paramActivationDelay)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*big.Int{maxCallDepth.ID(): big.NewInt(32)}, activated)
+	// the activation doesn't change the params of the node by itself
+	assert.Nil(t, systemParams.getNextBlockParam(maxCallDepth.ID()))
+	SetNextBlockParams(activated)
+	assert.Equal(t, big.NewInt(32), GetNextBlockParam(maxCallDepth.ID()))
+	CommitParams(true)
+	assert.Equal(t, int32(32), GetMaxCallDepth())
+
+	param, err = GetSystemParam(scs, "maxcalldepth")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(32).Bytes(), param.Value)
+	assert.Nil(t, param.PendingValue)
+
+	// the params without delay are active from the next block
+	assert.NoError(t, execute(`{"Name":"v1voteDAO", "Args":["bpcount", "13"]}`))
+	assert.Equal(t, big.NewInt(13), GetNextBlockParam(bpCount.ID()))
+
+	params, err := GetSystemParams(scs)
+	assert.NoError(t, err)
+	if assert.Len(t, params.Params, int(sysParamMax)) {
+		assert.Equal(t, "MAXBLOCKSIZE", params.Params[maxBlockSize].Id)
+		assert.Nil(t, params.Params[maxBlockSize].Value, "the node config is used")
+		assert.Equal(t, "amount", params.Params[gasPrice].Type)
+	}
+	assert.Zero(t, GetMaxBlockBodySize())
+
+	// the gas costs of the crypto module
+	if assert.Len(t, cryptoGasParams, 12) {
+		assert.Equal(t, uint64(500), GetCryptoGas(0), "sha256")
+		assert.Equal(t, uint64(3000), GetCryptoGas(7), "ed25519")
+		assert.Equal(t, uint64(30000), GetCryptoGas(10), "bls pairing")
+	}
+	for _, i := range cryptoGasParams {
+		assert.NotNil(t, DefaultParams[i.ID()], "no default gas of %s", i.ID())
+	}
+	assert.EqualError(t, execute(`{"Name":"v1voteDAO", "Args":["blspairinggas", "10"]}`), "include invalid number range")
+	assert.NoError(t, execute(`{"Name":"v1voteDAO", "Args":["blspairinggas", "50000"]}`))
+	param, err = GetSystemParam(scs, "blspairinggas")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(50000).Bytes(), param.PendingValue)
+}
+
+func TestValidateByIdVersion(t *testing.T) {
+	// before the version 6, the negative candidates are accepted as before
+	assert.True(t, validateById(bpCount.ID(), big.NewInt(-1), 5))
+	assert.False(t, validateById(bpCount.ID(), big.NewInt(0), 5))
+	assert.False(t, validateById(bpCount.ID(), big.NewInt(101), 5))
+	assert.True(t, validateById(gasPrice.ID(), big.NewInt(-1), 5))
+	assert.False(t, validateById(gasPrice.ID(), new(big.Int).Add(types.MaxAER, big.NewInt(1)), 5))
+
+	assert.False(t, validateById(bpCount.ID(), big.NewInt(-1), 6))
+	assert.False(t, validateById(gasPrice.ID(), big.NewInt(-1), 6))
+	assert.True(t, validateById(bpCount.ID(), big.NewInt(13), 6))
+}
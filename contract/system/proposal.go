@@ -37,40 +37,17 @@ type Proposal struct {
 	Default        *big.Int
 }
 
-var SystemProposal = map[string]*Proposal{
-	bpCount.ID(): {
-		ID:             bpCount.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	stakingMin.ID(): {
-		ID:             stakingMin.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	gasPrice.ID(): {
-		ID:             gasPrice.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	namePrice.ID(): {
-		ID:             namePrice.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-}
+// SystemProposal is the proposal of each system param in the registry.
+var SystemProposal = func() map[string]*Proposal {
+	proposals := map[string]*Proposal{}
+	for i := sysParamIndex(0); i < sysParamMax; i++ {
+		proposals[i.ID()] = &Proposal{
+			ID:             i.ID(),
+			MultipleChoice: 1,
+		}
+	}
+	return proposals
+}()
 
 func (a *Proposal) GetKey() []byte {
 	return []byte(strings.ToUpper(a.ID))
@@ -119,11 +96,8 @@ func serializeProposalHistory(wtv whereToVotes) []byte {
 	return data
 }
 
-func isValidID(id string) bool {
-	for i := sysParamIndex(0); i < sysParamMax; i++ {
-		if strings.ToUpper(id) == i.ID() {
-			return true
-		}
-	}
-	return false
+// isValidID reports whether the param can be voted at the hardfork version.
+func isValidID(id string, version int32) bool {
+	i, ok := lookupParam(id)
+	return ok && i.spec().version <= version
 }
@@ -0,0 +1,222 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/dbkey"
+)
+
+const (
+	MaxScheduledCalls            = 1000 // the calls queued in the chain
+	MaxScheduledCallsPerContract = 32   // the calls queued by a contract
+	MaxScheduledCallsPerBlock    = 16   // the due calls executed at the start of a block
+	MaxScheduledCallArgsSize     = 4096
+	// the calls cannot be scheduled further than a week ahead, by the block number or the time
+	MaxScheduleBlocks  = 7 * 24 * 60 * 60
+	MaxScheduleSeconds = 7 * 24 * 60 * 60
+)
+
+// MinScheduledCallFee is the minimum prepaid fee of a call. The unused fee is returned to the contract, so it is a
+// deposit which makes holding the slots of the queue costly.
+var MinScheduledCallFee = types.NewAmount(100000000, types.Gaer) // 0.1 aergo
+
+var (
+	ErrTooManyScheduledCalls = errors.New("too many scheduled calls")
+	ErrScheduledCallNotFound = errors.New("scheduled call not found")
+	ErrScheduledCallArgs     = errors.New("invalid arguments of scheduled call")
+	ErrScheduledCallFee      = errors.New("the prepaid fee of scheduled call is less than 0.1 aergo")
+	ErrScheduledCallTooFar   = errors.New("scheduled call is too far ahead")
+)
+
+// ScheduledCall is a call of a contract function queued by contract.schedule. The chain executes it at the start of
+// the first block whose number or timestamp reaches the scheduled one, and the gas is paid from the prepaid fee.
+type ScheduledCall struct {
+	Id       uint64          `json:"id"`
+	Contract string          `json:"contract"`
+	Function string          `json:"function"`
+	Args     json.RawMessage `json:"args"`
+	BlockNo  uint64          `json:"blockNo,omitempty"`
+	Time     int64           `json:"time,omitempty"` // unix time in seconds
+	GasLimit uint64          `json:"gasLimit"`
+	Fee      string          `json:"fee"`
+}
+
+// scheduleEntry is an element of the queue of the scheduled calls, which is sorted by the id.
+type scheduleEntry struct {
+	Id       uint64 `json:"id"`
+	Contract string `json:"contract"`
+	BlockNo  uint64 `json:"blockNo,omitempty"`
+	Time     int64  `json:"time,omitempty"`
+}
+
+func (e *scheduleEntry) isDue(blockNo types.BlockNo, ts int64) bool {
+	if e.BlockNo != 0 {
+		return e.BlockNo <= blockNo
+	}
+	return e.Time <= ts
+}
+
+// GetFee returns the prepaid fee of the call.
+func (c *ScheduledCall) GetFee() *big.Int {
+	fee, ok := new(big.Int).SetString(c.Fee, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return fee
+}
+
+// Payload returns the payload of the call tx, as sent by a user.
+func (c *ScheduledCall) Payload() []byte {
+	payload, _ := json.Marshal(struct {
+		Name string
+		Args json.RawMessage
+	}{c.Function, c.Args})
+	return payload
+}
+
+func getScheduleList(scs *statedb.ContractState) ([]*scheduleEntry, error) {
+	data, err := scs.GetData(dbkey.SystemScheduleList())
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	var list []*scheduleEntry
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func setScheduleList(scs *statedb.ContractState, list []*scheduleEntry) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(dbkey.SystemScheduleList(), data)
+}
+
+func getScheduledCall(scs *statedb.ContractState, id uint64) (*ScheduledCall, error) {
+	data, err := scs.GetData(dbkey.SystemSchedule(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrScheduledCallNotFound
+	}
+	var call ScheduledCall
+	if err = json.Unmarshal(data, &call); err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// AddScheduledCall queues the call with a new id, which is returned. The block number and the time are of the
+// current block, from which the call cannot be too far ahead.
+func AddScheduledCall(scs *statedb.ContractState, call *ScheduledCall, blockNo types.BlockNo, ts int64) (uint64, error) {
+	var args []interface{}
+	if len(call.Args) > MaxScheduledCallArgsSize || json.Unmarshal(call.Args, &args) != nil {
+		return 0, ErrScheduledCallArgs
+	}
+	if call.GetFee().Cmp(MinScheduledCallFee) < 0 {
+		return 0, ErrScheduledCallFee
+	}
+	if call.BlockNo > blockNo+MaxScheduleBlocks || call.Time > ts+MaxScheduleSeconds {
+		return 0, ErrScheduledCallTooFar
+	}
+	list, err := getScheduleList(scs)
+	if err != nil {
+		return 0, err
+	}
+	if len(list) >= MaxScheduledCalls {
+		return 0, ErrTooManyScheduledCalls
+	}
+	count := 0
+	for _, e := range list {
+		if e.Contract == call.Contract {
+			count++
+		}
+	}
+	if count >= MaxScheduledCallsPerContract {
+		return 0, ErrTooManyScheduledCalls
+	}
+
+	data, err := scs.GetData(dbkey.SystemScheduleId())
+	if err != nil {
+		return 0, err
+	}
+	call.Id = new(big.Int).SetBytes(data).Uint64() + 1
+	if err = scs.SetData(dbkey.SystemScheduleId(), new(big.Int).SetUint64(call.Id).Bytes()); err != nil {
+		return 0, err
+	}
+	if data, err = json.Marshal(call); err != nil {
+		return 0, err
+	}
+	if err = scs.SetData(dbkey.SystemSchedule(call.Id), data); err != nil {
+		return 0, err
+	}
+	list = append(list, &scheduleEntry{Id: call.Id, Contract: call.Contract, BlockNo: call.BlockNo, Time: call.Time})
+	return call.Id, setScheduleList(scs, list)
+}
+
+func removeScheduledCall(scs *statedb.ContractState, list []*scheduleEntry, i int) (*ScheduledCall, error) {
+	call, err := getScheduledCall(scs, list[i].Id)
+	if err != nil {
+		return nil, err
+	}
+	if err = scs.DeleteData(dbkey.SystemSchedule(call.Id)); err != nil {
+		return nil, err
+	}
+	return call, setScheduleList(scs, append(list[:i], list[i+1:]...))
+}
+
+// CancelScheduledCall removes the call from the queue, if it is scheduled by the contract.
+func CancelScheduledCall(scs *statedb.ContractState, contract string, id uint64) (*ScheduledCall, error) {
+	list, err := getScheduleList(scs)
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range list {
+		if e.Id == id && e.Contract == contract {
+			return removeScheduledCall(scs, list, i)
+		}
+	}
+	return nil, ErrScheduledCallNotFound
+}
+
+// PopDueScheduledCall removes the due call of the lowest id from the queue and returns it. It returns nil if no call
+// is due at the block.
+func PopDueScheduledCall(scs *statedb.ContractState, blockNo types.BlockNo, ts int64) (*ScheduledCall, error) {
+	list, err := getScheduleList(scs)
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range list {
+		if e.isDue(blockNo, ts) {
+			return removeScheduledCall(scs, list, i)
+		}
+	}
+	return nil, nil
+}
+
+// ListScheduledCalls returns the queued calls of the contract, or all of them if the contract is empty.
+func ListScheduledCalls(scs *statedb.ContractState, contract string) ([]*ScheduledCall, error) {
+	list, err := getScheduleList(scs)
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]*ScheduledCall, 0)
+	for _, e := range list {
+		if len(contract) != 0 && e.Contract != contract {
+			continue
+		}
+		call, err := getScheduledCall(scs, e.Id)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
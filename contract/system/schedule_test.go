@@ -0,0 +1,120 @@
+package system
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledCalls(t *testing.T) {
+	scs, _, _ := initTest(t)
+	defer deinitTest()
+
+	const (
+		contractA = "AmgLnRaGFLyvCPCEMHYJHooufT1c1pENTRGeV78WNPTxwQ2RYUW7"
+		contractB = "AmhNNBNY7XFk4p5ym4CJf8nTcRTEHjWzAeXJfhP71244CjBCAQU3"
+	)
+	fee := scheduleFee
+
+	id1, err := AddScheduledCall(scs, &ScheduledCall{Contract: contractA, Function: "release", Args: json.RawMessage(`[1,"a"]`), BlockNo: 10, GasLimit: 100000, Fee: fee(1)}, 1, 900)
+	require.NoError(t, err)
+	id2, err := AddScheduledCall(scs, &ScheduledCall{Contract: contractB, Function: "close", Args: json.RawMessage(`[]`), Time: 1000, Fee: fee(2)}, 1, 900)
+	require.NoError(t, err)
+	id3, err := AddScheduledCall(scs, &ScheduledCall{Contract: contractA, Function: "release", Args: json.RawMessage(`[]`), BlockNo: 5, Fee: fee(3)}, 1, 900)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, []uint64{id1, id2, id3})
+
+	_, err = AddScheduledCall(scs, &ScheduledCall{Contract: contractA, Function: "release", Args: json.RawMessage(`{"a":1}`), BlockNo: 5}, 1, 900)
+	assert.Equal(t, ErrScheduledCallArgs, err)
+
+	calls, err := ListScheduledCalls(scs, contractA)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Equal(t, id1, calls[0].Id)
+	assert.Equal(t, `{"Name":"release","Args":[1,"a"]}`, string(calls[0].Payload()))
+	assert.Equal(t, fee(1), calls[0].GetFee().String())
+	calls, err = ListScheduledCalls(scs, "")
+	require.NoError(t, err)
+	assert.Len(t, calls, 3)
+
+	// only the contract scheduling the call can cancel it
+	_, err = CancelScheduledCall(scs, contractA, id2)
+	assert.Equal(t, ErrScheduledCallNotFound, err)
+	call, err := CancelScheduledCall(scs, contractB, id2)
+	require.NoError(t, err)
+	assert.Equal(t, "close", call.Function)
+	_, err = CancelScheduledCall(scs, contractB, id2)
+	assert.Equal(t, ErrScheduledCallNotFound, err)
+
+	// the due calls are popped in the order of the ids
+	call, err = PopDueScheduledCall(scs, 4, 2000)
+	require.NoError(t, err)
+	assert.Nil(t, call)
+	call, err = PopDueScheduledCall(scs, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, id1, call.Id)
+	call, err = PopDueScheduledCall(scs, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, id3, call.Id)
+	call, err = PopDueScheduledCall(scs, 10, 0)
+	require.NoError(t, err)
+	assert.Nil(t, call)
+
+	// the ids are not reused
+	id4, err := AddScheduledCall(scs, &ScheduledCall{Contract: contractB, Function: "close", Args: json.RawMessage(`[]`), Time: 1000, Fee: fee(2)}, 1, 900)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), id4)
+	call, err = PopDueScheduledCall(scs, 11, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, id4, call.Id)
+}
+
+func TestScheduledCallsLimit(t *testing.T) {
+	scs, _, _ := initTest(t)
+	defer deinitTest()
+
+	const contract = "AmgLnRaGFLyvCPCEMHYJHooufT1c1pENTRGeV78WNPTxwQ2RYUW7"
+	fee := scheduleFee
+	for i := 0; i < MaxScheduledCallsPerContract; i++ {
+		_, err := AddScheduledCall(scs, &ScheduledCall{Contract: contract, Function: "f", Args: json.RawMessage(`[]`), BlockNo: 10, Fee: fee(1)}, 1, 900)
+		require.NoError(t, err)
+	}
+	_, err := AddScheduledCall(scs, &ScheduledCall{Contract: contract, Function: "f", Args: json.RawMessage(`[]`), BlockNo: 10, Fee: fee(1)}, 1, 900)
+	assert.Equal(t, ErrTooManyScheduledCalls, err)
+}
+
+func TestScheduledCallsGriefing(t *testing.T) {
+	scs, _, _ := initTest(t)
+	defer deinitTest()
+
+	const contract = "AmgLnRaGFLyvCPCEMHYJHooufT1c1pENTRGeV78WNPTxwQ2RYUW7"
+	add := func(call *ScheduledCall) error {
+		call.Contract, call.Function, call.Args = contract, "f", json.RawMessage(`[]`)
+		_, err := AddScheduledCall(scs, call, 100, 1000)
+		return err
+	}
+
+	// the cheap calls cannot hold the slots of the queue
+	assert.Equal(t, ErrScheduledCallFee, add(&ScheduledCall{BlockNo: 200}))
+	assert.Equal(t, ErrScheduledCallFee, add(&ScheduledCall{BlockNo: 200, Fee: "1"}))
+	assert.Equal(t, ErrScheduledCallFee, add(&ScheduledCall{BlockNo: 200,
+		Fee: new(big.Int).Sub(MinScheduledCallFee, big.NewInt(1)).String()}))
+
+	// nor be scheduled forever ahead
+	assert.Equal(t, ErrScheduledCallTooFar, add(&ScheduledCall{BlockNo: 101 + MaxScheduleBlocks, Fee: scheduleFee(1)}))
+	assert.Equal(t, ErrScheduledCallTooFar, add(&ScheduledCall{Time: 1001 + MaxScheduleSeconds, Fee: scheduleFee(1)}))
+	assert.NoError(t, add(&ScheduledCall{BlockNo: 100 + MaxScheduleBlocks, Fee: scheduleFee(1)}))
+	assert.NoError(t, add(&ScheduledCall{Time: 1000 + MaxScheduleSeconds, Fee: scheduleFee(1)}))
+
+	calls, err := ListScheduledCalls(scs, contract)
+	require.NoError(t, err)
+	assert.Len(t, calls, 2)
+}
+
+// scheduleFee returns the prepaid fee of n times the minimum.
+func scheduleFee(n int64) string {
+	return new(big.Int).Mul(big.NewInt(n), MinScheduledCallFee).String()
+}
@@ -12,12 +12,28 @@ func _() {
 	_ = x[stakingMin-1]
 	_ = x[gasPrice-2]
 	_ = x[namePrice-3]
-	_ = x[sysParamMax-4]
+	_ = x[maxBlockSize-4]
+	_ = x[maxCallDepth-5]
+	_ = x[sqlMaxDbSize-6]
+	_ = x[mempoolFadeout-7]
+	_ = x[ed25519VerifyGas-8]
+	_ = x[p256VerifyGas-9]
+	_ = x[blsPairingGas-10]
+	_ = x[sha256Gas-11]
+	_ = x[keccak256Gas-12]
+	_ = x[ripemd160Gas-13]
+	_ = x[blake2bGas-14]
+	_ = x[hashWordGas-15]
+	_ = x[ecVerifyGas-16]
+	_ = x[verifyProofGas-17]
+	_ = x[blsVerifyGas-18]
+	_ = x[blsHashToCurveGas-19]
+	_ = x[sysParamMax-20]
 }
 
-const _sysParamIndex_name = "bpCountstakingMingasPricenamePricesysParamMax"
+const _sysParamIndex_name = "bpCountstakingMingasPricenamePricemaxBlockSizemaxCallDepthsqlMaxDbSizemempoolFadeouted25519VerifyGasp256VerifyGasblsPairingGassha256Gaskeccak256Gasripemd160Gasblake2bGashashWordGasecVerifyGasverifyProofGasblsVerifyGasblsHashToCurveGassysParamMax"
 
-var _sysParamIndex_index = [...]uint8{0, 7, 17, 25, 34, 45}
+var _sysParamIndex_index = [...]uint8{0, 7, 17, 25, 34, 46, 58, 70, 84, 100, 113, 126, 135, 147, 159, 169, 180, 191, 205, 217, 234, 245}
 
 func (i sysParamIndex) String() string {
 	if i < 0 || i >= sysParamIndex(len(_sysParamIndex_index)-1) {
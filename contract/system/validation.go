@@ -52,7 +52,7 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.Accoun
 		if blockInfo.ForkVersion < 2 {
 			return nil, fmt.Errorf("not supported operation")
 		}
-		id, err := parseIDForProposal(&ci)
+		id, err := parseIDForProposal(&ci, blockInfo.ForkVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -79,7 +79,7 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.Accoun
 			if !ok {
 				return nil, fmt.Errorf("include invalid number")
 			}
-			if !validateById(id, candidateNumber) {
+			if !validateById(id, candidateNumber, blockInfo.ForkVersion) {
 				return nil, fmt.Errorf("include invalid number range")
 			}
 		}
@@ -105,6 +105,43 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.Accoun
 		context.Proposal = proposal
 		context.Staked = staked
 		context.Vote = oldvote
+	case types.OpcreateMultisig:
+		if blockInfo.ForkVersion < 6 {
+			return nil, fmt.Errorf("not supported operation")
+		}
+		multisig, err := validateForCreateMultisig(&ci, txBody, scs)
+		if err != nil {
+			return nil, err
+		}
+		context.Multisig = multisig
+	case types.OprotateKey:
+		if blockInfo.ForkVersion < 6 {
+			return nil, fmt.Errorf("not supported operation")
+		}
+		signingKey, err := validateForRotateKey(&ci, txBody, sender)
+		if err != nil {
+			return nil, err
+		}
+		context.SigningKey = signingKey
+	case types.Opdelegate:
+		if blockInfo.ForkVersion < 6 {
+			return nil, fmt.Errorf("not supported operation")
+		}
+		staked, delegation, err := validateForDelegate(account, &ci, txBody, scs)
+		if err != nil {
+			return nil, err
+		}
+		context.Staked = staked
+		context.Delegation = delegation
+	case types.Opundelegate:
+		if blockInfo.ForkVersion < 6 {
+			return nil, fmt.Errorf("not supported operation")
+		}
+		delegation, err := validateForUndelegate(account, txBody, scs, blockNo)
+		if err != nil {
+			return nil, err
+		}
+		context.Delegation = delegation
 	default:
 		return nil, types.ErrTxInvalidPayload
 	}
@@ -139,8 +176,15 @@ func validateForStaking(account []byte, txBody *types.TxBody, scs *statedb.Contr
 }
 
 func validateForVote(account []byte, txBody *types.TxBody, scs *statedb.ContractState, blockNo uint64, voteKey []byte) (*types.Staking, *types.Vote, error) {
-	staked, err := checkStakingBefore(account, scs)
+	staked, err := getStaking(scs, account)
 	if err != nil {
+		return nil, nil, err
+	}
+	power, err := accountVotingPower(scs, account, staked)
+	if err != nil {
+		return nil, nil, err
+	}
+	if power.Sign() == 0 {
 		return nil, nil, types.ErrMustStakeBeforeVote
 	}
 	oldvote, err := GetVote(scs, account, voteKey)
@@ -161,6 +205,13 @@ func validateForUnstaking(account []byte, txBody *types.TxBody, scs *statedb.Con
 	if staked.GetAmountBigInt().Cmp(txBody.GetAmountBigInt()) < 0 {
 		return nil, types.ErrExceedAmount
 	}
+	delegated, err := delegatedAmount(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	if new(big.Int).Sub(staked.GetAmountBigInt(), delegated).Cmp(txBody.GetAmountBigInt()) < 0 {
+		return nil, fmt.Errorf("the delegated stake cannot be unstaked")
+	}
 	if staked.GetWhen()+StakingDelay > blockNo {
 		return nil, types.ErrLessTimeHasPassed
 	}
@@ -172,16 +223,26 @@ func validateForUnstaking(account []byte, txBody *types.TxBody, scs *statedb.Con
 	return staked, nil
 }
 
-func parseIDForProposal(ci *types.CallInfo) (string, error) {
+func parseIDForProposal(ci *types.CallInfo, version int32) (string, error) {
 	//length should be checked before this function
 	id, ok := ci.Args[0].(string)
-	if !ok || len(id) < 1 || !isValidID(id) {
+	if !ok || len(id) < 1 || !isValidID(id, version) {
 		return "", fmt.Errorf("args[%d] invalid id", 0)
 	}
 	return strings.ToUpper(id), nil
 }
 
-func validateById(id string, candidate *big.Int) bool {
+// validateById checks the candidate is in the bounds of the param in the registry. Before the version 6, only zero and
+// the upper bounds of some params are rejected as before.
+func validateById(id string, candidate *big.Int, version int32) bool {
+	if version < 6 {
+		return validateByIdLegacy(id, candidate)
+	}
+	i, ok := lookupParam(id)
+	return ok && i.spec().inBounds(candidate)
+}
+
+func validateByIdLegacy(id string, candidate *big.Int) bool {
 	if big.NewInt(0).Cmp(candidate) == 0 {
 		return false
 	}
@@ -52,13 +52,21 @@ func GetVotingCatalog() []types.VotingIssue {
 type vprCmd struct {
 	*SystemContext
 	voteResult *VoteResult
+	voter      []byte
+	voterID    types.AccountID
 
 	add func(v *types.Vote) error
 	sub func(v *types.Vote) error
 }
 
 func newVprCmd(ctx *SystemContext, vr *VoteResult) *vprCmd {
-	cmd := &vprCmd{SystemContext: ctx, voteResult: vr}
+	return newVoterVprCmd(ctx, vr, ctx.Sender.ID(), ctx.Sender.AccountID())
+}
+
+// newVoterVprCmd returns the command applying the votes of the voter, which may be other than the sender: the
+// delegate whose voting power is changed by the delegation of the sender.
+func newVoterVprCmd(ctx *SystemContext, vr *VoteResult, voter []byte, voterID types.AccountID) *vprCmd {
+	cmd := &vprCmd{SystemContext: ctx, voteResult: vr, voter: voter, voterID: voterID}
 
 	if vprLogger.IsDebugEnabled() {
 		vprLogger.Debug().
@@ -89,11 +97,11 @@ func newVprCmd(ctx *SystemContext, vr *VoteResult) *vprCmd {
 
 func (c *vprCmd) subVpr(v *types.Vote) {
 	no := c.BlockInfo.No
-	aid := c.Sender.AccountID()
+	aid := c.voterID
 
 	// Handle exception 1. multisig contract ( AmhNcvE7RR84xoRzYNyATnwZR2JXaC5ut7neu89R13aj1b4eUxKp )
 	if aid.String() == "A9zXKkooeGYAZC5ReCcgeg4ddsvMHAy2ivUafXhrnzpj" {
-		votingPowerRank.sub(statedb.EmptyAccountID, c.Sender.ID(), v.GetAmountBigInt())
+		votingPowerRank.sub(statedb.EmptyAccountID, c.voter, v.GetAmountBigInt())
 		return
 	}
 
@@ -101,22 +109,22 @@ func (c *vprCmd) subVpr(v *types.Vote) {
 	// When block is reverted, votingPowerRank is not reverted and calculated three times.
 	if aid.String() == "36t2u7Q31HmEbkkYZng7DHNm3xepxHKUfgGrAXNA8pMW" && no == 138015125 {
 		for i := 0; i < 3; i++ {
-			votingPowerRank.sub(aid, c.Sender.ID(), v.GetAmountBigInt())
+			votingPowerRank.sub(aid, c.voter, v.GetAmountBigInt())
 		}
 		return
 	}
 
 	// normal case
-	votingPowerRank.sub(aid, c.Sender.ID(), v.GetAmountBigInt())
+	votingPowerRank.sub(aid, c.voter, v.GetAmountBigInt())
 }
 
 func (c *vprCmd) addVpr(v *types.Vote) {
 	no := c.BlockInfo.No
-	aid := c.Sender.AccountID()
+	aid := c.voterID
 
 	// Handle exception 1. multisig contract ( AmhNcvE7RR84xoRzYNyATnwZR2JXaC5ut7neu89R13aj1b4eUxKp )
 	if aid.String() == "A9zXKkooeGYAZC5ReCcgeg4ddsvMHAy2ivUafXhrnzpj" {
-		votingPowerRank.add(statedb.EmptyAccountID, c.Sender.ID(), v.GetAmountBigInt())
+		votingPowerRank.add(statedb.EmptyAccountID, c.voter, v.GetAmountBigInt())
 		return
 	}
 
@@ -124,13 +132,13 @@ func (c *vprCmd) addVpr(v *types.Vote) {
 	// When block is reverted, votingPowerRank is not reverted and calculated three times.
 	if aid.String() == "36t2u7Q31HmEbkkYZng7DHNm3xepxHKUfgGrAXNA8pMW" && no == 138015125 {
 		for i := 0; i < 3; i++ {
-			votingPowerRank.add(aid, c.Sender.ID(), v.GetAmountBigInt())
+			votingPowerRank.add(aid, c.voter, v.GetAmountBigInt())
 		}
 		return
 	}
 
 	// normal case
-	votingPowerRank.add(aid, c.Sender.ID(), v.GetAmountBigInt())
+	votingPowerRank.add(aid, c.voter, v.GetAmountBigInt())
 }
 
 type voteCmd struct {
@@ -183,13 +191,19 @@ func newVoteCmd(ctx *SystemContext) (sysCmd, error) {
 	// on the state DB must be updated even for voting.
 	staked.SetWhen(ctx.BlockInfo.No)
 
-	if staked.GetAmountBigInt().Cmp(new(big.Int).SetUint64(0)) == 0 {
+	// the stake delegated to other account is excluded and the stake delegated
+	// from others is included, so a delegate can vote without its own stake.
+	power, err := accountVotingPower(scs, ctx.Sender.ID(), staked)
+	if err != nil {
+		return nil, err
+	}
+	if power.Sign() == 0 {
 		return nil, types.ErrMustStakeBeforeVote
 	}
 
 	cmd.newVote = &types.Vote{
 		Candidate: cmd.candidate,
-		Amount:    staked.GetAmount(),
+		Amount:    power.Bytes(),
 	}
 
 	voteResult, err := loadVoteResult(scs, cmd.issue)
@@ -253,26 +267,34 @@ func (c *voteCmd) updateVoteResult() error {
 			Msg("update vote result")
 	}
 
-	return c.voteResult.Sync()
+	return c.voteResult.Sync(c.BlockInfo.No)
 }
 
 func refreshAllVote(context *SystemContext) error {
-	var (
-		scs          = context.scs
-		account      = context.Sender.ID()
-		staked       = context.Staked
-		stakedAmount = new(big.Int).SetBytes(staked.Amount)
-	)
+	power, err := accountVotingPower(context.scs, context.Sender.ID(), context.Staked)
+	if err != nil {
+		return err
+	}
+	return refreshVotes(context, context.Sender.ID(), context.Sender.AccountID(), power, false)
+}
+
+// refreshVotes updates the votes of the voter to the voting power. The votes
+// are only decreased unless sync is set, so that the voter must vote again to
+// use the increased power of its own stake.
+func refreshVotes(context *SystemContext, voter []byte, voterID types.AccountID, power *big.Int, sync bool) error {
+	scs := context.scs
 
 	for _, i := range GetVotingCatalog() {
 		key := i.Key()
 
-		oldvote, err := getVote(scs, key, account)
+		oldvote, err := getVote(scs, key, voter)
 		if err != nil {
 			return err
 		}
-		if oldvote.Amount == nil ||
-			new(big.Int).SetBytes(oldvote.Amount).Cmp(stakedAmount) <= 0 {
+		if oldvote.Amount == nil {
+			continue
+		}
+		if diff := new(big.Int).SetBytes(oldvote.Amount).Cmp(power); diff == 0 || (diff < 0 && !sync) {
 			continue
 		}
 		if types.OpvoteBP.ID() != i.ID() {
@@ -289,19 +311,19 @@ func refreshAllVote(context *SystemContext) error {
 			return err
 		}
 
-		cmd := newVprCmd(context, voteResult)
+		cmd := newVoterVprCmd(context, voteResult, voter, voterID)
 
 		if err = cmd.sub(oldvote); err != nil {
 			return err
 		}
-		oldvote.Amount = staked.GetAmount()
-		if err = setVote(scs, key, account, oldvote); err != nil {
+		oldvote.Amount = power.Bytes()
+		if err = setVote(scs, key, voter, oldvote); err != nil {
 			return err
 		}
 		if err = cmd.add(oldvote); err != nil {
 			return err
 		}
-		if err = voteResult.Sync(); err != nil {
+		if err = voteResult.Sync(context.BlockInfo.No); err != nil {
 			return err
 		}
 	}
@@ -255,7 +255,7 @@ func buildStakingPayload(isStaking bool) []byte {
 
 func TestVotingCatalog(t *testing.T) {
 	cat := GetVotingCatalog()
-	assert.Equal(t, 5, len(cat))
+	assert.Equal(t, 21, len(cat))
 	for _, issue := range cat {
 		fmt.Println(issue.ID())
 	}
@@ -108,7 +108,8 @@ func (vr *VoteResult) buildVoteList() *types.VoteList {
 }
 
 // Sync is write vote result data to state DB. if vote result over the threshold,
-func (vr *VoteResult) Sync() error {
+// the winner is applied to the param at the block.
+func (vr *VoteResult) Sync(blockNo types.BlockNo) error {
 	votingPowerRank.apply(vr.scs)
 	resultList := vr.buildVoteList()
 	if vr.ex {
@@ -117,7 +118,7 @@ func (vr *VoteResult) Sync() error {
 			if !ok {
 				return fmt.Errorf("abnormal winner is in vote %s", string(vr.key))
 			}
-			if err := updateParam(vr.scs, string(vr.key), value); err != nil {
+			if err := voteParam(vr.scs, string(vr.key), value, blockNo); err != nil {
 				return err
 			}
 		}
@@ -177,7 +178,7 @@ func InitVoteResult(scs *statedb.ContractState, voteResult map[string]*big.Int)
 	res.rmap = voteResult
 	res.scs = scs
 
-	return res.Sync()
+	return res.Sync(0)
 }
 
 func getVoteResult(scs *statedb.ContractState, key []byte, n int) (*types.VoteList, error) {
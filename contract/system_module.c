@@ -7,6 +7,7 @@
 #include "_cgo_export.h"
 
 #define STATE_DB_KEY_PREFIX "_"
+#define GAS_BLOCK_READ      100   /* per block read to reach the one of getBlockHash or getBlockInfo */
 
 extern int getLuaExecContext(lua_State *L);
 
@@ -187,6 +188,54 @@ static int getTimestamp(lua_State *L) {
 	return 1;
 }
 
+static int getBlockHash(lua_State *L) {
+	int service = getLuaExecContext(L);
+	lua_Integer blockNo;
+	struct luaGetBlockHash_return ret;
+
+	lua_gasuse(L, 500);
+
+	blockNo = luaL_checkinteger(L, 1);
+	ret = luaGetBlockHash(L, service, blockNo);
+	if (ret.r1 != NULL) {
+		strPushAndRelease(L, ret.r1);
+		luaL_throwerror(L);
+	}
+	if (ret.r0 == NULL) {
+		lua_pushnil(L);
+		return 1;
+	}
+	strPushAndRelease(L, ret.r0);
+	lua_gasuse_mul(L, GAS_BLOCK_READ, ret.r2);
+	return 1;
+}
+
+static int getBlockInfo(lua_State *L) {
+	int service = getLuaExecContext(L);
+	lua_Integer blockNo;
+	struct luaGetBlockInfo_return ret;
+
+	lua_gasuse(L, 1000);
+
+	blockNo = luaL_checkinteger(L, 1);
+	ret = luaGetBlockInfo(L, service, blockNo);
+	if (ret.r1 != NULL) {
+		strPushAndRelease(L, ret.r1);
+		luaL_throwerror(L);
+	}
+	if (ret.r0 == NULL) {
+		lua_pushnil(L);
+		return 1;
+	}
+	if (lua_util_json_to_lua(L, ret.r0, false) != 0) {
+		strPushAndRelease(L, ret.r0);
+		luaL_error(L, "getBlockInfo error : can't convert %s", lua_tostring(L, -1));
+	}
+	free(ret.r0);
+	lua_gasuse_mul(L, GAS_BLOCK_READ, ret.r2);
+	return 1;
+}
+
 static int getContractID(lua_State *L) {
 	int service = getLuaExecContext(L);
 	char *id;
@@ -564,12 +613,21 @@ static const luaL_Reg system_lib_v4[] = {
 	{NULL, NULL}
 };
 
+static const luaL_Reg system_lib_v6[] = {
+	{"getBlockHash", getBlockHash},
+	{"getBlockInfo", getBlockInfo},
+	{NULL, NULL}
+};
+
 int luaopen_system(lua_State *L) {
 	if (vm_is_hardfork(L, 4)) {
 		luaL_register(L, "system", system_lib_v4);
 	} else {
 		luaL_register(L, "system", system_lib_v1);
 	}
+	if (vm_is_hardfork(L, 6)) {
+		luaL_register(L, NULL, system_lib_v6);
+	}
 	lua_pop(L, 1);
 	return 1;
 }
@@ -0,0 +1,41 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/v2/contract/system"
+	"github.com/aergoio/aergo/v2/state"
+	"github.com/aergoio/aergo/v2/state/statedb"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+// ActivateParams activates the pending values of the voted system params whose activation block is reached. It is
+// called at the start of the block, before the scheduled calls and the txs. The activated values are active on the
+// next block, unless the block is replayed by the execution context. It returns whether any param is activated.
+func ActivateParams(execCtx context.Context, bs *state.BlockState, bi *types.BlockHeaderInfo) (bool, error) {
+	if bi.ForkVersion < 6 {
+		return false, nil
+	}
+	sysState, err := state.GetAccountState([]byte(types.AergoSystem), bs.StateDB)
+	if err != nil {
+		return false, err
+	}
+	scs, err := statedb.OpenContractState(sysState.IDNoPadding(), sysState.State(), bs.StateDB)
+	if err != nil {
+		return false, err
+	}
+	activated, err := system.ActivateParams(scs, bi.No)
+	if err != nil || len(activated) == 0 {
+		return false, err
+	}
+	if err = statedb.StageContractState(scs, bs.StateDB); err != nil {
+		return false, err
+	}
+	if err = sysState.PutState(); err != nil {
+		return false, err
+	}
+	if !isReplay(execCtx) {
+		system.SetNextBlockParams(activated)
+	}
+	return true, nil
+}
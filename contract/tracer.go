@@ -0,0 +1,291 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// TxTrace is the result of re-executing a transaction on the state of its parent block.
+type TxTrace struct {
+	TxHash  string      `json:"txhash"`
+	BlockNo uint64      `json:"blockNo"`
+	Status  string      `json:"status"`
+	Ret     string      `json:"ret,omitempty"`
+	GasUsed uint64      `json:"gasUsed"`
+	FeeUsed string      `json:"feeUsed"`
+	ErrorAt *TraceError `json:"errorAt,omitempty"`
+	Call    *TraceFrame `json:"call,omitempty"`
+	// Warnings tell where the replay may not be the same as the execution in the block
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// TraceFrame is a call frame of a traced transaction. It is one of the tx itself, or a call, delegatecall, deploy
+// or send made by a contract.
+type TraceFrame struct {
+	Type     string        `json:"type"`
+	Contract string        `json:"contract"`
+	Function string        `json:"function,omitempty"`
+	Args     []interface{} `json:"args,omitempty"`
+	Amount   string        `json:"amount,omitempty"`
+	Ret      string        `json:"ret,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	GasUsed  uint64        `json:"gasUsed"`
+	Reads    []StateAccess `json:"reads,omitempty"`
+	Writes   []StateAccess `json:"writes,omitempty"`
+	Sql      []string      `json:"sql,omitempty"`
+	Events   []TraceEvent  `json:"events,omitempty"`
+	Calls    []*TraceFrame `json:"calls,omitempty"`
+
+	gasStart uint64
+}
+
+// StateAccess is a read or a write of a state variable of the contract.
+type StateAccess struct {
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+type TraceEvent struct {
+	Name string `json:"name"`
+	Args string `json:"args"`
+}
+
+// TraceError tells the frame where the error of the failed transaction was raised.
+type TraceError struct {
+	Contract string `json:"contract"`
+	Function string `json:"function,omitempty"`
+	Depth    int    `json:"depth"`
+	Error    string `json:"error"`
+}
+
+// Tracer records the execution of a transaction while the transactions of its block are replayed. It is passed to
+// the vm by the execution context, see WithTracer. Other transactions replayed with the tracer are not recorded.
+type Tracer struct {
+	txHash  []byte
+	root    *TraceFrame
+	stack   []*TraceFrame
+	sqlRead bool // whether any replayed transaction opened a sql database
+}
+
+type tracerKey struct{}
+
+type replayKey struct{}
+
+type speculationKey struct{}
+
+func NewTracer(txHash []byte) *Tracer {
+	return &Tracer{txHash: txHash}
+}
+
+// WithTracer returns the execution context to replay the transactions with the tracer. The replayed transactions
+// don't use the slot of the block factory or the chain service, and open sql databases in read-only mode since
+// the replay must not change the databases of the chain.
+func WithTracer(execCtx context.Context, t *Tracer) context.Context {
+	return context.WithValue(execCtx, tracerKey{}, t)
+}
+
+// WithReplay returns the execution context to execute the transactions on a state which is not committed, such as
+// the dry run of a transaction. The transactions are executed as the replay with the tracer.
+func WithReplay(execCtx context.Context) context.Context {
+	return context.WithValue(execCtx, replayKey{}, true)
+}
+
+// Speculation tells whether a transaction executed speculatively on an overlay state of the block has done anything
+// which the access sets of the state don't record, such as the opening of a sql database or the governance. Such a
+// transaction must be executed again on the state of the block.
+type Speculation struct {
+	aborted bool
+}
+
+// WithSpeculation returns the execution context to execute a transaction speculatively. The transaction is executed
+// as the replay, and the speculation is aborted instead of opening a sql database or running the governance.
+func WithSpeculation(execCtx context.Context, s *Speculation) context.Context {
+	return context.WithValue(execCtx, speculationKey{}, s)
+}
+
+// Aborted reports whether the speculated transaction has done anything out of the access sets.
+func (s *Speculation) Aborted() bool {
+	return s.aborted
+}
+
+// abortSpeculation aborts the speculation of the transaction, and reports whether it is speculated.
+func abortSpeculation(ctx *vmContext) bool {
+	if ctx.execCtx == nil {
+		return false
+	}
+	s, _ := ctx.execCtx.Value(speculationKey{}).(*Speculation)
+	if s == nil {
+		return false
+	}
+	s.aborted = true
+	return true
+}
+
+// isReplay reports whether the execution context replays the transactions, with or without the tracer, or executes
+// a transaction speculatively.
+func isReplay(execCtx context.Context) bool {
+	return execCtx != nil && (execCtx.Value(replayKey{}) != nil || execCtx.Value(tracerKey{}) != nil ||
+		execCtx.Value(speculationKey{}) != nil)
+}
+
+func tracerOf(execCtx context.Context) *Tracer {
+	if execCtx == nil {
+		return nil
+	}
+	t, _ := execCtx.Value(tracerKey{}).(*Tracer)
+	return t
+}
+
+// Root returns the frame of the traced transaction, or nil if the transaction has not executed the vm.
+func (t *Tracer) Root() *TraceFrame {
+	return t.root
+}
+
+// SqlRead reports whether any transaction replayed with the tracer read a sql database, which is opened as of the
+// parent block of the replayed transactions.
+func (t *Tracer) SqlRead() bool {
+	return t.sqlRead
+}
+
+// ErrorAt returns the innermost frame which the error of the transaction is propagated from, or nil if the
+// transaction succeeded. A failed frame is followed only when its error is a part of the error of the caller,
+// since a failed call may be caught by pcall.
+func (t *Tracer) ErrorAt() *TraceError {
+	if t.root == nil || t.root.Error == "" {
+		return nil
+	}
+	f, depth := t.root, 0
+	for {
+		var next *TraceFrame
+		for _, c := range f.Calls {
+			if c.Error != "" && strings.Contains(f.Error, c.Error) {
+				next = c
+			}
+		}
+		if next == nil {
+			return &TraceError{Contract: f.Contract, Function: f.Function, Depth: depth, Error: f.Error}
+		}
+		f, depth = next, depth+1
+	}
+}
+
+func (t *Tracer) current() *TraceFrame {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+func (t *Tracer) push(f *TraceFrame) {
+	if parent := t.current(); parent != nil {
+		parent.Calls = append(parent.Calls, f)
+	} else if t.root == nil {
+		t.root = f
+	}
+	t.stack = append(t.stack, f)
+}
+
+func (t *Tracer) pop(errMsg string, gas uint64) {
+	f := t.current()
+	if f == nil {
+		return
+	}
+	f.Error = errMsg
+	if f.gasStart > gas {
+		f.GasUsed = f.gasStart - gas
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// setTracer attaches the tracer in the execution context to the vm context, if the transaction is to be traced.
+func setTracer(ctx *vmContext, execCtx context.Context) {
+	ctx.isReplay = isReplay(execCtx)
+	t := tracerOf(execCtx)
+	if t == nil {
+		return
+	}
+	if bytes.Equal(ctx.txHash, t.txHash) {
+		ctx.tracer = t
+	}
+}
+
+// traceDbOpen records that a replayed transaction opened a sql database.
+func traceDbOpen(ctx *vmContext) {
+	if t := tracerOf(ctx.execCtx); t != nil {
+		t.sqlRead = true
+	}
+}
+
+func traceEnter(ctx *vmContext, typ string, contract string, amount string, gas uint64) {
+	if ctx.tracer == nil {
+		return
+	}
+	if amount == "0" {
+		amount = ""
+	}
+	ctx.tracer.push(&TraceFrame{Type: typ, Contract: contract, Amount: amount, gasStart: gas})
+}
+
+func traceExit(ctx *vmContext, errMsg string, gas uint64) {
+	if ctx.tracer == nil {
+		return
+	}
+	ctx.tracer.pop(errMsg, gas)
+}
+
+// traceCall sets the resolved address, the function and the arguments of the current frame.
+func traceCall(ctx *vmContext, contract string, function string, args []interface{}) {
+	if ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Contract, f.Function, f.Args = contract, function, args
+	}
+}
+
+func traceReturn(ctx *vmContext, ret string) {
+	if ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Ret = ret
+	}
+}
+
+func traceStateRead(ctx *vmContext, key []byte, value []byte) {
+	if ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Reads = append(f.Reads, StateAccess{Key: convertKey(string(key)), Value: string(value)})
+	}
+}
+
+func traceStateWrite(ctx *vmContext, key []byte, value []byte, deleted bool) {
+	if ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Writes = append(f.Writes, StateAccess{Key: convertKey(string(key)), Value: string(value), Deleted: deleted})
+	}
+}
+
+func traceSql(ctx *vmContext, sql string) {
+	if ctx == nil || ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Sql = append(f.Sql, sql)
+	}
+}
+
+func traceEvent(ctx *vmContext, name string, args string) {
+	if ctx.tracer == nil {
+		return
+	}
+	if f := ctx.tracer.current(); f != nil {
+		f.Events = append(f.Events, TraceEvent{Name: name, Args: args})
+	}
+}
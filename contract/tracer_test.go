@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracer_callTree(t *testing.T) {
+	txHash := []byte("traced")
+	tracer := NewTracer(txHash)
+	execCtx := WithTracer(context.Background(), tracer)
+
+	other := &vmContext{txHash: []byte("other")}
+	setTracer(other, execCtx)
+	assert.True(t, other.isReplay)
+	assert.Nil(t, other.tracer)
+	traceEnter(other, "call", "A", "0", 1000)
+	assert.Nil(t, tracer.Root(), "other tx should not be recorded")
+
+	ctx := &vmContext{txHash: txHash}
+	setTracer(ctx, execCtx)
+	assert.True(t, ctx.isReplay)
+
+	traceEnter(ctx, "call", "A", "0", 1000)
+	traceCall(ctx, "A", "run", []interface{}{1})
+	traceStateRead(ctx, []byte("_sv_balances-alice"), []byte("10"))
+	// call caught by pcall
+	traceEnter(ctx, "call", "B", "1", 900)
+	traceExit(ctx, "[Contract.LuaCallContract] call err: caught", 850)
+	// call which raises the error of the tx
+	traceEnter(ctx, "delegatecall", "C", "", 800)
+	traceStateWrite(ctx, []byte("_sv_count"), []byte("2"), false)
+	traceSql(ctx, "insert into t values (1)")
+	traceEvent(ctx, "counted", `[2]`)
+	traceEnter(ctx, "send", "D", "5", 700)
+	traceExit(ctx, "", 690)
+	traceExit(ctx, "[Contract.LuaDelegateCallContract] call error: failed", 600)
+	traceExit(ctx, "@A:12: [Contract.LuaDelegateCallContract] call error: failed", 500)
+
+	root := tracer.Root()
+	assert.Equal(t, "run", root.Function)
+	assert.Equal(t, uint64(500), root.GasUsed)
+	assert.Equal(t, "balances[alice]", root.Reads[0].Key)
+	assert.Len(t, root.Calls, 2)
+	assert.Equal(t, "1", root.Calls[0].Amount)
+	assert.Equal(t, uint64(50), root.Calls[0].GasUsed)
+
+	c := root.Calls[1]
+	assert.Equal(t, "count", c.Writes[0].Key)
+	assert.Equal(t, []string{"insert into t values (1)"}, c.Sql)
+	assert.Equal(t, "counted", c.Events[0].Name)
+	assert.Len(t, c.Calls, 1)
+	assert.Equal(t, uint64(10), c.Calls[0].GasUsed)
+
+	errAt := tracer.ErrorAt()
+	if assert.NotNil(t, errAt) {
+		assert.Equal(t, "C", errAt.Contract)
+		assert.Equal(t, 1, errAt.Depth)
+	}
+}
+
+func TestTracer_replay(t *testing.T) {
+	tracer := NewTracer([]byte("traced"))
+	assert.False(t, isReplay(nil))
+	assert.False(t, isReplay(context.Background()))
+	assert.True(t, isReplay(WithReplay(context.Background())))
+	assert.True(t, isReplay(WithTracer(context.Background(), tracer)))
+
+	// a sql database opened by any replayed tx is reported
+	other := &vmContext{txHash: []byte("other"), execCtx: WithTracer(context.Background(), tracer)}
+	assert.False(t, tracer.SqlRead())
+	traceDbOpen(other)
+	assert.True(t, tracer.SqlRead())
+	traceDbOpen(&vmContext{execCtx: WithReplay(context.Background())})
+}
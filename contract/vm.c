@@ -1,3 +1,4 @@
+#include <stdio.h>
 #include <string.h>
 #include <stdlib.h>
 #include <stdint.h>
@@ -38,6 +39,11 @@ static int nsec(lua_State *L) {
 }
 #endif
 
+// the type annotations are only used by the compiler to generate the typed ABI
+static int abi_types(lua_State *L) {
+	return 0;
+}
+
 static void preloadModules(lua_State *L) {
 	int status;
 
@@ -53,6 +59,15 @@ static void preloadModules(lua_State *L) {
 		luaopen_name(L);
 	}
 
+	if (vm_is_hardfork(L, 6)) {
+		lua_getglobal(L, "abi");
+		if (lua_istable(L, -1)) {
+			lua_pushcfunction(L, abi_types);
+			lua_setfield(L, -2, "types");
+		}
+		lua_pop(L, 1);
+	}
+
 	if (!isPublic()) {
 		luaopen_db(L);
 	}
@@ -417,6 +432,52 @@ void vm_set_timeout_count_hook(lua_State *L, int limit) {
 	lua_sethook(L, timeout_count_hook, LUA_MASKCOUNT, VM_TIMEOUT_INST_COUNT);
 }
 
+#define VM_PROFILE_STACK_LEN 1024
+
+/* writes the names of the lua functions on the stack from the outermost one, and the current line */
+static void profile_stack(lua_State *L, lua_Debug *ar, char *buf, size_t size) {
+	lua_Debug frame;
+	int level, depth = 0;
+	size_t n = 0;
+
+	buf[0] = '\0';
+	while (lua_getstack(L, depth, &frame)) {
+		depth++;
+	}
+	for (level = depth - 1; level >= 0 && n < size; level--) {
+		lua_getstack(L, level, &frame);
+		lua_getinfo(L, "Sn", &frame);
+		if (strcmp(frame.what, "C") == 0) {
+			continue;
+		}
+		n += snprintf(buf + n, size - n, "%s%s", n > 0 ? ";" : "", frame.name != NULL ? frame.name : "?");
+	}
+	if (n < size) {
+		snprintf(buf + n, size - n, ":%d", ar->currentline);
+	}
+}
+
+static void profile_hook(lua_State *L, lua_Debug *ar) {
+	char stack[VM_PROFILE_STACK_LEN];
+
+	switch (ar->event) {
+	case LUA_HOOKCOUNT:
+		timeout_hook(L, ar);
+		break;
+	case LUA_HOOKLINE:
+		profile_stack(L, ar, stack, sizeof(stack));
+		luaProfileLine(L, luaL_service(L), stack);
+		break;
+	default:
+		/* charge the gas consumed before the call to the current line */
+		luaProfileLine(L, luaL_service(L), NULL);
+	}
+}
+
+void vm_set_profile_hook(lua_State *L) {
+	lua_sethook(L, profile_hook, LUA_MASKCOUNT | LUA_MASKLINE | LUA_MASKCALL, VM_TIMEOUT_INST_COUNT);
+}
+
 static int stacktrace(lua_State *L) {
 	if (!lua_isstring(L, 1))  /* 'message' not a string? */
 		return 1;               /* keep it intact */
@@ -550,6 +611,37 @@ sqlite3 *vm_get_db(lua_State *L) {
 	return db;
 }
 
+static int vm_trace_sql_stmt(unsigned type, void *service, void *stmt, void *sql) {
+	char *expanded;
+
+	if (type != SQLITE_TRACE_STMT) {
+		return 0;
+	}
+	expanded = sqlite3_expanded_sql((sqlite3_stmt *)stmt);
+	if (expanded != NULL) {
+		luaTraceSql((int)(intptr_t)service, expanded);
+		sqlite3_free(expanded);
+	}
+	return 0;
+}
+
+void vm_trace_sql(sqlite3 *db, int service) {
+	sqlite3_trace_v2(db, SQLITE_TRACE_STMT, vm_trace_sql_stmt, (void *)(intptr_t)service);
+}
+
+/* vm_trace_json_ret returns the results on the stack as json, not changing the stack nor the instruction count */
+char *vm_trace_json_ret(lua_State *L, int nresult) {
+	int top, count;
+	char *json_ret;
+
+	top = lua_gettop(L);
+	count = vm_instcount(L);
+	json_ret = lua_util_get_json_from_stack(L, top - nresult + 1, top, true);
+	lua_settop(L, top);
+	vm_setinstcount(L, count);
+	return json_ret;
+}
+
 void vm_get_abi_function(lua_State *L, char *fname) {
 	lua_getfield(L, LUA_GLOBALSINDEX, "abi");
 	lua_getfield(L, -1, "call");
@@ -38,6 +38,7 @@ import (
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/luac"
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
+	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/fee"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/internal/enc/hex"
@@ -74,6 +75,7 @@ var (
 type ChainAccessor interface {
 	GetBlockByNo(blockNo types.BlockNo) (*types.Block, error)
 	GetBestBlock() (*types.Block, error)
+	GetBlock(blockHash []byte) (*types.Block, error)
 }
 
 // vmContext contains context datas during execution of smart contract.
@@ -105,6 +107,10 @@ type vmContext struct {
 	remainedGas       uint64
 	execCtx           context.Context
 	internalOpsCall   InternalCall
+	isReplay          bool           // replays a tx of a past block or dry runs a tx, see WithTracer and WithReplay
+	tracer            *Tracer        // records the execution if this tx is traced
+	profiler          *Profiler      // attributes the consumed gas if this tx is profiled
+	recentBlocks      []*types.Block // the blocks before the current one, latest first, see getRecentBlock
 }
 
 type executor struct {
@@ -123,6 +129,9 @@ type executor struct {
 }
 
 func MaxCallDepth(version int32) int32 {
+	if version >= 6 {
+		return system.GetMaxCallDepth()
+	}
 	if version >= 3 {
 		return maxCallDepth
 	}
@@ -188,6 +197,8 @@ func NewVmContext(
 	if TraceBlockNo != 0 && TraceBlockNo == ctx.blockInfo.No {
 		ctx.traceFile = getTraceFile(ctx.blockInfo.No, txHash)
 	}
+	setTracer(ctx, execCtx)
+	setProfiler(ctx, execCtx)
 
 	return ctx
 }
@@ -245,6 +256,14 @@ func (ctx *vmContext) setRemainingGas(L *LState) {
 	}
 }
 
+// gasOf returns the remaining gas of the given LState
+func (ctx *vmContext) gasOf(L *LState) uint64 {
+	if ctx.IsGasSystem() {
+		return uint64(C.lua_gasget(L))
+	}
+	return 0
+}
+
 func (ctx *vmContext) usedFee() *big.Int {
 	return fee.TxExecuteFee(ctx.blockInfo.ForkVersion, ctx.bs.GasPrice, ctx.usedGas(), ctx.dbUpdateTotalSize)
 }
@@ -257,6 +276,7 @@ func (ctx *vmContext) usedGas() uint64 {
 }
 
 func (ctx *vmContext) addUpdateSize(updateSize int64) error {
+	profileUpdateSize(ctx, updateSize)
 	if ctx.IsGasSystem() {
 		return nil
 	}
@@ -588,9 +608,12 @@ func (ce *executor) call(instLimit C.int, target *LState) (ret C.int) {
 		return 0
 	}
 	logCall(ce.ctx, contract, ce.fname, ce.ci.Args, ce.amount.String())
+	traceCall(ce.ctx, contract, ce.fname, ce.ci.Args)
 	ce.setCountHook(instLimit)
+	profileEnter(ce.ctx, ce.L, contract, ce.fname)
 	nRet := C.int(0)
 	cErrMsg := C.vm_pcall(ce.L, ce.numArgs, &nRet)
+	profileExit(ce.ctx)
 	if cErrMsg != nil {
 		errMsg := C.GoString(cErrMsg)
 		if C.luaL_hassyserror(ce.L) != C.int(0) {
@@ -615,6 +638,12 @@ func (ce *executor) call(instLimit C.int, target *LState) (ret C.int) {
 			ce.jsonRet = retMsg
 		}
 	} else {
+		if ce.ctx.tracer != nil {
+			if jsonRet := C.vm_trace_json_ret(ce.L, nRet); jsonRet != nil {
+				traceReturn(ce.ctx, C.GoString(jsonRet))
+				C.free(unsafe.Pointer(jsonRet))
+			}
+		}
 		if c2ErrMsg := C.vm_copy_result(ce.L, target, nRet); c2ErrMsg != nil {
 			errMsg := C.GoString(c2ErrMsg)
 			ce.err = errors.New(errMsg)
@@ -655,7 +684,8 @@ func (ce *executor) commitCalledContract() error {
 
 	var err error
 	for _, v := range ctx.callState {
-		if v.tx != nil {
+		// the sql databases are read-only in the replay, so there is nothing to release
+		if v.tx != nil && !ctx.isReplay {
 			err = v.tx.release()
 			if err != nil {
 				return newVmError(err)
@@ -739,6 +769,16 @@ func (ce *executor) closeQuerySql() error {
 	return nil
 }
 
+// closeReplaySql closes the read-only sql transactions opened while replaying the tx
+func (ce *executor) closeReplaySql() {
+	for _, v := range ce.ctx.callState {
+		if v.tx != nil {
+			_ = v.tx.close()
+			v.tx = nil
+		}
+	}
+}
+
 func (ce *executor) setGas() {
 	if ce == nil || ce.L == nil || ce.err != nil {
 		return
@@ -864,6 +904,9 @@ func Call(
 	contexts[ctx.service] = ctx
 	ce := newExecutor(bytecode, contractAddress, ctx, &ci, ctx.curContract.amount, false, false, contractState)
 	defer ce.close()
+	if ctx.isReplay {
+		defer ce.closeReplaySql()
+	}
 
 	if ce.err == nil {
 		startTime := time.Now()
@@ -967,7 +1010,7 @@ func setContract(contractState *statedb.ContractState, contractAddress, payload
 		}
 		// the payload must be lua code. compile it to bytecode
 		sourceCode = code
-		bytecodeABI, err = Compile(string(sourceCode), nil)
+		bytecodeABI, err = Compile(string(sourceCode), nil, ctx.blockInfo.ForkVersion >= 6)
 		if err != nil {
 			ctrLgr.Warn().Err(err).Str("contract", types.EncodeAddress(contractAddress)).Msg("deploy - compile error")
 			return nil, nil, err
@@ -1048,6 +1091,9 @@ func Create(
 	// create a new executor for the constructor
 	ce := newExecutor(bytecode, contractAddress, ctx, &ci, ctx.curContract.amount, true, false, contractState)
 	defer ce.close()
+	if ctx.isReplay {
+		defer ce.closeReplaySql()
+	}
 
 	if ce.err == nil {
 		// call the constructor
@@ -1320,7 +1366,7 @@ func getMultiCallCode(contractState *statedb.ContractState) []byte {
 	if multicall_compiled == nil {
 		// compile the Lua code used to execute multicall txns
 		var err error
-		multicall_compiled, err = Compile(multicall_code, nil)
+		multicall_compiled, err = Compile(multicall_code, nil, false)
 		if err != nil {
 			ctrLgr.Error().Err(err).Msg("multicall compile")
 			return nil
@@ -1369,12 +1415,17 @@ func GetABI(contractState *statedb.ContractState, bs *state.BlockState) (*types.
 	return abi, nil
 }
 
-func Compile(code string, parent *LState) (util.LuaCode, error) {
+// Compile compiles the contract code to the bytecode and its ABI. The type annotations by abi.types are added to
+// the ABI only if typedABI is set, from the hardfork version 6.
+func Compile(code string, parent *LState, typedABI bool) (util.LuaCode, error) {
 	L := luac.NewLState()
 	if L == nil {
 		return nil, ErrVmStart
 	}
 	defer luac.CloseLState(L)
+	if !typedABI {
+		luac.DisableABITypes(L)
+	}
 	if parent != nil {
 		var lState = (*LState)(L)
 		if cErrMsg := C.vm_copy_service(lState, parent); cErrMsg != nil {
@@ -1396,3 +1447,19 @@ func Compile(code string, parent *LState) (util.LuaCode, error) {
 	}
 	return byteCodeAbi, nil
 }
+
+// compileLimited compiles the code which is not from a transaction, like the source submitted for the verification.
+// The chunk is run to generate the ABI, so the instructions are limited as it cannot be stopped by the timeout of a
+// block.
+func compileLimited(code string, typedABI bool) (util.LuaCode, error) {
+	L := luac.NewLState()
+	if L == nil {
+		return nil, ErrVmStart
+	}
+	defer luac.CloseLState(L)
+	if !typedABI {
+		luac.DisableABITypes(L)
+	}
+	C.vm_set_count_hook((*LState)(L), callMaxInstLimit)
+	return luac.Compile(L, code)
+}
@@ -22,6 +22,8 @@ const char *vm_pcall(lua_State *L, int argc, int *nresult);
 const char *vm_get_json_ret(lua_State *L, int nresult, int *err);
 const char *vm_copy_result(lua_State *L, lua_State *target, int cnt);
 sqlite3 *vm_get_db(lua_State *L);
+void vm_trace_sql(sqlite3 *db, int service);
+char *vm_trace_json_ret(lua_State *L, int nresult);
 void vm_get_abi_function(lua_State *L, char *fname);
 void vm_set_count_hook(lua_State *L, int limit);
 void vm_db_release_resource(lua_State *L);
@@ -29,6 +31,7 @@ bool vm_is_hardfork(lua_State *L, int version);
 void initViewFunction();
 void vm_set_timeout_hook(lua_State *L);
 void vm_set_timeout_count_hook(lua_State *L, int limit);
+void vm_set_profile_hook(lua_State *L);
 int vm_instcount(lua_State *L);
 void vm_setinstcount(lua_State *L, int count);
 const char *vm_copy_service(lua_State *L, lua_State *main);
@@ -14,6 +14,11 @@ struct proof {
 	size_t len;
 };
 
+struct blob {
+	void *data;
+	size_t len;
+};
+
 #define RLP_TSTRING 0
 #define RLP_TLIST 1
 
@@ -27,6 +32,7 @@ import "C"
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -38,6 +44,7 @@ import (
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
 	"github.com/aergoio/aergo/v2/contract/name"
 	"github.com/aergoio/aergo/v2/contract/system"
+	"github.com/aergoio/aergo/v2/fee"
 	"github.com/aergoio/aergo/v2/internal/common"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/internal/enc/hex"
@@ -56,6 +63,7 @@ var (
 )
 
 const (
+	maxBlockHistory   = 256
 	maxEventCntV2     = 50
 	maxEventCntV4     = 128
 	maxEventNameSize  = 64
@@ -86,6 +94,7 @@ func luaSetDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int, value
 	if ctx.isQuery == true || ctx.nestedView > 0 {
 		return C.CString("[System.LuaSetDB] set not permitted in query")
 	}
+	profileCallback(ctx, "state.set")
 
 	keyBytes := C.GoBytes(key, keyLen)
 	keyStr := string(keyBytes)
@@ -99,6 +108,7 @@ func luaSetDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int, value
 	if err := ctx.curContract.callState.ctrState.SetData(keyBytes, valueBytes); err != nil {
 		return C.CString(err.Error())
 	}
+	traceStateWrite(ctx, keyBytes, valueBytes, false)
 	if err := ctx.addUpdateSize(int64(types.HashIDLength + len(valueBytes))); err != nil {
 		C.luaL_setuncatchablerror(L)
 		return C.CString(err.Error())
@@ -119,6 +129,7 @@ func luaGetDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int, blkno
 	if ctx == nil {
 		return nil, C.CString("[System.LuaGetDB] contract state not found")
 	}
+	profileCallback(ctx, "state.get")
 	if blkno != nil {
 		bigNo, _ := new(big.Int).SetString(strings.TrimSpace(C.GoString(blkno)), 10)
 		if bigNo == nil || bigNo.Sign() < 0 {
@@ -160,10 +171,12 @@ func luaGetDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int, blkno
 		}
 	}
 
-	data, err := ctx.curContract.callState.ctrState.GetData(C.GoBytes(key, keyLen))
+	keyBytes := C.GoBytes(key, keyLen)
+	data, err := ctx.curContract.callState.ctrState.GetData(keyBytes)
 	if err != nil {
 		return nil, C.CString(err.Error())
 	}
+	traceStateRead(ctx, keyBytes, data)
 	if data == nil {
 		return nil, nil
 	}
@@ -179,6 +192,7 @@ func luaDelDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int) *C.cha
 	if ctx.isQuery == true || ctx.nestedView > 0 {
 		return C.CString("[System.LuaDelDB] delete not permitted in query")
 	}
+	profileCallback(ctx, "state.delete")
 
 	keyBytes := C.GoBytes(key, keyLen)
 	keyStr := string(keyBytes)
@@ -190,6 +204,7 @@ func luaDelDB(L *LState, service C.int, key unsafe.Pointer, keyLen C.int) *C.cha
 	if err := ctx.curContract.callState.ctrState.DeleteData(keyBytes); err != nil {
 		return C.CString(err.Error())
 	}
+	traceStateWrite(ctx, keyBytes, nil, true)
 	if err := ctx.addUpdateSize(int64(32)); err != nil {
 		C.luaL_setuncatchablerror(L)
 		return C.CString(err.Error())
@@ -260,6 +275,7 @@ func luaCallContract(L *LState, service C.int, contractId *C.char, fname *C.char
 	if ctx == nil {
 		return -1, C.CString("[Contract.LuaCallContract] contract state not found")
 	}
+	profileCallback(ctx, "contract.call")
 
 	opId := logOperation(ctx, amountStr, "call", contractAddress, fnameStr, argsStr)
 	defer func() {
@@ -267,9 +283,15 @@ func luaCallContract(L *LState, service C.int, contractId *C.char, fname *C.char
 			logOperationResult(ctx, opId, C.GoString(errormsg))
 		}
 	}()
+	if ctx.tracer != nil {
+		traceEnter(ctx, "call", contractAddress, amountStr, ctx.gasOf(L))
+		defer func() {
+			traceExit(ctx, C.GoString(errormsg), ctx.gasOf(L))
+		}()
+	}
 
 	// get the contract address
-	cid, err := getAddressNameResolved(contractAddress, ctx.bs)
+	cid, err := getAddressNameResolved(contractAddress, ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return -1, C.CString("[Contract.LuaCallContract] invalid contractId: " + err.Error())
 	}
@@ -398,6 +420,7 @@ func luaDelegateCallContract(L *LState, service C.int, contractId *C.char,
 	if ctx == nil {
 		return -1, C.CString("[Contract.LuaDelegateCallContract] contract state not found")
 	}
+	profileCallback(ctx, "contract.delegatecall")
 
 	var isMultiCall bool
 	var cid []byte
@@ -409,6 +432,12 @@ func luaDelegateCallContract(L *LState, service C.int, contractId *C.char,
 			logOperationResult(ctx, opId, C.GoString(errormsg))
 		}
 	}()
+	if ctx.tracer != nil {
+		traceEnter(ctx, "delegatecall", contractIdStr, "", ctx.gasOf(L))
+		defer func() {
+			traceExit(ctx, C.GoString(errormsg), ctx.gasOf(L))
+		}()
+	}
 
 	// get the contract address
 	if contractIdStr == "multicall" {
@@ -417,7 +446,7 @@ func luaDelegateCallContract(L *LState, service C.int, contractId *C.char,
 		fnameStr = "execute"
 		cid = ctx.curContract.contractId
 	} else {
-		cid, err = getAddressNameResolved(contractIdStr, ctx.bs)
+		cid, err = getAddressNameResolved(contractIdStr, ctx.bs, ctx.blockInfo)
 		if err != nil {
 			return -1, C.CString("[Contract.LuaDelegateCallContract] invalid contractId: " + err.Error())
 		}
@@ -518,12 +547,12 @@ func luaDelegateCallContract(L *LState, service C.int, contractId *C.char,
 	return ret, nil
 }
 
-func getAddressNameResolved(account string, bs *state.BlockState) ([]byte, error) {
+func getAddressNameResolved(account string, bs *state.BlockState, bi *types.BlockHeaderInfo) ([]byte, error) {
 	accountLen := len(account)
 	if accountLen == types.EncodedAddressLength {
 		return types.DecodeAddress(account)
-	} else if accountLen == types.NameLength {
-		cid, err := name.Resolve(bs, []byte(account), false)
+	} else if accountLen == types.NameLength || (bi.ForkVersion >= 6 && types.IsSubName([]byte(account))) {
+		cid, err := name.Resolve(bs, []byte(account), false, bi.No)
 		if err != nil {
 			return nil, err
 		}
@@ -544,6 +573,7 @@ func luaSendAmount(L *LState, service C.int, contractId *C.char, amount *C.char)
 	if ctx == nil {
 		return C.CString("[Contract.LuaSendAmount] contract state not found")
 	}
+	profileCallback(ctx, "contract.send")
 
 	opId := logOperation(ctx, amountStr, "send", contractAddress)
 	defer func() {
@@ -551,6 +581,12 @@ func luaSendAmount(L *LState, service C.int, contractId *C.char, amount *C.char)
 			logOperationResult(ctx, opId, C.GoString(errormsg))
 		}
 	}()
+	if ctx.tracer != nil {
+		traceEnter(ctx, "send", contractAddress, amountStr, ctx.gasOf(L))
+		defer func() {
+			traceExit(ctx, C.GoString(errormsg), ctx.gasOf(L))
+		}()
+	}
 
 	// read the amount to be sent
 	amountBig, err := transformAmount(amountStr, ctx.blockInfo.ForkVersion)
@@ -564,7 +600,7 @@ func luaSendAmount(L *LState, service C.int, contractId *C.char, amount *C.char)
 	}
 
 	// get the receiver account
-	cid, err := getAddressNameResolved(contractAddress, ctx.bs)
+	cid, err := getAddressNameResolved(contractAddress, ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return C.CString("[Contract.LuaSendAmount] invalid contractId: " + err.Error())
 	}
@@ -784,7 +820,7 @@ func luaGetBalance(L *LState, service C.int, contractId *C.char) (*C.char, *C.ch
 	if contractId == nil {
 		return C.CString(ctx.curContract.callState.ctrState.GetBalanceBigInt().String()), nil
 	}
-	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs)
+	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return nil, C.CString("[Contract.LuaGetBalance] invalid contractId: " + err.Error())
 	}
@@ -852,6 +888,80 @@ func luaGetPrevBlockHash(L *LState, service C.int) *C.char {
 	return C.CString(base58.Encode(ctx.blockInfo.PrevBlockHash))
 }
 
+// getRecentBlock returns the block of the number within maxBlockHistory blocks before the current block, or nil if
+// it is out of the range. The blocks are followed back from the previous block hash, rather than by the number,
+// so that the same blocks are returned while executing a block of a side chain. It also returns the number of the
+// blocks read to reach it, which are charged by the caller. The blocks read are kept for the tx, so the count is
+// the same on every node.
+func (ctx *vmContext) getRecentBlock(blockNo types.BlockNo) (*types.Block, int, error) {
+	curNo := ctx.blockInfo.No
+	if blockNo >= curNo || curNo-blockNo > maxBlockHistory {
+		return nil, 0, nil
+	}
+	if ctx.cdb == nil {
+		return nil, 0, errors.New("the chain is not accessible")
+	}
+	walked := 0
+	for idx := int(curNo - blockNo - 1); len(ctx.recentBlocks) <= idx; walked++ {
+		hash := ctx.blockInfo.PrevBlockHash
+		if n := len(ctx.recentBlocks); n > 0 {
+			hash = ctx.recentBlocks[n-1].GetHeader().GetPrevBlockHash()
+		}
+		block, err := ctx.cdb.GetBlock(hash)
+		if err != nil {
+			return nil, walked, err
+		}
+		ctx.recentBlocks = append(ctx.recentBlocks, block)
+	}
+	return ctx.recentBlocks[curNo-blockNo-1], walked, nil
+}
+
+//export luaGetBlockHash
+func luaGetBlockHash(L *LState, service C.int, blockNo C.lua_Integer) (*C.char, *C.char, C.int) {
+	ctx := contexts[service]
+	if blockNo < 0 {
+		return nil, nil, 0
+	}
+	block, walked, err := ctx.getRecentBlock(types.BlockNo(blockNo))
+	if err != nil {
+		return nil, C.CString("[Contract.LuaGetBlockHash] " + err.Error()), C.int(walked)
+	}
+	if block == nil {
+		return nil, nil, C.int(walked)
+	}
+	return C.CString(base58.Encode(block.BlockHash())), nil, C.int(walked)
+}
+
+//export luaGetBlockInfo
+func luaGetBlockInfo(L *LState, service C.int, blockNo C.lua_Integer) (*C.char, *C.char, C.int) {
+	ctx := contexts[service]
+	if blockNo < 0 {
+		return nil, nil, 0
+	}
+	block, walked, err := ctx.getRecentBlock(types.BlockNo(blockNo))
+	if err != nil {
+		return nil, C.CString("[Contract.LuaGetBlockInfo] " + err.Error()), C.int(walked)
+	}
+	if block == nil {
+		return nil, nil, C.int(walked)
+	}
+	header := block.GetHeader()
+	info, err := json.Marshal(map[string]interface{}{
+		"hash":          base58.Encode(block.BlockHash()),
+		"number":        header.GetBlockNo(),
+		"timestamp":     header.GetTimestamp() / 1e9,
+		"prev_hash":     base58.Encode(header.GetPrevBlockHash()),
+		"state_root":    base58.Encode(header.GetBlocksRootHash()),
+		"txs_root":      base58.Encode(header.GetTxsRootHash()),
+		"receipts_root": base58.Encode(header.GetReceiptsRootHash()),
+		"coinbase":      types.EncodeAddress(header.GetCoinbaseAccount()),
+	})
+	if err != nil {
+		return nil, C.CString("[Contract.LuaGetBlockInfo] " + err.Error()), C.int(walked)
+	}
+	return C.CString(string(info)), nil, C.int(walked)
+}
+
 //export luaGetDbHandle
 func luaGetDbHandle(service C.int) *C.sqlite3 {
 	ctx := contexts[service]
@@ -863,8 +973,17 @@ func luaGetDbHandle(service C.int) *C.sqlite3 {
 	var tx sqlTx
 	var err error
 
+	// the sql databases are out of the access sets of the speculation
+	if abortSpeculation(ctx) {
+		return nil
+	}
 	aid := types.ToAccountID(curContract.contractId)
-	if ctx.isQuery == true {
+	// the replay of a past tx must not change the database
+	readOnly := ctx.isQuery || ctx.isReplay
+	if ctx.isReplay {
+		traceDbOpen(ctx)
+	}
+	if readOnly {
 		tx, err = beginReadOnly(aid.String(), curContract.rp)
 	} else {
 		tx, err = beginTx(aid.String(), curContract.rp)
@@ -873,7 +992,7 @@ func luaGetDbHandle(service C.int) *C.sqlite3 {
 		sqlLgr.Error().Err(err).Msg("Begin SQL Transaction")
 		return nil
 	}
-	if ctx.isQuery == false {
+	if !readOnly {
 		err = tx.savepoint()
 		if err != nil {
 			sqlLgr.Error().Err(err).Msg("Begin SQL Transaction")
@@ -881,9 +1000,18 @@ func luaGetDbHandle(service C.int) *C.sqlite3 {
 		}
 	}
 	cs.tx = tx
+	if ctx.tracer != nil || ctx.profiler != nil {
+		C.vm_trace_sql(cs.tx.getHandle(), service)
+	}
 	return cs.tx.getHandle()
 }
 
+//export luaTraceSql
+func luaTraceSql(service C.int, sql *C.char) {
+	traceSql(contexts[service], C.GoString(sql))
+	profileSql(contexts[service])
+}
+
 func checkHexString(data string) bool {
 	if len(data) >= 2 && data[0] == '0' && (data[1] == 'x' || data[1] == 'X') {
 		return true
@@ -930,6 +1058,7 @@ func luaECVerify(L *LState, service C.int, msg *C.char, sig *C.char, addr *C.cha
 	if ctx == nil {
 		return -1, C.CString("[Contract.LuaEcVerify]not found contract state")
 	}
+	profileCallback(ctx, "crypto.ecverify")
 	setInstMinusCount(ctx, L, 10000)
 
 	var pubKey *btcec.PublicKey
@@ -1056,6 +1185,104 @@ func luaCryptoKeccak256(data unsafe.Pointer, dataLen C.int) (unsafe.Pointer, int
 	}
 }
 
+//export luaCryptoRipemd160
+func luaCryptoRipemd160(data unsafe.Pointer, dataLen C.int) (unsafe.Pointer, int) {
+	d, isHex := luaCryptoToBytes(data, dataLen)
+	h := ripemd160Hash(d)
+	if isHex {
+		hexb := []byte("0x" + hex.Encode(h))
+		return C.CBytes(hexb), len(hexb)
+	}
+	return C.CBytes(h), len(h)
+}
+
+//export luaCryptoBlake2b
+func luaCryptoBlake2b(data unsafe.Pointer, dataLen C.int, size C.int) (unsafe.Pointer, int, *C.char) {
+	d, isHex := luaCryptoToBytes(data, dataLen)
+	h, err := blake2bHash(d, int(size))
+	if err != nil {
+		return nil, 0, C.CString("[Contract.LuaCryptoBlake2b] " + err.Error())
+	}
+	if isHex {
+		hexb := []byte("0x" + hex.Encode(h))
+		return C.CBytes(hexb), len(hexb), nil
+	}
+	return C.CBytes(h), len(h), nil
+}
+
+func luaCryptoBlobs(blobs unsafe.Pointer, n C.int) [][]byte {
+	cBlobs := (*[1 << 30]C.struct_blob)(blobs)[:n:n]
+	b := make([][]byte, int(n))
+	for i, blob := range cBlobs {
+		b[i], _ = luaCryptoToBytes(blob.data, C.int(blob.len))
+	}
+	return b
+}
+
+// luaCryptoVerify returns 1 if verified, 0 if not, or -1 with the error message. The frame is the name of the lua
+// function for the profiler.
+func luaCryptoVerify(L *LState, service C.int, fname string, frame string, instCount C.int, verify func() (bool, error)) (C.int, *C.char) {
+	ctx := contexts[service]
+	if ctx == nil {
+		return -1, C.CString("[Contract." + fname + "] not found contract state")
+	}
+	profileCallback(ctx, frame)
+	setInstMinusCount(ctx, L, instCount)
+	ok, err := verify()
+	if err != nil {
+		return -1, C.CString("[Contract." + fname + "] " + err.Error())
+	}
+	if ok {
+		return C.int(1), nil
+	}
+	return C.int(0), nil
+}
+
+// luaCryptoGas returns the gas cost of the crypto module, which is a system param. The op is enum crypto_gas of
+// crypto_module.h.
+//
+//export luaCryptoGas
+func luaCryptoGas(op C.int) C.ulonglong {
+	return C.ulonglong(system.GetCryptoGas(int(op)))
+}
+
+//export luaCryptoEd25519Verify
+func luaCryptoEd25519Verify(L *LState, service C.int, msg, sig, pubKey unsafe.Pointer, msgLen, sigLen, pubKeyLen C.int) (C.int, *C.char) {
+	return luaCryptoVerify(L, service, "LuaCryptoEd25519Verify", "crypto.ed25519_verify", 5000+(msgLen+31)/32*10, func() (bool, error) {
+		bMsg, _ := luaCryptoToBytes(msg, msgLen)
+		bSig, _ := luaCryptoToBytes(sig, sigLen)
+		bPubKey, _ := luaCryptoToBytes(pubKey, pubKeyLen)
+		return verifyEd25519(bMsg, bSig, bPubKey)
+	})
+}
+
+//export luaCryptoP256Verify
+func luaCryptoP256Verify(L *LState, service C.int, hash, sig, pubKey unsafe.Pointer, hashLen, sigLen, pubKeyLen C.int) (C.int, *C.char) {
+	return luaCryptoVerify(L, service, "LuaCryptoP256Verify", "crypto.p256_verify", 10000, func() (bool, error) {
+		bHash, _ := luaCryptoToBytes(hash, hashLen)
+		bSig, _ := luaCryptoToBytes(sig, sigLen)
+		bPubKey, _ := luaCryptoToBytes(pubKey, pubKeyLen)
+		return verifyP256(bHash, bSig, bPubKey)
+	})
+}
+
+//export luaCryptoBlsVerify
+func luaCryptoBlsVerify(L *LState, service C.int, msgs unsafe.Pointer, nMsgs C.int, sig unsafe.Pointer, sigLen C.int, pubKeys unsafe.Pointer, nPubKeys C.int) (C.int, *C.char) {
+	return luaCryptoVerify(L, service, "LuaCryptoBlsVerify", "crypto.bls_verify", 100000*nPubKeys+5000*nMsgs, func() (bool, error) {
+		bSig, _ := luaCryptoToBytes(sig, sigLen)
+		return verifyBlsAggregate(luaCryptoBlobs(msgs, nMsgs), bSig, luaCryptoBlobs(pubKeys, nPubKeys))
+	})
+}
+
+//export luaCryptoBlsPopVerify
+func luaCryptoBlsPopVerify(L *LState, service C.int, pubKey, proof unsafe.Pointer, pubKeyLen, proofLen C.int) (C.int, *C.char) {
+	return luaCryptoVerify(L, service, "LuaCryptoBlsPopVerify", "crypto.bls_pop_verify", 105000, func() (bool, error) {
+		bPubKey, _ := luaCryptoToBytes(pubKey, pubKeyLen)
+		bProof, _ := luaCryptoToBytes(proof, proofLen)
+		return verifyBlsPop(bPubKey, bProof)
+	})
+}
+
 // transformAmount processes the input string to calculate the total amount,
 // taking into account the different units ("aergo", "gaer", "aer")
 func transformAmount(amountStr string, forkVersion int32) (*big.Int, error) {
@@ -1215,6 +1442,7 @@ func luaDeployContract(
 	if ctx.isQuery == true || ctx.nestedView > 0 {
 		return -1, C.CString("[Contract.LuaDeployContract]send not permitted in query")
 	}
+	profileCallback(ctx, "contract.deploy")
 	bs := ctx.bs
 
 	opId := logOperation(ctx, amountStr, "deploy", contractStr, argsStr)
@@ -1223,13 +1451,24 @@ func luaDeployContract(
 			logOperationResult(ctx, opId, C.GoString(errormsg))
 		}
 	}()
+	if ctx.tracer != nil {
+		traceEnter(ctx, "deploy", "", amountStr, ctx.gasOf(L))
+		defer func() {
+			// the address of the new contract is returned on success
+			var errMsg string
+			if ret < 0 {
+				errMsg = C.GoString(errormsg)
+			}
+			traceExit(ctx, errMsg, ctx.gasOf(L))
+		}()
+	}
 
 	// contract code
 	var codeABI []byte
 	var sourceCode []byte
 
 	// check if contract name or address is given
-	cid, err := getAddressNameResolved(contractStr, bs)
+	cid, err := getAddressNameResolved(contractStr, bs, ctx.blockInfo)
 	if err == nil {
 		// check if contract exists
 		contractState, err := getOnlyContractState(ctx, cid)
@@ -1256,9 +1495,9 @@ func luaDeployContract(
 	// compile contract code if not found
 	if len(codeABI) == 0 {
 		if ctx.blockInfo.ForkVersion >= 2 {
-			codeABI, err = Compile(contractStr, L)
+			codeABI, err = Compile(contractStr, L, ctx.blockInfo.ForkVersion >= 6)
 		} else {
-			codeABI, err = Compile(contractStr, nil)
+			codeABI, err = Compile(contractStr, nil, false)
 		}
 		if err != nil {
 			if C.luaL_hasuncatchablerror(L) != C.int(0) &&
@@ -1306,6 +1545,7 @@ func luaDeployContract(
 	if err != nil {
 		return -1, C.CString("[Contract.LuaDeployContract] invalid args:" + err.Error())
 	}
+	traceCall(ctx, types.EncodeAddress(newContract.ID()), constructor, ci.Args)
 
 	// send the amount to the contract
 	senderState := prevContractInfo.callState.accState
@@ -1435,13 +1675,14 @@ func luaRandomInt(min, max, service C.int) C.int {
 }
 
 //export luaEvent
-func luaEvent(L *LState, service C.int, name *C.char, args *C.char) *C.char {
+func luaEvent(L *LState, service C.int, name *C.char, args *C.char, indexed C.int) *C.char {
 	eventName := C.GoString(name)
 	eventArgs := C.GoString(args)
 	ctx := contexts[service]
 	if ctx.isQuery == true || ctx.nestedView > 0 {
 		return C.CString("[Contract.Event] event not permitted in query")
 	}
+	profileCallback(ctx, "contract.event")
 	if ctx.eventCount >= maxEventCnt(ctx) {
 		return C.CString(fmt.Sprintf("[Contract.Event] exceeded the maximum number of events(%d)", maxEventCnt(ctx)))
 	}
@@ -1451,6 +1692,10 @@ func luaEvent(L *LState, service C.int, name *C.char, args *C.char) *C.char {
 	if len(eventArgs) > maxEventArgSize {
 		return C.CString(fmt.Sprintf("[Contract.Event] exceeded the maximum length of event args(%d)", maxEventArgSize))
 	}
+	topics, err := eventTopics(eventArgs, int(indexed))
+	if err != nil {
+		return C.CString("[Contract.Event] " + err.Error())
+	}
 	ctx.events = append(
 		ctx.events,
 		&types.Event{
@@ -1458,13 +1703,41 @@ func luaEvent(L *LState, service C.int, name *C.char, args *C.char) *C.char {
 			EventIdx:        ctx.eventCount,
 			EventName:       eventName,
 			JsonArgs:        eventArgs,
+			Topics:          topics,
 		},
 	)
 	ctx.eventCount++
 	logOperation(ctx, "", "event", eventName, eventArgs)
+	traceEvent(ctx, eventName, eventArgs)
 	return nil
 }
 
+// eventTopics returns the topics of the first indexed args of the event.
+func eventTopics(jsonArgs string, indexed int) ([][]byte, error) {
+	if indexed == 0 {
+		return nil, nil
+	}
+	if indexed > types.MaxEventTopics {
+		return nil, fmt.Errorf("exceeded the maximum number of indexed args(%d)", types.MaxEventTopics)
+	}
+	var args []json.RawMessage
+	if err := json.Unmarshal([]byte(jsonArgs), &args); err != nil {
+		return nil, errors.New("invalid event args")
+	}
+	if indexed > len(args) {
+		return nil, errors.New("invalid number of indexed args")
+	}
+	topics := make([][]byte, indexed)
+	for i := range topics {
+		topic, err := types.EventTopic(args[i])
+		if err != nil {
+			return nil, errors.New("invalid event args")
+		}
+		topics[i] = topic
+	}
+	return topics, nil
+}
+
 //export luaGetEventCount
 func luaGetEventCount(L *LState, service C.int) C.int {
 	eventCount := contexts[service].eventCount
@@ -1528,7 +1801,7 @@ func luaIsContract(L *LState, service C.int, contractId *C.char) (C.int, *C.char
 		return -1, C.CString("[Contract.LuaIsContract] contract state not found")
 	}
 
-	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs)
+	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return -1, C.CString("[Contract.LuaIsContract] invalid contractId: " + err.Error())
 	}
@@ -1554,7 +1827,7 @@ func luaNameResolve(L *LState, service C.int, name_or_address *C.char) *C.char {
 		// also checks if valid address
 		addr, err = types.DecodeAddress(account)
 	} else {
-		addr, err = name.Resolve(ctx.bs, []byte(account), false)
+		addr, err = name.Resolve(ctx.bs, []byte(account), false, ctx.blockInfo.No)
 	}
 	if err != nil {
 		return C.CString("[Contract.LuaNameResolve] " + err.Error())
@@ -1562,6 +1835,26 @@ func luaNameResolve(L *LState, service C.int, name_or_address *C.char) *C.char {
 	return C.CString(types.EncodeAddress(addr))
 }
 
+//export luaNameReverse
+func luaNameReverse(L *LState, service C.int, address *C.char) *C.char {
+	ctx := contexts[service]
+	if ctx == nil {
+		return C.CString("[Contract.LuaNameReverse] contract state not found")
+	}
+	addr, err := types.DecodeAddress(C.GoString(address))
+	if err != nil || len(addr) != types.AddressLength {
+		return C.CString("[Contract.LuaNameReverse] invalid address: " + C.GoString(address))
+	}
+	reversed, err := name.Reverse(ctx.bs, addr, ctx.blockInfo.No)
+	if err != nil {
+		return C.CString("[Contract.LuaNameReverse] " + err.Error())
+	}
+	if reversed == nil {
+		return nil
+	}
+	return C.CString(string(reversed))
+}
+
 //export luaGovernance
 func luaGovernance(L *LState, service C.int, gType C.char, arg *C.char) (errormsg *C.char) {
 
@@ -1574,6 +1867,14 @@ func luaGovernance(L *LState, service C.int, gType C.char, arg *C.char) (errorms
 		return C.CString("[Contract.LuaGovernance] governance not permitted in query")
 	}
 
+	// the governance changes the voting power rank and the params kept by the node, so a replay must not run it
+	if abortSpeculation(ctx) {
+		return C.CString("[Contract.LuaGovernance] governance not permitted in speculation")
+	}
+	if ctx.isReplay {
+		return C.CString("[Contract.LuaGovernance] governance not permitted in replay")
+	}
+
 	var amountBig *big.Int
 	var payload []byte
 	var opId int64
@@ -1682,6 +1983,138 @@ func luaGovernance(L *LState, service C.int, gType C.char, arg *C.char) (errorms
 	return nil
 }
 
+//export luaScheduleCall
+func luaScheduleCall(L *LState, service C.int, blockNo C.lua_Integer, ts C.lua_Integer, fname *C.char, args *C.char,
+	gasLimit C.lua_Integer, prepaidFee *C.char) (C.lua_Integer, *C.char) {
+
+	ctx := contexts[service]
+	if ctx == nil {
+		return 0, C.CString("[Contract.LuaScheduleCall] contract state not found")
+	}
+	if ctx.isQuery == true || ctx.nestedView > 0 {
+		return 0, C.CString("[Contract.LuaScheduleCall] schedule not permitted in query")
+	}
+	if blockNo < 0 || ts < 0 || gasLimit < 0 || (blockNo == 0) == (ts == 0) {
+		return 0, C.CString("[Contract.LuaScheduleCall] invalid schedule time")
+	}
+	if (blockNo != 0 && uint64(blockNo) <= ctx.blockInfo.No) || (ts != 0 && int64(ts) <= ctx.blockInfo.Ts/1e9) {
+		return 0, C.CString("[Contract.LuaScheduleCall] schedule time has passed")
+	}
+	amountBig, err := transformAmount(C.GoString(prepaidFee), ctx.blockInfo.ForkVersion)
+	if err != nil {
+		return 0, C.CString("[Contract.LuaScheduleCall] invalid fee: " + err.Error())
+	}
+	if amountBig.Sign() <= 0 && fee.GasEnabled(ctx.blockInfo.ForkVersion) {
+		return 0, C.CString("[Contract.LuaScheduleCall] invalid fee: no prepaid fee")
+	}
+
+	cid := []byte(types.AergoSystem)
+	aid := types.ToAccountID(cid)
+	scsState, err := getContractState(ctx, cid)
+	if err != nil {
+		return 0, C.CString("[Contract.LuaScheduleCall] getAccount error: " + err.Error())
+	}
+	senderState := ctx.curContract.callState.accState
+	if senderState.Balance().Cmp(amountBig) < 0 {
+		return 0, C.CString("[Contract.LuaScheduleCall] error: " + types.ErrInsufficientBalance.Error())
+	}
+
+	opId := logOperation(ctx, amountBig.String(), "schedule", C.GoString(fname), C.GoString(args))
+	defer func() {
+		if err != nil {
+			logOperationResult(ctx, opId, err.Error())
+		}
+	}()
+
+	seq, err := createRecoveryPoint(aid, ctx, senderState, scsState, zeroBig, false, false)
+	if err != nil {
+		return 0, C.CString("[Contract.LuaScheduleCall] database error: " + err.Error())
+	}
+	id, err := system.AddScheduledCall(scsState.ctrState, &system.ScheduledCall{
+		Contract: types.EncodeAddress(ctx.curContract.contractId),
+		Function: C.GoString(fname),
+		Args:     json.RawMessage(C.GoString(args)),
+		BlockNo:  uint64(blockNo),
+		Time:     int64(ts),
+		GasLimit: uint64(gasLimit),
+		Fee:      amountBig.String(),
+	}, ctx.blockInfo.No, ctx.blockInfo.Ts/1e9)
+	if err != nil {
+		if rErr := clearRecoveryPoint(L, ctx, seq, true); rErr != nil {
+			return 0, C.CString("[Contract.LuaScheduleCall] recovery error: " + rErr.Error())
+		}
+		return 0, C.CString("[Contract.LuaScheduleCall] error: " + err.Error())
+	}
+	if seq == 1 {
+		if err = clearRecoveryPoint(L, ctx, seq, false); err != nil {
+			return 0, C.CString("[Contract.LuaScheduleCall] recovery error: " + err.Error())
+		}
+	}
+
+	// the prepaid fee is kept by the system account until the call is executed or cancelled
+	if err = state.SendBalance(senderState, scsState.accState, amountBig); err != nil {
+		return 0, C.CString("[Contract.LuaScheduleCall] error: " + err.Error())
+	}
+	if ctx.lastRecoveryPoint != nil {
+		_, _ = createRecoveryPoint(aid, ctx, senderState, scsState, amountBig, true, false)
+	}
+
+	return C.lua_Integer(id), nil
+}
+
+//export luaCancelScheduledCall
+func luaCancelScheduledCall(L *LState, service C.int, id C.lua_Integer) *C.char {
+	ctx := contexts[service]
+	if ctx == nil {
+		return C.CString("[Contract.LuaCancelScheduledCall] contract state not found")
+	}
+	if ctx.isQuery == true || ctx.nestedView > 0 {
+		return C.CString("[Contract.LuaCancelScheduledCall] cancel not permitted in query")
+	}
+
+	cid := []byte(types.AergoSystem)
+	aid := types.ToAccountID(cid)
+	scsState, err := getContractState(ctx, cid)
+	if err != nil {
+		return C.CString("[Contract.LuaCancelScheduledCall] getAccount error: " + err.Error())
+	}
+
+	opId := logOperation(ctx, "", "cancelSchedule", strconv.FormatInt(int64(id), 10))
+	defer func() {
+		if err != nil {
+			logOperationResult(ctx, opId, err.Error())
+		}
+	}()
+
+	seq, err := createRecoveryPoint(aid, ctx, ctx.curContract.callState.accState, scsState, zeroBig, false, false)
+	if err != nil {
+		return C.CString("[Contract.LuaCancelScheduledCall] database error: " + err.Error())
+	}
+	call, err := system.CancelScheduledCall(scsState.ctrState, types.EncodeAddress(ctx.curContract.contractId), uint64(id))
+	if err != nil {
+		if rErr := clearRecoveryPoint(L, ctx, seq, true); rErr != nil {
+			return C.CString("[Contract.LuaCancelScheduledCall] recovery error: " + rErr.Error())
+		}
+		return C.CString("[Contract.LuaCancelScheduledCall] error: " + err.Error())
+	}
+	if seq == 1 {
+		if err = clearRecoveryPoint(L, ctx, seq, false); err != nil {
+			return C.CString("[Contract.LuaCancelScheduledCall] recovery error: " + err.Error())
+		}
+	}
+
+	// refund the prepaid fee
+	amountBig := call.GetFee()
+	if err = state.SendBalance(scsState.accState, ctx.curContract.callState.accState, amountBig); err != nil {
+		return C.CString("[Contract.LuaCancelScheduledCall] error: " + err.Error())
+	}
+	if ctx.lastRecoveryPoint != nil {
+		_, _ = createRecoveryPoint(aid, ctx, scsState.accState, ctx.curContract.callState, amountBig, true, false)
+	}
+
+	return nil
+}
+
 //export luaViewStart
 func luaViewStart(service C.int) {
 	ctx := contexts[service]
@@ -1717,11 +2150,12 @@ func luaCheckTimeout(service C.int) C.int {
 		service = service + C.int(maxContext)
 	}
 
-	if service != BlockFactory {
+	ctx := contexts[service]
+	// the block factory stops at the timeout of the block generation, and a profiled tx at the deadline of the dry run
+	if service != BlockFactory && (ctx == nil || ctx.profiler == nil) {
 		return 0
 	}
 
-	ctx := contexts[service]
 	select {
 	case <-ctx.execCtx.Done():
 		return 1
@@ -1730,6 +2164,22 @@ func luaCheckTimeout(service C.int) C.int {
 	}
 }
 
+//export luaProfileLine
+func luaProfileLine(L *LState, service C.int, stack *C.char) {
+	if service < 0 || int(service) >= len(contexts) {
+		return
+	}
+	ctx := contexts[service]
+	if ctx == nil {
+		return
+	}
+	var stackStr string
+	if stack != nil {
+		stackStr = C.GoString(stack)
+	}
+	profileLine(ctx, stackStr)
+}
+
 //export luaIsFeeDelegation
 func luaIsFeeDelegation(L *LState, service C.int) (C.int, *C.char) {
 	ctx := contexts[service]
@@ -175,6 +175,13 @@ func (bc *DummyChain) GetBlockByNo(blockNo types.BlockNo) (*types.Block, error)
 	return bc.bestBlock, nil
 }
 
+func (bc *DummyChain) GetBlock(blockHash []byte) (*types.Block, error) {
+	if bytes.Equal(bc.bestBlock.BlockHash(), blockHash) {
+		return bc.bestBlock, nil
+	}
+	return nil, errors.New("block not found")
+}
+
 func (bc *DummyChain) SetTimestamp(value int64) {
 	bc.timestamp = value
 }
@@ -393,7 +400,7 @@ func executeTx(
 		err       error
 	)
 
-	if account, err = name.Resolve(bs, txBody.GetAccount(), isQuirkTx); err != nil {
+	if account, err = name.Resolve(bs, txBody.GetAccount(), isQuirkTx, bi.No); err != nil {
 		return err
 	}
 
@@ -461,7 +468,7 @@ func executeTx(
 		return err
 	}
 
-	if recipient, err = name.Resolve(bs, txBody.Recipient, isQuirkTx); err != nil {
+	if recipient, err = name.Resolve(bs, txBody.Recipient, isQuirkTx, bi.No); err != nil {
 		return err
 	}
 	var receiver *state.AccountState
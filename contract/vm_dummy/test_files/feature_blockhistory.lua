@@ -0,0 +1,28 @@
+function blockHash(n)
+  return system.getBlockHash(n)
+end
+
+function blockInfo(n)
+  local info = system.getBlockInfo(n)
+  if info == nil then
+    return nil
+  end
+  return info.hash, info.number, info.prev_hash
+end
+
+function outOfRange()
+  local n = system.getBlockheight()
+  return system.getBlockHash(n) == nil, system.getBlockHash(n - 257) == nil, system.getBlockInfo(-1) == nil
+end
+
+function checkPrevBlockHash()
+  assert(system.getBlockHash(system.getBlockheight() - 1) == system.getPrevBlockHash(), "different prev block hash")
+end
+
+function readBlocks(back)
+  local n = system.getBlockheight() - back
+  return system.getBlockHash(n) == system.getBlockHash(n)
+end
+
+abi.register(checkPrevBlockHash, readBlocks)
+abi.register_view(blockHash, blockInfo, outOfRange)
@@ -0,0 +1,70 @@
+function hashes()
+  return crypto.ripemd160("0x616263"), crypto.blake2b("0x616263"), #crypto.blake2b("abc", 64)
+end
+
+function ed25519()
+  return crypto.ed25519_verify("",
+    "0xe5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+    "0xd75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+end
+
+function p256()
+  return crypto.p256_verify(crypto.sha256("hello"),
+    "0x6bf0ce81d9af202d2b3b6cdb7f40aa5c5c3cfcd52cb498287a8ffca42eb49f14bfabad588c935ec339e53cf1f7ff80b9d7b252dfc7ae3a38849ad366cf22d82d",
+    "0x02fb50388f29498d0a93ad25ec4c34037b9d3cc3cca4787eb6fedabe2b3003eac8")
+end
+
+-- the test vectors of the BLS signature in the consensus spec tests of Ethereum
+local pubkeys = {
+  "0xa491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a",
+  "0xb301803f8b5ac4a1133581fc676dfedc60d891dd5fa99028805e5ea5b08d3491af75d0707adab3b70c6a6a580217bf81",
+  "0xb53d21a4cfd562c469cc81514d4ce5a6b577d8403d32a394dc265dd190b47fa9f829fdd7963afdf972e5e77854051f6f",
+}
+local msgs = {
+  "0x0000000000000000000000000000000000000000000000000000000000000000",
+  "0x5656565656565656565656565656565656565656565656565656565656565656",
+  "0xabababababababababababababababababababababababababababababababab",
+}
+
+function bls()
+  return crypto.bls_verify(msgs[1],
+    "0xb6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+    pubkeys[1]),
+    crypto.bls_aggregate_verify(msgs[3],
+    "0x9712c3edd73a209c742b8250759db12549b3eaf43b5ca61376d9f30e2747dbcf842d8b2ac0901d2a093713e20284a7670fcf6954e9ab93de991bb9b313e664785a075fc285806fa5224c82bde146561b446ccfc706a64b8579513cfc4ff1d930",
+    pubkeys),
+    crypto.bls_aggregate_verify(msgs,
+    "0x9104e74b9dfd3ad502f25d6a5ef57db0ed7d9a0e00f3500586d8ce44231212542fcfaf87840539b398bf07626705cf1105d246ca1062c6c2e1a53029a0f790ed5e3cb1f52f8234dc5144c45fc847c0cd37a92d68e7c5ba7c648a8a339f171244",
+    pubkeys),
+    crypto.bls_aggregate_verify({msgs[2], msgs[1], msgs[3]},
+    "0x9104e74b9dfd3ad502f25d6a5ef57db0ed7d9a0e00f3500586d8ce44231212542fcfaf87840539b398bf07626705cf1105d246ca1062c6c2e1a53029a0f790ed5e3cb1f52f8234dc5144c45fc847c0cd37a92d68e7c5ba7c648a8a339f171244",
+    pubkeys),
+    crypto.bls_pop_verify(pubkeys[1],
+    "0xb803eb0ed93ea10224a73b6b9c725796be9f5fefd215ef7a5b97234cc956cf6870db6127b7e4d824ec62276078e787db05584ce1adbf076bc0808ca0f15b73d59060254b25393d95dfc7abe3cda566842aaedf50bbb062aae1bbb6ef3b1f77e1")
+end
+
+-- verifies a message of n bytes, or an empty message of the same gas otherwise
+function ed25519Msg(n, long)
+  local msg = string.rep("a", n)
+  if long == 0 then
+    msg = ""
+  end
+  return crypto.ed25519_verify(msg,
+    "0xe5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+    "0xd75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+end
+
+-- verifies the signature of a message by all the public keys, or of the messages by each public key
+function blsMsgs(nMsgs)
+  if nMsgs == 1 then
+    return crypto.bls_aggregate_verify(msgs[3],
+      "0x9712c3edd73a209c742b8250759db12549b3eaf43b5ca61376d9f30e2747dbcf842d8b2ac0901d2a093713e20284a7670fcf6954e9ab93de991bb9b313e664785a075fc285806fa5224c82bde146561b446ccfc706a64b8579513cfc4ff1d930",
+      pubkeys)
+  end
+  return crypto.bls_aggregate_verify(msgs,
+    "0x9104e74b9dfd3ad502f25d6a5ef57db0ed7d9a0e00f3500586d8ce44231212542fcfaf87840539b398bf07626705cf1105d246ca1062c6c2e1a53029a0f790ed5e3cb1f52f8234dc5144c45fc847c0cd37a92d68e7c5ba7c648a8a339f171244",
+    pubkeys)
+end
+
+abi.register_view(hashes, ed25519, p256, bls)
+abi.register(ed25519Msg, blsMsgs)
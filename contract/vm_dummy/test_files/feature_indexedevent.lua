@@ -0,0 +1,18 @@
+function transfer(to, amount)
+  contract.indexedEvent("transfer", 2, system.getSender(), to, amount)
+end
+
+function tooMany()
+  contract.indexedEvent("many", 5, 1, 2, 3, 4, 5)
+end
+
+function notEnough()
+  contract.indexedEvent("few", 2, 1)
+end
+
+function supported()
+  return contract.indexedEvent ~= nil
+end
+
+abi.register(transfer, tooMany, notEnough)
+abi.register_view(supported)
@@ -0,0 +1,5 @@
+function functions()
+    return name_service.resolve ~= nil, name_service.reverse ~= nil
+end
+
+abi.register_view(functions)
@@ -0,0 +1,38 @@
+state.var {
+  counts = state.map()
+}
+
+local function add(key, n)
+  local count = counts[key] or 0
+  counts[key] = count + n
+  return counts[key]
+end
+
+function run(n)
+  local total = 0
+  for i = 1, n do
+    total = total + add("k" .. (i % 3), i)
+  end
+  return crypto.sha256(tostring(total))
+end
+
+function constructor()
+  db.exec("create table if not exists items(n integer)")
+end
+
+function insert(n)
+  local stmt = db.prepare("insert into items values (?)")
+  for i = 1, n do
+    stmt:exec(i)
+  end
+end
+
+function count()
+  local rs = db.query("select count(*) from items")
+  if rs:next() then
+    return rs:get()
+  end
+end
+
+abi.register(run, insert)
+abi.register_view(count)
@@ -0,0 +1,53 @@
+state.var {
+  counter = state.value()
+}
+
+function constructor()
+  counter:set(0)
+end
+
+function tick(n)
+  assert(system.getSender() == system.getContractID(), "not a scheduled call")
+  counter:set(counter:get() + n)
+  contract.event("tick", n)
+end
+
+function fail()
+  error("failed on purpose")
+end
+
+function scheduleAt(blockNo, n, fee)
+  return contract.schedule(blockNo, "tick", {n}, 0, fee)
+end
+
+function scheduleTime(ts, fee)
+  return contract.schedule({time = ts}, "tick", {1}, 0, fee)
+end
+
+function scheduleFail(blockNo, fee)
+  return contract.schedule({block = blockNo}, "fail", nil, 0, fee)
+end
+
+function scheduleAndRevert(blockNo, fee)
+  local ok = pcall(function()
+    contract.schedule(blockNo, "tick", {100}, 0, fee)
+    error("revert")
+  end)
+  return ok
+end
+
+function cancel(id)
+  contract.cancelSchedule(id)
+end
+
+function get()
+  return counter:get()
+end
+
+function supported()
+  return contract.schedule ~= nil
+end
+
+abi.payable(constructor)
+abi.register(tick, fail, scheduleAt, scheduleTime, scheduleFail, scheduleAndRevert, cancel)
+abi.register_view(get, supported)
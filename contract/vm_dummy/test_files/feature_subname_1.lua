@@ -0,0 +1,18 @@
+function call(name)
+    return contract.call(name, "echo", name)
+end
+
+function send(name)
+    contract.send(name, 1)
+end
+
+function balance(name)
+    return contract.balance(name)
+end
+
+function isContract(name)
+    return system.isContract(name)
+end
+
+abi.register(call, send)
+abi.register_view(balance, isContract)
@@ -0,0 +1,9 @@
+function echo(name)
+    return name
+end
+
+function default()
+end
+
+abi.register(echo)
+abi.payable(default)
@@ -0,0 +1,11 @@
+function transfer(to, amount, memo)
+    return true
+end
+
+function balances(owners)
+    return {}
+end
+
+abi.register(transfer, balances)
+abi.types(transfer, {"address", "bignum", "string?"}, {"boolean"})
+abi.types(balances, {"[address]"}, {"{total:bignum}"})
@@ -2,6 +2,7 @@ package vm_dummy
 
 // helper functions
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -18,6 +19,7 @@ import (
 	"github.com/aergoio/aergo/v2/cmd/aergoluac/util"
 	"github.com/aergoio/aergo/v2/config"
 	"github.com/aergoio/aergo/v2/contract"
+	"github.com/aergoio/aergo/v2/contract/name"
 	"github.com/aergoio/aergo/v2/contract/system"
 	"github.com/aergoio/aergo/v2/fee"
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
@@ -52,6 +54,7 @@ type DummyChain struct {
 	bestBlockId     types.BlockID
 	blockIds        []types.BlockID
 	blocks          []*types.Block
+	scheduled       string // the receipts of the scheduled calls executed in the last block
 	testReceiptDB   db.DB
 	tmpDir          string
 	timeout         int
@@ -225,6 +228,11 @@ func (bc *DummyChain) GetReceipt(txHash []byte) *types.Receipt {
 	return r
 }
 
+// ScheduledCallReceipts returns the receipts of the scheduled calls executed in the last block.
+func (bc *DummyChain) ScheduledCallReceipts() string {
+	return bc.scheduled
+}
+
 func (bc *DummyChain) GetAccountState(name string) (*types.State, error) {
 	return bc.sdb.GetStateDB().GetAccountState(types.ToAccountID(contract.StrHash(name)))
 }
@@ -245,6 +253,15 @@ func (bc *DummyChain) GetBestBlock() (*types.Block, error) {
 	return bc.bestBlock, nil
 }
 
+func (bc *DummyChain) GetBlock(blockHash []byte) (*types.Block, error) {
+	for i := len(bc.blocks) - 1; i >= 0; i-- {
+		if bytes.Equal(bc.blocks[i].BlockHash(), blockHash) {
+			return bc.blocks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("block not found: %s", base58.Encode(blockHash))
+}
+
 type LuaTxTester interface {
 	run(execCtx context.Context, bs *state.BlockState, bc *DummyChain, bi *types.BlockHeaderInfo, receiptTx db.Transaction) error
 	Hash() []byte
@@ -353,6 +370,75 @@ func (l *luaTxSend) run(execCtx context.Context, bs *state.BlockState, bc *Dummy
 	return nil
 }
 
+// luaTxName is a tx of the name service, which registers and updates the names.
+type luaTxName struct {
+	sender  []byte
+	amount  *big.Int
+	payload []byte
+	txId    uint64
+}
+
+var _ LuaTxTester = (*luaTxName)(nil)
+
+func NewLuaTxName(sender string, amount *big.Int, payload string) *luaTxName {
+	return &luaTxName{
+		sender:  contract.StrHash(sender),
+		amount:  amount,
+		payload: []byte(payload),
+		txId:    newTxId(),
+	}
+}
+
+func (l *luaTxName) Hash() []byte {
+	return hash(l.txId)
+}
+
+func (l *luaTxName) okMsg() string {
+	return "SUCCESS"
+}
+
+func (l *luaTxName) run(execCtx context.Context, bs *state.BlockState, bc *DummyChain, bi *types.BlockHeaderInfo, receiptTx db.Transaction) error {
+	sender, err := state.GetAccountState(l.sender, bs.StateDB)
+	if err != nil {
+		return err
+	}
+	receiver, err := state.GetAccountState([]byte(types.AergoName), bs.StateDB)
+	if err != nil {
+		return err
+	}
+	scs, err := statedb.OpenContractState(receiver.IDNoPadding(), receiver.State(), bs.StateDB)
+	if err != nil {
+		return err
+	}
+	txBody := &types.TxBody{
+		Account:   l.sender,
+		Recipient: []byte(types.AergoName),
+		Amount:    l.amount.Bytes(),
+		Payload:   l.payload,
+		Type:      types.TxType_GOVERNANCE,
+	}
+	if _, err = name.ExecuteNameTx(bs, scs, txBody, sender, receiver, bi); err != nil {
+		return err
+	}
+	if err = statedb.StageContractState(scs, bs.StateDB); err != nil {
+		return err
+	}
+	if err = sender.PutState(); err != nil {
+		return err
+	}
+	if err = receiver.PutState(); err != nil {
+		return err
+	}
+
+	r := types.NewReceipt([]byte(types.AergoName), l.okMsg(), "")
+	r.TxHash = l.Hash()
+	r.GasUsed = fee.TxGas(len(l.payload))
+	b, _ := r.MarshalBinaryTest()
+	receiptTx.Set(l.Hash(), b)
+
+	return nil
+}
+
 type luaTxContract interface {
 	LuaTxTester
 	sender() []byte
@@ -593,7 +679,7 @@ func (l *luaTxDeploy) run(execCtx context.Context, bs *state.BlockState, bc *Dum
 		// compile the plain code to bytecode
 		payload := util.LuaCodePayload(l._payload)
 		code := string(payload.Code())
-		byteCode, err := contract.Compile(code, nil)
+		byteCode, err := contract.Compile(code, nil, false)
 		if err != nil {
 			return err
 		}
@@ -710,14 +796,42 @@ func (l *luaTxCall) okMsg() string {
 }
 
 func (bc *DummyChain) ConnectBlock(txs ...LuaTxTester) error {
+	return bc.connectBlock(context.Background(), txs...)
+}
+
+// ConnectBlockWithProfiler connects a block of the txs, and attributes the gas consumed by the profiled tx to its
+// call stacks.
+func (bc *DummyChain) ConnectBlockWithProfiler(p *contract.Profiler, txs ...LuaTxTester) error {
+	return bc.connectBlock(contract.WithProfiler(context.Background(), p), txs...)
+}
+
+// ProfileTx executes the tx with the profiler as a tx of the next block, and discards its changes as the profileTx of
+// the chain service does. The sql databases are written and then rolled back.
+func (bc *DummyChain) ProfileTx(p *contract.Profiler, tx LuaTxTester) error {
+	blockState := bc.newBState()
+	receiptTx := bc.BeginReceiptTx()
+	defer receiptTx.Discard()
+	defer contract.CloseDatabase()
+
+	return tx.run(contract.WithProfiler(context.Background(), p), blockState, bc, types.NewBlockHeaderInfo(bc.cBlock), receiptTx)
+}
+
+func (bc *DummyChain) connectBlock(execCtx context.Context, txs ...LuaTxTester) error {
 	blockState := bc.newBState()
 	tx := bc.BeginReceiptTx()
 	defer tx.Commit()
 	defer contract.CloseDatabase()
 
 	//timeout := make(chan struct{})
-	blockContext, _ := context.WithTimeout(context.Background(), time.Duration(bc.timeout)*time.Millisecond)
+	blockContext, _ := context.WithTimeout(execCtx, time.Duration(bc.timeout)*time.Millisecond)
 	//contract.SetBPTimeout(timeout)
+	if _, err := contract.ActivateParams(execCtx, blockState, types.NewBlockHeaderInfo(bc.cBlock)); err != nil {
+		return err
+	}
+	if _, err := contract.ExecuteScheduledCalls(blockContext, blockState, bc, types.NewBlockHeaderInfo(bc.cBlock), contract.BlockFactory); err != nil {
+		return err
+	}
+	bc.scheduled = blockState.ScheduledCallReceipts()
 	for _, x := range txs {
 		if err := x.run(blockContext, blockState, bc, types.NewBlockHeaderInfo(bc.cBlock), tx); err != nil {
 			return err
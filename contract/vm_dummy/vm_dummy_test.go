@@ -14,6 +14,9 @@ import (
 	"testing"
 
 	"github.com/aergoio/aergo/v2/contract"
+	"github.com/aergoio/aergo/v2/contract/system"
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
+	"github.com/aergoio/aergo/v2/state/statedb"
 	"github.com/aergoio/aergo/v2/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -891,6 +894,39 @@ func TestGetABI(t *testing.T) {
 	}
 }
 
+func TestTypedABI(t *testing.T) {
+	code := readLuaCode(t, "typed_abi.lua")
+
+	// abi.types is not available before the hardfork version 6
+	bc, err := LoadDummyChain(SetHardForkVersion(5))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "typed", 0, code))
+	require.Errorf(t, err, "expected error on abi.types")
+
+	bc, err = LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "typed", 0, code))
+	require.NoErrorf(t, err, "failed to connect new block")
+
+	abi, err := bc.GetABI("typed")
+	require.NoErrorf(t, err, "failed to get abi")
+	require.Equal(t, types.ABIVersionTyped, abi.Version)
+	transfer := abi.GetFunction("transfer")
+	require.NotNil(t, transfer)
+	jsonFn, err := json.Marshal(transfer)
+	require.NoErrorf(t, err, "failed to marshal function")
+	require.Equalf(t, `{"name":"transfer","arguments":[{"name":"to","type":"address"},{"name":"amount","type":"bignum"},{"name":"memo","type":"string?"}],"returns":["boolean"]}`, string(jsonFn), "not equal function")
+	require.Equal(t, []string{"{total:bignum}"}, abi.GetFunction("balances").GetReturns())
+
+	require.NoError(t, abi.CheckCall(&types.CallInfo{Name: "transfer", Args: []interface{}{"AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2", map[string]interface{}{"_bignum": "10"}}}))
+	require.Error(t, abi.CheckCall(&types.CallInfo{Name: "transfer", Args: []interface{}{"AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2", 10}}))
+
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "typed", 0, `{"Name":"transfer","Args":["AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2",{"_bignum":"10"}]}`))
+	require.NoErrorf(t, err, "failed to call the typed function")
+}
+
 func TestPayable(t *testing.T) {
 	code := readLuaCode(t, "payable.lua")
 
@@ -4536,6 +4572,159 @@ func TestFeatureLuaCryptoVerifyProof(t *testing.T) {
 	}
 }
 
+func TestFeatureLuaCryptoV6(t *testing.T) {
+	code := readLuaCode(t, "feature_crypto_v6.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(5))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "crypto", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	err = bc.Query("crypto", `{"Name":"ed25519"}`, "attempt to call field 'ed25519_verify'", "")
+	require.NoErrorf(t, err, "failed to query")
+
+	bc, err = LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "crypto", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+
+	err = bc.Query("crypto", `{"Name":"hashes"}`, "", `["0x8eb208f7e05d987a9b044a8e98c6b087f15a0bfc","0xbddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319",64]`)
+	require.NoErrorf(t, err, "failed to query")
+	err = bc.Query("crypto", `{"Name":"ed25519"}`, "", `true`)
+	require.NoErrorf(t, err, "failed to query")
+	err = bc.Query("crypto", `{"Name":"p256"}`, "", `true`)
+	require.NoErrorf(t, err, "failed to query")
+	err = bc.Query("crypto", `{"Name":"bls"}`, "", `[true,true,true,false,true]`)
+	require.NoErrorf(t, err, "failed to query")
+
+	gasUsed := func(payload string) int64 {
+		tx := NewLuaTxCall("user1", "crypto", 0, payload)
+		require.NoErrorf(t, bc.ConnectBlock(tx), "failed to call %s", payload)
+		return int64(bc.GetReceipt(tx.Hash()).GetGasUsed())
+	}
+	// the gas of ed25519 grows by 10 per 32 bytes of the message
+	diff := gasUsed(`{"Name":"ed25519Msg","Args":[3200,1]}`) - gasUsed(`{"Name":"ed25519Msg","Args":[3200,0]}`)
+	assert.GreaterOrEqual(t, diff, int64(1000))
+	assert.Less(t, diff, int64(1100))
+	// each message of bls is hashed to the curve for 5000
+	diff = gasUsed(`{"Name":"blsMsgs","Args":[3]}`) - gasUsed(`{"Name":"blsMsgs","Args":[1]}`)
+	assert.GreaterOrEqual(t, diff, int64(2*5000))
+}
+
+func TestFeatureNameServiceV6(t *testing.T) {
+	code := readLuaCode(t, "feature_name_v6.lua")
+
+	for _, tt := range []struct {
+		version int32
+		expect  string
+	}{
+		{5, `[true,false]`},
+		{6, `[true,true]`},
+	} {
+		bc, err := LoadDummyChain(SetHardForkVersion(tt.version))
+		require.NoErrorf(t, err, "failed to create dummy chain")
+		defer bc.Release()
+		err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "name", 0, code))
+		require.NoErrorf(t, err, "failed to deploy")
+		err = bc.Query("name", `{"Name":"functions"}`, "", tt.expect)
+		require.NoErrorf(t, err, "failed to query on version %d", tt.version)
+	}
+}
+
+func TestFeatureSubName(t *testing.T) {
+	code := readLuaCode(t, "feature_subname_1.lua")
+	code2 := readLuaCode(t, "feature_subname_2.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("user1", 10, types.Aergo),
+		NewLuaTxDeploy("user1", "caller", 0, code),
+		NewLuaTxDeploy("user1", "callee", 0, code2),
+		NewLuaTxSendBig("user1", "caller", types.NewAmount(1, types.Aergo)),
+	)
+	require.NoErrorf(t, err, "failed to deploy")
+
+	subName := "pay.AB1234567890"
+	err = bc.ConnectBlock(
+		NewLuaTxName("user1", types.NewAmount(1, types.Aergo), `{"Name":"v1createName","Args":["AB1234567890"]}`),
+		NewLuaTxName("user1", big.NewInt(0), fmt.Sprintf(`{"Name":"v1createName","Args":["%s"]}`, subName)),
+		NewLuaTxName("user1", types.NewAmount(1, types.Aergo),
+			fmt.Sprintf(`{"Name":"v1updateName","Args":["%s","%s"]}`, subName, nameToAddress("callee"))),
+	)
+	require.NoErrorf(t, err, "failed to register the subname")
+
+	// the subname is resolved by the calls of a contract as a name is
+	tx := NewLuaTxCall("user1", "caller", 0, fmt.Sprintf(`{"Name":"call","Args":["%s"]}`, subName))
+	err = bc.ConnectBlock(tx)
+	require.NoErrorf(t, err, "failed to call by the subname")
+	assert.Equal(t, fmt.Sprintf(`"%s"`, subName), bc.GetReceipt(tx.Hash()).GetRet())
+
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "caller", 0, fmt.Sprintf(`{"Name":"send","Args":["%s"]}`, subName)))
+	require.NoErrorf(t, err, "failed to send to the subname")
+
+	err = bc.Query("caller", fmt.Sprintf(`{"Name":"balance","Args":["%s"]}`, subName), "", `"1"`)
+	require.NoErrorf(t, err, "failed to query")
+	err = bc.Query("caller", fmt.Sprintf(`{"Name":"isContract","Args":["%s"]}`, subName), "", `true`)
+	require.NoErrorf(t, err, "failed to query")
+}
+
+func TestFeatureBlockHistory(t *testing.T) {
+	code := readLuaCode(t, "feature_blockhistory.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(5))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "history", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	err = bc.Query("history", `{"Name":"blockHash","Args":[0]}`, "attempt to call field 'getBlockHash'", "")
+	require.NoErrorf(t, err, "failed to query")
+
+	bc, err = LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "history", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	for i := 0; i < 3; i++ {
+		err = bc.ConnectBlock(NewLuaTxCall("user1", "history", 0, `{"Name":"checkPrevBlockHash"}`))
+		require.NoErrorf(t, err, "failed to call")
+	}
+
+	// the query runs on the best block, so the blocks before it are accessible
+	for no := 0; no < len(bc.blocks)-1; no++ {
+		hash := base58.Encode(bc.blocks[no].BlockHash())
+		err = bc.Query("history", fmt.Sprintf(`{"Name":"blockHash","Args":[%d]}`, no), "", fmt.Sprintf(`"%s"`, hash))
+		require.NoErrorf(t, err, "failed to query")
+		err = bc.Query("history", fmt.Sprintf(`{"Name":"blockInfo","Args":[%d]}`, no), "",
+			fmt.Sprintf(`["%s",%d,"%s"]`, hash, no, base58.Encode(bc.blocks[no].GetHeader().GetPrevBlockHash())))
+		require.NoErrorf(t, err, "failed to query")
+	}
+	err = bc.Query("history", `{"Name":"outOfRange"}`, "", `[true,true,true]`)
+	require.NoErrorf(t, err, "failed to query")
+
+	// the gas is charged per block read to reach the block, once in a tx
+	bc, err = LoadDummyChain(SetPubNet(), SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccountBig("user1", types.NewAmount(100, types.Aergo)), NewLuaTxDeploy("user1", "history", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	for i := 0; i < 3; i++ {
+		err = bc.ConnectBlock()
+		require.NoErrorf(t, err, "failed to connect")
+	}
+	gasUsed := func(back int) uint64 {
+		tx := NewLuaTxCall("user1", "history", 0, fmt.Sprintf(`{"Name":"readBlocks","Args":[%d]}`, back))
+		err := bc.ConnectBlock(tx)
+		require.NoErrorf(t, err, "failed to call")
+		return bc.GetReceipt(tx.Hash()).GasUsed
+	}
+	near, far := gasUsed(1), gasUsed(3)
+	assert.Equal(t, near+2*100, far)
+}
+
 func TestFeatureFeeDelegation(t *testing.T) {
 	code := readLuaCode(t, "feature_feedelegation_1.lua")
 	code2 := readLuaCode(t, "feature_feedelegation_2.lua")
@@ -6287,3 +6476,245 @@ func readLuaCode(t *testing.T, file string) (luaCode string) {
 func nameToAddress(name string) (address string) {
 	return types.EncodeAddress(contract.StrHash(name))
 }
+
+func TestFeatureSchedule(t *testing.T) {
+	code := readLuaCode(t, "feature_schedule.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(5))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "sched", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	err = bc.Query("sched", `{"Name":"supported"}`, "", "false")
+	require.NoErrorf(t, err, "failed to query")
+
+	bc, err = LoadDummyChain(SetPubNet(), SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	bc.SetTimestamp(false, 1000)
+	err = bc.ConnectBlock(
+		NewLuaTxAccountBig("user1", types.NewAmount(100, types.Aergo)),
+		NewLuaTxDeploy("user1", "sched", uint64(types.Aergo), code),
+	)
+	require.NoErrorf(t, err, "failed to deploy")
+
+	balance := func() *big.Int {
+		s, err := bc.GetAccountState("sched")
+		require.NoErrorf(t, err, "failed to get account state")
+		return s.GetBalanceBigInt()
+	}
+	const prepaid = "100000000000000000" // the minimum, 0.1 aergo
+	initial := balance()
+
+	// the prepaid fee is escrowed until the call is executed
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,5,"%s"]}`, bc.BestBlockNo()+3, prepaid)))
+	require.NoErrorf(t, err, "failed to call")
+	require.Equal(t, new(big.Int).Sub(initial, system.MinScheduledCallFee), balance())
+
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	require.Empty(t, bc.ScheduledCallReceipts())
+	err = bc.Query("sched", `{"Name":"get"}`, "", "0")
+	require.NoErrorf(t, err, "failed to query")
+
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	receipts := bc.ScheduledCallReceipts()
+	require.Contains(t, receipts, `"status":"SUCCESS"`)
+	require.Contains(t, receipts, `"name":"tick"`)
+	err = bc.Query("sched", `{"Name":"get"}`, "", "5")
+	require.NoErrorf(t, err, "failed to query")
+	// only the fee used is paid
+	after := balance()
+	require.True(t, after.Cmp(initial) < 0)
+	require.True(t, after.Cmp(new(big.Int).Sub(initial, system.MinScheduledCallFee)) > 0)
+
+	// a canceled call is refunded
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,1,"%s"]}`, bc.BestBlockNo()+10, prepaid)))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, `{"Name":"cancel","Args":[2]}`))
+	require.NoErrorf(t, err, "failed to call")
+	require.Equal(t, after, balance())
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, `{"Name":"cancel","Args":[2]}`).Fail("scheduled call not found"))
+	require.NoErrorf(t, err, "failed to call")
+
+	// a failed call is recorded in its receipt
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleFail","Args":[%d,"%s"]}`, bc.BestBlockNo()+2, prepaid)))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	receipts = bc.ScheduledCallReceipts()
+	require.Contains(t, receipts, `"status":"ERROR"`)
+	require.Contains(t, receipts, "failed on purpose")
+
+	// a call scheduled inside a reverted pcall is discarded
+	after = balance()
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAndRevert","Args":[%d,"%s"]}`, bc.BestBlockNo()+2, prepaid)))
+	require.NoErrorf(t, err, "failed to call")
+	require.Equal(t, after, balance())
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	require.Empty(t, bc.ScheduledCallReceipts())
+
+	// time based schedule
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleTime","Args":[1100,"%s"]}`, prepaid)))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	require.Empty(t, bc.ScheduledCallReceipts())
+	bc.SetTimestamp(false, 1100)
+	err = bc.ConnectBlock()
+	require.NoErrorf(t, err, "failed to connect")
+	require.Contains(t, bc.ScheduledCallReceipts(), `"status":"SUCCESS"`)
+	err = bc.Query("sched", `{"Name":"get"}`, "", "6")
+	require.NoErrorf(t, err, "failed to query")
+
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,1,"%s"]}`, bc.BestBlockNo(), prepaid)).Fail("schedule time has passed"))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.Query("sched", fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,1,"%s"]}`, bc.BestBlockNo()+5, prepaid), "not permitted in query", "")
+	require.NoErrorf(t, err, "failed to query")
+
+	// the cheap calls scheduled far ahead cannot hold the slots of the queue
+	err = bc.ConnectBlock(
+		NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,1,"10000000"]}`, bc.BestBlockNo()+5)).Fail(system.ErrScheduledCallFee.Error()),
+		NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleAt","Args":[%d,1,"%s"]}`, bc.BestBlockNo()+2+system.MaxScheduleBlocks, prepaid)).Fail(system.ErrScheduledCallTooFar.Error()),
+		NewLuaTxCall("user1", "sched", 0, fmt.Sprintf(`{"Name":"scheduleTime","Args":[%d,"%s"]}`, 1101+system.MaxScheduleSeconds, prepaid)).Fail(system.ErrScheduledCallTooFar.Error()),
+	)
+	require.NoErrorf(t, err, "failed to call")
+}
+
+func TestFeatureIndexedEvent(t *testing.T) {
+	code := readLuaCode(t, "feature_indexedevent.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(5))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "event", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+	err = bc.Query("event", `{"Name":"supported"}`, "", "false")
+	require.NoErrorf(t, err, "failed to query")
+
+	bc, err = LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "event", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+
+	tx := NewLuaTxCall("user1", "event", 0, `{"Name":"transfer","Args":["bob",100]}`)
+	err = bc.ConnectBlock(tx)
+	require.NoErrorf(t, err, "failed to call")
+	events := bc.GetEvents(tx.Hash())
+	require.Len(t, events, 1)
+	sender, err := types.EventTopic([]byte(fmt.Sprintf(`"%s"`, types.EncodeAddress(contract.StrHash("user1")))))
+	require.NoError(t, err)
+	to, err := types.EventTopic([]byte(`"bob"`))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{sender, to}, events[0].Topics)
+	assert.Equal(t, fmt.Sprintf(`["%s","bob",100]`, types.EncodeAddress(contract.StrHash("user1"))), events[0].JsonArgs)
+	assert.True(t, events[0].Filter(&types.FilterInfo{Topics: [][]byte{nil, to}}, nil))
+
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "event", 0, `{"Name":"tooMany"}`).Fail("exceeded the maximum number of indexed args"))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "event", 0, `{"Name":"notEnough"}`).Fail("invalid number of indexed args"))
+	require.NoErrorf(t, err, "failed to call")
+}
+
+func TestContractSourceVerify(t *testing.T) {
+	code := readLuaCode(t, "feature_indexedevent.lua")
+	typedCode := readLuaCode(t, "typed_abi.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(6))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(
+		NewLuaTxAccount("user1", 1, types.Aergo),
+		NewLuaTxDeploy("user1", "plain", 0, code),
+		NewLuaTxDeploy("user1", "typed", 0, typedCode),
+	)
+	require.NoErrorf(t, err, "failed to deploy")
+
+	ctrState, err := statedb.OpenContractStateAccount(contract.StrHash("plain"), bc.sdb.GetStateDB())
+	require.NoError(t, err)
+	typedABI, err := contract.VerifySource(ctrState, code)
+	require.NoError(t, err)
+	assert.False(t, typedABI)
+	_, err = contract.VerifySource(ctrState, code+"\nfunction extra() end\n")
+	assert.Equal(t, contract.ErrSourceMismatch, err)
+	_, err = contract.VerifySource(ctrState, typedCode)
+	assert.Equal(t, contract.ErrSourceMismatch, err)
+
+	// the source cannot run forever while generating its ABI, nor be larger than a transaction
+	_, err = contract.VerifySource(ctrState, "while true do end\n"+code)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeded the maximum instruction count")
+	_, err = contract.VerifySource(ctrState, code+strings.Repeat(" ", types.TxMaxSize))
+	assert.Equal(t, contract.ErrSourceTooLarge, err)
+
+	// the source using abi.types only matches with the typed ABI
+	ctrState, err = statedb.OpenContractStateAccount(contract.StrHash("typed"), bc.sdb.GetStateDB())
+	require.NoError(t, err)
+	typedABI, err = contract.VerifySource(ctrState, typedCode)
+	require.NoError(t, err)
+	assert.True(t, typedABI)
+
+	ctrState, err = statedb.OpenContractStateAccount(contract.StrHash("user1"), bc.sdb.GetStateDB())
+	require.NoError(t, err)
+	_, err = contract.VerifySource(ctrState, code)
+	assert.Equal(t, contract.ErrNotContract, err)
+}
+
+func TestProfiler(t *testing.T) {
+	code := readLuaCode(t, "feature_profile.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(4))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "profile", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+
+	tx := NewLuaTxCall("user1", "profile", 0, `{"Name":"run","Args":[10]}`)
+	profiler := contract.NewProfiler(tx.Hash())
+	err = bc.ConnectBlockWithProfiler(profiler, tx)
+	require.NoErrorf(t, err, "failed to call")
+
+	address := types.EncodeAddress(contract.StrHash("profile"))
+	var total uint64
+	for _, line := range strings.Split(strings.TrimSpace(profiler.Folded()), "\n") {
+		i := strings.LastIndex(line, " ")
+		require.Greater(t, i, 0, line)
+		assert.True(t, strings.HasPrefix(line, address+";run"), line)
+		gas, err := strconv.ParseUint(line[i+1:], 10, 64)
+		require.NoError(t, err)
+		total += gas
+	}
+	assert.Contains(t, profiler.Folded(), ";add:7;state.set ")
+	assert.Contains(t, profiler.Folded(), ";add:6;state.get ")
+	assert.Contains(t, profiler.FoldedStorage(), ";add:7 ")
+	receipt := bc.GetReceipt(tx.Hash())
+	assert.Greater(t, total, uint64(0))
+	assert.LessOrEqual(t, total, receipt.GasUsed)
+}
+
+func TestProfilerSql(t *testing.T) {
+	code := readLuaCode(t, "feature_profile.lua")
+
+	bc, err := LoadDummyChain(SetHardForkVersion(4))
+	require.NoErrorf(t, err, "failed to create dummy chain")
+	defer bc.Release()
+	err = bc.ConnectBlock(NewLuaTxAccount("user1", 1, types.Aergo), NewLuaTxDeploy("user1", "profile", 0, code))
+	require.NoErrorf(t, err, "failed to deploy")
+
+	// the rows are inserted while profiling, and rolled back after it
+	tx := NewLuaTxCall("user1", "profile", 0, `{"Name":"insert","Args":[3]}`)
+	profiler := contract.NewProfiler(tx.Hash())
+	err = bc.ProfileTx(profiler, tx)
+	require.NoErrorf(t, err, "failed to profile")
+	assert.Contains(t, profiler.Folded(), ";insert:")
+	err = bc.Query("profile", `{"Name":"count"}`, "", "0")
+	require.NoErrorf(t, err, "failed to query")
+
+	err = bc.ConnectBlock(NewLuaTxCall("user1", "profile", 0, `{"Name":"insert","Args":[3]}`))
+	require.NoErrorf(t, err, "failed to call")
+	err = bc.Query("profile", `{"Name":"count"}`, "", "3")
+	require.NoErrorf(t, err, "failed to query")
+}
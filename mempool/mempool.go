@@ -193,13 +193,26 @@ func (mp *MemPool) monitor() {
 	}
 
 }
+// fadeoutPeriod returns the period for which the transactions stay in the
+// mempool. The period voted as a system param overrides the node config, unless
+// the fadeout is disabled.
+func fadeoutPeriod() time.Duration {
+	if evictPeriod == 0 {
+		return 0
+	}
+	if hours := system.GetMempoolFadeout(); hours != 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return evictPeriod
+}
+
 func (mp *MemPool) evictTransactions() {
 	//startTime := time.Now()
 	//expireTimer := time.NewTimer(evictWorkTimeout)
 	mp.Lock()
 	defer mp.Unlock()
 
-	eTime := time.Now().Add(-1 * evictPeriod)
+	eTime := time.Now().Add(-1 * fadeoutPeriod())
 	workTO := time.NewTimer(evictWorkTimeout)
 	total := 0
 L:
@@ -556,6 +569,10 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 		check++
 	}
 
+	if mp.nextBlockVersion() >= 6 {
+		mp.removeExpired(mp.bestBlockInfo.No + 1)
+	}
+
 	ag[1] = time.Since(start)
 	mp.Debug().Int("given", len(block.GetBody().GetTxs())).
 		Int("check", check).
@@ -565,22 +582,66 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 	return nil
 }
 
+// removeExpired evicts the transactions whose validity window is over before the block of blockNo.
+// Unlike the ones filtered by state, they can never be included in a block, even after reorg.
+func (mp *MemPool) removeExpired(blockNo types.BlockNo) {
+	total := 0
+	for _, list := range mp.pool {
+		diff, delTxs := list.FilterByExpiry(blockNo)
+		if len(delTxs) == 0 {
+			continue
+		}
+		mp.orphan -= diff
+		for _, tx := range delTxs {
+			mp.cache.Delete(types.ToTxID(tx.GetHash()))
+			mp.length--
+		}
+		mp.Trace().Array("txs", types.LogTrsactions{TXs: delTxs, Limit: 5}).Msg("transactions were evicted by expiry")
+		total += len(delTxs)
+		mp.releaseMemPoolList(list)
+	}
+	if total > 0 {
+		mp.Info().Int("num", total).Uint64("blockNo", blockNo).Msg("evict expired transactions")
+	}
+}
+
 // signature verification
 func (mp *MemPool) verifyTx(tx types.Transaction) error {
 	err := tx.Validate(mp.acceptChainIdHash, mp.isPublic)
 	if err != nil {
 		return err
 	}
-	if !tx.GetTx().NeedNameVerify() {
-		err = key.VerifyTx(tx.GetTx())
+	if tx.GetTx().HasMultisigAccount() {
+		mp.RLock()
+		multisig, err := mp.getMultisig(tx.GetBody().GetAccount())
+		mp.RUnlock()
+		if err != nil {
+			return err
+		}
+		err = key.VerifyMultisigTx(tx.GetTx(), multisig)
+		if err != nil {
+			return err
+		}
+	} else if !tx.GetTx().NeedNameVerify() {
+		mp.RLock()
+		signingKey, err := mp.getSigningKey(tx.GetBody().GetAccount())
+		mp.RUnlock()
+		if err != nil {
+			return err
+		}
+		err = key.VerifyTxWithAddress(tx.GetTx(), signingKey)
 		if err != nil {
 			return err
 		}
 	} else {
 		mp.RLock()
 		account := mp.getAddress(tx.GetBody().GetAccount())
+		signingKey, err := mp.getSigningKey(account)
 		mp.RUnlock()
-		err = key.VerifyTxWithAddress(tx.GetTx(), account)
+		if err != nil {
+			return err
+		}
+		err = key.VerifyTxWithAddress(tx.GetTx(), signingKey)
 		if err != nil {
 			return err
 		}
@@ -591,6 +652,28 @@ func (mp *MemPool) verifyTx(tx types.Transaction) error {
 	return nil
 }
 
+func (mp *MemPool) getMultisig(account []byte) (*types.MultisigAccount, error) {
+	if mp.testConfig {
+		return nil, types.ErrMultisigNotFound
+	}
+
+	scs, err := statedb.GetSystemAccountState(mp.stateDB)
+	if err != nil {
+		mp.Error().Str("for multisig", types.EncodeAddress(account)).Msgf("failed to open contract %s", types.AergoSystem)
+		return nil, err
+	}
+	return system.GetMultisig(scs, account)
+}
+
+// getSigningKey returns the key which signs the txs of the address, which may be bound by key rotation.
+func (mp *MemPool) getSigningKey(address []byte) ([]byte, error) {
+	state, err := mp.getAccountState(address)
+	if err != nil {
+		return nil, err
+	}
+	return state.SigningKeyOf(address), nil
+}
+
 func (mp *MemPool) getAddress(account []byte) []byte {
 	return mp.getNameDest(account, false)
 }
@@ -616,7 +699,7 @@ func (mp *MemPool) getNameDest(account []byte, owner bool) []byte {
 	if owner {
 		return name.GetOwner(scs, account)
 	}
-	return name.GetAddress(scs, account)
+	return name.GetActiveAddress(scs, account, mp.bestBlockInfo.No+1)
 }
 
 func (mp *MemPool) nextBlockVersion() int32 {
@@ -635,6 +718,9 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 	if blacklist.Check(types.EncodeAddress(account)) {
 		return types.ErrTxNotAllowedAccount
 	}
+	if err := tx.GetTx().ValidateWithBlockNo(mp.bestBlockInfo.No+1, mp.nextBlockVersion()); err != nil {
+		return err
+	}
 	ns, err := mp.getAccountState(account)
 	if err != nil {
 		return err
@@ -713,7 +799,11 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 			if err != nil {
 				return err
 			}
-			if _, err := name.ValidateNameTx(tx.GetBody(), sender, scs); err != nil {
+			nextBlockInfo := types.BlockHeaderInfo{
+				No:          mp.bestBlockInfo.No + 1,
+				ForkVersion: mp.nextBlockVersion(),
+			}
+			if _, err := name.ValidateNameTx(tx.GetBody(), sender, scs, &nextBlockInfo); err != nil {
 				return err
 			}
 		case types.AergoEnterprise:
@@ -725,7 +815,11 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 			if err != nil {
 				return err
 			}
-			if _, err := enterprise.ValidateEnterpriseTx(tx.GetBody(), sender, enterprisecs, mp.bestBlockInfo.No+1); err != nil {
+			nextBlockInfo := types.BlockHeaderInfo{
+				No:          mp.bestBlockInfo.No + 1,
+				ForkVersion: mp.nextBlockVersion(),
+			}
+			if _, err := enterprise.ValidateEnterpriseTx(tx.GetBody(), sender, enterprisecs, &nextBlockInfo); err != nil {
 				return err
 			}
 		}
@@ -1045,10 +1139,11 @@ func (mp *MemPool) getUnconfirmed(accounts []types.Address, countOnly bool) []*u
 
 	getTxList := func(acc types.Address) (*txList, *time.Time) {
 		eTime := func(tl *txList) *time.Time {
-			if evictPeriod == 0 {
+			period := fadeoutPeriod()
+			if period == 0 {
 				return nil
 			}
-			t := tl.GetLastModifiedTime().Add(evictPeriod)
+			t := tl.GetLastModifiedTime().Add(period)
 			return &t
 		}
 		if tl, err := mp.acquireMemPoolList([]byte(acc)); err == nil {
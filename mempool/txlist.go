@@ -166,6 +166,33 @@ func (tl *txList) FilterByState(st *types.State) (int, []types.Transaction) {
 	return oldCnt - newCnt, removed
 }
 
+// FilterByExpiry removes the transactions which can't be included in the block of blockNo or after it,
+// and returns the number of changed orphan and the removed transactions
+func (tl *txList) FilterByExpiry(blockNo types.BlockNo) (int, []types.Transaction) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	oldCnt := len(tl.list) - tl.ready
+	var left, removed []types.Transaction
+	for _, x := range tl.list {
+		if x.GetBody().IsExpired(blockNo) {
+			removed = append(removed, x)
+		} else {
+			left = append(left, x)
+		}
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	tl.list = left
+	tl.updateReady()
+	newCnt := len(tl.list) - tl.ready
+
+	tl.lastTime = time.Now()
+	return oldCnt - newCnt, removed
+}
+
 func (tl *txList) updateReady() {
 	tl.ready = 0
 	for i := 0; i < len(tl.list); i++ {
@@ -246,3 +246,37 @@ func TestListRemoveTx(t *testing.T) {
 	assert.Equal(t, nine.GetTx().GetHash(), tx.GetHash(), "removed tx")
 	mpl.Put(six)
 }
+
+func TestListFilterByExpiry(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	mpl := newTxList(nil, NewState(3, 0), dummyMempool)
+
+	var txs []types.Transaction
+	for _, until := range []uint64{0, 20, 10, 0} {
+		tx := genTx(0, 0, uint64(4+len(txs)), 0)
+		tx.GetBody().ValidUntilBlock = until
+		tx.GetTx().Hash = tx.CalculateTxHash()
+		mpl.Put(tx)
+		txs = append(txs, tx)
+	}
+	assert.Equal(t, 4, mpl.Len(), "ready count")
+
+	changedOrphan, removed := mpl.FilterByExpiry(10)
+	assert.Equal(t, 0, changedOrphan, "nothing expired")
+	assert.Empty(t, removed)
+
+	// the tx of nonce 6 is expired, and the tx of nonce 7 becomes an orphan
+	changedOrphan, removed = mpl.FilterByExpiry(11)
+	assert.Equal(t, -1, changedOrphan, "new orphan count")
+	if assert.Len(t, removed, 1) {
+		assert.Equal(t, txs[2].GetHash(), removed[0].GetHash(), "removed tx")
+	}
+	assert.Equal(t, 3, mpl.allLen(), "list length")
+	assert.Equal(t, 2, mpl.Len(), "ready count")
+
+	changedOrphan, removed = mpl.FilterByExpiry(21)
+	assert.Equal(t, 0, changedOrphan, "new orphan count")
+	assert.Len(t, removed, 1)
+	assert.Equal(t, 1, mpl.Len(), "ready count")
+}
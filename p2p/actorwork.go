@@ -199,20 +199,12 @@ func (p2ps *P2P) NotifyNewTX(msg *message.NotifyNewTransactions) bool {
 	for i, tx := range msg.Txs {
 		hashes[i] = types.ToTxID(tx.Hash)
 	}
-	// create message data
-	skipped, sent := 0, 0
-	// send to peers
-	peers := p2ps.pm.GetPeers()
 	p2ps.sm.RegisterTxNotice(msg.Txs)
-	for _, rPeer := range peers {
-		if rPeer != nil && rPeer.State() == types.RUNNING {
-			sent++
-			rPeer.PushTxsNotice(hashes)
-		} else {
-			skipped++
-		}
+	// send to a part of peers, and the others will get notice relayed by receivers.
+	receivers := selectTxNoticeReceivers(p2ps.pm.GetPeers(), hashes)
+	for _, rPeer := range receivers {
+		rPeer.PushTxsNotice(hashes)
 	}
-	//p2ps.Debug().Int("skippeer_cnt", skipped).Int("sendpeer_cnt", sent).Int("hash_cnt", len(hashes)).Msg("Notifying newTXs to peers")
 
 	return true
 }
@@ -0,0 +1,361 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package addrbook
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+const (
+	addrBookFile = "addrbook.json"
+
+	// MaxEntries is the maximum number of entries. the entries of lowest priorities are dropped if exceeded.
+	MaxEntries = 2000
+	// MaxIdleTime is time after which the entry is removed if peer was not seen since.
+	MaxIdleTime = time.Hour * 24 * 14
+	// MaxConsecutiveFailures is the count of failures in a row after which the entry is removed.
+	MaxConsecutiveFailures = 10
+	// MaxCandidatesPerGroup is maximum count of candidates in a same network group in a single Candidates call.
+	MaxCandidatesPerGroup = 2
+)
+
+type entry struct {
+	PeerID    string   `json:"peerID"`
+	Addresses []string `json:"addresses"`
+	Role      string   `json:"role,omitempty"`
+	Version   string   `json:"version,omitempty"`
+
+	Added       time.Time `json:"added"`
+	LastSeen    time.Time `json:"lastSeen,omitempty"`
+	LastAttempt time.Time `json:"lastAttempt,omitempty"`
+	Success     int       `json:"success"`
+	Failure     int       `json:"failure"`
+	// ConsecutiveFailure is reset when the peer is connected.
+	ConsecutiveFailure int `json:"consecutiveFailure"`
+
+	id    types.PeerID
+	addrs []types.Multiaddr
+}
+
+// lastActive is the last time when the peer was known to be alive
+func (e *entry) lastActive() time.Time {
+	if e.LastSeen.After(e.Added) {
+		return e.LastSeen
+	}
+	return e.Added
+}
+
+func (e *entry) meta() p2pcommon.PeerMeta {
+	return p2pcommon.PeerMeta{ID: e.id, Addresses: e.addrs, Role: types.PeerRole(types.PeerRole_value[e.Role]), Version: e.Version}
+}
+
+func (e *entry) setAddresses(addrs []types.Multiaddr) {
+	e.addrs = addrs
+	e.Addresses = make([]string, len(addrs))
+	for i, a := range addrs {
+		e.Addresses[i] = a.String()
+	}
+}
+
+// group returns network group of the peer. Peers in same /16 ipv4 or /32 ipv6 network are regarded as same group.
+func (e *entry) group() string {
+	if len(e.addrs) == 0 {
+		return ""
+	}
+	ip := types.GetIPFromMultiaddr(e.addrs[0])
+	if ip == nil {
+		// domain name
+		return types.AddressFromMultiAddr(e.addrs[0])
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(16, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(32, 128)).String()
+}
+
+type fileAddressBook struct {
+	logger  *log.Logger
+	path    string
+	entries map[types.PeerID]*entry
+	mutex   sync.Mutex
+}
+
+var _ p2pcommon.AddressBook = (*fileAddressBook)(nil)
+
+// NewAddressBook create address book which is stored in dataDir
+func NewAddressBook(dataDir string, logger *log.Logger) p2pcommon.AddressBook {
+	return &fileAddressBook{logger: logger, path: filepath.Join(dataDir, addrBookFile), entries: make(map[types.PeerID]*entry)}
+}
+
+func (ab *fileAddressBook) Load() error {
+	bytes, err := os.ReadFile(ab.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ab.logger.Debug().Str("file", ab.path).Msg("address book file is not exist. starting with empty address book")
+			return nil
+		}
+		return err
+	}
+	var loaded []*entry
+	if err = json.Unmarshal(bytes, &loaded); err != nil {
+		return err
+	}
+
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	for _, e := range loaded {
+		id, err := types.IDB58Decode(e.PeerID)
+		if err != nil {
+			continue
+		}
+		addrs := make([]types.Multiaddr, 0, len(e.Addresses))
+		for _, a := range e.Addresses {
+			if ma, err := types.ParseMultiaddr(a); err == nil {
+				addrs = append(addrs, ma)
+			}
+		}
+		if len(addrs) == 0 {
+			continue
+		}
+		e.id = id
+		e.addrs = addrs
+		ab.entries[id] = e
+	}
+	removed := ab.prune(time.Now())
+	ab.logger.Info().Str("file", ab.path).Int("size", len(ab.entries)).Int("removed", removed).Msg("loaded address book")
+	return nil
+}
+
+func (ab *fileAddressBook) Save() error {
+	ab.mutex.Lock()
+	list := make([]*entry, 0, len(ab.entries))
+	for _, e := range ab.entries {
+		list = append(list, e)
+	}
+	ab.mutex.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].PeerID < list[j].PeerID })
+
+	bytes, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write to temporary file and rename it, not to corrupt existing file on failure
+	tmpPath := ab.path + ".tmp"
+	if err = os.WriteFile(tmpPath, bytes, 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, ab.path); err != nil {
+		return err
+	}
+	ab.logger.Debug().Str("file", ab.path).Int("size", len(list)).Msg("saved address book")
+	return nil
+}
+
+func (ab *fileAddressBook) AddAddresses(metas []p2pcommon.PeerMeta) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	now := time.Now()
+	for _, meta := range metas {
+		if len(meta.Addresses) == 0 || meta.Hidden {
+			continue
+		}
+		e, found := ab.entries[meta.ID]
+		if !found {
+			e = &entry{PeerID: types.IDB58Encode(meta.ID), id: meta.ID, Added: now}
+			ab.entries[meta.ID] = e
+		}
+		e.setAddresses(meta.Addresses)
+		e.Role = meta.Role.String()
+		e.Version = meta.Version
+	}
+	if len(ab.entries) > MaxEntries {
+		ab.evict(len(ab.entries) - MaxEntries)
+	}
+}
+
+func (ab *fileAddressBook) MarkConnected(info p2pcommon.RemoteInfo) {
+	meta := info.Meta
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	now := time.Now()
+	e, found := ab.entries[meta.ID]
+	if !found {
+		e = &entry{PeerID: types.IDB58Encode(meta.ID), id: meta.ID, Added: now}
+		ab.entries[meta.ID] = e
+	}
+	addrs := meta.Addresses
+	if !info.Connection.Outbound {
+		addrs = withObservedAddress(addrs, info.Connection.IP, meta.PrimaryPort())
+	}
+	if len(addrs) > 0 {
+		e.setAddresses(addrs)
+	}
+	e.Role = meta.Role.String()
+	e.Version = meta.Version
+	e.LastSeen = now
+	e.ConsecutiveFailure = 0
+	// only outbound connection proves that the advertised address is reachable
+	if info.Connection.Outbound {
+		e.Success++
+	}
+}
+
+// withObservedAddress appends address of ip observed in inbound connection with advertised port, if all advertised addresses
+// are not public. It is for the peer behind NAT with port forwarding, which advertises its private address.
+func withObservedAddress(addrs []types.Multiaddr, observed net.IP, port uint32) []types.Multiaddr {
+	if observed == nil || port == 0 || !isPublicIP(observed) {
+		return addrs
+	}
+	for _, a := range addrs {
+		ip := types.GetIPFromMultiaddr(a)
+		if ip == nil || isPublicIP(ip) {
+			// advertised domain name or public address is already usable
+			return addrs
+		}
+	}
+	ma, err := types.ToMultiAddr(observed.String(), port)
+	if err != nil {
+		return addrs
+	}
+	return append([]types.Multiaddr{ma}, addrs...)
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified())
+}
+
+func (ab *fileAddressBook) MarkFailed(id types.PeerID) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	if e, found := ab.entries[id]; found {
+		e.LastAttempt = time.Now()
+		e.Failure++
+		e.ConsecutiveFailure++
+	}
+}
+
+func (ab *fileAddressBook) Candidates(max int, exclude func(id types.PeerID) bool) []p2pcommon.PeerMeta {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	if max <= 0 {
+		return nil
+	}
+	// group entries by network, and each group is sorted by priority
+	groups := make(map[string][]*entry)
+	for id, e := range ab.entries {
+		if exclude != nil && exclude(id) {
+			continue
+		}
+		g := e.group()
+		groups[g] = append(groups[g], e)
+	}
+	ordered := make([][]*entry, 0, len(groups))
+	for _, es := range groups {
+		sort.Slice(es, func(i, j int) bool { return higherPriority(es[i], es[j]) })
+		ordered = append(ordered, es)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return higherPriority(ordered[i][0], ordered[j][0]) })
+
+	// pick the best one of each group in turn, so that peers are spread over as many networks as possible.
+	metas := make([]p2pcommon.PeerMeta, 0, max)
+	for round := 0; round < MaxCandidatesPerGroup; round++ {
+		for _, es := range ordered {
+			if round < len(es) {
+				metas = append(metas, es[round].meta())
+				if len(metas) >= max {
+					return metas
+				}
+			}
+		}
+	}
+	return metas
+}
+
+// higherPriority returns true if peer of a is more promising to connect than b.
+func higherPriority(a, b *entry) bool {
+	if a.ConsecutiveFailure != b.ConsecutiveFailure {
+		return a.ConsecutiveFailure < b.ConsecutiveFailure
+	}
+	if (a.Success > 0) != (b.Success > 0) {
+		return a.Success > 0
+	}
+	return a.lastActive().After(b.lastActive())
+}
+
+func (ab *fileAddressBook) Prune(now time.Time) int {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	removed := ab.prune(now)
+	if removed > 0 {
+		ab.logger.Debug().Int("removed", removed).Int("remained", len(ab.entries)).Msg("pruned dead entries of address book")
+	}
+	return removed
+}
+
+func (ab *fileAddressBook) prune(now time.Time) int {
+	removed := 0
+	for id, e := range ab.entries {
+		if e.ConsecutiveFailure >= MaxConsecutiveFailures || now.Sub(e.lastActive()) > MaxIdleTime {
+			delete(ab.entries, id)
+			removed++
+		}
+	}
+	if len(ab.entries) > MaxEntries {
+		removed += ab.evict(len(ab.entries) - MaxEntries)
+	}
+	return removed
+}
+
+// evict removes cnt entries of the lowest priority
+func (ab *fileAddressBook) evict(cnt int) int {
+	list := make([]*entry, 0, len(ab.entries))
+	for _, e := range ab.entries {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return higherPriority(list[j], list[i]) })
+	if cnt > len(list) {
+		cnt = len(list)
+	}
+	for _, e := range list[:cnt] {
+		delete(ab.entries, e.id)
+	}
+	return cnt
+}
+
+func (ab *fileAddressBook) Size() int {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	return len(ab.entries)
+}
+
+// dummyAddressBook is used when address book is disabled by configuration.
+type dummyAddressBook struct{}
+
+// NewDummyAddressBook create address book that stores nothing
+func NewDummyAddressBook() p2pcommon.AddressBook {
+	return dummyAddressBook{}
+}
+
+func (dummyAddressBook) Load() error                             { return nil }
+func (dummyAddressBook) Save() error                             { return nil }
+func (dummyAddressBook) AddAddresses(metas []p2pcommon.PeerMeta) {}
+func (dummyAddressBook) MarkConnected(info p2pcommon.RemoteInfo) {}
+func (dummyAddressBook) MarkFailed(id types.PeerID)              {}
+func (dummyAddressBook) Candidates(max int, exclude func(id types.PeerID) bool) []p2pcommon.PeerMeta {
+	return nil
+}
+func (dummyAddressBook) Prune(now time.Time) int { return 0 }
+func (dummyAddressBook) Size() int               { return 0 }
@@ -0,0 +1,160 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package addrbook
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+var logger = log.NewLogger("p2p.addrbook.test")
+
+func TestFileAddressBook_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	metas := []p2pcommon.PeerMeta{
+		p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "192.168.0.2", 7846, "v2.0.0"),
+		p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "node.aergo.io", 7846, "v2.0.0"),
+		{ID: types.RandomPeerID()}, // no address
+	}
+	ab := NewAddressBook(dir, logger)
+	ab.AddAddresses(metas)
+	if ab.Size() != 2 {
+		t.Fatalf("AddAddresses() size = %v, want %v", ab.Size(), 2)
+	}
+	ab.MarkConnected(p2pcommon.RemoteInfo{Meta: metas[0], Connection: p2pcommon.RemoteConn{Outbound: true}})
+	ab.MarkFailed(metas[1].ID)
+	if err := ab.Save(); err != nil {
+		t.Fatalf("Save() err = %v", err)
+	}
+
+	loaded := NewAddressBook(dir, logger)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+	if loaded.Size() != 2 {
+		t.Fatalf("Load() size = %v, want %v", loaded.Size(), 2)
+	}
+	e0 := loaded.(*fileAddressBook).entries[metas[0].ID]
+	if e0 == nil || e0.Success != 1 || !types.IsSamePeerID(e0.meta().ID, metas[0].ID) || !e0.addrs[0].Equal(metas[0].Addresses[0]) {
+		t.Errorf("Load() entry = %v, want success 1 and same address", e0)
+	}
+	if e1 := loaded.(*fileAddressBook).entries[metas[1].ID]; e1 == nil || e1.ConsecutiveFailure != 1 {
+		t.Errorf("Load() entry = %v, want failure 1", e1)
+	}
+	// connected peer has higher priority
+	cands := loaded.Candidates(10, nil)
+	if len(cands) != 2 || !types.IsSamePeerID(cands[0].ID, metas[0].ID) {
+		t.Errorf("Candidates() = %v, want %v first", cands, metas[0].ID)
+	}
+
+	// not existing file is not an error
+	if err := NewAddressBook(t.TempDir(), logger).Load(); err != nil {
+		t.Errorf("Load() of empty dir err = %v", err)
+	}
+}
+
+func TestFileAddressBook_Prune(t *testing.T) {
+	now := time.Now()
+	ab := NewAddressBook(t.TempDir(), logger).(*fileAddressBook)
+	fresh := p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "192.168.0.2", 7846, "v2.0.0")
+	old := p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "192.168.0.3", 7846, "v2.0.0")
+	failed := p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "192.168.0.4", 7846, "v2.0.0")
+	ab.AddAddresses([]p2pcommon.PeerMeta{fresh, old, failed})
+	ab.entries[old.ID].Added = now.Add(-MaxIdleTime - time.Hour)
+	for i := 0; i < MaxConsecutiveFailures; i++ {
+		ab.MarkFailed(failed.ID)
+	}
+
+	if got := ab.Prune(now); got != 2 {
+		t.Errorf("Prune() = %v, want %v", got, 2)
+	}
+	if _, found := ab.entries[fresh.ID]; !found || ab.Size() != 1 {
+		t.Errorf("Prune() removed wrong entries")
+	}
+}
+
+func TestFileAddressBook_Candidates(t *testing.T) {
+	ab := NewAddressBook(t.TempDir(), logger)
+	// 4 peers in same /16 network, and 2 peers in other networks
+	var sameNet, others []p2pcommon.PeerMeta
+	for _, ip := range []string{"211.10.1.1", "211.10.2.1", "211.10.3.1", "211.10.4.1"} {
+		sameNet = append(sameNet, p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), ip, 7846, "v2.0.0"))
+	}
+	for _, ip := range []string{"58.1.1.1", "121.1.1.1"} {
+		others = append(others, p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), ip, 7846, "v2.0.0"))
+	}
+	ab.AddAddresses(sameNet)
+	ab.AddAddresses(others)
+
+	tests := []struct {
+		name    string
+		max     int
+		exclude func(id types.PeerID) bool
+
+		wantSize   int
+		wantSameNw int
+	}{
+		{"TZero", 0, nil, 0, 0},
+		{"TSpread", 3, nil, 3, 1},
+		{"TGroupLimit", 10, nil, 4, MaxCandidatesPerGroup},
+		{"TExclude", 10, func(id types.PeerID) bool { return types.IsSamePeerID(id, others[0].ID) }, 3, MaxCandidatesPerGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ab.Candidates(tt.max, tt.exclude)
+			if len(got) != tt.wantSize {
+				t.Fatalf("Candidates() size = %v, want %v", len(got), tt.wantSize)
+			}
+			sameCnt := 0
+			for _, m := range got {
+				if tt.exclude != nil && tt.exclude(m.ID) {
+					t.Errorf("Candidates() returned excluded peer %v", m.ID)
+				}
+				if types.GetIPFromMultiaddr(m.Addresses[0]).Mask(net.CIDRMask(16, 32)).Equal(net.ParseIP("211.10.0.0")) {
+					sameCnt++
+				}
+			}
+			if sameCnt != tt.wantSameNw {
+				t.Errorf("Candidates() peers in same network = %v, want %v", sameCnt, tt.wantSameNw)
+			}
+		})
+	}
+}
+
+func TestFileAddressBook_MarkConnectedInbound(t *testing.T) {
+	privateMeta := p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "192.168.0.2", 7846, "v2.0.0")
+	publicMeta := p2pcommon.NewMetaWith1Addr(types.RandomPeerID(), "58.1.2.3", 7846, "v2.0.0")
+	tests := []struct {
+		name     string
+		meta     p2pcommon.PeerMeta
+		observed string
+
+		wantAddrCnt int
+		wantFirst   string
+	}{
+		{"TBehindNAT", privateMeta, "58.3.2.1", 2, "/ip4/58.3.2.1/tcp/7846"},
+		{"TPrivateObserved", privateMeta, "10.0.0.3", 1, "/ip4/192.168.0.2/tcp/7846"},
+		{"TPublic", publicMeta, "58.3.2.1", 1, "/ip4/58.1.2.3/tcp/7846"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ab := NewAddressBook(t.TempDir(), logger).(*fileAddressBook)
+			ab.MarkConnected(p2pcommon.RemoteInfo{Meta: tt.meta, Connection: p2pcommon.RemoteConn{IP: net.ParseIP(tt.observed), Port: 51234, Outbound: false}})
+			e := ab.entries[tt.meta.ID]
+			if len(e.Addresses) != tt.wantAddrCnt || e.Addresses[0] != tt.wantFirst {
+				t.Errorf("MarkConnected() addresses = %v, want %v first", e.Addresses, tt.wantFirst)
+			}
+			if e.Success != 0 {
+				t.Errorf("MarkConnected() success = %v, want 0 for inbound connection", e.Success)
+			}
+		})
+	}
+}
@@ -116,7 +116,7 @@ func (br *BlocksChunkReceiver) handleInWaiting(msg p2pcommon.Message, msgBody p2
 			br.cancelReceiving(message.UnexpectedBlockError, body.HasNext)
 			return
 		}
-		if block.Size() > int(chain.MaxBlockSize()) {
+		if block.Size() > int(chain.MaxBlockSizeLimit()) {
 			br.cancelReceiving(message.TooBigBlockError, body.HasNext)
 			return
 		}
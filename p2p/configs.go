@@ -15,6 +15,8 @@ const (
 	defaultPingInterval = time.Second * 60
 	// txNoticeInterval is max wait time when not sufficient txs to notify is collected. i.e newTxNotice is sent to peer within this time.
 	txNoticeInterval = time.Second * 1
+	// minTxGossipFanout is minimum number of peers to which new txs are noticed, when square root of peer count is less than it.
+	minTxGossipFanout = 4
 	// writeMsgBufferSize is queue size of message to a peer. connection will be closed when queue is exceeded.
 	writeMsgBufferSize = 40
 )
@@ -22,6 +24,8 @@ const (
 // constants for node discovery
 const (
 	DiscoveryQueryInterval = time.Minute * 1
+	// AddrBookSaveInterval is interval to prune and save address book to file
+	AddrBookSaveInterval = time.Minute * 10
 
 	MaxAddrListSizePolaris = 200
 	MaxAddrListSizePeer    = 50
@@ -44,6 +48,8 @@ const (
 	DefaultPeerTxCacheSize   = 10000
 	// DefaultPeerTxQueueSize is maximum size of hashes in a single tx notice message
 	DefaultPeerTxQueueSize = 2000
+	// DefaultPeerTxNoticeRate is maximum number of tx hashes sent to a peer during txNoticeInterval
+	DefaultPeerTxNoticeRate = 4000
 	// value to sent to cache, since block and tx cache need only hash itself (stored as key of map)
 	cachePlaceHolder = true
 )
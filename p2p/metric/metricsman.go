@@ -45,6 +45,10 @@ type metricsManager struct {
 
 	deadTotalIn  int64
 	deadTotalOut int64
+	deadSavedIn  int64
+	deadSavedOut int64
+	deadDupTx    int64
+	deadDupBlk   int64
 }
 
 var _ MetricsManager = (*metricsManager)(nil)
@@ -99,6 +103,10 @@ func (mm *metricsManager) Remove(pid types.PeerID, manNum uint32) *PeerMetric {
 		}
 		atomic.AddInt64(&mm.deadTotalIn, metric.totalIn)
 		atomic.AddInt64(&mm.deadTotalOut, metric.totalOut)
+		atomic.AddInt64(&mm.deadSavedIn, metric.SavedIn())
+		atomic.AddInt64(&mm.deadSavedOut, metric.SavedOut())
+		atomic.AddInt64(&mm.deadDupTx, metric.DupTxNotice())
+		atomic.AddInt64(&mm.deadDupBlk, metric.DupBlkNotice())
 		delete(mm.metricsMap, pid)
 		return metric
 	}
@@ -126,7 +134,7 @@ func (mm *metricsManager) Summary() map[string]interface{} {
 	// There can be a little error
 	sum := make(map[string]interface{})
 	sum["since"] = mm.startTime
-	var totalIn, totalOut int64
+	var totalIn, totalOut, savedIn, savedOut, dupTx, dupBlk int64
 	if len(mm.Metrics()) > 0 {
 		var cnt = 0
 		//var inAps, inLoad, outAps, outLoad int64
@@ -134,12 +142,24 @@ func (mm *metricsManager) Summary() map[string]interface{} {
 			cnt++
 			totalIn += met.totalIn
 			totalOut += met.totalOut
+			savedIn += met.SavedIn()
+			savedOut += met.SavedOut()
+			dupTx += met.DupTxNotice()
+			dupBlk += met.DupBlkNotice()
 		}
 	}
 	totalIn += atomic.LoadInt64(&mm.deadTotalIn)
 	totalOut += atomic.LoadInt64(&mm.deadTotalOut)
+	savedIn += atomic.LoadInt64(&mm.deadSavedIn)
+	savedOut += atomic.LoadInt64(&mm.deadSavedOut)
+	dupTx += atomic.LoadInt64(&mm.deadDupTx)
+	dupBlk += atomic.LoadInt64(&mm.deadDupBlk)
 	sum["in"] = totalIn
 	sum["out"] = totalOut
+	sum["in_saved"] = savedIn
+	sum["out_saved"] = savedOut
+	sum["dup_tx_notice"] = dupTx
+	sum["dup_blk_notice"] = dupBlk
 	return sum
 }
 
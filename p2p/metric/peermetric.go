@@ -21,12 +21,20 @@ type PeerMetric struct {
 	Since    time.Time
 	totalIn  int64
 	totalOut int64
+	// savedIn and savedOut are bytes reduced by message compression
+	savedIn  int64
+	savedOut int64
+	// dupTxNotice and dupBlkNotice are count of hashes which the peer noticed again
+	dupTxNotice  int64
+	dupBlkNotice int64
 
 	InMetric  DataMetric
 	OutMetric DataMetric
 }
 
 var _ p2pcommon.MsgIOListener = (*PeerMetric)(nil)
+var _ p2pcommon.MsgCompressionListener = (*PeerMetric)(nil)
+var _ p2pcommon.NoticeListener = (*PeerMetric)(nil)
 
 func (m *PeerMetric) OnRead(protocol p2pcommon.SubProtocol, read int) {
 	atomic.AddInt64(&m.totalIn, int64(read))
@@ -38,6 +46,22 @@ func (m *PeerMetric) OnWrite(protocol p2pcommon.SubProtocol, write int) {
 	m.OutMetric.AddBytes(write)
 }
 
+func (m *PeerMetric) OnDecompressed(protocol p2pcommon.SubProtocol, compressed, original int) {
+	atomic.AddInt64(&m.savedIn, int64(original-compressed))
+}
+
+func (m *PeerMetric) OnCompressed(protocol p2pcommon.SubProtocol, original, compressed int) {
+	atomic.AddInt64(&m.savedOut, int64(original-compressed))
+}
+
+func (m *PeerMetric) OnDupTxNotice(dupCnt int) {
+	atomic.AddInt64(&m.dupTxNotice, int64(dupCnt))
+}
+
+func (m *PeerMetric) OnDupBlkNotice() {
+	atomic.AddInt64(&m.dupBlkNotice, 1)
+}
+
 func (m *PeerMetric) TotalIn() int64 {
 	return atomic.LoadInt64(&m.totalIn)
 }
@@ -46,6 +70,26 @@ func (m *PeerMetric) TotalOut() int64 {
 	return atomic.LoadInt64(&m.totalOut)
 }
 
+// SavedIn returns total bytes saved by compression of received messages
+func (m *PeerMetric) SavedIn() int64 {
+	return atomic.LoadInt64(&m.savedIn)
+}
+
+// SavedOut returns total bytes saved by compression of sent messages
+func (m *PeerMetric) SavedOut() int64 {
+	return atomic.LoadInt64(&m.savedOut)
+}
+
+// DupTxNotice returns count of tx hashes noticed by the peer, which the peer was already known to have
+func (m *PeerMetric) DupTxNotice() int64 {
+	return atomic.LoadInt64(&m.dupTxNotice)
+}
+
+// DupBlkNotice returns count of block hashes noticed by the peer, which the peer was already known to have
+func (m *PeerMetric) DupBlkNotice() int64 {
+	return atomic.LoadInt64(&m.dupBlkNotice)
+}
+
 // Deprecated
 func (m *PeerMetric) InputAdded(added int) {
 	atomic.AddInt64(&m.totalIn, int64(added))
@@ -55,6 +55,7 @@ type P2P struct {
 	prm    p2pcommon.PeerRoleManager
 	lm     p2pcommon.ListManager
 	cm     p2pcommon.CertificateManager
+	rl     *rateLimiter
 	mutex  sync.Mutex
 
 	// inited between construction and start
@@ -110,8 +111,11 @@ func (p2ps *P2P) initP2P(chainSvc *chain.ChainService) {
 	syncMan := newSyncManager(p2ps, peerMan, p2ps.Logger)
 	versionMan := newDefaultVersionManager(p2ps, p2ps, peerMan, p2ps.ca, p2ps.Logger, p2ps.genesisChainID)
 
+	rateLimiter := newRateLimiter(cfg.P2P)
+
 	// connect managers each other
 	peerMan.AddPeerEventListener(p2ps.cm)
+	peerMan.AddPeerEventListener(rateLimiter)
 
 	p2ps.mutex.Lock()
 	p2ps.signer = signer
@@ -122,6 +126,7 @@ func (p2ps *P2P) initP2P(chainSvc *chain.ChainService) {
 	p2ps.sm = syncMan
 	//p2ps.rm = reconMan
 	p2ps.mm = metricMan
+	p2ps.rl = rateLimiter
 	p2ps.lm = lm
 
 	p2ps.mutex.Unlock()
@@ -271,6 +276,7 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		context.Respond(p2ps.selfMeta)
 	case *message.GetPeers:
 		peers := p2ps.pm.GetPeerAddresses(msg.NoHidden, msg.ShowSelf)
+		p2ps.rl.fillPeerInfos(peers, p2ps.mm)
 		context.Respond(&message.GetPeersRsp{Peers: peers})
 	case *message.GetSyncAncestor:
 		p2ps.GetSyncAncestor(context, msg)
@@ -466,7 +472,11 @@ func (p2ps *P2P) CreateRemotePeer(remoteInfo p2pcommon.RemoteInfo, seq uint32, r
 	}
 
 	newPeer := newRemotePeer(remoteInfo, seq, p2ps.pm, p2ps, p2ps.Logger, p2ps.mf, p2ps.signer, rw)
-	rw.AddIOListener(p2ps.mm.NewMetric(newPeer.ID(), newPeer.ManageNumber()))
+	peerMetric := p2ps.mm.NewMetric(newPeer.ID(), newPeer.ManageNumber())
+	rw.AddIOListener(peerMetric)
+	newPeer.noticeListener = peerMetric
+	newPeer.limiter = p2ps.rl.newPeerLimiter(newPeer.ID(), newPeer.ManageNumber())
+	rw.AddIOListener(newPeer.limiter)
 
 	// insert Handlers
 	p2ps.insertHandlers(newPeer)
@@ -512,6 +522,16 @@ func (p2ps *P2P) RoleManager() p2pcommon.PeerRoleManager {
 }
 
 func (p2ps *P2P) initLocalSettings(conf *config.P2PConfig) {
+	compType, err := p2pcommon.ParseCompressionType(conf.NPCompression)
+	if err != nil {
+		panic("invalid npcompression " + conf.NPCompression + " : " + err.Error())
+	}
+	threshold := conf.NPCompressThreshold
+	if threshold <= 0 {
+		threshold = p2pcommon.DefaultCompressThreshold
+	}
+	p2ps.localSettings.Compression = p2pcommon.CompressionSetting{Type: compType, Threshold: threshold}
+
 	meta := p2ps.selfMeta
 	switch meta.Role {
 	case types.PeerRole_Producer:
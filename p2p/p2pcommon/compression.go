@@ -0,0 +1,101 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2pcommon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability is bit flags of optional features of p2p protocol. Local peer announces its capabilities in status message
+// of handshake since p2p version 2.0.0, and the features are only used when both peers support it.
+type Capability uint32
+
+const (
+	// CapSnappy means that peer can decompress payloads compressed by snappy.
+	CapSnappy Capability = 1 << iota
+	// CapZstd means that peer can decompress payloads compressed by zstd.
+	CapZstd
+)
+
+// LocalCapabilities is capabilities which this aergosvr supports.
+const LocalCapabilities = CapSnappy | CapZstd
+
+func (c Capability) Has(o Capability) bool {
+	return c&o == o
+}
+
+// CompressionType is the algorithm used to compress payload of a message.
+type CompressionType uint8
+
+const (
+	CompressNone CompressionType = iota
+	CompressSnappy
+	CompressZstd
+)
+
+// DefaultCompressThreshold is minimum payload size in bytes to be compressed.
+const DefaultCompressThreshold = 1024
+
+var compressionNames = map[CompressionType]string{
+	CompressNone:   "none",
+	CompressSnappy: "snappy",
+	CompressZstd:   "zstd",
+}
+
+func (c CompressionType) String() string {
+	if name, found := compressionNames[c]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(c))
+}
+
+// Capability returns the capability which remote peer must have to receive messages compressed by this type.
+func (c CompressionType) Capability() Capability {
+	switch c {
+	case CompressSnappy:
+		return CapSnappy
+	case CompressZstd:
+		return CapZstd
+	default:
+		return 0
+	}
+}
+
+// ParseCompressionType returns compression type of name. empty string is regarded as none.
+func ParseCompressionType(name string) (CompressionType, error) {
+	if len(name) == 0 {
+		return CompressNone, nil
+	}
+	name = strings.ToLower(name)
+	for c, n := range compressionNames {
+		if n == name {
+			return c, nil
+		}
+	}
+	return CompressNone, fmt.Errorf("unknown compression %s", name)
+}
+
+// CompressionSetting is local preference of message compression.
+type CompressionSetting struct {
+	Type CompressionType
+	// Threshold is minimum payload size to be compressed. smaller payload is sent as is.
+	Threshold int
+}
+
+// Negotiate returns the compression type to use for remote peer with capabilities.
+func (s CompressionSetting) Negotiate(remote Capability) CompressionType {
+	if s.Type == CompressNone || !remote.Has(s.Type.Capability()) {
+		return CompressNone
+	}
+	return s.Type
+}
+
+// MsgCompressor is optional feature of MsgReadWriter, which can send messages with compressed payloads.
+type MsgCompressor interface {
+	// SetCompression sets compression of writing messages. Reading compressed message is always available regardless of it.
+	SetCompression(compType CompressionType, threshold int)
+}
@@ -0,0 +1,57 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2pcommon
+
+import "testing"
+
+func TestParseCompressionType(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    CompressionType
+		wantErr bool
+	}{
+		{"TEmpty", "", CompressNone, false},
+		{"TNone", "none", CompressNone, false},
+		{"TSnappy", "snappy", CompressSnappy, false},
+		{"TZstdUpper", "ZSTD", CompressZstd, false},
+		{"TUnknown", "gzip", CompressNone, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCompressionType(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCompressionType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseCompressionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompressionSetting_Negotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  CompressionType
+		remote Capability
+		want   CompressionType
+	}{
+		{"TBoth", CompressSnappy, LocalCapabilities, CompressSnappy},
+		{"TLocalOff", CompressNone, LocalCapabilities, CompressNone},
+		{"TLegacyRemote", CompressZstd, 0, CompressNone},
+		{"TRemoteSnappyOnly", CompressZstd, CapSnappy, CompressNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := CompressionSetting{Type: tt.local, Threshold: DefaultCompressThreshold}
+			if got := s.Negotiate(tt.remote); got != tt.want {
+				t.Errorf("Negotiate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
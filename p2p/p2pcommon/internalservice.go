@@ -36,4 +36,5 @@ type InternalService interface {
 type LocalSettings struct {
 	AgentID       types.PeerID
 	InternalZones []*net.IPNet
+	Compression   CompressionSetting
 }
@@ -52,6 +52,12 @@ type MessageHandler interface {
 	PostHandle(msg Message, msgBody MessageBody)
 }
 
+// ThrottleResponder is optional extension of MessageHandler. It answers to the request which exceeds rate limit, instead
+// of handling it. The request is silently dropped if the handler does not implement it.
+type ThrottleResponder interface {
+	RespondThrottled(msg Message)
+}
+
 type HandlerAdvice interface {
 	PreHandle()
 	PostHandle(msg Message, msgBody MessageBody)
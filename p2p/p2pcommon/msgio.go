@@ -22,4 +22,11 @@ type MsgIOListener interface {
 	OnWrite(protocol SubProtocol, write int)
 }
 
+// MsgCompressionListener is optional extension of MsgIOListener. It is notified of the original and the compressed payload size
+// of each compressed message, in addition to OnRead or OnWrite.
+type MsgCompressionListener interface {
+	OnDecompressed(protocol SubProtocol, compressed, original int)
+	OnCompressed(protocol SubProtocol, original, compressed int)
+}
+
 //go:generate mockgen -source=msgio.go -package=p2pmock -destination=../p2pmock/mock_msgio.go
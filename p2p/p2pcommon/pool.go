@@ -59,6 +59,29 @@ type WaitingPeerManager interface {
 	OnInboundConn(s network.Stream)
 }
 
+// AddressBook keeps addresses of peers which local peer has ever known, with the history of connection trials.
+// It is persisted in data directory, so local peer can reconnect to known peers quickly after restart without
+// polaris or designated peers.
+type AddressBook interface {
+	// Load reads entries from the file and drop entries which are too old.
+	Load() error
+	// Save writes entries to the file
+	Save() error
+
+	// AddAddresses adds metas of discovered peers. Existing entries are updated with new addresses.
+	AddAddresses(metas []PeerMeta)
+	// MarkConnected updates entry of peer which is successfully handshaked.
+	MarkConnected(info RemoteInfo)
+	// MarkFailed updates entry of peer to which local peer failed to connect.
+	MarkFailed(id types.PeerID)
+
+	// Candidates returns at most max peers to connect, which are not in exclude. Peers in different subnets are preferred.
+	Candidates(max int, exclude func(id types.PeerID) bool) []PeerMeta
+	// Prune removes dead entries and returns the count of removed ones.
+	Prune(now time.Time) int
+	Size() int
+}
+
 //go:generate mockgen -source=pool.go -package=p2pmock -destination=../p2pmock/mock_peerfinder.go
 
 type WaitingPeer struct {
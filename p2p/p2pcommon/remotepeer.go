@@ -54,6 +54,8 @@ type RemotePeer interface {
 	UpdateBlkCache(blkHash types.BlockID, blkNumber types.BlockNo) bool
 	// updateTxCache add hashes to transaction cache and return newly added hashes.
 	UpdateTxCache(hashes []types.TxID) []types.TxID
+	// UnknownTxs returns hashes which are not in the transaction cache, i.e. remote peer is not known to have.
+	UnknownTxs(hashes []types.TxID) []types.TxID
 	// updateLastNotice change estimate of the last status of remote peer
 	UpdateLastNotice(blkHash types.BlockID, blkNumber types.BlockNo)
 
@@ -65,3 +67,9 @@ type RemotePeer interface {
 	// DoTask execute task in remote peer's own goroutine, it should not consume lots of time to process.
 	DoTask(task PeerTask) bool
 }
+
+// NoticeListener is notified of hashes which were noticed again by remote peer, though remote peer is already known to have.
+type NoticeListener interface {
+	OnDupTxNotice(dupCnt int)
+	OnDupBlkNotice()
+}
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	network "github.com/libp2p/go-libp2p/core/network"
 	reflect "reflect"
+	time "time"
 )
 
 // MockPeerEventListener is a mock of PeerEventListener interface
@@ -226,3 +227,132 @@ func (mr *MockWaitingPeerManagerMockRecorder) OnInboundConn(s interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnInboundConn", reflect.TypeOf((*MockWaitingPeerManager)(nil).OnInboundConn), s)
 }
+
+// MockAddressBook is a mock of AddressBook interface
+type MockAddressBook struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressBookMockRecorder
+}
+
+// MockAddressBookMockRecorder is the mock recorder for MockAddressBook
+type MockAddressBookMockRecorder struct {
+	mock *MockAddressBook
+}
+
+// NewMockAddressBook creates a new mock instance
+func NewMockAddressBook(ctrl *gomock.Controller) *MockAddressBook {
+	mock := &MockAddressBook{ctrl: ctrl}
+	mock.recorder = &MockAddressBookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAddressBook) EXPECT() *MockAddressBookMockRecorder {
+	return m.recorder
+}
+
+// AddAddresses mocks base method
+func (m *MockAddressBook) AddAddresses(metas []p2pcommon.PeerMeta) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddAddresses", metas)
+}
+
+// AddAddresses indicates an expected call of AddAddresses
+func (mr *MockAddressBookMockRecorder) AddAddresses(metas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAddresses", reflect.TypeOf((*MockAddressBook)(nil).AddAddresses), metas)
+}
+
+// Candidates mocks base method
+func (m *MockAddressBook) Candidates(max int, exclude func(types.PeerID) bool) []p2pcommon.PeerMeta {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Candidates", max, exclude)
+	ret0, _ := ret[0].([]p2pcommon.PeerMeta)
+	return ret0
+}
+
+// Candidates indicates an expected call of Candidates
+func (mr *MockAddressBookMockRecorder) Candidates(max, exclude interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Candidates", reflect.TypeOf((*MockAddressBook)(nil).Candidates), max, exclude)
+}
+
+// Load mocks base method
+func (m *MockAddressBook) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockAddressBookMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockAddressBook)(nil).Load))
+}
+
+// MarkConnected mocks base method
+func (m *MockAddressBook) MarkConnected(info p2pcommon.RemoteInfo) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkConnected", info)
+}
+
+// MarkConnected indicates an expected call of MarkConnected
+func (mr *MockAddressBookMockRecorder) MarkConnected(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConnected", reflect.TypeOf((*MockAddressBook)(nil).MarkConnected), info)
+}
+
+// MarkFailed mocks base method
+func (m *MockAddressBook) MarkFailed(id types.PeerID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkFailed", id)
+}
+
+// MarkFailed indicates an expected call of MarkFailed
+func (mr *MockAddressBookMockRecorder) MarkFailed(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockAddressBook)(nil).MarkFailed), id)
+}
+
+// Prune mocks base method
+func (m *MockAddressBook) Prune(now time.Time) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", now)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune
+func (mr *MockAddressBookMockRecorder) Prune(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockAddressBook)(nil).Prune), now)
+}
+
+// Save mocks base method
+func (m *MockAddressBook) Save() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockAddressBookMockRecorder) Save() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAddressBook)(nil).Save))
+}
+
+// Size mocks base method
+func (m *MockAddressBook) Size() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Size indicates an expected call of Size
+func (mr *MockAddressBookMockRecorder) Size() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockAddressBook)(nil).Size))
+}
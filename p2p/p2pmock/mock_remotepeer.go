@@ -353,6 +353,20 @@ func (mr *MockRemotePeerMockRecorder) UpdateTxCache(hashes interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTxCache", reflect.TypeOf((*MockRemotePeer)(nil).UpdateTxCache), hashes)
 }
 
+// UnknownTxs mocks base method
+func (m *MockRemotePeer) UnknownTxs(hashes []types.TxID) []types.TxID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnknownTxs", hashes)
+	ret0, _ := ret[0].([]types.TxID)
+	return ret0
+}
+
+// UnknownTxs indicates an expected call of UnknownTxs
+func (mr *MockRemotePeerMockRecorder) UnknownTxs(hashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnknownTxs", reflect.TypeOf((*MockRemotePeer)(nil).UnknownTxs), hashes)
+}
+
 // UpdateLastNotice mocks base method
 func (m *MockRemotePeer) UpdateLastNotice(blkHash types.BlockID, blkNumber types.BlockNo) {
 	m.ctrl.T.Helper()
@@ -404,3 +418,50 @@ func (mr *MockRemotePeerMockRecorder) DoTask(task interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoTask", reflect.TypeOf((*MockRemotePeer)(nil).DoTask), task)
 }
+
+// MockNoticeListener is a mock of NoticeListener interface
+type MockNoticeListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoticeListenerMockRecorder
+}
+
+// MockNoticeListenerMockRecorder is the mock recorder for MockNoticeListener
+type MockNoticeListenerMockRecorder struct {
+	mock *MockNoticeListener
+}
+
+// NewMockNoticeListener creates a new mock instance
+func NewMockNoticeListener(ctrl *gomock.Controller) *MockNoticeListener {
+	mock := &MockNoticeListener{ctrl: ctrl}
+	mock.recorder = &MockNoticeListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNoticeListener) EXPECT() *MockNoticeListenerMockRecorder {
+	return m.recorder
+}
+
+// OnDupBlkNotice mocks base method
+func (m *MockNoticeListener) OnDupBlkNotice() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnDupBlkNotice")
+}
+
+// OnDupBlkNotice indicates an expected call of OnDupBlkNotice
+func (mr *MockNoticeListenerMockRecorder) OnDupBlkNotice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDupBlkNotice", reflect.TypeOf((*MockNoticeListener)(nil).OnDupBlkNotice))
+}
+
+// OnDupTxNotice mocks base method
+func (m *MockNoticeListener) OnDupTxNotice(dupCnt int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnDupTxNotice", dupCnt)
+}
+
+// OnDupTxNotice indicates an expected call of OnDupTxNotice
+func (mr *MockNoticeListenerMockRecorder) OnDupTxNotice(dupCnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDupTxNotice", reflect.TypeOf((*MockNoticeListener)(nil).OnDupTxNotice), dupCnt)
+}
@@ -0,0 +1,92 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2putil
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket is a thread-safe token bucket which is filled at rate tokens per second, up to burst tokens.
+// Tokens can be taken more than remained, and then bucket is in debt until it is filled again. A nil TokenBucket
+// means unlimited, i.e. it always allows.
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket creates a token bucket which is full at first. It returns nil, the unlimited bucket, if rate is not
+// positive.
+func NewTokenBucket(rate float64, burst float64) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now(), now: time.Now}
+}
+
+// Rate returns the fill rate per second, or 0 if unlimited.
+func (b *TokenBucket) Rate() float64 {
+	if b == nil {
+		return 0
+	}
+	return b.rate
+}
+
+// Allow takes a token and returns true if a token is remained, or returns false without taking.
+func (b *TokenBucket) Allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Available returns true if bucket is not in debt.
+func (b *TokenBucket) Available() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	return b.tokens > 0
+}
+
+// Take takes n tokens regardless of remained tokens.
+func (b *TokenBucket) Take(n float64) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	b.tokens -= n
+}
+
+// refill must be called in mutex
+func (b *TokenBucket) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
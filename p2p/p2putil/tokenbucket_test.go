@@ -0,0 +1,81 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2putil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst float64
+		take  float64
+		wait  time.Duration
+
+		wantAllowed int
+	}{
+		{"TBurst", 10, 5, 0, 0, 5},
+		{"TRefill", 10, 5, 0, time.Millisecond * 300, 8},
+		{"TFullCap", 10, 5, 0, time.Second * 10, 10},
+		{"TDebt", 10, 5, 20, time.Second, 0},
+		{"TRepaid", 10, 5, 20, time.Millisecond * 1900, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			b := NewTokenBucket(tt.rate, tt.burst)
+			b.now = func() time.Time { return now }
+			b.last = now
+			b.Take(tt.take)
+
+			allowed := 0
+			for i := 0; i < 5; i++ {
+				if b.Allow() {
+					allowed++
+				}
+			}
+			now = now.Add(tt.wait)
+			for i := 0; i < 5; i++ {
+				if b.Allow() {
+					allowed++
+				}
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("Allow() allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestTokenBucket_Unlimited(t *testing.T) {
+	b := NewTokenBucket(0, 10)
+	if b != nil {
+		t.Fatalf("NewTokenBucket() = %v, want nil", b)
+	}
+	b.Take(100)
+	if !b.Allow() || !b.Available() || b.Rate() != 0 {
+		t.Errorf("unlimited bucket denied")
+	}
+}
+
+func TestTokenBucket_Available(t *testing.T) {
+	now := time.Now()
+	b := NewTokenBucket(1000, 1000)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	b.Take(1500)
+	if b.Available() {
+		t.Errorf("Available() = true, want false in debt")
+	}
+	now = now.Add(time.Second)
+	if !b.Available() {
+		t.Errorf("Available() = false, want true after refill")
+	}
+}
@@ -88,6 +88,16 @@ func (dp *dynamicPeerFinder) CheckAndFill() {
 	if toConnCount <= 0 {
 		return
 	}
+	// reuse peers known at previous runs first, it is helpful when polaris is not available.
+	excl := func(id types.PeerID) bool {
+		_, waiting := dp.pm.waitingPeers[id]
+		_, connected := dp.pm.remotePeers[id]
+		return waiting || connected || id == dp.pm.SelfNodeID()
+	}
+	if known := dp.pm.addrBook.Candidates(toConnCount, excl); len(known) > 0 {
+		dp.logger.Debug().Int("count", len(known)).Msg("adding peers in address book to waiting pool")
+		dp.pm.wpManager.OnDiscoveredPeers(known)
+	}
 	now := time.Now()
 	// query to polaris
 	if dp.usePolaris && now.After(dp.polarisTurn) {
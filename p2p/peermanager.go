@@ -11,6 +11,7 @@ import (
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-lib/log"
 	cfg "github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/addrbook"
 	"github.com/aergoio/aergo/v2/p2p/metric"
 	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
 	"github.com/aergoio/aergo/v2/p2p/p2pkey"
@@ -45,6 +46,7 @@ type peerManager struct {
 
 	peerFinder p2pcommon.PeerFinder
 	wpManager  p2pcommon.WaitingPeerManager
+	addrBook   p2pcommon.AddressBook
 
 	mutex        *sync.Mutex
 	manageNumber uint32
@@ -133,6 +135,12 @@ func NewPeerManager(is p2pcommon.InternalService, hsFactory p2pcommon.HSHandlerF
 		msgBufSize:        msgBufSize,
 	}
 
+	if p2pConf.NPAddrBook {
+		pm.addrBook = addrbook.NewAddressBook(cfg.DataDir, logger)
+	} else {
+		pm.addrBook = addrbook.NewDummyAddressBook()
+	}
+
 	// additional initializations
 	pm.init()
 
@@ -222,10 +230,14 @@ func (pm *peerManager) runManagePeers() {
 	if !atomic.CompareAndSwapInt32(&pm.status, initial, running) {
 		panic("wrong internal status")
 	}
+	if err := pm.addrBook.Load(); err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to load address book, starting with empty one")
+	}
 	instantStart := time.Millisecond << 4
 	initialAddrDelay := time.Second * 2
 	finderTimer := time.NewTimer(initialAddrDelay)
 	connManTimer := time.NewTimer(initialAddrDelay << 1)
+	addrBookTicker := time.NewTicker(AddrBookSaveInterval)
 
 MANLOOP:
 	for {
@@ -263,6 +275,9 @@ MANLOOP:
 				inInfo.foundC <- false
 			}
 		case workResult := <-pm.workDoneChannel:
+			if workResult.Result != nil && !workResult.Inbound {
+				pm.addrBook.MarkFailed(workResult.Meta.ID)
+			}
 			pm.wpManager.OnWorkDone(workResult)
 			// Retry
 			if !connManTimer.Stop() {
@@ -280,20 +295,26 @@ MANLOOP:
 		case peerMeta := <-pm.addPeerChannel:
 			pm.wpManager.InstantConnect(peerMeta)
 		case peerMetas := <-pm.fillPoolChannel:
+			pm.addrBook.AddAddresses(peerMetas)
 			if pm.wpManager.OnDiscoveredPeers(peerMetas) > 0 {
 				if !connManTimer.Stop() {
 					<-connManTimer.C
 				}
 				connManTimer.Reset(instantStart)
 			}
+		case <-addrBookTicker.C:
+			pm.addrBook.Prune(time.Now())
+			pm.saveAddrBook()
 		case task := <-pm.taskChannel:
 			task()
 		case <-pm.finishChannel:
 			finderTimer.Stop()
 			connManTimer.Stop()
+			addrBookTicker.Stop()
 			break MANLOOP
 		}
 	}
+	pm.saveAddrBook()
 	// guaranty no new peer connection will be made
 	pm.nt.RemoveStreamHandler(p2pcommon.P2PSubAddr)
 
@@ -354,6 +375,9 @@ func (pm *peerManager) tryRegister(hsResult connPeerResult) p2pcommon.RemotePeer
 	go newPeer.RunPeer()
 
 	pm.insertPeer(peerID, newPeer)
+	if !remote.Hidden {
+		pm.addrBook.MarkConnected(remote)
+	}
 	pm.logger.Info().Str("claimedRole", newPeer.Meta().Role.String()).Str("role", newPeer.AcceptedRole().String()).Bool("outbound", remote.Connection.Outbound).Str("zone", remote.Zone.String()).Str(p2putil.LogPeerName, newPeer.Name()).Str("addr", remote.Connection.IP.String()+":"+strconv.Itoa(int(remote.Connection.Port))).Msg("peer is added to peerService")
 
 	pm.mutex.Lock()
@@ -365,6 +389,12 @@ func (pm *peerManager) tryRegister(hsResult connPeerResult) p2pcommon.RemotePeer
 	return newPeer
 }
 
+func (pm *peerManager) saveAddrBook() {
+	if err := pm.addrBook.Save(); err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to save address book")
+	}
+}
+
 func (pm *peerManager) changePeerAttributes(remote p2pcommon.RemoteInfo, peerID types.PeerID) p2pcommon.RemoteInfo {
 	// override options by configurations of node
 	_, remote.Designated = pm.designatedPeers[peerID]
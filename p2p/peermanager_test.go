@@ -10,6 +10,7 @@ import (
 
 	"github.com/aergoio/aergo-lib/log"
 	cfg "github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/addrbook"
 	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
 	"github.com/aergoio/aergo/v2/p2p/p2pkey"
 	"github.com/aergoio/aergo/v2/p2p/p2pmock"
@@ -229,6 +230,7 @@ func Test_peerManager_runManagePeers_MultiConnWorks(t *testing.T) {
 			pm := &peerManager{
 				peerFinder:   mockPeerFinder,
 				wpManager:    mockWPManager,
+				addrBook:     addrbook.NewDummyAddressBook(),
 				remotePeers:  make(map[types.PeerID]p2pcommon.RemotePeer, 10),
 				waitingPeers: make(map[types.PeerID]*p2pcommon.WaitingPeer, 10),
 				conf:         dummyCfg,
@@ -356,6 +358,7 @@ func Test_peerManager_StopInRun(t *testing.T) {
 				nt:         mockNT,
 				peerFinder: mockPeerFinder,
 				wpManager:  mockWPManager,
+				addrBook:   addrbook.NewDummyAddressBook(),
 
 				mutex:         &sync.Mutex{},
 				finishChannel: make(chan struct{}),
@@ -509,9 +512,15 @@ func Test_peerManager_tryRegister(t *testing.T) {
 			mockMF := p2pmock.NewMockMoFactory(ctrl)
 			mockMF.EXPECT().NewMsgRequestOrder(false, p2pcommon.GoAway, gomock.Any()).Return(&pbRequestOrder{}).MaxTimes(1)
 			mockRW.EXPECT().WriteMsg(gomock.Any()).MaxTimes(1)
+			// hidden peers must not be recorded to address book
+			mockBook := p2pmock.NewMockAddressBook(ctrl)
+			if tt.wantSucc && !tt.wantHidden {
+				mockBook.EXPECT().MarkConnected(gomock.AssignableToTypeOf(p2pcommon.RemoteInfo{})).Times(1)
+			}
 
 			pm := &peerManager{
 				peerFactory:     mockPeerFactory,
+				addrBook:        mockBook,
 				designatedPeers: desigPeers,
 				hiddenPeerSet:   hiddenPeers,
 				logger:          logger,
@@ -592,6 +601,7 @@ func Test_peerManager_tryRegisterCollision(t *testing.T) {
 			pm := &peerManager{
 				is:              mockIS,
 				peerFactory:     mockPeerFactory,
+				addrBook:        addrbook.NewDummyAddressBook(),
 				designatedPeers: make(map[types.PeerID]p2pcommon.PeerMeta),
 				hiddenPeerSet:   make(map[types.PeerID]bool),
 				logger:          logger,
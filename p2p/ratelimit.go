@@ -0,0 +1,147 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/metric"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/p2p/p2putil"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/aergoio/aergo/v2/types/message"
+)
+
+// reqBurstSeconds is how many seconds of requests can be accepted at once
+const reqBurstSeconds = 2
+
+// rateLimiter limits requests from remote peers, and bandwidth to send to them. Requests are throttled while upload of
+// a peer or of all peers exceeds its cap. Upload caps are checked only by requests which need heavy response, since
+// other messages like notices or responses of local requests can't be refused.
+type rateLimiter struct {
+	reqRates   map[p2pcommon.SubProtocol]int
+	peerUpload int64
+	globalCap  int64
+	global     *p2putil.TokenBucket
+
+	mutex         sync.Mutex
+	peers         map[types.PeerID]*peerRateLimiter
+	deadThrottled int64
+}
+
+var _ p2pcommon.PeerEventListener = (*rateLimiter)(nil)
+
+func newRateLimiter(conf *config.P2PConfig) *rateLimiter {
+	rl := &rateLimiter{
+		reqRates: map[p2pcommon.SubProtocol]int{
+			p2pcommon.GetBlocksRequest: conf.NPBlockReqRate,
+			p2pcommon.GetHashesRequest: conf.NPHashReqRate,
+			p2pcommon.GetTXsRequest:    conf.NPTxReqRate,
+		},
+		peerUpload: conf.NPPeerUploadRate,
+		globalCap:  conf.NPUploadRate,
+		global:     p2putil.NewTokenBucket(float64(conf.NPUploadRate), float64(conf.NPUploadRate)),
+		peers:      make(map[types.PeerID]*peerRateLimiter),
+	}
+	return rl
+}
+
+// newPeerLimiter creates limiter for a newly connected remote peer
+func (rl *rateLimiter) newPeerLimiter(pid types.PeerID, manageNum uint32) *peerRateLimiter {
+	l := &peerRateLimiter{rl: rl, manageNum: manageNum,
+		reqBuckets: make(map[p2pcommon.SubProtocol]*p2putil.TokenBucket, len(rl.reqRates)),
+		upload:     p2putil.NewTokenBucket(float64(rl.peerUpload), float64(rl.peerUpload)),
+	}
+	for protocol, rate := range rl.reqRates {
+		l.reqBuckets[protocol] = p2putil.NewTokenBucket(float64(rate), float64(rate*reqBurstSeconds))
+	}
+	rl.mutex.Lock()
+	rl.peers[pid] = l
+	rl.mutex.Unlock()
+	return l
+}
+
+func (rl *rateLimiter) OnPeerConnect(pid types.PeerID) {
+}
+
+func (rl *rateLimiter) OnPeerDisconnect(peer p2pcommon.RemotePeer) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	if l, found := rl.peers[peer.ID()]; found && l.manageNum == peer.ManageNumber() {
+		rl.deadThrottled += l.Throttled()
+		delete(rl.peers, peer.ID())
+	}
+}
+
+// fillPeerInfos sets current upload rate, cap and throttled count of each peer. Self peer has summary of all peers.
+func (rl *rateLimiter) fillPeerInfos(infos []*message.PeerInfo, mm metric.MetricsManager) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+	var selfInfo *message.PeerInfo
+	totalThrottled := rl.deadThrottled
+	for _, l := range rl.peers {
+		totalThrottled += l.Throttled()
+	}
+	for _, pi := range infos {
+		if pi.Self {
+			selfInfo = pi
+			continue
+		}
+		pid := types.PeerID(pi.Addr.PeerID)
+		pi.UploadCap = rl.peerUpload
+		if met, found := mm.Metric(pid); found {
+			pi.UploadRate = met.OutMetric.APS()
+		}
+		if l, found := rl.peers[pid]; found {
+			pi.Throttled = l.Throttled()
+		}
+	}
+	if selfInfo != nil {
+		selfInfo.UploadCap = rl.globalCap
+		for _, met := range mm.Metrics() {
+			selfInfo.UploadRate += met.OutMetric.APS()
+		}
+		selfInfo.Throttled = totalThrottled
+	}
+}
+
+// peerRateLimiter limits requests of a remote peer. It also listens written bytes to the peer to track upload.
+type peerRateLimiter struct {
+	rl         *rateLimiter
+	manageNum  uint32
+	reqBuckets map[p2pcommon.SubProtocol]*p2putil.TokenBucket
+	upload     *p2putil.TokenBucket
+	throttled  int64
+}
+
+var _ p2pcommon.MsgIOListener = (*peerRateLimiter)(nil)
+
+// allowRequest returns whether the request can be handled now. The protocols which are not limited are always allowed.
+func (l *peerRateLimiter) allowRequest(protocol p2pcommon.SubProtocol) bool {
+	bucket, limited := l.reqBuckets[protocol]
+	if !limited {
+		return true
+	}
+	if l.upload.Available() && l.rl.global.Available() && bucket.Allow() {
+		return true
+	}
+	atomic.AddInt64(&l.throttled, 1)
+	return false
+}
+
+func (l *peerRateLimiter) Throttled() int64 {
+	return atomic.LoadInt64(&l.throttled)
+}
+
+func (l *peerRateLimiter) OnRead(protocol p2pcommon.SubProtocol, read int) {
+}
+
+func (l *peerRateLimiter) OnWrite(protocol p2pcommon.SubProtocol, write int) {
+	l.upload.Take(float64(write))
+	l.rl.global.Take(float64(write))
+}
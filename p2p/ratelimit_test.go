@@ -0,0 +1,88 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/v2/config"
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/p2p/p2pmock"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_peerRateLimiter_allowRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     *config.P2PConfig
+		protocol p2pcommon.SubProtocol
+		written  int
+		reqCnt   int
+
+		wantAllowed int
+	}{
+		{"TNotLimited", &config.P2PConfig{NPBlockReqRate: 1}, p2pcommon.PingRequest, 0, 10, 10},
+		{"TUnlimited", &config.P2PConfig{NPBlockReqRate: 0}, p2pcommon.GetBlocksRequest, 0, 10, 10},
+		{"TReqRate", &config.P2PConfig{NPBlockReqRate: 3}, p2pcommon.GetBlocksRequest, 0, 10, 3 * reqBurstSeconds},
+		{"TOtherClass", &config.P2PConfig{NPBlockReqRate: 1, NPTxReqRate: 4}, p2pcommon.GetTXsRequest, 0, 10, 4 * reqBurstSeconds},
+		{"TPeerUpload", &config.P2PConfig{NPHashReqRate: 10, NPPeerUploadRate: 1000}, p2pcommon.GetHashesRequest, 2000, 10, 0},
+		{"TGlobalUpload", &config.P2PConfig{NPHashReqRate: 10, NPUploadRate: 1000}, p2pcommon.GetHashesRequest, 2000, 10, 0},
+		{"TUnderUpload", &config.P2PConfig{NPHashReqRate: 10, NPUploadRate: 1000}, p2pcommon.GetHashesRequest, 500, 10, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := newRateLimiter(tt.conf)
+			l := rl.newPeerLimiter(types.RandomPeerID(), 1)
+			l.OnWrite(p2pcommon.GetHashesResponse, tt.written)
+
+			allowed := 0
+			for i := 0; i < tt.reqCnt; i++ {
+				if l.allowRequest(tt.protocol) {
+					allowed++
+				}
+			}
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowRequest() allowed = %v, want %v", allowed, tt.wantAllowed)
+			}
+			if l.Throttled() != int64(tt.reqCnt-allowed) {
+				t.Errorf("Throttled() = %v, want %v", l.Throttled(), tt.reqCnt-allowed)
+			}
+		})
+	}
+}
+
+func Test_rateLimiter_OnPeerDisconnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pid := types.RandomPeerID()
+	rl := newRateLimiter(&config.P2PConfig{NPBlockReqRate: 1})
+	l := rl.newPeerLimiter(pid, 2)
+	for i := 0; i < 5; i++ {
+		l.allowRequest(p2pcommon.GetBlocksRequest)
+	}
+
+	// old connection of same peer must not remove current limiter
+	oldPeer := p2pmock.NewMockRemotePeer(ctrl)
+	oldPeer.EXPECT().ID().Return(pid).AnyTimes()
+	oldPeer.EXPECT().ManageNumber().Return(uint32(1)).AnyTimes()
+	rl.OnPeerDisconnect(oldPeer)
+	if _, found := rl.peers[pid]; !found {
+		t.Fatalf("OnPeerDisconnect() removed limiter of different connection")
+	}
+
+	curPeer := p2pmock.NewMockRemotePeer(ctrl)
+	curPeer.EXPECT().ID().Return(pid).AnyTimes()
+	curPeer.EXPECT().ManageNumber().Return(uint32(2)).AnyTimes()
+	rl.OnPeerDisconnect(curPeer)
+	if _, found := rl.peers[pid]; found {
+		t.Errorf("OnPeerDisconnect() limiter is not removed")
+	}
+	if rl.deadThrottled != 3 {
+		t.Errorf("OnPeerDisconnect() deadThrottled = %v, want %v", rl.deadThrottled, 3)
+	}
+}
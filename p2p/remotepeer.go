@@ -74,6 +74,11 @@ type remotePeerImpl struct {
 	txQueueLock         *sync.Mutex
 	txNoticeQueue       *p2putil.PressableQueue
 	maxTxNoticeHashSize int
+	// txNoticeBudget is the number of tx hashes which can be sent to this peer until next txNoticeInterval
+	txNoticeBudget int
+	noticeListener p2pcommon.NoticeListener
+	// limiter limits requests from remote peer. nil means unlimited
+	limiter *peerRateLimiter
 
 	rw p2pcommon.MsgReadWriter
 
@@ -106,6 +111,7 @@ func newRemotePeer(remote p2pcommon.RemoteInfo, manageNum uint32, pm p2pcommon.P
 		txQueueLock:         &sync.Mutex{},
 		txNoticeQueue:       p2putil.NewPressableQueue(DefaultPeerTxQueueSize),
 		maxTxNoticeHashSize: DefaultPeerTxQueueSize,
+		txNoticeBudget:      DefaultPeerTxNoticeRate,
 		taskChannel:         make(chan p2pcommon.PeerTask, 1),
 	}
 	rPeer.writeBuf = make(chan p2pcommon.MsgOrder, pm.MsgBufSize())
@@ -300,6 +306,14 @@ func (p *remotePeerImpl) handleMsg(msg p2pcommon.Message) (err error) {
 		return fmt.Errorf("invalid protocol %s", subProto)
 	}
 
+	if p.limiter != nil && !p.limiter.allowRequest(subProto) {
+		p.logger.Debug().Str(p2putil.LogPeerName, p.Name()).Stringer(p2putil.LogMsgID, msg.ID()).Stringer(p2putil.LogProtoID, subProto).Msg("request exceeds rate limit")
+		if tr, ok := handler.(p2pcommon.ThrottleResponder); ok {
+			tr.RespondThrottled(msg)
+		}
+		return nil
+	}
+
 	handler.PreHandle()
 
 	payload, err := handler.ParsePayload(msg.Payload())
@@ -389,8 +403,9 @@ func (p *remotePeerImpl) PushTxsNotice(txHashes []types.TxID) {
 	for _, hash := range txHashes {
 		if !p.txNoticeQueue.Offer(hash) {
 			p.sendTxNotices()
-			// this Offer is always succeeded by invariant
-			p.txNoticeQueue.Offer(hash)
+			// the queue can be still full if the rate limit is exceeded. the oldest hash is dropped then, since the
+			// peer can get tx notice from other peers.
+			p.txNoticeQueue.Press(hash)
 		}
 	}
 }
@@ -436,35 +451,38 @@ func (p *remotePeerImpl) writeToPeer(m p2pcommon.MsgOrder) {
 	}
 }
 
+// trySendTxNotices is called every txNoticeInterval, and it also refills the rate limit budget.
 func (p *remotePeerImpl) trySendTxNotices() {
 	p.txQueueLock.Lock()
 	defer p.txQueueLock.Unlock()
+	p.txNoticeBudget = DefaultPeerTxNoticeRate
 	p.sendTxNotices()
 }
 
-// sendTxNotices must be called in txQueueLock
+// sendTxNotices must be called in txQueueLock. Hashes exceeding rate limit are remained in queue and sent later.
 func (p *remotePeerImpl) sendTxNotices() {
-	// make create multiple message if queue is too many hashes.
-	for {
-		// no need to send if queue is empty
-		if p.txNoticeQueue.Size() == 0 {
-			return
-		}
-		hashes := make([][]byte, 0, p.txNoticeQueue.Size())
-		skippedTxIDs := make([]types.TxID, 0)
-		for element := p.txNoticeQueue.Poll(); element != nil; element = p.txNoticeQueue.Poll() {
-			hash := element.(types.TxID)
-			if p.txHashCache.Contains(hash) {
-				skippedTxIDs = append(skippedTxIDs, hash)
-				continue
-			}
-			hashes = append(hashes, hash[:])
-			p.txHashCache.Add(hash, cachePlaceHolder)
+	// no need to send if queue is empty
+	if p.txNoticeQueue.Size() == 0 || p.txNoticeBudget <= 0 {
+		return
+	}
+	hashes := make([][]byte, 0, p.txNoticeQueue.Size())
+	for p.txNoticeBudget > 0 {
+		element := p.txNoticeQueue.Poll()
+		if element == nil {
+			break
 		}
-		if len(hashes) > 0 {
-			mo := p.mf.NewMsgTxBroadcastOrder(&types.NewTransactionsNotice{TxHashes: hashes})
-			p.SendMessage(mo)
+		hash := element.(types.TxID)
+		// skip hashes which remote peer already have
+		if p.txHashCache.Contains(hash) {
+			continue
 		}
+		hashes = append(hashes, hash[:])
+		p.txHashCache.Add(hash, cachePlaceHolder)
+		p.txNoticeBudget--
+	}
+	if len(hashes) > 0 {
+		mo := p.mf.NewMsgTxBroadcastOrder(&types.NewTransactionsNotice{TxHashes: hashes})
+		p.SendMessage(mo)
 	}
 }
 
@@ -520,6 +538,9 @@ func (p *remotePeerImpl) UpdateBlkCache(blkHash types.BlockID, blkNumber types.B
 	p.UpdateLastNotice(blkHash, blkNumber)
 	// lru cache can't accept byte slice key
 	found, _ := p.blkHashCache.ContainsOrAdd(blkHash, true)
+	if found && p.noticeListener != nil {
+		p.noticeListener.OnDupBlkNotice()
+	}
 	return found
 }
 
@@ -531,9 +552,22 @@ func (p *remotePeerImpl) UpdateTxCache(hashes []types.TxID) []types.TxID {
 			added = append(added, hash)
 		}
 	}
+	if dupCnt := len(hashes) - len(added); dupCnt > 0 && p.noticeListener != nil {
+		p.noticeListener.OnDupTxNotice(dupCnt)
+	}
 	return added
 }
 
+func (p *remotePeerImpl) UnknownTxs(hashes []types.TxID) []types.TxID {
+	unknown := make([]types.TxID, 0, len(hashes))
+	for _, hash := range hashes {
+		if !p.txHashCache.Contains(hash) {
+			unknown = append(unknown, hash)
+		}
+	}
+	return unknown
+}
+
 func (p *remotePeerImpl) UpdateLastNotice(blkHash types.BlockID, blkNumber types.BlockNo) {
 	p.lastStatus = &types.LastBlockStatus{CheckTime: time.Now(), BlockHash: blkHash[:], BlockNumber: blkNumber}
 }
@@ -466,14 +466,17 @@ func TestRemotePeerImpl_pushTxsNotice(t *testing.T) {
 	tests := []struct {
 		name       string
 		in         []types.TxID
+		budget     int
 		expectSend int
 	}{
 		// 1. single tx
-		{"TSingle", sampleHashes[:1], 0},
+		{"TSingle", sampleHashes[:1], DefaultPeerTxNoticeRate, 0},
 		// 2, multiple tx less than capacity
-		{"TSmall", sampleHashes[:maxTxHashSize], 0},
+		{"TSmall", sampleHashes[:maxTxHashSize], DefaultPeerTxNoticeRate, 0},
 		// 3. multiple tx more than capacity. last one is not sent but just queued.
-		{"TLarge", sampleHashes[:maxTxHashSize*3+1], 3},
+		{"TLarge", sampleHashes[:maxTxHashSize*3+1], DefaultPeerTxNoticeRate, 3},
+		// 4. sending is stopped when rate limit is exceeded, and old hashes are dropped instead.
+		{"TRateLimit", sampleHashes[:maxTxHashSize*3+1], maxTxHashSize + 5, 2},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -493,8 +496,12 @@ func TestRemotePeerImpl_pushTxsNotice(t *testing.T) {
 			p := newRemotePeer(sampleRemote, 0, mockPeerManager, nil, logger, mockMF, mockSigner, nil)
 			p.txNoticeQueue = p2putil.NewPressableQueue(maxTxHashSize)
 			p.maxTxNoticeHashSize = maxTxHashSize
+			p.txNoticeBudget = test.budget
 
 			p.PushTxsNotice(test.in)
+			if p.txNoticeQueue.Size() > maxTxHashSize {
+				t.Errorf("PushTxsNotice() queue size = %v, want not exceed %v", p.txNoticeQueue.Size(), maxTxHashSize)
+			}
 		})
 	}
 }
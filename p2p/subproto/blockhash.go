@@ -18,6 +18,8 @@ type getHashRequestHandler struct {
 	BaseMsgHandler
 }
 
+var _ p2pcommon.ThrottleResponder = (*getHashRequestHandler)(nil)
+
 type getHashResponseHandler struct {
 	BaseMsgHandler
 }
@@ -33,6 +35,12 @@ func (bh *getHashRequestHandler) ParsePayload(rawbytes []byte) (p2pcommon.Messag
 	return p2putil.UnmarshalAndReturn(rawbytes, &types.GetHashesRequest{})
 }
 
+// RespondThrottled answers busy response to the hashes request which exceeds rate limit.
+func (bh *getHashRequestHandler) RespondThrottled(msg p2pcommon.Message) {
+	resp := &types.GetHashesResponse{Status: types.ResultStatus_RESOURCE_EXHAUSTED}
+	bh.peer.SendMessage(bh.peer.MF().NewMsgResponseOrder(msg.ID(), p2pcommon.GetHashesResponse, resp))
+}
+
 func (bh *getHashRequestHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.MessageBody) {
 	remotePeer := bh.peer
 	data := msgBody.(*types.GetHashesRequest)
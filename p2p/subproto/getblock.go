@@ -22,6 +22,7 @@ type blockRequestHandler struct {
 }
 
 var _ p2pcommon.MessageHandler = (*blockRequestHandler)(nil)
+var _ p2pcommon.ThrottleResponder = (*blockRequestHandler)(nil)
 
 type blockResponseHandler struct {
 	BaseMsgHandler
@@ -60,6 +61,12 @@ func (bh *blockRequestHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.M
 	}
 }
 
+// RespondThrottled answers busy response to the block request which exceeds rate limit.
+func (bh *blockRequestHandler) RespondThrottled(msg p2pcommon.Message) {
+	resp := &types.GetBlockResponse{Status: types.ResultStatus_RESOURCE_EXHAUSTED}
+	bh.peer.SendMessage(bh.peer.MF().NewMsgResponseOrder(msg.ID(), p2pcommon.GetBlocksResponse, resp))
+}
+
 func (bh *blockRequestHandler) handleBlkReq(msg p2pcommon.Message, data *types.GetBlockRequest) {
 	defer bh.release()
 	remotePeer := bh.peer
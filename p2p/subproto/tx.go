@@ -21,6 +21,7 @@ type txRequestHandler struct {
 }
 
 var _ p2pcommon.MessageHandler = (*txRequestHandler)(nil)
+var _ p2pcommon.ThrottleResponder = (*txRequestHandler)(nil)
 
 type txResponseHandler struct {
 	BaseMsgHandler
@@ -61,6 +62,12 @@ func (th *txRequestHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.Mess
 	}
 }
 
+// RespondThrottled answers busy response to the tx request which exceeds rate limit. The requester will retry later.
+func (th *txRequestHandler) RespondThrottled(msg p2pcommon.Message) {
+	resp := &types.GetTransactionsResponse{Status: types.ResultStatus_RESOURCE_EXHAUSTED}
+	th.peer.SendMessage(th.peer.MF().NewMsgResponseOrder(msg.ID(), p2pcommon.GetTXsResponse, resp))
+}
+
 // newTxRespHandler creates handler for GetTransactionsResponse
 func NewTxRespHandler(pm p2pcommon.PeerManager, peer p2pcommon.RemotePeer, logger *log.Logger, actor p2pcommon.ActorService) *txResponseHandler {
 	th := &txResponseHandler{BaseMsgHandler{protocol: p2pcommon.GetTXsResponse, pm: pm, peer: peer, actor: actor, logger: logger}}
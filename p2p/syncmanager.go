@@ -58,7 +58,7 @@ func (sm *syncManager) HandleBlockProducedNotice(peer p2pcommon.RemotePeer, bloc
 		return
 	}
 	// check if block size is over the limit
-	if block.Size() > int(chain.MaxBlockSize()) {
+	if block.Size() > int(chain.MaxBlockSizeLimit()) {
 		sm.logger.Info().Str(p2putil.LogPeerName, peer.Name()).Str(p2putil.LogBlkHash, block.BlockID().String()).Int("size", block.Size()).Msg("invalid blockProduced notice. block size exceed limit")
 		return
 	}
@@ -108,7 +108,7 @@ func (sm *syncManager) HandleGetBlockResponse(peer p2pcommon.RemotePeer, msg p2p
 	}
 	block := blocks[0]
 	// check if block size is over the limit
-	if block.Size() > int(chain.MaxBlockSize()) {
+	if block.Size() > int(chain.MaxBlockSizeLimit()) {
 		sm.logger.Info().Str(p2putil.LogPeerName, peer.Name()).Str(p2putil.LogBlkHash, block.BlockID().String()).Int("size", block.Size()).Msg("cancel to add block. block size exceed limit")
 		return
 	}
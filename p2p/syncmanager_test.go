@@ -29,8 +29,11 @@ func TestSyncManager_HandleBlockProducedNotice(t *testing.T) {
 	logger := log.NewLogger("test.p2p")
 	sampleBlock := &types.Block{Hash: dummyBlockHash}
 	txs := make([]*types.Tx, 1)
-	txs[0] = &types.Tx{Hash: make([]byte, 1024*1024*2)}
+	txs[0] = &types.Tx{Hash: make([]byte, 1024*1024*9)}
 	sampleBigBlock := &types.Block{Hash: dummyBlockHash, Body: &types.BlockBody{Txs: txs}}
+	// larger than the block size of the node, as a block after the activation of a voted block size
+	largeTxs := []*types.Tx{{Hash: make([]byte, 1024*1024*2)}}
+	sampleLargeBlock := &types.Block{Hash: dummyBlockHash, Body: &types.BlockBody{Txs: largeTxs}}
 	var blkHash = types.ToBlockID(dummyBlockHash)
 	// test if new block notice comes
 	tests := []struct {
@@ -45,6 +48,7 @@ func TestSyncManager_HandleBlockProducedNotice(t *testing.T) {
 		// 2. Rare case - valid block hash but already exist in local cache
 		{"TExist", &blkHash, sampleBlock, false},
 		{"TTooBigBlock", nil, sampleBigBlock, false},
+		{"TLargeBlock", nil, sampleLargeBlock, true},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,56 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/types"
+)
+
+// txGossipFanout returns the number of peers to which new txs are noticed. Noticing to square root of peers is enough to
+// spread txs to whole network, since each receiver relays them again.
+func txGossipFanout(peerCnt int) int {
+	fanout := int(math.Ceil(math.Sqrt(float64(peerCnt))))
+	if fanout < minTxGossipFanout {
+		fanout = minTxGossipFanout
+	}
+	return fanout
+}
+
+// selectTxNoticeReceivers selects peers to which tx notice is sent. Peers which are known to have all txs are excluded.
+// Block producers and agents always receive notice, since they need txs to make blocks, and others are chosen randomly
+// up to fanout.
+func selectTxNoticeReceivers(peers []p2pcommon.RemotePeer, hashes []types.TxID) []p2pcommon.RemotePeer {
+	running := 0
+	receivers := make([]p2pcommon.RemotePeer, 0, len(peers))
+	candidates := make([]p2pcommon.RemotePeer, 0, len(peers))
+	for _, rPeer := range peers {
+		if rPeer == nil || rPeer.State() != types.RUNNING {
+			continue
+		}
+		running++
+		if len(rPeer.UnknownTxs(hashes)) == 0 {
+			continue
+		}
+		switch rPeer.AcceptedRole() {
+		case types.PeerRole_Producer, types.PeerRole_Agent:
+			receivers = append(receivers, rPeer)
+		default:
+			candidates = append(candidates, rPeer)
+		}
+	}
+	fanout := txGossipFanout(running)
+	if len(candidates) > fanout {
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		candidates = candidates[:fanout]
+	}
+	return append(receivers, candidates...)
+}
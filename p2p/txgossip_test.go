@@ -0,0 +1,90 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/aergoio/aergo/v2/p2p/p2pmock"
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_txGossipFanout(t *testing.T) {
+	tests := []struct {
+		name    string
+		peerCnt int
+		want    int
+	}{
+		{"TZero", 0, minTxGossipFanout},
+		{"TSmall", 9, minTxGossipFanout},
+		{"TSquare", 100, 10},
+		{"TCeil", 101, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := txGossipFanout(tt.peerCnt); got != tt.want {
+				t.Errorf("txGossipFanout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_selectTxNoticeReceivers(t *testing.T) {
+	hashes := []types.TxID{generateHash(1), generateHash(2)}
+	tests := []struct {
+		name     string
+		watchers int
+		bps      int
+		knownAll int
+		stopped  int
+
+		wantCnt int
+		wantBPs int
+	}{
+		{"TFew", 3, 0, 0, 0, 3, 0},
+		{"TFanout", 100, 0, 0, 0, 10, 0},
+		{"TBPAlways", 95, 5, 0, 0, 15, 5},
+		{"TExcludeKnown", 2, 0, 3, 0, 2, 0},
+		{"TExcludeStopped", 2, 0, 0, 3, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			peers := make([]p2pcommon.RemotePeer, 0)
+			addPeers := func(cnt int, role types.PeerRole, state types.PeerState, unknown []types.TxID) {
+				for i := 0; i < cnt; i++ {
+					mPeer := p2pmock.NewMockRemotePeer(ctrl)
+					mPeer.EXPECT().State().Return(state).AnyTimes()
+					mPeer.EXPECT().AcceptedRole().Return(role).AnyTimes()
+					mPeer.EXPECT().UnknownTxs(gomock.Any()).Return(unknown).AnyTimes()
+					peers = append(peers, mPeer)
+				}
+			}
+			addPeers(tt.watchers, types.PeerRole_Watcher, types.RUNNING, hashes)
+			addPeers(tt.bps, types.PeerRole_Producer, types.RUNNING, hashes[:1])
+			addPeers(tt.knownAll, types.PeerRole_Watcher, types.RUNNING, nil)
+			addPeers(tt.stopped, types.PeerRole_Watcher, types.STOPPED, hashes)
+
+			got := selectTxNoticeReceivers(peers, hashes)
+			if len(got) != tt.wantCnt {
+				t.Fatalf("selectTxNoticeReceivers() len = %v, want %v", len(got), tt.wantCnt)
+			}
+			bpCnt := 0
+			for _, p := range got {
+				if p.AcceptedRole() == types.PeerRole_Producer {
+					bpCnt++
+				}
+			}
+			if bpCnt != tt.wantBPs {
+				t.Errorf("selectTxNoticeReceivers() producers = %v, want %v", bpCnt, tt.wantBPs)
+			}
+		})
+	}
+}
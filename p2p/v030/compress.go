@@ -0,0 +1,63 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package v030
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/v2/p2p/p2pcommon"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The highest byte of subprotocol field in message header is used as compression flag. Subprotocols never use that byte,
+// so peers which do not support compression still read the field with same value as before.
+const (
+	compressFlagShift = 24
+	subProtocolMask   = 0x00ffffff
+)
+
+var (
+	// encoder and decoder of zstd are safe for concurrent use with EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(uint64(p2pcommon.MaxPayloadLength)))
+)
+
+func compressPayload(compType p2pcommon.CompressionType, payload []byte) ([]byte, error) {
+	switch compType {
+	case p2pcommon.CompressSnappy:
+		return snappy.Encode(nil, payload), nil
+	case p2pcommon.CompressZstd:
+		return zstdEncoder.EncodeAll(payload, make([]byte, 0, len(payload)>>1)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compType)
+	}
+}
+
+func decompressPayload(compType p2pcommon.CompressionType, payload []byte) ([]byte, error) {
+	switch compType {
+	case p2pcommon.CompressSnappy:
+		size, err := snappy.DecodedLen(payload)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(size) > p2pcommon.MaxPayloadLength {
+			return nil, fmt.Errorf("too big payload")
+		}
+		return snappy.Decode(nil, payload)
+	case p2pcommon.CompressZstd:
+		decoded, err := zstdDecoder.DecodeAll(payload, nil)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(len(decoded)) > p2pcommon.MaxPayloadLength {
+			return nil, fmt.Errorf("too big payload")
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compType)
+	}
+}
@@ -23,9 +23,14 @@ type V030ReadWriter struct {
 	writeBuf [msgHeaderLength]byte
 	c        io.Closer
 
+	compType      p2pcommon.CompressionType
+	compThreshold int
+
 	ls []p2pcommon.MsgIOListener
 }
 
+var _ p2pcommon.MsgCompressor = (*V030ReadWriter)(nil)
+
 func NewV030MsgPipe(s io.ReadWriteCloser) *V030ReadWriter {
 	return NewV030ReadWriter(s, s, s)
 }
@@ -53,6 +58,12 @@ func (rw *V030ReadWriter) AddIOListener(l p2pcommon.MsgIOListener) {
 	rw.ls = append(rw.ls, l)
 }
 
+// SetCompression must be called before the first WriteMsg after handshake, since it is not guarded by lock.
+func (rw *V030ReadWriter) SetCompression(compType p2pcommon.CompressionType, threshold int) {
+	rw.compType = compType
+	rw.compThreshold = threshold
+}
+
 // ReadMsg() must be used in single thread
 func (rw *V030ReadWriter) ReadMsg() (p2pcommon.Message, error) {
 	readN := 0
@@ -66,7 +77,7 @@ func (rw *V030ReadWriter) ReadMsg() (p2pcommon.Message, error) {
 		return nil, fmt.Errorf("invalid msgHeader")
 	}
 
-	msg, bodyLen := parseHeader(rw.readBuf)
+	msg, compType, bodyLen := parseHeader(rw.readBuf)
 	if bodyLen > p2pcommon.MaxPayloadLength {
 		return nil, fmt.Errorf("too big payload")
 	}
@@ -80,6 +91,15 @@ func (rw *V030ReadWriter) ReadMsg() (p2pcommon.Message, error) {
 		return nil, fmt.Errorf("failed to read paylod of msg %s %s : payload length mismatch", msg.Subprotocol().String(), msg.ID())
 	}
 
+	if compType != p2pcommon.CompressNone {
+		compressed := len(payload)
+		payload, err = decompressPayload(compType, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress paylod of msg %s %s : %s", msg.Subprotocol().String(), msg.ID(), err.Error())
+		}
+		rw.notifyCompression(msg.Subprotocol(), true, compressed, len(payload))
+	}
+
 	msg.SetPayload(payload)
 	for _, l := range rw.ls {
 		l.OnRead(msg.Subprotocol(), readN)
@@ -111,7 +131,8 @@ func (rw *V030ReadWriter) WriteMsg(msg p2pcommon.Message) error {
 		return fmt.Errorf("too big payload")
 	}
 
-	rw.marshalHeader(msg)
+	payload, compType := rw.compress(msg)
+	rw.marshalHeader(msg, compType, uint32(len(payload)))
 	written, err := rw.w.Write(rw.writeBuf[:])
 	if err != nil {
 		return err
@@ -120,12 +141,12 @@ func (rw *V030ReadWriter) WriteMsg(msg p2pcommon.Message) error {
 	if written != msgHeaderLength {
 		return fmt.Errorf("header is not written")
 	}
-	written, err = rw.w.Write(msg.Payload())
+	written, err = rw.w.Write(payload)
 	if err != nil {
 		return err
 	}
 	writeN += written
-	if written != int(msg.Length()) {
+	if written != len(payload) {
 		return fmt.Errorf("wrong write")
 	}
 	for _, l := range rw.ls {
@@ -134,18 +155,47 @@ func (rw *V030ReadWriter) WriteMsg(msg p2pcommon.Message) error {
 	return rw.w.Flush()
 }
 
-func parseHeader(buf [msgHeaderLength]byte) (*p2pcommon.MessageValue, uint32) {
-	subProtocol := p2pcommon.SubProtocol(binary.BigEndian.Uint32(buf[0:4]))
+// compress returns the payload to write and compression type of it. The original payload is returned if compression is
+// disabled, payload is smaller than threshold, or compressed one is not smaller than the original.
+func (rw *V030ReadWriter) compress(msg p2pcommon.Message) ([]byte, p2pcommon.CompressionType) {
+	payload := msg.Payload()
+	if rw.compType == p2pcommon.CompressNone || len(payload) < rw.compThreshold {
+		return payload, p2pcommon.CompressNone
+	}
+	compressed, err := compressPayload(rw.compType, payload)
+	if err != nil || len(compressed) >= len(payload) {
+		return payload, p2pcommon.CompressNone
+	}
+	rw.notifyCompression(msg.Subprotocol(), false, len(compressed), len(payload))
+	return compressed, rw.compType
+}
+
+func (rw *V030ReadWriter) notifyCompression(protocol p2pcommon.SubProtocol, read bool, compressed, original int) {
+	for _, l := range rw.ls {
+		if cl, ok := l.(p2pcommon.MsgCompressionListener); ok {
+			if read {
+				cl.OnDecompressed(protocol, compressed, original)
+			} else {
+				cl.OnCompressed(protocol, original, compressed)
+			}
+		}
+	}
+}
+
+func parseHeader(buf [msgHeaderLength]byte) (*p2pcommon.MessageValue, p2pcommon.CompressionType, uint32) {
+	rawProtocol := binary.BigEndian.Uint32(buf[0:4])
+	subProtocol := p2pcommon.SubProtocol(rawProtocol & subProtocolMask)
+	compType := p2pcommon.CompressionType(rawProtocol >> compressFlagShift)
 	length := binary.BigEndian.Uint32(buf[4:8])
 	timestamp := int64(binary.BigEndian.Uint64(buf[8:16]))
 	msgID := p2pcommon.MustParseBytes(buf[16:32])
 	orgID := p2pcommon.MustParseBytes(buf[32:48])
-	return p2pcommon.NewLiteMessageValue(subProtocol, msgID, orgID, timestamp), length
+	return p2pcommon.NewLiteMessageValue(subProtocol, msgID, orgID, timestamp), compType, length
 }
 
-func (rw *V030ReadWriter) marshalHeader(m p2pcommon.Message) {
-	binary.BigEndian.PutUint32(rw.writeBuf[0:4], m.Subprotocol().Uint32())
-	binary.BigEndian.PutUint32(rw.writeBuf[4:8], m.Length())
+func (rw *V030ReadWriter) marshalHeader(m p2pcommon.Message, compType p2pcommon.CompressionType, length uint32) {
+	binary.BigEndian.PutUint32(rw.writeBuf[0:4], m.Subprotocol().Uint32()|uint32(compType)<<compressFlagShift)
+	binary.BigEndian.PutUint32(rw.writeBuf[4:8], length)
 	binary.BigEndian.PutUint64(rw.writeBuf[8:16], uint64(m.Timestamp()))
 
 	msgID := m.ID()
@@ -120,9 +120,77 @@ func Test_ReadWrite(t *testing.T) {
 	}
 }
 
+func Test_ReadWriteCompressed(t *testing.T) {
+	var sampleID p2pcommon.MsgID
+	sampleUUID, _ := uuid.NewV4()
+	copy(sampleID[:], sampleUUID[:])
+	bigHashes := make([][]byte, 0, len(sampleTxs)*1000)
+	for i := 0; i < 1000; i++ {
+		bigHashes = append(bigHashes, sampleTxs...)
+	}
+
+	tests := []struct {
+		name      string
+		compType  p2pcommon.CompressionType
+		threshold int
+		ids       [][]byte
+
+		wantCompressed bool
+	}{
+		{"TNone", p2pcommon.CompressNone, 0, bigHashes, false},
+		{"TSnappy", p2pcommon.CompressSnappy, 1024, bigHashes, true},
+		{"TZstd", p2pcommon.CompressZstd, 1024, bigHashes, true},
+		{"TUnderThreshold", p2pcommon.CompressSnappy, 1024, sampleTxs, false},
+		{"TEmpty", p2pcommon.CompressZstd, 0, nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sizeChecker, sizeChecker2 := ioSum{}, ioSum{}
+			samplePData := &types.NewTransactionsNotice{TxHashes: test.ids}
+			payload, _ := proto.Encode(samplePData)
+			sample := p2pcommon.NewMessageValue(p2pcommon.NewTxNotice, sampleID, p2pcommon.EmptyID, time.Now().UnixNano(), payload)
+
+			buf := bytes.NewBuffer(nil)
+			target := NewV030ReadWriter(nil, buf, nil)
+			target.SetCompression(test.compType, test.threshold)
+			target.AddIOListener(&sizeChecker)
+
+			err := target.WriteMsg(sample)
+			assert.Nil(t, err)
+
+			actual := buf.Bytes()
+			assert.Equal(t, test.wantCompressed, len(actual) < len(payload)+msgHeaderLength)
+			assert.Equal(t, len(actual), sizeChecker.writeN)
+			assert.Equal(t, len(payload)-len(actual)+msgHeaderLength, sizeChecker.savedOut)
+
+			// reader decompresses message regardless of its own setting
+			rd := NewV030ReadWriter(bufio.NewReader(buf), ioutil.Discard, nil)
+			rd.AddIOListener(&sizeChecker2)
+
+			readMsg, err := rd.ReadMsg()
+			assert.Nil(t, err)
+			assert.Equal(t, sample, readMsg)
+			assert.Equal(t, p2pcommon.NewTxNotice, readMsg.Subprotocol())
+			assert.Equal(t, sizeChecker.writeN, sizeChecker2.readN)
+			assert.Equal(t, sizeChecker.savedOut, sizeChecker2.savedIn)
+		})
+	}
+}
+
 type ioSum struct {
 	readN  int
 	writeN int
+
+	savedIn  int
+	savedOut int
+}
+
+func (s *ioSum) OnDecompressed(protocol p2pcommon.SubProtocol, compressed, original int) {
+	s.savedIn += original - compressed
+}
+
+func (s *ioSum) OnCompressed(protocol p2pcommon.SubProtocol, original, compressed int) {
+	s.savedOut += original - compressed
 }
 
 func (s *ioSum) OnRead(protocol p2pcommon.SubProtocol, read int) {
@@ -93,6 +93,7 @@ func (h *V200Handshaker) DoForOutbound(ctx context.Context) (*p2pcommon.Handshak
 	if err = h.checkRemoteStatus(remotePeerStatus); err != nil {
 		return nil, err
 	} else {
+		h.setupCompression(remotePeerStatus)
 		hsResult := &p2pcommon.HandshakeResult{Meta: h.remoteMeta, BestBlockHash: h.remoteHash, BestBlockNo: h.remoteNo, MsgRW: h.msgRW, Certificates: h.remoteCerts, Hidden: remotePeerStatus.NoExpose}
 		return hsResult, nil
 	}
@@ -235,6 +236,7 @@ func (h *V200Handshaker) DoForInbound(ctx context.Context) (*p2pcommon.Handshake
 	if err != nil {
 		return nil, err
 	}
+	h.setupCompression(remotePeerStatus)
 	hsResult := &p2pcommon.HandshakeResult{Meta: h.remoteMeta, BestBlockHash: h.remoteHash, BestBlockNo: h.remoteNo, MsgRW: h.msgRW, Certificates: h.remoteCerts, Hidden: remotePeerStatus.NoExpose}
 	return hsResult, nil
 }
@@ -255,6 +257,20 @@ func (h *V200Handshaker) sendGoAway(msg string) {
 	}
 }
 
+// setupCompression enables compression of outgoing messages if remote peer can decompress it. It must be called after
+// local status is sent, since the status message itself must not be compressed.
+func (h *V200Handshaker) setupCompression(status *types.Status) {
+	setting := h.is.LocalSettings().Compression
+	compType := setting.Negotiate(p2pcommon.Capability(status.GetCapabilities()))
+	if compType == p2pcommon.CompressNone {
+		return
+	}
+	if compressor, ok := h.msgRW.(p2pcommon.MsgCompressor); ok {
+		h.logger.Debug().Stringer(p2putil.LogPeerID, types.LogPeerShort(h.peerID)).Stringer("compression", compType).Msg("enabling message compression")
+		compressor.SetCompression(compType, setting.Threshold)
+	}
+}
+
 func (h *V200Handshaker) checkByRole(status *types.Status) error {
 	if h.remoteMeta.Role == types.PeerRole_Agent {
 		err := h.checkAgent(status)
@@ -315,6 +331,7 @@ func (h *V200Handshaker) createLocalStatus(chainID *types.ChainID, bestBlock *ty
 		NoExpose:      h.selfMeta.Hidden,
 		Version:       p2pkey.NodeVersion(),
 		Genesis:       h.localGenesisHash,
+		Capabilities:  uint32(p2pcommon.LocalCapabilities),
 	}
 
 	if h.selfMeta.Role == types.PeerRole_Agent {
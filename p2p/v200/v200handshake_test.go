@@ -122,6 +122,7 @@ func TestV200StatusHS_doForOutbound(t *testing.T) {
 	dummyAddr := dummyMeta.ToPeerAddress()
 	dummyBlock := &types.Block{Hash: dummyBlockHash, Header: &types.BlockHeader{BlockNo: dummyBlockHeight}}
 	mockIS.EXPECT().SelfMeta().Return(dummyMeta).AnyTimes()
+	mockIS.EXPECT().LocalSettings().Return(p2pcommon.LocalSettings{}).AnyTimes()
 	mockIS.EXPECT().GetChainAccessor().Return(mockCA).AnyTimes()
 	mockCA.EXPECT().GetBestBlock().Return(dummyBlock, nil).AnyTimes()
 
@@ -229,6 +230,7 @@ func TestV200VersionedHS_DoForInbound(t *testing.T) {
 	dummyBlock := &types.Block{Hash: dummyBlockHash, Header: &types.BlockHeader{BlockNo: dummyBlockHeight}}
 	//dummyBlkRsp := message.GetBestBlockRsp{Block: dummyBlock}
 	mockIS.EXPECT().SelfMeta().Return(dummyMeta).AnyTimes()
+	mockIS.EXPECT().LocalSettings().Return(p2pcommon.LocalSettings{}).AnyTimes()
 	mockIS.EXPECT().GetChainAccessor().Return(mockCA).AnyTimes()
 	mockCA.EXPECT().GetBestBlock().Return(dummyBlock, nil).AnyTimes()
 
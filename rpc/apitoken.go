@@ -0,0 +1,216 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aergoio/aergo/v2/types"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiTokenKey is the context key of the hash of the api token authorized.
+type apiTokenKey struct{}
+
+// apiToken is an api token with its rate limiter.
+type apiToken struct {
+	*types.APIToken
+	limiter *rate.Limiter
+}
+
+func newAPIToken(v string) (*apiToken, error) {
+	t, err := types.ParseAPIToken(v)
+	if err != nil {
+		return nil, err
+	}
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if t.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(t.Rate), int(t.Rate))
+	}
+	return &apiToken{APIToken: t, limiter: limiter}, nil
+}
+
+func parseAPITokens(values []string) map[string]*apiToken {
+	ret := map[string]*apiToken{}
+	for _, v := range values {
+		t, err := newAPIToken(v)
+		if err != nil {
+			logger.Warn().Err(err).Msg("invalid api token config")
+			continue
+		}
+		ret[t.Hash] = t
+	}
+	return ret
+}
+
+// setLocalAPITokens sets the api tokens in the config of the node. If
+// required, the requests without a valid api token are rejected, even with a
+// client certificate permitted. It fails on an invalid token, or if no token
+// is available while required.
+func (rpc *AergoRPCService) setLocalAPITokens(values []string, required bool) error {
+	tokens := map[string]*apiToken{}
+	for _, v := range values {
+		t, err := newAPIToken(v)
+		if err != nil {
+			return err
+		}
+		tokens[t.Hash] = t
+	}
+
+	rpc.apiTokenLock.Lock()
+	defer rpc.apiTokenLock.Unlock()
+
+	if required && len(tokens) == 0 && len(rpc.enterpriseTokens) == 0 {
+		return errors.New("api token is required, but no api token is configured")
+	}
+	rpc.localTokens = tokens
+	rpc.apiTokenRequired = required
+	return nil
+}
+
+func (rpc *AergoRPCService) setEnterpriseAPITokens(conf *types.EnterpriseConfig) {
+	rpc.apiTokenLock.Lock()
+	defer rpc.apiTokenLock.Unlock()
+
+	rpc.enterpriseTokens = parseAPITokens(conf.GetValues())
+	rpc.enterpriseTokensOn = conf.GetOn()
+}
+
+func (rpc *AergoRPCService) setEnterpriseAPITokensOn(v bool) {
+	rpc.apiTokenLock.Lock()
+	defer rpc.apiTokenLock.Unlock()
+
+	logger.Info().Bool("value", v).Msg("rpc api tokens of enterprise")
+	rpc.enterpriseTokensOn = v
+}
+
+func (rpc *AergoRPCService) setEnterpriseAPITokensMap(v []string) {
+	rpc.apiTokenLock.Lock()
+	defer rpc.apiTokenLock.Unlock()
+
+	// the values of the event are all the tokens in the conf
+	rpc.enterpriseTokens = parseAPITokens(v)
+}
+
+func (rpc *AergoRPCService) lookupAPIToken(token string) *apiToken {
+	rpc.apiTokenLock.RLock()
+	defer rpc.apiTokenLock.RUnlock()
+
+	hash := types.HashAPIToken(token)
+	if t, ok := rpc.localTokens[hash]; ok {
+		return t
+	}
+	if t, ok := rpc.enterpriseTokens[hash]; ok && rpc.enterpriseTokensOn {
+		return t
+	}
+	return nil
+}
+
+func (rpc *AergoRPCService) isAPITokenRequired() bool {
+	rpc.apiTokenLock.RLock()
+	defer rpc.apiTokenLock.RUnlock()
+	return rpc.apiTokenRequired
+}
+
+// authorizeAPIToken checks that the api token allows the method within its
+// rate limit. The returned context is marked as authorized, so that the
+// permission of the client certificate is not checked; see checkAuth. A
+// request without api token is rejected if the token is required, or passed to
+// the permission check.
+func (rpc *AergoRPCService) authorizeAPIToken(ctx context.Context, token, method string) (context.Context, error) {
+	if token == "" {
+		if rpc.isAPITokenRequired() {
+			return nil, status.Error(codes.Unauthenticated, "api token required")
+		}
+		return ctx, nil
+	}
+	t := rpc.lookupAPIToken(token)
+	if t == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid api token")
+	}
+	if !t.Allows(method) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed for the api token", method)
+	}
+	if !t.limiter.Allow() {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return context.WithValue(ctx, apiTokenKey{}, t.Hash), nil
+}
+
+func isAPITokenAuthorized(ctx context.Context) bool {
+	return ctx.Value(apiTokenKey{}) != nil
+}
+
+// AuthorizeHTTPRequest checks the api token in the Authorization header of a
+// web3 request for the method.
+func (rpc *AergoRPCService) AuthorizeHTTPRequest(r *http.Request, method string) (*http.Request, error) {
+	ctx, err := rpc.authorizeAPIToken(r.Context(), bearerToken(r.Header.Get("Authorization")), method)
+	if err != nil {
+		return nil, err
+	}
+	return r.WithContext(ctx), nil
+}
+
+func (rpc *AergoRPCService) unaryAPITokenInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := rpc.authorizeAPIToken(ctx, tokenFromMetadata(ctx), methodName(info.FullMethod))
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (rpc *AergoRPCService) streamAPITokenInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := rpc.authorizeAPIToken(ss.Context(), tokenFromMetadata(ss.Context()), methodName(info.FullMethod))
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorizedStream is a server stream with the context authorized by the api
+// token.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// methodName returns the method name of the full method (e.g.
+// /types.AergoRPCService/GetBlock).
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	return bearerToken(values[0])
+}
+
+func bearerToken(header string) string {
+	const prefix = "Bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
@@ -0,0 +1,95 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAergoRPCService_APIToken(t *testing.T) {
+	rpc := &AergoRPCService{}
+	readToken, limitedToken := "read-only", "limited"
+	assert.NoError(t, rpc.setLocalAPITokens([]string{
+		types.HashAPIToken(readToken) + ":GetBlock,GetTX:0",
+		types.HashAPIToken(limitedToken) + ":*:1",
+	}, false))
+
+	call := func(token, method string) (bool, error) {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		var authorized bool
+		_, err := rpc.unaryAPITokenInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/types.AergoRPCService/" + method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				authorized = isAPITokenAuthorized(ctx)
+				return nil, rpc.checkAuth(ctx, ReadBlockChain)
+			})
+		return authorized, err
+	}
+
+	authorized, err := call(readToken, "GetBlock")
+	assert.NoError(t, err)
+	assert.True(t, authorized)
+	_, err = call(readToken, "SendTX")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = call("unknown", "GetBlock")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = call(limitedToken, "SendTX")
+	assert.NoError(t, err)
+	_, err = call(limitedToken, "SendTX")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	authorized, err = call("", "GetBlock")
+	assert.NoError(t, err, "without token, the permission is checked as before")
+	assert.False(t, authorized)
+	assert.NoError(t, rpc.setLocalAPITokens([]string{types.HashAPIToken(readToken) + ":GetBlock:0"}, true))
+	_, err = call("", "GetBlock")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "token required")
+
+	// the token is checked by its allow-list instead of the permission of the client certificate
+	rpc.setClientAuth(&types.EnterpriseConfig{On: true, Values: []string{"cert:R"}})
+	authorized, err = call(readToken, "GetBlock")
+	assert.True(t, authorized)
+	assert.NoError(t, err, "no client certificate needed")
+	_, err = call(readToken, "SendTX")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = call("", "GetBlock")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "token required with the rpc permissions")
+	assert.NoError(t, rpc.setLocalAPITokens([]string{types.HashAPIToken(readToken) + ":GetBlock:0"}, false))
+	_, err = call("", "GetBlock")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "no client certificate")
+	rpc.setClientAuthOn(false)
+
+	// an invalid config fails
+	assert.Error(t, rpc.setLocalAPITokens([]string{"invalid:*:0"}, false))
+	assert.Error(t, rpc.setLocalAPITokens(nil, true), "no token available")
+	assert.NoError(t, rpc.setLocalAPITokens(nil, false))
+
+	// the tokens of the enterprise contract are valid while it is on
+	rpc.setEnterpriseAPITokens(&types.EnterpriseConfig{Values: []string{types.HashAPIToken(readToken) + ":*:0"}})
+	_, err = call(readToken, "GetBlock")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	rpc.setEnterpriseAPITokensOn(true)
+	_, err = call(readToken, "GetBlock")
+	assert.NoError(t, err)
+	assert.NoError(t, rpc.setLocalAPITokens(nil, true), "the tokens of the enterprise contract are available")
+
+	req := httptest.NewRequest("GET", "/v1/getBlock", nil)
+	req.Header.Set("Authorization", "Bearer "+readToken)
+	req, err = rpc.AuthorizeHTTPRequest(req, "getBlock")
+	assert.NoError(t, err)
+	assert.True(t, isAPITokenAuthorized(req.Context()))
+}
@@ -49,7 +49,13 @@ func (rpc *AergoRPCService) setClientAuthMap(v []string) {
 	rpc.clientAuthLock.Unlock()
 }
 
+// checkAuth checks the permission of the client certificate for the request. A request with an api token is checked by
+// the allow-list of the token in the interceptor instead, so that it needs no client certificate.
 func (rpc *AergoRPCService) checkAuth(ctx context.Context, auth Authentication) error {
+	if isAPITokenAuthorized(ctx) {
+		return nil
+	}
+
 	rpc.clientAuthLock.RLock()
 	defer rpc.clientAuthLock.RUnlock()
 
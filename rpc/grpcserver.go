@@ -70,6 +70,12 @@ type AergoRPCService struct {
 	clientAuthOn   bool
 	clientAuth     map[string]Authentication
 
+	apiTokenLock       sync.RWMutex
+	apiTokenRequired   bool
+	localTokens        map[string]*apiToken
+	enterpriseTokens   map[string]*apiToken
+	enterpriseTokensOn bool
+
 	types.UnimplementedAergoRPCServiceServer
 }
 
@@ -125,7 +131,8 @@ func (rpc *AergoRPCService) fillPeerMetrics(result *types.Metrics) {
 	mets := make([]*types.PeerMetric, len(metrics))
 	for i, met := range metrics {
 		rMet := &types.PeerMetric{PeerID: []byte(met.PeerID), SumIn: met.TotalIn(), AvrIn: met.InMetric.APS(),
-			SumOut: met.TotalOut(), AvrOut: met.OutMetric.APS()}
+			SumOut: met.TotalOut(), AvrOut: met.OutMetric.APS(), SavedIn: met.SavedIn(), SavedOut: met.SavedOut(),
+			DupTxNotice: met.DupTxNotice(), DupBlkNotice: met.DupBlkNotice()}
 		mets[i] = rMet
 	}
 
@@ -993,7 +1000,8 @@ func (rpc *AergoRPCService) GetPeers(ctx context.Context, in *types.PeersParams)
 	ret := &types.PeerList{Peers: make([]*types.Peer, 0, len(rsp.Peers))}
 	for _, pi := range rsp.Peers {
 		blkNotice := &types.NewBlockNotice{BlockHash: pi.LastBlockHash, BlockNo: pi.LastBlockNumber}
-		peer := &types.Peer{Address: pi.Addr, State: int32(pi.State), Bestblock: blkNotice, LashCheck: pi.CheckTime.UnixNano(), Hidden: pi.Hidden, Selfpeer: pi.Self, Version: pi.Version, Certificates: pi.Certificates, AcceptedRole: pi.AcceptedRole}
+		peer := &types.Peer{Address: pi.Addr, State: int32(pi.State), Bestblock: blkNotice, LashCheck: pi.CheckTime.UnixNano(), Hidden: pi.Hidden, Selfpeer: pi.Self, Version: pi.Version, Certificates: pi.Certificates, AcceptedRole: pi.AcceptedRole,
+			UploadRate: pi.UploadRate, UploadCap: pi.UploadCap, Throttled: pi.Throttled}
 		ret.Peers = append(ret.Peers, peer)
 	}
 
@@ -1092,6 +1100,63 @@ func (rpc *AergoRPCService) GetStaking(ctx context.Context, in *types.AccountAdd
 	return rsp.Staking, rsp.Err
 }
 
+// GetKeyRotations handles rpc request of the signing key and the key rotation history of an account.
+func (rpc *AergoRPCService) GetKeyRotations(ctx context.Context, in *types.AccountAddress) (*types.KeyRotationList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Value) > types.AddressLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Only support valid address")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetKeyRotations{Addr: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetKeyRotations").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetKeyRotationsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Rotations, rsp.Err
+}
+
+// GetDelegations handles rpc request of the stake delegation of an account and the delegations it received.
+func (rpc *AergoRPCService) GetDelegations(ctx context.Context, in *types.AccountAddress) (*types.DelegationList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Value) > types.AddressLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Only support valid address")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetDelegations{Addr: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetDelegations").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetDelegationsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Delegations, rsp.Err
+}
+
+// GetSystemParams handles rpc request of the governable system params with their pending values.
+func (rpc *AergoRPCService) GetSystemParams(ctx context.Context, in *types.Empty) (*types.SystemParamList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetSystemParams{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetSystemParams").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetSystemParamsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Params, rsp.Err
+}
+
 func (rpc *AergoRPCService) GetNameInfo(ctx context.Context, in *types.Name) (*types.NameInfo, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
@@ -1114,6 +1179,46 @@ func (rpc *AergoRPCService) GetNameInfo(ctx context.Context, in *types.Name) (*t
 	return rsp.Owner, rsp.Err
 }
 
+// GetReverseName handles rpc request of the name of an address verified by its forward record.
+func (rpc *AergoRPCService) GetReverseName(ctx context.Context, in *types.AccountAddress) (*types.NameInfo, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Value) != types.AddressLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Only support valid address")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetReverseName{Addr: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetReverseName").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetReverseNameRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Info, rsp.Err
+}
+
+// GetNameRecords handles rpc request of the text records of a name.
+func (rpc *AergoRPCService) GetNameRecords(ctx context.Context, in *types.Name) (*types.NameRecordList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Name) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "input name is empty")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetNameRecords{Name: in.Name, BlockNo: in.BlockNo}, defaultActorTimeout, "rpc.(*AergoRPCService).GetNameRecords").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetNameRecordsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Records, rsp.Err
+}
+
 func (rpc *AergoRPCService) GetReceipt(ctx context.Context, in *types.SingleBytes) (*types.Receipt, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
@@ -1149,6 +1254,144 @@ func (rpc *AergoRPCService) GetInternalOperations(ctx context.Context, in *types
 	return &types.SingleBytes{Value: []byte(rsp.Operations)}, rsp.Err
 }
 
+// TraceTx handles rpc request of re-executing a tx on the state of its parent block. It takes long since the previous
+// txs in the block are also executed. The trace lists warnings where the replay may differ from the execution in the
+// block, such as the reads of the sql databases, which are as of the parent block. It needs the permission to control
+// the node, since the replay of the block is as costly as its execution.
+func (rpc *AergoRPCService) TraceTx(ctx context.Context, in *types.SingleBytes) (*types.SingleBytes, error) {
+	if err := rpc.checkAuth(ctx, ControlNode); err != nil {
+		return nil, err
+	}
+	if len(in.Value) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "input tx hash is empty")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.TraceTx{TxHash: in.Value}, halfMinute, "rpc.(*AergoRPCService).TraceTx").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.TraceTxRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to trace tx: %s", rsp.Err.Error())
+	}
+	return &types.SingleBytes{Value: []byte(rsp.Trace)}, nil
+}
+
+// ProfileTx handles rpc request of a dry run of a tx with the gas profiler. The tx is executed on the state of the best
+// block and is not committed. It needs the permission to control the node, since the dry run holds the chain lock.
+func (rpc *AergoRPCService) ProfileTx(ctx context.Context, in *types.Tx) (*types.SingleBytes, error) {
+	if err := rpc.checkAuth(ctx, ControlNode); err != nil {
+		return nil, err
+	}
+	if in.GetBody() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "input tx is empty")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ProfileTx{Tx: in}, halfMinute, "rpc.(*AergoRPCService).ProfileTx").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.ProfileTxRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to profile tx: %s", rsp.Err.Error())
+	}
+	return &types.SingleBytes{Value: []byte(rsp.Profile)}, nil
+}
+
+// GetScheduledCallReceipts handles rpc request of the receipts of the scheduled calls executed at the start of a block.
+func (rpc *AergoRPCService) GetScheduledCallReceipts(ctx context.Context, in *types.BlockNumberParam) (*types.SingleBytes, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetScheduledCallReceipts{BlockNo: in.BlockNo}, defaultActorTimeout, "rpc.(*AergoRPCService).GetScheduledCallReceipts").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetScheduledCallReceiptsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return &types.SingleBytes{Value: []byte(rsp.Receipts)}, rsp.Err
+}
+
+// ListScheduledCalls handles rpc request of the queued scheduled calls of a contract. All of the queued calls are
+// returned if the address is empty.
+func (rpc *AergoRPCService) ListScheduledCalls(ctx context.Context, in *types.SingleBytes) (*types.SingleBytes, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListScheduledCalls{Contract: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).ListScheduledCalls").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.ListScheduledCallsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled calls: %s", rsp.Err.Error())
+	}
+	return &types.SingleBytes{Value: []byte(rsp.Calls)}, nil
+}
+
+// VerifyContractSource compiles the source of a contract and registers it on the node if it matches the deployed code.
+func (rpc *AergoRPCService) VerifyContractSource(ctx context.Context, in *types.ContractSource) (*types.ContractSource, error) {
+	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.ContractAddress) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "input address is empty")
+	}
+	if len(in.Source) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "input source is empty")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.VerifyContractSource{Contract: in.ContractAddress, Source: in.Source}, defaultActorTimeout,
+		"rpc.(*AergoRPCService).VerifyContractSource").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.VerifyContractSourceRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to verify the source: %s", rsp.Err.Error())
+	}
+	return rsp.Source, nil
+}
+
+// GetContractSource returns the verified source of a contract.
+func (rpc *AergoRPCService) GetContractSource(ctx context.Context, in *types.SingleBytes) (*types.ContractSource, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Value) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "input address is empty")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetContractSource{Contract: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetContractSource").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetContractSourceRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", rsp.Err.Error())
+	}
+	return rsp.Source, nil
+}
+
 func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (*types.ABI, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
@@ -1175,6 +1418,9 @@ func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query)
 	if len(in.ContractAddress) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "input contract info is empty")
 	}
+	if err := rpc.checkQueryArgs(in); err != nil {
+		return nil, err
+	}
 	result, err := rpc.hub.RequestFuture(message.ChainSvc,
 		&message.GetQuery{Contract: in.ContractAddress, Queryinfo: in.Queryinfo}, defaultActorTimeout, "rpc.(*AergoRPCService).QueryContract").Result()
 	if err != nil {
@@ -1187,6 +1433,30 @@ func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query)
 	return &types.SingleBytes{Value: rsp.Result}, rsp.Err
 }
 
+// checkQueryArgs validates the arguments of the query against the type annotations of the typed ABI. The query
+// is passed to the contract as it is if the ABI or the function has no type annotations.
+func (rpc *AergoRPCService) checkQueryArgs(in *types.Query) error {
+	var ci types.CallInfo
+	if err := json.Unmarshal(in.Queryinfo, &ci); err != nil {
+		return nil
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetABI{Contract: in.ContractAddress}, defaultActorTimeout, "rpc.(*AergoRPCService).checkQueryArgs").Result()
+	if err != nil {
+		return err
+	}
+	rsp, ok := result.(message.GetABIRsp)
+	if !ok || rsp.Err != nil || rsp.ABI.GetVersion() != types.ABIVersionTyped {
+		return nil
+	}
+	if fn := rsp.ABI.GetFunction(ci.Name); fn != nil {
+		if err = fn.CheckArgs(ci.Args); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid arguments: %v", err)
+		}
+	}
+	return nil
+}
+
 // QueryContractState queries the state of a contract state variable without executing a contract function.
 func (rpc *AergoRPCService) QueryContractState(ctx context.Context, in *types.StateQuery) (*types.StateQueryProof, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
@@ -1349,6 +1619,54 @@ func (rpc *AergoRPCService) GetEnterpriseConfig(ctx context.Context, in *types.E
 	return rsp.Conf, nil
 }
 
+// ListEnterpriseHistory returns the audit log of aergo.enterprise, latest first.
+func (rpc *AergoRPCService) ListEnterpriseHistory(ctx context.Context, in *types.EnterpriseHistoryQuery) (*types.EnterpriseHistoryList, error) {
+	genesis := rpc.actorHelper.GetChainAccessor().GetGenesisInfo()
+	if genesis.PublicNet() {
+		return nil, status.Error(codes.Unavailable, "not supported in public")
+	}
+
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if in.ToBlock != 0 && in.FromBlock > in.ToBlock {
+		return nil, status.Errorf(codes.InvalidArgument, "fromBlock is greater than toBlock")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListEnterpriseHistory{Query: in}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEnterpriseHistory").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.ListEnterpriseHistoryRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.History, rsp.Err
+}
+
+// ListEnterpriseProposals returns the approval policy of aergo.enterprise and
+// the proposals pending for approvals.
+func (rpc *AergoRPCService) ListEnterpriseProposals(ctx context.Context, in *types.Empty) (*types.EnterpriseProposalList, error) {
+	genesis := rpc.actorHelper.GetChainAccessor().GetGenesisInfo()
+	if genesis.PublicNet() {
+		return nil, status.Error(codes.Unavailable, "not supported in public")
+	}
+
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListEnterpriseProposals{}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEnterpriseProposals").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.ListEnterpriseProposalsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Proposals, rsp.Err
+}
+
 func (rpc *AergoRPCService) GetConfChangeProgress(ctx context.Context, in *types.SingleBytes) (*types.ConfChangeProgress, error) {
 	var (
 		progress *types.ConfChangeProgress
@@ -6,6 +6,8 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"github.com/aergoio/aergo/v2/types/utils"
 	"math/big"
@@ -23,6 +25,11 @@ import (
 	"github.com/aergoio/aergo/v2/types/message"
 	"github.com/aergoio/aergo/v2/types/message/messagemock"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func TestAergoRPCService_dummys(t *testing.T) {
@@ -237,3 +244,23 @@ func TestAergoRPCService_GetBlockIncompleteArg(t *testing.T) {
 		})
 	}
 }
+
+func TestAergoRPCService_TraceTxPermission(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("client certificate")}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}}})
+	rpc := &AergoRPCService{}
+
+	// tracing a tx needs the permission to control the node, as profiling a tx does
+	rpc.setClientAuth(&types.EnterpriseConfig{On: true, Values: []string{types.EncodeB64(cert.Raw) + ":R"}})
+	_, err := rpc.TraceTx(ctx, &types.SingleBytes{Value: dummyTxHash})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = rpc.ProfileTx(ctx, &types.Tx{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	rpc.setClientAuth(&types.EnterpriseConfig{On: true, Values: []string{types.EncodeB64(cert.Raw) + ":RC"}})
+	_, err = rpc.TraceTx(ctx, &types.SingleBytes{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -75,7 +75,10 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 		opts = append(opts, grpc.StreamInterceptor(otgrpc.OpenTracingStreamServerInterceptor(tracer)))
 	}
 
-	var entConf *types.EnterpriseConfig
+	opts = append(opts, grpc.ChainUnaryInterceptor(actualServer.unaryAPITokenInterceptor))
+	opts = append(opts, grpc.ChainStreamInterceptor(actualServer.streamAPITokenInterceptor))
+
+	var entConf, tokenConf *types.EnterpriseConfig
 	genesis := chainAccessor.GetGenesisInfo()
 	if !genesis.ID.PublicNet {
 		conf, err := chainAccessor.GetEnterpriseConfig("rpcpermissions")
@@ -84,6 +87,12 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 		} else {
 			entConf = conf
 		}
+		conf, err = chainAccessor.GetEnterpriseConfig("rpctokens")
+		if err != nil {
+			logger.Error().Err(err).Msg("could not get api tokens")
+		} else {
+			tokenConf = conf
+		}
 	}
 
 	if cfg.RPC.NSEnableTLS {
@@ -136,6 +145,10 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 
 	actualServer.actorHelper = rpcsvc
 	actualServer.setClientAuth(entConf)
+	actualServer.setEnterpriseAPITokens(tokenConf)
+	if err := actualServer.setLocalAPITokens(cfg.RPC.APITokens, cfg.RPC.RequireAPIToken); err != nil {
+		logger.Fatal().Err(err).Msg("invalid api token config")
+	}
 
 	rpcsvc.httpServer = &http.Server{
 		Handler:        rpcsvc.grpcWebHandlerFunc(grpcWebServer, http.DefaultServeMux, actualServer),
@@ -204,6 +217,8 @@ func (ns *RPC) Receive(context actor.Context) {
 							value = true
 						}
 						server.setClientAuthOn(value)
+					} else if conf == enterprise.RPCTokens {
+						server.setEnterpriseAPITokensOn(e.JsonArgs == "true")
 					} else if conf == enterprise.AccountWhite {
 						value := false
 						if e.JsonArgs == "true" {
@@ -226,6 +241,12 @@ func (ns *RPC) Receive(context actor.Context) {
 							return
 						}
 						server.setClientAuthMap(values)
+					} else if conf == enterprise.RPCTokens {
+						var values []string
+						if err := json.Unmarshal([]byte(e.JsonArgs), &values); err != nil {
+							return
+						}
+						server.setEnterpriseAPITokensMap(values)
 					} else if conf == enterprise.AccountWhite {
 						values := make([]string, 1024)
 						if err := json.Unmarshal([]byte(e.JsonArgs), &values); err != nil {
@@ -245,6 +266,8 @@ func (ns *RPC) Receive(context actor.Context) {
 						}
 						ns.TellTo(message.P2PSvc, msg)
 					}
+				case "Audit", "Propose", "Approve", "Reject", "Expire":
+					// the audit log and the proposals, whose config is handled by the event of the change
 				default:
 					logger.Warn().Str("Enterprise event", eventName[0]).Str("conf", conf).Msg("unknown message in RPCPERMISSION")
 				}
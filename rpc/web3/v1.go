@@ -45,6 +45,7 @@ func (api *Web3APIv1) NewHandler() {
 		"/queryContract":           api.QueryContract,
 		"/listEvents":              api.ListEvents,
 		"/getABI":                  api.GetABI,
+		"/getContractSource":       api.GetContractSource,
 		"/queryContractStateProof": api.QueryContractState,
 		"/getTxCount":              api.GetBlockTransactionCount,
 		"/getChainInfo":            api.GetChainInfo,
@@ -63,11 +64,15 @@ func (api *Web3APIv1) NewHandler() {
 
 	if consensus.Type == "raft" {
 		handlerGet["/getEnterpriseConfig"] = api.GetEnterpriseConfig
+		handlerGet["/listEnterpriseHistory"] = api.ListEnterpriseHistory
+		handlerGet["/listEnterpriseProposals"] = api.ListEnterpriseProposals
 	} else if consensus.Type == "dpos" {
 		handlerGet["/getStaking"] = api.GetStaking
 		handlerGet["/getVotes"] = api.GetVotes
 		handlerGet["/getAccountVotes"] = api.GetAccountVotes
 		handlerGet["/getNameInfo"] = api.GetNameInfo
+		handlerGet["/getReverseName"] = api.GetReverseName
+		handlerGet["/getNameRecords"] = api.GetNameRecords
 	}
 
 	handlerPost := map[string]APIHandler{
@@ -226,7 +231,7 @@ func (api *Web3APIv1) GetNameInfo() (handler http.Handler, ok bool) {
 	if name != "" {
 		request.Name = name
 
-		if len(name) > types.NameLength {
+		if len(name) > types.NameLength && !types.IsSubName([]byte(name)) {
 			return commonResponseHandler(&types.Empty{}, errors.New("name is not in format 12 digits")), true
 		}
 	} else {
@@ -251,6 +256,67 @@ func (api *Web3APIv1) GetNameInfo() (handler http.Handler, ok bool) {
 	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
 }
 
+func (api *Web3APIv1) GetReverseName() (handler http.Handler, ok bool) {
+	values, err := url.ParseQuery(api.request.URL.RawQuery)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	// Params
+	request := &types.AccountAddress{}
+	address := values.Get("address")
+	if address != "" {
+		addressBytes, err := types.DecodeAddress(address)
+		if err != nil {
+			return commonResponseHandler(&types.Empty{}, err), true
+		}
+		request.Value = addressBytes
+	} else {
+		return commonResponseHandlerWithCode(&types.Empty{}, errors.New("Missing required parameter: address"), http.StatusBadRequest), true
+	}
+
+	result, err := api.rpc.GetReverseName(api.request.Context(), request)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	output := jsonrpc.ConvNameInfo(result)
+	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
+}
+
+func (api *Web3APIv1) GetNameRecords() (handler http.Handler, ok bool) {
+	values, err := url.ParseQuery(api.request.URL.RawQuery)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	// Params
+	request := &types.Name{}
+	name := values.Get("name")
+	if name != "" {
+		request.Name = name
+	} else {
+		return commonResponseHandlerWithCode(&types.Empty{}, errors.New("Missing required parameter: name"), http.StatusBadRequest), true
+	}
+
+	number := values.Get("number")
+	if number != "" {
+		numberValue, parseErr := strconv.ParseUint(number, 10, 64)
+		if parseErr != nil {
+			return commonResponseHandler(&types.Empty{}, parseErr), true
+		}
+		request.BlockNo = numberValue
+	}
+
+	result, err := api.rpc.GetNameRecords(api.request.Context(), request)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	output := jsonrpc.ConvNameRecords(result)
+	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
+}
+
 func (api *Web3APIv1) GetBalance() (handler http.Handler, ok bool) {
 	values, err := url.ParseQuery(api.request.URL.RawQuery)
 	if err != nil {
@@ -804,6 +870,19 @@ func (api *Web3APIv1) ListEvents() (handler http.Handler, ok bool) {
 		request.ArgFilter = []byte(argFilter)
 	}
 
+	// the indexed args in JSON, matched by position
+	for _, topic := range values["topic"] {
+		if topic == "" {
+			request.Topics = append(request.Topics, nil)
+			continue
+		}
+		topicHash, err := types.EventTopic([]byte(topic))
+		if err != nil {
+			return commonResponseHandler(&types.Empty{}, err), true
+		}
+		request.Topics = append(request.Topics, topicHash)
+	}
+
 	recentBlockCnt := values.Get("recentBlockCnt")
 	if recentBlockCnt != "" {
 		recentBlockCntValue, parseErr := strconv.ParseInt(recentBlockCnt, 10, 32)
@@ -852,6 +931,32 @@ func (api *Web3APIv1) GetABI() (handler http.Handler, ok bool) {
 	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
 }
 
+func (api *Web3APIv1) GetContractSource() (handler http.Handler, ok bool) {
+	values, err := url.ParseQuery(api.request.URL.RawQuery)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	// Params
+	request := &types.SingleBytes{}
+	address := values.Get("address")
+	if address != "" {
+		hashBytes, err := types.DecodeAddress(address)
+		if err != nil {
+			return commonResponseHandler(&types.Empty{}, err), true
+		}
+		request.Value = hashBytes
+	}
+
+	result, err := api.rpc.GetContractSource(api.request.Context(), request)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	output := jsonrpc.ConvContractSource(result)
+	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
+}
+
 func (api *Web3APIv1) GetAccountVotes() (handler http.Handler, ok bool) {
 	values, err := url.ParseQuery(api.request.URL.RawQuery)
 	if err != nil {
@@ -1169,6 +1274,59 @@ func (api *Web3APIv1) GetEnterpriseConfig() (handler http.Handler, ok bool) {
 	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
 }
 
+func (api *Web3APIv1) ListEnterpriseHistory() (handler http.Handler, ok bool) {
+	values, err := url.ParseQuery(api.request.URL.RawQuery)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	// Params
+	request := &types.EnterpriseHistoryQuery{
+		Key:    values.Get("key"),
+		Action: values.Get("action"),
+	}
+	admin := values.Get("admin")
+	if admin != "" {
+		request.Admin, err = types.DecodeAddress(admin)
+		if err != nil {
+			return commonResponseHandlerWithCode(&types.Empty{}, err, http.StatusBadRequest), true
+		}
+	}
+	for param, v := range map[string]*uint64{"from": &request.FromBlock, "to": &request.ToBlock, "before": &request.Before} {
+		if value := values.Get(param); value != "" {
+			if *v, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return commonResponseHandlerWithCode(&types.Empty{}, err, http.StatusBadRequest), true
+			}
+		}
+	}
+	limit := values.Get("limit")
+	if limit != "" {
+		limitValue, parseErr := strconv.ParseUint(limit, 10, 32)
+		if parseErr != nil {
+			return commonResponseHandlerWithCode(&types.Empty{}, parseErr, http.StatusBadRequest), true
+		}
+		request.Limit = uint32(limitValue)
+	}
+
+	result, err := api.rpc.ListEnterpriseHistory(api.request.Context(), request)
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	output := jsonrpc.ConvEnterpriseHistory(result)
+	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
+}
+
+func (api *Web3APIv1) ListEnterpriseProposals() (handler http.Handler, ok bool) {
+	result, err := api.rpc.ListEnterpriseProposals(api.request.Context(), &types.Empty{})
+	if err != nil {
+		return commonResponseHandler(&types.Empty{}, err), true
+	}
+
+	output := jsonrpc.ConvEnterpriseProposals(result)
+	return stringResponseHandler(jsonrpc.MarshalJSON(output), nil), true
+}
+
 func (api *Web3APIv1) CommitTX() (handler http.Handler, ok bool) {
 	body, err := io.ReadAll(api.request.Body)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-lib/log"
@@ -17,6 +18,8 @@ import (
 
 	"github.com/rs/cors"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Status string
@@ -75,6 +78,11 @@ func NewWeb3(cfg *config.Config, rpc *rpc.AergoRPCService) *Web3 {
 			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
 			return
 		}
+		r, err := rpc.AuthorizeHTTPRequest(r, strings.TrimPrefix(r.URL.Path, prefixV1+"/"))
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), httpStatusOf(err))
+			return
+		}
 		web3svc.handler(w, r)
 	})
 	mux.Handle("/v1/", c.Handler(limitedHandler))
@@ -133,6 +141,20 @@ func (web3 *Web3) Receive(context actor.Context) {
 	}
 }
 
+// httpStatusOf returns the http status of the error of the api token.
+func httpStatusOf(err error) int {
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func getPortFromConfig(cfg *config.Config) int {
 	if cfg == nil || cfg.Web3 == nil || cfg.Web3.NetServicePort == 0 {
 		return 80
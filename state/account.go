@@ -97,6 +97,17 @@ func (as *AccountState) StorageRoot() []byte {
 	return as.newState.StorageRoot
 }
 
+func (as *AccountState) SetSigningKey(signingKey []byte) {
+	as.newState.SigningKey = signingKey
+}
+
+func (as *AccountState) SigningKey() []byte {
+	if as.newState == nil {
+		return nil
+	}
+	return as.newState.SigningKey
+}
+
 func (as *AccountState) IsNew() bool {
 	return as.newOne
 }
@@ -17,6 +17,7 @@ type BlockState struct {
 	BpReward      big.Int // final bp reward, increment when tx executes
 	receipts      types.Receipts
 	internalOps   []string
+	scheduled     []string
 	CCProposal    *consensus.ConfChangePropose
 	prevBlockHash []byte
 	consensus     []byte // Consensus Header
@@ -93,12 +94,26 @@ func (bs *BlockState) InternalOps() string {
 	return "[" + strings.Join(bs.internalOps, ",") + "]"
 }
 
+func (bs *BlockState) AddScheduledCallReceipt(receipt string) {
+	bs.scheduled = append(bs.scheduled, receipt)
+}
+
+func (bs *BlockState) ScheduledCallReceipts() string {
+	if bs == nil || len(bs.scheduled) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(bs.scheduled, ",") + "]"
+}
+
 func (bs *BlockState) AddReceipt(r *types.Receipt) error {
 	if len(r.Events) > 0 {
 		rBloom := bloom.New(types.BloomBitBits, types.BloomHashKNum)
 		for _, e := range r.Events {
 			rBloom.Add(e.ContractAddress)
 			rBloom.Add([]byte(e.EventName))
+			for _, topic := range e.Topics {
+				rBloom.Add(topic)
+			}
 		}
 		binary, _ := rBloom.GobEncode()
 		r.Bloom = binary[24:]
@@ -111,6 +126,19 @@ func (bs *BlockState) AddReceipt(r *types.Receipt) error {
 	return nil
 }
 
+// AddTxResults adds the receipts, the internal operations and the bp reward of the txs executed on the other block
+// state, such as an overlay state which a tx is executed speculatively on.
+func (bs *BlockState) AddTxResults(other *BlockState) error {
+	for _, r := range other.receipts.Get() {
+		if err := bs.AddReceipt(r); err != nil {
+			return err
+		}
+	}
+	bs.internalOps = append(bs.internalOps, other.internalOps...)
+	bs.BpReward.Add(&bs.BpReward, &other.BpReward)
+	return nil
+}
+
 func (bs *BlockState) Receipts() *types.Receipts {
 	if bs == nil {
 		return nil
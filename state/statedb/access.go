@@ -0,0 +1,114 @@
+package statedb
+
+import (
+	"github.com/aergoio/aergo/v2/types"
+)
+
+// accessKey is an account state, or a storage key of the account if key is not empty.
+type accessKey struct {
+	account types.AccountID
+	key     types.HashID
+}
+
+// AccessSet records the account states and the storage keys which are read and written through a StateDB.
+// It is used to detect the conflicts between transactions executed in parallel. It is not safe for
+// concurrent use, so a StateDB recording accesses must be used by one goroutine at a time.
+type AccessSet struct {
+	reads  map[accessKey]struct{}
+	writes map[accessKey]struct{}
+}
+
+func NewAccessSet() *AccessSet {
+	return &AccessSet{
+		reads:  map[accessKey]struct{}{},
+		writes: map[accessKey]struct{}{},
+	}
+}
+
+func (a *AccessSet) readState(id types.AccountID) {
+	if a == nil {
+		return
+	}
+	a.reads[accessKey{account: id}] = struct{}{}
+}
+
+func (a *AccessSet) writeState(id types.AccountID) {
+	if a == nil {
+		return
+	}
+	a.writes[accessKey{account: id}] = struct{}{}
+}
+
+func (a *AccessSet) readStorage(id types.AccountID, key types.HashID) {
+	if a == nil {
+		return
+	}
+	a.reads[accessKey{account: id, key: key}] = struct{}{}
+}
+
+func (a *AccessSet) writeStorage(id types.AccountID, key types.HashID) {
+	if a == nil {
+		return
+	}
+	a.writes[accessKey{account: id, key: key}] = struct{}{}
+}
+
+// WrittenStates returns the accounts whose state is written.
+func (a *AccessSet) WrittenStates() []types.AccountID {
+	var ids []types.AccountID
+	for k := range a.writes {
+		if k.key == EmptyHashID {
+			ids = append(ids, k.account)
+		}
+	}
+	return ids
+}
+
+// Conflicts tells whether anything read in this set is written in the given set.
+func (a *AccessSet) Conflicts(written *AccessSet) bool {
+	small, large := a.reads, written.writes
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for k := range small {
+		if _, ok := large[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge adds the reads and the writes of the given set to this set.
+func (a *AccessSet) Merge(other *AccessSet) {
+	for k := range other.reads {
+		a.reads[k] = struct{}{}
+	}
+	for k := range other.writes {
+		a.writes[k] = struct{}{}
+	}
+}
+
+// CopyStorage puts the values of the storage keys written in the set, from the contract storages staged to the
+// given StateDB, to the contract storages of this StateDB. A key whose write is rolled back is not copied.
+func (states *StateDB) CopyStorage(from *StateDB, written *AccessSet) {
+	for k := range written.writes {
+		if k.key == EmptyHashID {
+			continue
+		}
+		src := from.Cache.get(k.account)
+		if src == nil {
+			continue
+		}
+		et := src.get(k.key)
+		if et == nil {
+			continue
+		}
+		dst := states.Cache.get(k.account)
+		if dst == nil {
+			// the storage root of the account doesn't change until the update of the block state
+			dst = newBufferedStorage(src.Trie.Root, states.Store)
+			states.Cache.put(k.account, dst)
+		}
+		dst.put(newValueEntry(k.key, et.Value()))
+	}
+}
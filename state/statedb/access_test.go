@@ -0,0 +1,138 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessSetRecord(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	testAddress := []byte("test_address")
+	otherAccount := types.ToAccountID([]byte("other_address"))
+
+	access := NewAccessSet()
+	stateDB.SetAccessSet(access)
+
+	// account states
+	_, err := stateDB.GetState(otherAccount)
+	assert.NoError(t, err)
+	err = stateDB.PutState(testAccount, testStates[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []types.AccountID{testAccount}, access.WrittenStates())
+
+	// contract storage
+	contractState, err := OpenContractStateAccount(testAddress, stateDB)
+	assert.NoError(t, err)
+	_, err = contractState.GetData([]byte("read_key"))
+	assert.NoError(t, err)
+	err = contractState.SetData([]byte("write_key"), []byte("value"))
+	assert.NoError(t, err)
+	assert.Len(t, access.writes, 2)
+	assert.Equal(t, []types.AccountID{testAccount}, access.WrittenStates())
+
+	// stop recording
+	stateDB.SetAccessSet(nil)
+	_, err = stateDB.GetState(types.ToAccountID([]byte("not_recorded")))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		record   func(a *AccessSet)
+		conflict bool
+	}{
+		{"readWrittenState", func(a *AccessSet) { a.readState(testAccount) }, true},
+		{"readOnlyReadState", func(a *AccessSet) { a.readState(otherAccount) }, false},
+		{"readWrittenKey", func(a *AccessSet) { a.readStorage(testAccount, types.GetHashID([]byte("write_key"))) }, true},
+		{"readOtherKey", func(a *AccessSet) { a.readStorage(testAccount, types.GetHashID([]byte("read_key"))) }, false},
+		{"writeReadState", func(a *AccessSet) { a.writeState(otherAccount) }, false},
+		{"readNotRecorded", func(a *AccessSet) { a.readState(types.ToAccountID([]byte("not_recorded"))) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAccessSet()
+			tt.record(a)
+			assert.Equal(t, tt.conflict, a.Conflicts(access))
+		})
+	}
+}
+
+func TestAccessSetMerge(t *testing.T) {
+	a := NewAccessSet()
+	a.writeState(testAccount)
+
+	b := NewAccessSet()
+	b.writeStorage(testAccount, types.GetHashID([]byte("key")))
+	b.writeStorage(testAccount, types.GetHashID([]byte("key")))
+	assert.Len(t, b.writes, 1)
+
+	a.Merge(b)
+	assert.Len(t, a.writes, 2)
+
+	r := NewAccessSet()
+	r.readStorage(testAccount, types.GetHashID([]byte("key")))
+	assert.True(t, r.Conflicts(a))
+	r = NewAccessSet()
+	r.readStorage(testAccount, types.GetHashID([]byte("other_key")))
+	assert.False(t, r.Conflicts(a))
+}
+
+func TestAccessSetUnchangedState(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	assert.NoError(t, stateDB.PutState(testAccount, testStates[0]))
+	assert.NoError(t, stateDB.Update())
+	assert.NoError(t, stateDB.Commit())
+
+	access := NewAccessSet()
+	stateDB.SetAccessSet(access)
+	defer stateDB.SetAccessSet(nil)
+
+	// putting back the same state is not a write
+	assert.NoError(t, stateDB.PutState(testAccount, testStates[0].Clone()))
+	assert.Empty(t, access.WrittenStates())
+
+	assert.NoError(t, stateDB.PutState(testAccount, testStates[1]))
+	assert.Equal(t, []types.AccountID{testAccount}, access.WrittenStates())
+}
+
+func TestCopyStorage(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	testAddress := []byte("test_address")
+
+	// the storage of the block state has a value written by a previous tx
+	contractState, err := OpenContractStateAccount(testAddress, stateDB)
+	assert.NoError(t, err)
+	assert.NoError(t, contractState.SetData([]byte("key1"), []byte("value0")))
+	assert.NoError(t, contractState.SetData([]byte("key2"), []byte("value0")))
+	assert.NoError(t, StageContractState(contractState, stateDB))
+
+	// a tx executed on an overlay state
+	overlay := NewStateDB(stateDB.Store, stateDB.GetRoot(), false)
+	access := NewAccessSet()
+	overlay.SetAccessSet(access)
+	contractState, err = OpenContractStateAccount(testAddress, overlay)
+	assert.NoError(t, err)
+	assert.NoError(t, contractState.SetData([]byte("key1"), []byte("value1")))
+	assert.NoError(t, contractState.DeleteData([]byte("key3")))
+	snapshot := contractState.Snapshot()
+	assert.NoError(t, contractState.SetData([]byte("key4"), []byte("value1")))
+	assert.NoError(t, contractState.Rollback(snapshot))
+	assert.NoError(t, StageContractState(contractState, overlay))
+
+	stateDB.CopyStorage(overlay, access)
+
+	contractState, err = OpenContractStateAccount(testAddress, stateDB)
+	assert.NoError(t, err)
+	for key, expected := range map[string][]byte{"key1": []byte("value1"), "key2": []byte("value0"), "key3": nil, "key4": nil} {
+		value, err := contractState.GetData([]byte(key))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+	assert.True(t, contractState.HasKey([]byte("key3")), "the delete is copied")
+	assert.False(t, contractState.HasKey([]byte("key4")), "the rolled back write is not copied")
+}
@@ -14,6 +14,7 @@ type ContractState struct {
 	code    []byte
 	storage *bufferedStorage
 	store   db.DB
+	access  *AccessSet
 }
 
 func (cs *ContractState) SetCode(sourceCode []byte, bytecode []byte) error {
@@ -107,18 +108,23 @@ func (cs *ContractState) GetRawKV(key []byte) ([]byte, error) {
 
 // HasKey returns existence of the key
 func (cs *ContractState) HasKey(key []byte) bool {
-	return cs.storage.has(types.GetHashID(key), true)
+	id := types.GetHashID(key)
+	cs.access.readStorage(cs.account, id)
+	return cs.storage.has(id, true)
 }
 
 // SetData store key and value pair to the storage.
 func (cs *ContractState) SetData(key, value []byte) error {
-	cs.storage.put(newValueEntry(types.GetHashID(key), value))
+	id := types.GetHashID(key)
+	cs.access.writeStorage(cs.account, id)
+	cs.storage.put(newValueEntry(id, value))
 	return nil
 }
 
 // GetData returns the value corresponding to the key from the buffered storage.
 func (cs *ContractState) GetData(key []byte) ([]byte, error) {
 	id := types.GetHashID(key)
+	cs.access.readStorage(cs.account, id)
 	if entry := cs.storage.get(id); entry != nil {
 		if value := entry.Value(); value != nil {
 			return value.([]byte), nil
@@ -146,12 +152,15 @@ func (cs *ContractState) getInitialData(id []byte) ([]byte, error) {
 // GetInitialData returns the value corresponding to the key from the contract storage.
 func (cs *ContractState) GetInitialData(key []byte) ([]byte, error) {
 	id := types.GetHashID(key)
+	cs.access.readStorage(cs.account, id)
 	return cs.getInitialData(id[:])
 }
 
 // DeleteData remove key and value pair from the storage.
 func (cs *ContractState) DeleteData(key []byte) error {
-	cs.storage.put(newValueEntryDelete(types.GetHashID(key)))
+	id := types.GetHashID(key)
+	cs.access.writeStorage(cs.account, id)
+	cs.storage.put(newValueEntryDelete(id))
 	return nil
 }
 
@@ -218,6 +227,7 @@ func OpenContractState(id []byte, st *types.State, states *StateDB) (*ContractSt
 		account: aid,
 		storage: storage,
 		store:   states.Store,
+		access:  states.access,
 	}
 	return res, nil
 }
@@ -64,6 +64,8 @@ type StateDB struct {
 	Trie     *trie.Trie
 	Store    db.DB
 	Testmode bool
+
+	access *AccessSet
 }
 
 // NewStateDB craete StateDB instance
@@ -86,6 +88,14 @@ func (states *StateDB) Clone() *StateDB {
 	return NewStateDB(states.Store, states.GetRoot(), states.Testmode)
 }
 
+// SetAccessSet makes the StateDB record the accesses of the account states and the contract storages to the
+// given set. A nil set stops the recording.
+func (states *StateDB) SetAccessSet(a *AccessSet) {
+	states.lock.Lock()
+	defer states.lock.Unlock()
+	states.access = a
+}
+
 // GetRoot returns root hash of trie
 func (states *StateDB) GetRoot() []byte {
 	states.lock.RLock()
@@ -146,6 +156,11 @@ func (states *StateDB) PutState(id types.AccountID, state *types.State) error {
 	if id == EmptyAccountID {
 		return errPutState
 	}
+	// putting back the state of the block start, as the receiver of a call which doesn't send the balance, is not
+	// recorded as a write, so that it doesn't conflict with the other txs reading the account
+	if states.access != nil && !states.isTrieState(id, state) {
+		states.access.writeState(id)
+	}
 	states.Buffer.put(newValueEntry(types.HashID(id), state))
 	return nil
 }
@@ -175,6 +190,7 @@ func (states *StateDB) GetState(id types.AccountID) (*types.State, error) {
 	if id == EmptyAccountID {
 		return nil, errGetState
 	}
+	states.access.readState(id)
 	return states.getState(id)
 }
 
@@ -189,6 +205,15 @@ func (states *StateDB) getState(id types.AccountID) (*types.State, error) {
 	return states.getTrieState(id)
 }
 
+// isTrieState tells whether the state is the same as the state of account id in the trie.
+func (states *StateDB) isTrieState(id types.AccountID, state *types.State) bool {
+	st, err := states.getTrieState(id)
+	if err != nil || st == nil || state == nil {
+		return false
+	}
+	return bytes.Equal(getHashBytes(st), getHashBytes(state))
+}
+
 // getTrieState gets state of account id from trie.
 // nil value is returned when there is no state corresponding to account id.
 func (states *StateDB) getTrieState(id types.AccountID) (*types.State, error) {
@@ -0,0 +1,380 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ABIVersionTyped is the version of the ABI which has the types of the arguments and the return values.
+const ABIVersionTyped = "0.3"
+
+// basic types of the ABI type annotations
+const (
+	ABITypeAny     = "any"
+	ABITypeString  = "string"
+	ABITypeNumber  = "number"
+	ABITypeInteger = "integer"
+	ABITypeBoolean = "boolean"
+	ABITypeBignum  = "bignum"
+	ABITypeAddress = "address"
+	ABITypeTable   = "table"
+
+	abiTypeArray = "array"
+	abiTypeShape = "shape"
+)
+
+var abiBasicTypes = map[string]bool{
+	ABITypeAny:     true,
+	ABITypeString:  true,
+	ABITypeNumber:  true,
+	ABITypeInteger: true,
+	ABITypeBoolean: true,
+	ABITypeBignum:  true,
+	ABITypeAddress: true,
+	ABITypeTable:   true,
+}
+
+// ABIType is a parsed type annotation of an argument or a return value of a contract function. It is one of the
+// basic types, an array like "[address]", or a table shape like "{to:address,amount:bignum}". A type ending with
+// "?" may be nil.
+type ABIType struct {
+	Kind     string
+	Elem     *ABIType    // element type of an array
+	Fields   []*ABIField // fields of a table shape, sorted by name
+	Optional bool
+}
+
+type ABIField struct {
+	Name string
+	Type *ABIType
+}
+
+// ParseABIType parses a type annotation.
+func ParseABIType(s string) (*ABIType, error) {
+	p := &abiTypeParser{s: s}
+	t, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %v", s, err)
+	}
+	if p.skipSpace(); p.pos != len(p.s) {
+		return nil, fmt.Errorf("invalid type %q: unexpected %q", s, p.s[p.pos:])
+	}
+	return t, nil
+}
+
+type abiTypeParser struct {
+	s   string
+	pos int
+}
+
+func (p *abiTypeParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *abiTypeParser) peek() byte {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *abiTypeParser) expect(c byte) error {
+	if p.peek() != c {
+		return fmt.Errorf("expected %q at %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *abiTypeParser) name() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *abiTypeParser) parse() (*ABIType, error) {
+	var t *ABIType
+	switch p.peek() {
+	case '[':
+		p.pos++
+		elem, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(']'); err != nil {
+			return nil, err
+		}
+		t = &ABIType{Kind: abiTypeArray, Elem: elem}
+	case '{':
+		p.pos++
+		t = &ABIType{Kind: abiTypeShape}
+		seen := map[string]bool{}
+		for {
+			name := p.name()
+			if len(name) == 0 {
+				return nil, fmt.Errorf("expected a field name at %d", p.pos)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("duplicated field %s", name)
+			}
+			seen[name] = true
+			if err := p.expect(':'); err != nil {
+				return nil, err
+			}
+			ft, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			t.Fields = append(t.Fields, &ABIField{Name: name, Type: ft})
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if err := p.expect('}'); err != nil {
+			return nil, err
+		}
+		sort.Slice(t.Fields, func(i, j int) bool { return t.Fields[i].Name < t.Fields[j].Name })
+	default:
+		kind := p.name()
+		if !abiBasicTypes[kind] {
+			return nil, fmt.Errorf("unknown type %q", kind)
+		}
+		t = &ABIType{Kind: kind}
+	}
+	if p.peek() == '?' {
+		p.pos++
+		t.Optional = true
+	}
+	return t, nil
+}
+
+func (t *ABIType) String() string {
+	var s string
+	switch t.Kind {
+	case abiTypeArray:
+		s = "[" + t.Elem.String() + "]"
+	case abiTypeShape:
+		fields := make([]string, len(t.Fields))
+		for i, f := range t.Fields {
+			fields[i] = f.Name + ":" + f.Type.String()
+		}
+		s = "{" + strings.Join(fields, ",") + "}"
+	default:
+		s = t.Kind
+	}
+	if t.Optional {
+		s += "?"
+	}
+	return s
+}
+
+// Check tells whether the value decoded from the JSON arguments of a call has the type.
+func (t *ABIType) Check(v interface{}) error {
+	if v == nil {
+		if t.Optional || t.Kind == ABITypeAny {
+			return nil
+		}
+		return fmt.Errorf("expected %s, got nil", t)
+	}
+	switch t.Kind {
+	case ABITypeAny:
+		return nil
+	case ABITypeString:
+		if _, ok := v.(string); ok {
+			return nil
+		}
+	case ABITypeNumber:
+		if _, ok := jsonNumber(v); ok {
+			return nil
+		}
+	case ABITypeInteger:
+		if n, ok := jsonNumber(v); ok && n == math.Trunc(n) {
+			return nil
+		}
+	case ABITypeBoolean:
+		if _, ok := v.(bool); ok {
+			return nil
+		}
+	case ABITypeBignum:
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+			if s, ok := m["_bignum"].(string); ok {
+				if _, ok := new(big.Int).SetString(s, 0); ok {
+					return nil
+				}
+			}
+		}
+	case ABITypeAddress:
+		if s, ok := v.(string); ok {
+			if _, err := DecodeAddress(s); err == nil {
+				return nil
+			}
+		}
+	case ABITypeTable:
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil
+		}
+	case abiTypeArray:
+		if a, ok := v.([]interface{}); ok {
+			for i, e := range a {
+				if err := t.Elem.Check(e); err != nil {
+					return fmt.Errorf("[%d]: %v", i+1, err)
+				}
+			}
+			return nil
+		}
+	case abiTypeShape:
+		if m, ok := v.(map[string]interface{}); ok {
+			for k := range m {
+				if t.field(k) == nil {
+					return fmt.Errorf("unknown field %s in %s", k, t)
+				}
+			}
+			for _, f := range t.Fields {
+				if err := f.Type.Check(m[f.Name]); err != nil {
+					return fmt.Errorf("%s: %v", f.Name, err)
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("expected %s, got %s", t, jsonString(v))
+}
+
+func (t *ABIType) field(name string) *ABIField {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func jsonNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// IsTyped tells whether any argument or return value of the function has a type annotation.
+func (fn *Function) IsTyped() bool {
+	for _, arg := range fn.GetArguments() {
+		if arg.GetType() != "" {
+			return true
+		}
+	}
+	return len(fn.GetReturns()) > 0
+}
+
+// CheckArgs validates the arguments of a call against the type annotations of the function. The arguments of a
+// function without type annotations are not checked.
+func (fn *Function) CheckArgs(args []interface{}) error {
+	if !fn.IsTyped() {
+		return nil
+	}
+	variadic := false
+	for i, arg := range fn.Arguments {
+		if arg.GetName() == "..." {
+			variadic = true
+			continue
+		}
+		if arg.GetType() == "" {
+			continue
+		}
+		t, err := ParseABIType(arg.GetType())
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		if i < len(args) {
+			v = args[i]
+		}
+		if err = t.Check(v); err != nil {
+			return fmt.Errorf("argument %d (%s) of %s: %v", i+1, arg.GetName(), fn.GetName(), err)
+		}
+	}
+	if !variadic && len(args) > len(fn.Arguments) {
+		return fmt.Errorf("too many arguments for %s: %d, expected %d", fn.GetName(), len(args), len(fn.Arguments))
+	}
+	return nil
+}
+
+// GetFunction returns the function with the given name, or nil if it doesn't exist in the ABI.
+func (abi *ABI) GetFunction(name string) *Function {
+	for _, fn := range abi.GetFunctions() {
+		if fn.GetName() == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// CheckCall validates the function and the arguments of a call against the ABI.
+func (abi *ABI) CheckCall(ci *CallInfo) error {
+	fn := abi.GetFunction(ci.Name)
+	if fn == nil {
+		return errors.New("function " + ci.Name + " not found in the abi")
+	}
+	return fn.CheckArgs(ci.Args)
+}
+
+// SetTypes sets the type annotations of the arguments and the return values of the function, and marks the ABI as
+// the typed version. The types are validated and normalized.
+func (abi *ABI) SetTypes(name string, args []string, returns []string) error {
+	fn := abi.GetFunction(name)
+	if fn == nil {
+		return fmt.Errorf("abi.types: function %s is not registered", name)
+	}
+	if len(args) > len(fn.Arguments) {
+		return fmt.Errorf("abi.types: %s has %d arguments, but %d types are given", name, len(fn.Arguments), len(args))
+	}
+	for i, s := range args {
+		if fn.Arguments[i].GetName() == "..." {
+			return fmt.Errorf("abi.types: the variable arguments of %s cannot have a type", name)
+		}
+		t, err := ParseABIType(s)
+		if err != nil {
+			return fmt.Errorf("abi.types: %s: %v", name, err)
+		}
+		fn.Arguments[i].Type = t.String()
+	}
+	fn.Returns = nil
+	for _, s := range returns {
+		t, err := ParseABIType(s)
+		if err != nil {
+			return fmt.Errorf("abi.types: %s: %v", name, err)
+		}
+		fn.Returns = append(fn.Returns, t.String())
+	}
+	abi.Version = ABIVersionTyped
+	return nil
+}
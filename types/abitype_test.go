@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseABIType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"string", "string", false},
+		{"bignum?", "bignum?", false},
+		{"[address]", "[address]", false},
+		{"{ to: address, amount: bignum }", "{amount:bignum,to:address}", false},
+		{"[{name:string,tags:[string]?}]?", "[{name:string,tags:[string]?}]?", false},
+		{"", "", true},
+		{"int", "", true},
+		{"[string", "", true},
+		{"{a:string,a:number}", "", true},
+		{"{:string}", "", true},
+		{"string]", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseABIType(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestABITypeCheck(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value string
+		valid bool
+	}{
+		{"string", `"a"`, true},
+		{"string", `1`, false},
+		{"string", `null`, false},
+		{"string?", `null`, true},
+		{"any", `null`, true},
+		{"number", `1.5`, true},
+		{"integer", `1.5`, false},
+		{"integer", `-3`, true},
+		{"boolean", `true`, true},
+		{"bignum", `{"_bignum":"123456789012345678901234567890"}`, true},
+		{"bignum", `"123"`, false},
+		{"bignum", `{"_bignum":"12a"}`, false},
+		{"address", `"AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2"`, true},
+		{"address", `"AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU3"`, false},
+		{"table", `{"a":1}`, true},
+		{"table", `[1]`, true},
+		{"table", `"a"`, false},
+		{"[integer]", `[1,2,3]`, true},
+		{"[integer]", `[1,"2"]`, false},
+		{"{to:address,memo:string?}", `{"to":"aergo.system"}`, true},
+		{"{to:address,memo:string?}", `{"memo":"a"}`, false},
+		{"{to:address,memo:string?}", `{"to":"aergo.system","other":1}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+" "+tt.value, func(t *testing.T) {
+			typ, err := ParseABIType(tt.typ)
+			assert.NoError(t, err)
+			var v interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.value), &v))
+			if err = typ.Check(v); tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestABICheckCall(t *testing.T) {
+	abi := &ABI{
+		Version: "0.2",
+		Functions: []*Function{
+			{Name: "transfer", Arguments: []*FnArgument{{Name: "to"}, {Name: "amount"}, {Name: "memo"}}},
+			{Name: "log", Arguments: []*FnArgument{{Name: "level"}, {Name: "..."}}},
+		},
+	}
+	// untyped functions are not checked
+	assert.NoError(t, abi.CheckCall(&CallInfo{Name: "transfer", Args: []interface{}{1}}))
+	assert.Error(t, abi.CheckCall(&CallInfo{Name: "unknown"}))
+
+	assert.NoError(t, abi.SetTypes("transfer", []string{"address", "bignum", "string?"}, []string{"boolean"}))
+	assert.NoError(t, abi.SetTypes("log", []string{"integer"}, nil))
+	assert.Equal(t, ABIVersionTyped, abi.Version)
+	assert.Error(t, abi.SetTypes("unknown", nil, nil))
+	assert.Error(t, abi.SetTypes("log", []string{"integer", "string"}, nil), "variable arguments")
+	assert.Error(t, abi.SetTypes("transfer", []string{"address", "bignum", "string", "string"}, nil), "too many types")
+	assert.Error(t, abi.SetTypes("transfer", []string{"address", "bigint"}, nil), "unknown type")
+
+	tests := []struct {
+		name  string
+		args  string
+		valid bool
+	}{
+		{"transfer", `["aergo.system", {"_bignum":"10"}]`, true},
+		{"transfer", `["aergo.system", {"_bignum":"10"}, "memo"]`, true},
+		{"transfer", `["aergo.system", 10]`, false},
+		{"transfer", `["aergo.system"]`, false},
+		{"transfer", `["aergo.system", {"_bignum":"10"}, "memo", 1]`, false},
+		{"log", `[1, "a", "b"]`, true},
+		{"log", `["1"]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+tt.args, func(t *testing.T) {
+			ci := &CallInfo{Name: tt.name}
+			assert.NoError(t, json.Unmarshal([]byte(tt.args), &ci.Args))
+			if err := abi.CheckCall(ci); tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strings"
@@ -13,6 +14,25 @@ const AddressLength = 33
 const NameLength = 12
 const EncodedAddressLength = 52
 
+// SubNameMaxLength is the max length of a subname (e.g. pay.myname), which is
+// shorter than an address not to be taken for it.
+const SubNameMaxLength = AddressLength - 1
+
+// NameRecordKeyMaxLength and NameRecordValueMaxLength are the limits of a
+// text record of a name, and NameRecordMax is the number of records a name
+// can have.
+const (
+	NameRecordKeyMaxLength   = 32
+	NameRecordValueMaxLength = 256
+	NameRecordMax            = 16
+)
+
+// IsSubName reports whether the name is a subname under a name.
+func IsSubName(name []byte) bool {
+	return len(name) > NameLength && len(name) <= SubNameMaxLength && bytes.IndexByte(name, '.') > 0 &&
+		!IsSpecialAccount(name)
+}
+
 // NewAccount alloc new account object
 func NewAccount(addr []byte) *Account {
 	return &Account{
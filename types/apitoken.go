@@ -0,0 +1,88 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var InvalidAPITokenErr = errors.New("invalid api token format")
+
+// APIToken is a bearer token of the RPC API, which allows a set of methods
+// with a rate limit. It is configured by the hash of the token not to expose
+// the token itself in the config or the enterprise contract.
+type APIToken struct {
+	Hash    string   // hex encoded sha256 of the token
+	Methods []string // allowed methods, nil for all methods
+	Rate    uint64   // requests per second, 0 for no limit
+}
+
+// ParseAPIToken parses the config value of an API token, which is in the form
+// of <hash>:<methods>:<rate>. The methods are comma separated names of RPC or
+// web3 methods, or * for all methods.
+//
+// e.g. 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08:GetBlock,GetTX:10
+func ParseAPIToken(str string) (*APIToken, error) {
+	v := strings.Split(str, ":")
+	if len(v) != 3 {
+		return nil, InvalidAPITokenErr
+	}
+	hash, err := hex.DecodeString(v[0])
+	if err != nil || len(hash) != sha256.Size {
+		return nil, fmt.Errorf("invalid api token hash %s", v[0])
+	}
+	token := &APIToken{Hash: strings.ToLower(v[0])}
+	if v[1] != "*" {
+		for _, m := range strings.Split(v[1], ",") {
+			if err := validateMethodName(m); err != nil {
+				return nil, err
+			}
+			token.Methods = append(token.Methods, m)
+		}
+	}
+	if token.Rate, err = strconv.ParseUint(v[2], 10, 32); err != nil {
+		return nil, fmt.Errorf("invalid api token rate %s", v[2])
+	}
+	return token, nil
+}
+
+// HashAPIToken returns the hash of the token used in the config.
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Allows reports whether the token allows the method. The method names are
+// case insensitive, so that a name matches both of the RPC method (GetBlock)
+// and the web3 method (getBlock).
+func (t *APIToken) Allows(method string) bool {
+	if t.Methods == nil {
+		return true
+	}
+	for _, m := range t.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateMethodName(m string) error {
+	if len(m) == 0 {
+		return fmt.Errorf("empty method name in api token")
+	}
+	for _, c := range m {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return fmt.Errorf("invalid method name %s in api token", m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPIToken(t *testing.T) {
+	hash := HashAPIToken("test")
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", hash)
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+		methods []string
+		rate    uint64
+	}{
+		{"all", hash + ":*:0", false, nil, 0},
+		{"methods", hash + ":GetBlock,getTX:10", false, []string{"GetBlock", "getTX"}, 10},
+		{"missing rate", hash + ":*", true, nil, 0},
+		{"not hex", "test:*:0", true, nil, 0},
+		{"short hash", hash[:32] + ":*:0", true, nil, 0},
+		{"empty method", hash + ":GetBlock,:0", true, nil, 0},
+		{"invalid method", hash + ":Get-Block:0", true, nil, 0},
+		{"invalid rate", hash + ":*:-1", true, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := ParseAPIToken(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, hash, token.Hash)
+			assert.Equal(t, tt.methods, token.Methods)
+			assert.Equal(t, tt.rate, token.Rate)
+		})
+	}
+
+	token, _ := ParseAPIToken(hash + ":GetBlock,GetTX:0")
+	assert.True(t, token.Allows("GetBlock"))
+	assert.True(t, token.Allows("getBlock"), "web3 method")
+	assert.False(t, token.Allows("SendTX"))
+	token, _ = ParseAPIToken(hash + ":*:0")
+	assert.True(t, token.Allows("SendTX"))
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/internal/enc/proto"
 	"github.com/aergoio/aergo/v2/internal/merkle"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/minio/sha256-simd"
 )
@@ -529,19 +531,67 @@ func (tx *Tx) CalculateTxHash() []byte {
 	binary.Write(digest, binary.LittleEndian, txBody.Type)
 	digest.Write(txBody.ChainIdHash)
 	digest.Write(txBody.Sign)
+	// the validity window is hashed only if it's set, to keep the hash of the txs without it
+	if txBody.HasValidity() {
+		binary.Write(digest, binary.LittleEndian, txBody.ValidAfterBlock)
+		binary.Write(digest, binary.LittleEndian, txBody.ValidUntilBlock)
+	}
 	return digest.Sum(nil)
 }
 
+// CalculateHashWithoutSign returns the hash of the tx body without the sign, which is signed by the sender.
+func (tx *TxBody) CalculateHashWithoutSign() []byte {
+	h := sha256.New()
+	binary.Write(h, binary.LittleEndian, tx.Nonce)
+	h.Write(tx.Account)
+	h.Write(tx.Recipient)
+	h.Write(tx.Amount)
+	h.Write(tx.Payload)
+	binary.Write(h, binary.LittleEndian, tx.GasLimit)
+	h.Write(tx.GasPrice)
+	binary.Write(h, binary.LittleEndian, tx.Type)
+	h.Write(tx.ChainIdHash)
+	if tx.HasValidity() {
+		binary.Write(h, binary.LittleEndian, tx.ValidAfterBlock)
+		binary.Write(h, binary.LittleEndian, tx.ValidUntilBlock)
+	}
+	return h.Sum(nil)
+}
+
+// VerifySign checks that the sign of the tx is made by the key of the public key.
+func (tx *Tx) VerifySign(pubkey []byte) error {
+	txBody := tx.Body
+	hash := txBody.CalculateHashWithoutSign()
+	sign, err := ecdsa.ParseSignature(txBody.Sign)
+	if err != nil {
+		return err
+	}
+	key, err := btcec.ParsePubKey(pubkey)
+	if err != nil {
+		return err
+	}
+	if !sign.Verify(hash, key) {
+		return ErrSignNotMatch
+	}
+	return nil
+}
+
 func (tx *Tx) NeedNameVerify() bool {
 	return tx.HasNameAccount()
 }
 
 func (tx *Tx) HasNameAccount() bool {
-	return len(tx.Body.Account) <= NameLength
+	return isNameAddress(tx.Body.Account)
 }
 
 func (tx *Tx) HasNameRecipient() bool {
-	return tx.Body.Recipient != nil && len(tx.Body.Recipient) <= NameLength
+	return tx.Body.Recipient != nil && isNameAddress(tx.Body.Recipient)
+}
+
+// isNameAddress reports whether the address of a tx is a name or a subname
+// rather than an account address.
+func isNameAddress(addr []byte) bool {
+	return len(addr) <= NameLength || IsSubName(addr)
 }
 
 func (tx *Tx) Clone() *Tx {
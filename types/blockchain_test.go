@@ -250,3 +250,24 @@ func TestBlockLimit(t *testing.T) {
 	a.True(block.Size() <= txSize*i+hdrSize, "block size violation")
 	a.True(block.Size() <= limit, "block size violation")
 }
+
+func TestTxHasName(t *testing.T) {
+	addr := ToAddress("AmMXVdJ8DnEFysN58cox9RADC74dF1CLrQimKCMdB4XXMkJeuQgL")
+	for _, tt := range []struct {
+		recipient []byte
+		expect    bool
+	}{
+		{[]byte("AB1234567890"), true},
+		{[]byte("pay.AB1234567890"), true},
+		{[]byte("x.pay.AB1234567890"), true},
+		{[]byte(AergoSystem), true},
+		{addr, false},
+		{nil, false},
+	} {
+		tx := &Tx{Body: &TxBody{Account: tt.recipient, Recipient: tt.recipient}}
+		assert.Equalf(t, tt.expect, tx.HasNameRecipient(), "recipient %s", tt.recipient)
+		if tt.recipient != nil {
+			assert.Equalf(t, tt.expect, tx.HasNameAccount(), "account %s", tt.recipient)
+		}
+	}
+}
@@ -29,6 +29,14 @@ func InternalOps(blockNo types.BlockNo) []byte {
 	return append([]byte(internalOpsPrefix), types.BlockNoToBytes(blockNo)...)
 }
 
+func ScheduledCalls(blockNo types.BlockNo) []byte {
+	return append([]byte(scheduledCallsPrefix), types.BlockNoToBytes(blockNo)...)
+}
+
+func ContractSource(contract []byte) []byte {
+	return append([]byte(contractSourcePrefix), contract...)
+}
+
 //---------------------------------------------------------------------------------//
 // metadata
 
@@ -99,12 +107,40 @@ func EnterpriseConf(conf []byte) []byte {
 	return append([]byte(enterpriseConf), bytes.ToUpper(conf)...)
 }
 
+func EnterpriseHistory(seq uint64) []byte {
+	return append([]byte(enterpriseHistory), types.Uint64ToBytes(seq)...)
+}
+
+func EnterpriseHistoryCount() []byte {
+	return []byte(enterpriseHistoryCount)
+}
+
+func EnterprisePolicy() []byte {
+	return []byte(enterprisePolicy)
+}
+
+func EnterpriseProposals() []byte {
+	return []byte(enterpriseProposals)
+}
+
+func EnterpriseProposalSeq() []byte {
+	return []byte(enterpriseProposalSeq)
+}
+
 // name
 func Name(accountName []byte) []byte {
 	// lower double check
 	return append([]byte(name), bytes.ToLower(accountName)...)
 }
 
+func NameReverse(address []byte) []byte {
+	return append([]byte(nameReverse), address...)
+}
+
+func NameRecords(accountName []byte) []byte {
+	return append([]byte(nameRecords), bytes.ToLower(accountName)...)
+}
+
 // system
 func SystemParam(id string) []byte {
 	// upper double check
@@ -139,6 +175,46 @@ func SystemVpr(i uint8) []byte {
 	return append([]byte(systemVpr), []byte(fmt.Sprintf("%v", i))...)
 }
 
+func SystemScheduleId() []byte {
+	return []byte(systemScheduleId)
+}
+
+func SystemScheduleList() []byte {
+	return []byte(systemScheduleList)
+}
+
+func SystemSchedule(id uint64) []byte {
+	return append([]byte(systemSchedule), types.Uint64ToBytes(id)...)
+}
+
+func SystemMultisig(account []byte) []byte {
+	return append([]byte(systemMultisig), account...)
+}
+
+func SystemKeyRotation(account []byte) []byte {
+	return append([]byte(systemKeyRotation), account...)
+}
+
+func SystemDelegation(account []byte) []byte {
+	return append([]byte(systemDelegation), account...)
+}
+
+func SystemDelegators(delegate []byte) []byte {
+	return append([]byte(systemDelegators), delegate...)
+}
+
+func SystemDelegator(delegate []byte, idx uint64) []byte {
+	return append(append([]byte(systemDelegator), delegate...), types.Uint64ToBytes(idx)...)
+}
+
+func SystemDelegatorIdx(delegator []byte) []byte {
+	return append([]byte(systemDelegatorIdx), delegator...)
+}
+
+func SystemParamPending(id string) []byte {
+	return append([]byte(systemParamPending), bytes.ToUpper([]byte(id))...)
+}
+
 // creator
 func CreatorMeta() []byte {
 	return []byte(creatorMeta)
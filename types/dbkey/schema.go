@@ -10,6 +10,8 @@ const (
 const (
 	receiptsPrefix = "r"
 	internalOpsPrefix = "i"
+	scheduledCallsPrefix = "sc"
+	contractSourcePrefix = "cs"
 )
 
 // metadata
@@ -39,11 +41,18 @@ const (
 // governance
 const (
 	// enterprise
-	enterpriseAdmins = "ADMINS"
-	enterpriseConf   = "conf\\"
+	enterpriseAdmins       = "ADMINS"
+	enterpriseConf         = "conf\\"
+	enterpriseHistory      = "history\\"
+	enterpriseHistoryCount = "historycount"
+	enterprisePolicy       = "approvalpolicy"
+	enterpriseProposals    = "proposals"
+	enterpriseProposalSeq  = "proposalseq"
 
 	// name
-	name = "name"
+	name        = "name"
+	nameReverse = "namereverse\\"
+	nameRecords = "namerecords\\"
 
 	// system
 	systemParam        = "param\\"
@@ -54,6 +63,16 @@ const (
 	systemVoteTotal    = "total"
 	systemVoteSort     = "sort"
 	systemVpr          = "VotingPowerBucket/"
+	systemScheduleId   = "scheduleid"
+	systemScheduleList = "schedulelist"
+	systemSchedule     = "schedule\\"
+	systemMultisig     = "multisig\\"
+	systemKeyRotation  = "keyrotation\\"
+	systemDelegation   = "delegation\\"
+	systemDelegators   = "delegators\\"
+	systemDelegator    = "delegator\\"
+	systemDelegatorIdx = "delegatoridx\\"
+	systemParamPending = "parampending\\"
 
 	creatorMeta = "Creator"
 )
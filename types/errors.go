@@ -50,8 +50,22 @@ var (
 
 	ErrTxOnlySupportedInPriv = errors.New("tx only supported in private")
 
+	ErrTxInvalidValidity = errors.New("tx invalid validity window")
+
+	ErrTxValidityNotSupported = errors.New("tx validity window is not supported yet")
+
+	//ErrTxNotYetValid is returned if the block number is before the validity window of the tx
+	ErrTxNotYetValid = errors.New("tx is not valid yet")
+
+	//ErrTxExpired is returned if the block number is after the validity window of the tx
+	ErrTxExpired = errors.New("tx is expired")
+
 	ErrSignNotMatch = errors.New("signature not matched")
 
+	ErrNotEnoughSignatures = errors.New("not enough signatures for the threshold of multisig account")
+
+	ErrMultisigNotFound = errors.New("multisig account not found")
+
 	ErrCouldNotRecoverPubKey = errors.New("could not recover pubkey from sign")
 
 	ErrShouldUnlockAccount = errors.New("should unlock account first")
@@ -101,6 +101,8 @@ func ConvNameInfo(msg *types.NameInfo) *InOutNameInfo {
 	ni.Name = msg.Name.Name
 	ni.Owner = types.EncodeAddress(msg.Owner)
 	ni.Destination = types.EncodeAddress(msg.Destination)
+	ni.Expire = msg.Expire
+	ni.Status = msg.Status
 
 	return ni
 }
@@ -109,6 +111,28 @@ type InOutNameInfo struct {
 	Name        string `json:"name"`
 	Owner       string `json:"owner"`
 	Destination string `json:"destination"`
+	Expire      uint64 `json:"expire,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+func ConvNameRecords(msg *types.NameRecordList) *InOutNameRecords {
+	if msg == nil {
+		return nil
+	}
+
+	nr := &InOutNameRecords{}
+	nr.Name = msg.Name
+	nr.Records = make(map[string]string)
+	for _, r := range msg.Records {
+		nr.Records[r.Key] = r.Value
+	}
+
+	return nr
+}
+
+type InOutNameRecords struct {
+	Name    string            `json:"name"`
+	Records map[string]string `json:"records"`
 }
 
 func ConvBalance(msg *types.State) *InOutBalance {
@@ -89,6 +89,88 @@ type InOutEnterpriseConfig struct {
 	Values []string `json:"values"`
 }
 
+func ConvEnterpriseHistory(msg *types.EnterpriseHistoryList) *InOutEnterpriseHistory {
+	if msg == nil {
+		return nil
+	}
+	eh := &InOutEnterpriseHistory{}
+	eh.History = make([]*InOutEnterpriseHistoryItem, len(msg.History))
+	for i, h := range msg.History {
+		eh.History[i] = &InOutEnterpriseHistoryItem{
+			Seq:     h.Seq,
+			Admin:   types.EncodeAddress(h.Admin),
+			Action:  h.Action,
+			Key:     h.Key,
+			Removed: h.Removed,
+			Added:   h.Added,
+			BlockNo: h.BlockNo,
+			TxHash:  base58.Encode(h.TxHash),
+		}
+	}
+	eh.Next = msg.Next
+	return eh
+}
+
+type InOutEnterpriseHistory struct {
+	History []*InOutEnterpriseHistoryItem `json:"history"`
+	Next    uint64                        `json:"next,omitempty"`
+}
+
+func ConvEnterpriseProposals(msg *types.EnterpriseProposalList) *InOutEnterpriseProposals {
+	if msg == nil {
+		return nil
+	}
+	ep := &InOutEnterpriseProposals{}
+	ep.Threshold = msg.Threshold
+	ep.Window = msg.Window
+	ep.Proposals = make([]*InOutEnterpriseProposal, len(msg.Proposals))
+	for i, p := range msg.Proposals {
+		ep.Proposals[i] = &InOutEnterpriseProposal{
+			ID:          p.Id,
+			Proposer:    types.EncodeAddress(p.Proposer),
+			Call:        p.Call,
+			BlockNo:     p.BlockNo,
+			ExpireBlock: p.ExpireBlock,
+			Approvals:   make([]string, len(p.Approvals)),
+			Rejections:  make([]string, len(p.Rejections)),
+		}
+		for j, a := range p.Approvals {
+			ep.Proposals[i].Approvals[j] = types.EncodeAddress(a)
+		}
+		for j, r := range p.Rejections {
+			ep.Proposals[i].Rejections[j] = types.EncodeAddress(r)
+		}
+	}
+	return ep
+}
+
+type InOutEnterpriseProposals struct {
+	Threshold uint64                     `json:"threshold"`
+	Window    uint64                     `json:"window"`
+	Proposals []*InOutEnterpriseProposal `json:"proposals"`
+}
+
+type InOutEnterpriseProposal struct {
+	ID          uint64   `json:"id"`
+	Proposer    string   `json:"proposer"`
+	Call        string   `json:"call"`
+	BlockNo     uint64   `json:"blockNo"`
+	ExpireBlock uint64   `json:"expireBlock"`
+	Approvals   []string `json:"approvals"`
+	Rejections  []string `json:"rejections"`
+}
+
+type InOutEnterpriseHistoryItem struct {
+	Seq     uint64   `json:"seq"`
+	Admin   string   `json:"admin"`
+	Action  string   `json:"action"`
+	Key     string   `json:"key"`
+	Removed []string `json:"removed"`
+	Added   []string `json:"added"`
+	BlockNo uint64   `json:"blockNo"`
+	TxHash  string   `json:"txHash"`
+}
+
 func ConvConfChangeProgress(msg *types.ConfChangeProgress) *InOutConfChangeProgress {
 	if msg == nil {
 		return nil
@@ -43,6 +43,140 @@ type InOutStaking struct {
 	When   uint64 `json:"when,omitempty"`
 }
 
+func ConvKeyRotations(msg *types.KeyRotationList) *InOutKeyRotations {
+	if msg == nil {
+		return nil
+	}
+
+	kr := &InOutKeyRotations{}
+	kr.Account = types.EncodeAddress(msg.Account)
+	if len(msg.SigningKey) != 0 {
+		kr.SigningKey = types.EncodeAddress(msg.SigningKey)
+	}
+	kr.Rotations = make([]*InOutKeyRotation, len(msg.Rotations))
+	for i, r := range msg.Rotations {
+		kr.Rotations[i] = &InOutKeyRotation{
+			Pubkey:  types.EncodeAddress(r.Pubkey),
+			BlockNo: r.BlockNo,
+			TxHash:  base58.Encode(r.TxHash),
+		}
+	}
+	return kr
+}
+
+type InOutKeyRotations struct {
+	Account    string              `json:"account,omitempty"`
+	SigningKey string              `json:"signingKey,omitempty"`
+	Rotations  []*InOutKeyRotation `json:"rotations,omitempty"`
+}
+
+type InOutKeyRotation struct {
+	Pubkey  string `json:"pubkey,omitempty"`
+	BlockNo uint64 `json:"blockNo,omitempty"`
+	TxHash  string `json:"txHash,omitempty"`
+}
+
+func ConvDelegations(msg *types.DelegationList) *InOutDelegations {
+	if msg == nil {
+		return nil
+	}
+
+	ds := &InOutDelegations{}
+	ds.Account = types.EncodeAddress(msg.Account)
+	if msg.Delegation != nil {
+		ds.Delegation = ConvDelegation(msg.Delegation)
+	}
+	ds.Received = msg.GetReceivedBigInt().String()
+	ds.Delegators = make([]*InOutDelegation, len(msg.Delegators))
+	for i, d := range msg.Delegators {
+		ds.Delegators[i] = ConvDelegation(d)
+	}
+	return ds
+}
+
+type InOutDelegations struct {
+	Account    string             `json:"account,omitempty"`
+	Delegation *InOutDelegation   `json:"delegation,omitempty"`
+	Received   string             `json:"received,omitempty"`
+	Delegators []*InOutDelegation `json:"delegators,omitempty"`
+}
+
+func ConvDelegation(msg *types.Delegation) *InOutDelegation {
+	if msg == nil {
+		return nil
+	}
+
+	d := &InOutDelegation{}
+	if len(msg.Delegator) != 0 {
+		d.Delegator = types.EncodeAddress(msg.Delegator)
+	}
+	if len(msg.Delegate) != 0 {
+		d.Delegate = types.EncodeAddress(msg.Delegate)
+	}
+	d.Amount = msg.GetAmountBigInt().String()
+	d.When = msg.When
+	return d
+}
+
+type InOutDelegation struct {
+	Delegator string `json:"delegator,omitempty"`
+	Delegate  string `json:"delegate,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	When      uint64 `json:"when,omitempty"`
+}
+
+func ConvSystemParams(msg *types.SystemParamList) *InOutSystemParams {
+	if msg == nil {
+		return nil
+	}
+
+	ps := &InOutSystemParams{}
+	ps.Params = make([]*InOutSystemParam, len(msg.Params))
+	for i, p := range msg.Params {
+		ps.Params[i] = ConvSystemParam(p)
+	}
+	return ps
+}
+
+type InOutSystemParams struct {
+	Params []*InOutSystemParam `json:"params,omitempty"`
+}
+
+func ConvSystemParam(msg *types.SystemParam) *InOutSystemParam {
+	if msg == nil {
+		return nil
+	}
+
+	p := &InOutSystemParam{
+		Id:              msg.Id,
+		Description:     msg.Description,
+		Type:            msg.Type,
+		Min:             new(big.Int).SetBytes(msg.Min).String(),
+		Max:             new(big.Int).SetBytes(msg.Max).String(),
+		ActivationDelay: msg.ActivationDelay,
+	}
+	if msg.Value != nil {
+		p.Value = new(big.Int).SetBytes(msg.Value).String()
+	}
+	if msg.PendingValue != nil {
+		p.PendingValue = new(big.Int).SetBytes(msg.PendingValue).String()
+		p.ActivationBlock = msg.ActivationBlock
+	}
+	return p
+}
+
+type InOutSystemParam struct {
+	Id              string `json:"id,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Type            string `json:"type,omitempty"`
+	Value           string `json:"value,omitempty"`
+	Min             string `json:"min,omitempty"`
+	Max             string `json:"max,omitempty"`
+	ActivationDelay uint64 `json:"activationDelay,omitempty"`
+	PendingValue    string `json:"pendingValue,omitempty"`
+	ActivationBlock uint64 `json:"activationBlock,omitempty"`
+}
+
 func ConvVoteInfo(msg *types.VoteInfo) *InOutVoteInfo {
 	if msg == nil {
 		return nil
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"github.com/aergoio/aergo/v2/internal/enc/base58"
 	"github.com/aergoio/aergo/v2/types"
 )
 
@@ -56,3 +57,28 @@ type InOutQueryContractState struct {
 	ContractProof *InOutStateAndPoof       `json:"contractProof,omitempty"`
 	VarProofs     []*InOutContractVarProof `json:"varProofs,omitempty"`
 }
+
+func ConvContractSource(msg *types.ContractSource) *InOutContractSource {
+	if msg == nil {
+		return nil
+	}
+	cs := &InOutContractSource{}
+	cs.Address = types.EncodeAddress(msg.ContractAddress)
+	cs.Source = msg.Source
+	cs.CodeHash = base58.Encode(msg.CodeHash)
+	cs.TypedAbi = msg.TypedAbi
+	cs.VerifiedBlockNo = msg.VerifiedBlockNo
+	cs.VerifiedTime = msg.VerifiedTime
+	cs.OnChain = msg.OnChain
+	return cs
+}
+
+type InOutContractSource struct {
+	Address         string `json:"address"`
+	Source          string `json:"source,omitempty"`
+	CodeHash        string `json:"codeHash"`
+	TypedAbi        bool   `json:"typedAbi,omitempty"`
+	VerifiedBlockNo uint64 `json:"verifiedBlockNo,omitempty"`
+	VerifiedTime    int64  `json:"verifiedTime,omitempty"`
+	OnChain         bool   `json:"onChain,omitempty"`
+}
@@ -42,6 +42,7 @@ func ConvFunction(msg *types.Function) *InOutFunction {
 	fn.Payable = msg.Payable
 	fn.View = msg.View
 	fn.FeeDelegation = msg.FeeDelegation
+	fn.Returns = msg.Returns
 	return fn
 }
 
@@ -51,6 +52,7 @@ type InOutFunction struct {
 	Payable       bool                     `json:"payable"`
 	View          bool                     `json:"view"`
 	FeeDelegation bool                     `json:"feeDelegation"`
+	Returns       []string                 `json:"returns,omitempty"`
 }
 
 func ConvFunctionArgument(msg *types.FnArgument) *InOutFunctionArgument {
@@ -59,11 +61,13 @@ func ConvFunctionArgument(msg *types.FnArgument) *InOutFunctionArgument {
 	}
 	return &InOutFunctionArgument{
 		Name: msg.Name,
+		Type: msg.Type,
 	}
 }
 
 type InOutFunctionArgument struct {
 	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
 }
 
 func ConvStateVar(msg *types.StateVar) *InOutStateVar {
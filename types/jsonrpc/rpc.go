@@ -123,6 +123,10 @@ func ConvLongPeer(msg *types.Peer) *InOutLongPeer {
 		p.ProducerIDs[i] = base58.Encode(pid)
 	}
 
+	p.UploadRate = msg.UploadRate
+	p.UploadCap = msg.UploadCap
+	p.Throttled = msg.Throttled
+
 	if msg.Address.Role == types.PeerRole_Agent {
 		p.Certificates = make([]*InOutCert, len(msg.Certificates))
 		for i, cert := range msg.Certificates {
@@ -137,6 +141,10 @@ type InOutLongPeer struct {
 	*InOutPeer   `json:",inline"`
 	ProducerIDs  []string     `json:"producerIDs,omitempty"`
 	Certificates []*InOutCert `json:"certificates,omitempty"`
+	// UploadRate is bytes per second recently sent, and UploadCap is its limit. 0 cap means unlimited.
+	UploadRate int64 `json:"uploadRate"`
+	UploadCap  int64 `json:"uploadCap"`
+	Throttled  int64 `json:"throttled"`
 }
 
 func ConvCert(msg *types.AgentCertificate) *InOutCert {
@@ -189,11 +197,16 @@ func ConvPeerMetric(msg *types.PeerMetric) *InOutPeerMetric {
 	}
 
 	return &InOutPeerMetric{
-		PeerID: base58.Encode(msg.PeerID),
-		SumIn:  msg.SumIn,
-		AvrIn:  msg.AvrIn,
-		SumOut: msg.SumOut,
-		AvrOut: msg.AvrOut,
+		PeerID:   base58.Encode(msg.PeerID),
+		SumIn:    msg.SumIn,
+		AvrIn:    msg.AvrIn,
+		SumOut:   msg.SumOut,
+		AvrOut:   msg.AvrOut,
+		SavedIn:  msg.SavedIn,
+		SavedOut: msg.SavedOut,
+
+		DupTxNotice:  msg.DupTxNotice,
+		DupBlkNotice: msg.DupBlkNotice,
 	}
 }
 
@@ -203,6 +216,12 @@ type InOutPeerMetric struct {
 	AvrIn  int64  `json:"avrIn,omitempty"`
 	SumOut int64  `json:"sumOut,omitempty"`
 	AvrOut int64  `json:"avrOut,omitempty"`
+	// bytes saved by message compression
+	SavedIn  int64 `json:"savedIn,omitempty"`
+	SavedOut int64 `json:"savedOut,omitempty"`
+	// hashes noticed again by the peer
+	DupTxNotice  int64 `json:"dupTxNotice,omitempty"`
+	DupBlkNotice int64 `json:"dupBlkNotice,omitempty"`
 }
 
 func ConvBLConfEntries(msg *types.BLConfEntries) *InOutBLConfEntries {
@@ -120,6 +120,8 @@ func ConvTxBody(msg *types.TxBody, payloadType EncodingType) *InOutTxBody {
 	tb.ChainIdHash = base58.Encode(msg.ChainIdHash)
 	tb.Sign = base58.Encode(msg.Sign)
 	tb.Type = msg.Type
+	tb.ValidAfterBlock = msg.ValidAfterBlock
+	tb.ValidUntilBlock = msg.ValidUntilBlock
 	return tb
 }
 
@@ -192,6 +194,8 @@ func ParseTxBody(tb *InOutTxBody) (msg *types.TxBody, err error) {
 		}
 	}
 	msg.Type = tb.Type
+	msg.ValidAfterBlock = tb.ValidAfterBlock
+	msg.ValidUntilBlock = tb.ValidUntilBlock
 	return msg, nil
 }
 
@@ -212,6 +216,9 @@ type InOutTxBody struct {
 	Type        types.TxType    `json:"type,omitempty"`
 	ChainIdHash string          `json:"chainIdHash,omitempty"`
 	Sign        string          `json:"sign,omitempty"`
+
+	ValidAfterBlock uint64 `json:"validAfterBlock,omitempty"`
+	ValidUntilBlock uint64 `json:"validUntilBlock,omitempty"`
 }
 
 func (b *InOutTxBody) String() string {
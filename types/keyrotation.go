@@ -0,0 +1,29 @@
+package types
+
+import "fmt"
+
+// ParseRotateKey returns the new signing key from the argument of the key rotation tx, which is the public key encoded
+// as an address.
+func ParseRotateKey(ci *CallInfo) ([]byte, error) {
+	if len(ci.Args) != 1 {
+		return nil, fmt.Errorf("invalid arguments in %s", ci)
+	}
+	encoded, ok := ci.Args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key %v", ci.Args[0])
+	}
+	pubkey, err := DecodeAddress(encoded)
+	if err != nil || len(pubkey) != AddressLength || (pubkey[0] != 0x02 && pubkey[0] != 0x03) {
+		return nil, fmt.Errorf("invalid public key %s", encoded)
+	}
+	return pubkey, nil
+}
+
+// SigningKeyOf returns the public key which signs the txs of the address: the key bound by key rotation, or the
+// address itself.
+func (st *State) SigningKeyOf(address []byte) []byte {
+	if signingKey := st.GetSigningKey(); len(signingKey) != 0 {
+		return signingKey
+	}
+	return address
+}
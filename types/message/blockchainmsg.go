@@ -101,6 +101,62 @@ type GetInternalOperationsRsp struct {
 	Err        error
 }
 
+// TraceTx requests to re-execute a transaction on the state of its parent block and to trace the execution.
+type TraceTx struct {
+	TxHash []byte
+}
+type TraceTxRsp struct {
+	Trace string
+	Err   error
+}
+
+// ProfileTx requests to execute a transaction on the state of the best block without committing it, and to attribute
+// the consumed gas to the call stacks.
+type ProfileTx struct {
+	Tx *types.Tx
+}
+type ProfileTxRsp struct {
+	Profile string
+	Err     error
+}
+
+// GetScheduledCallReceipts requests the receipts of the scheduled calls executed at the start of a block.
+type GetScheduledCallReceipts struct {
+	BlockNo types.BlockNo
+}
+type GetScheduledCallReceiptsRsp struct {
+	Receipts string
+	Err      error
+}
+
+// ListScheduledCalls requests the queued scheduled calls of a contract, or all of them if the contract is empty.
+type ListScheduledCalls struct {
+	Contract []byte
+}
+type ListScheduledCallsRsp struct {
+	Calls string
+	Err   error
+}
+
+// VerifyContractSource requests to compile the source of a contract and register it if it matches the deployed code.
+type VerifyContractSource struct {
+	Contract []byte
+	Source   string
+}
+type VerifyContractSourceRsp struct {
+	Source *types.ContractSource
+	Err    error
+}
+
+// GetContractSource requests the verified source of a contract.
+type GetContractSource struct {
+	Contract []byte
+}
+type GetContractSourceRsp struct {
+	Source *types.ContractSource
+	Err    error
+}
+
 type GetABI struct {
 	Contract []byte
 }
@@ -164,6 +220,34 @@ type GetStakingRsp struct {
 	Err     error
 }
 
+// GetKeyRotations requests the signing key and the key rotation history of an account.
+type GetKeyRotations struct {
+	Addr []byte
+}
+
+type GetKeyRotationsRsp struct {
+	Rotations *types.KeyRotationList
+	Err       error
+}
+
+// GetDelegations requests the stake delegation of an account and the delegations it received.
+type GetDelegations struct {
+	Addr []byte
+}
+
+type GetDelegationsRsp struct {
+	Delegations *types.DelegationList
+	Err         error
+}
+
+// GetSystemParams requests the governable system params.
+type GetSystemParams struct{}
+
+type GetSystemParamsRsp struct {
+	Params *types.SystemParamList
+	Err    error
+}
+
 type GetNameInfo struct {
 	Name    string
 	BlockNo types.BlockNo
@@ -174,10 +258,50 @@ type GetNameInfoRsp struct {
 	Err   error
 }
 
+// GetReverseName requests the name of an address verified by its forward record.
+type GetReverseName struct {
+	Addr []byte
+}
+
+type GetReverseNameRsp struct {
+	Info *types.NameInfo
+	Err  error
+}
+
+// GetNameRecords requests the text records of a name.
+type GetNameRecords struct {
+	Name    string
+	BlockNo types.BlockNo
+}
+
+type GetNameRecordsRsp struct {
+	Records *types.NameRecordList
+	Err     error
+}
+
 type GetEnterpriseConf struct {
 	Key string
 }
 
+// ListEnterpriseHistory requests the audit log of the enterprise contract.
+type ListEnterpriseHistory struct {
+	Query *types.EnterpriseHistoryQuery
+}
+
+type ListEnterpriseHistoryRsp struct {
+	History *types.EnterpriseHistoryList
+	Err     error
+}
+
+// ListEnterpriseProposals requests the pending proposals of the enterprise
+// contract.
+type ListEnterpriseProposals struct{}
+
+type ListEnterpriseProposalsRsp struct {
+	Proposals *types.EnterpriseProposalList
+	Err       error
+}
+
 type GetEnterpriseConfRsp struct {
 	Conf *types.EnterpriseConfig
 	Err  error
@@ -135,6 +135,12 @@ type PeerInfo struct {
 	LastBlockNumber uint64
 	State           types.PeerState
 	Self            bool
+	// UploadRate is bytes per second recently sent to the peer, and UploadCap is the limit of it. They are of all peers
+	// if the peer is self.
+	UploadRate int64
+	UploadCap  int64
+	// Throttled is the number of requests throttled by rate limit
+	Throttled int64
 }
 
 // GetPeersRsp contains peer meta information and current states.
@@ -172,6 +172,12 @@ type PeerMetric struct {
 	AvrIn  int64  `protobuf:"varint,3,opt,name=avrIn,proto3" json:"avrIn,omitempty"`
 	SumOut int64  `protobuf:"varint,4,opt,name=sumOut,proto3" json:"sumOut,omitempty"`
 	AvrOut int64  `protobuf:"varint,5,opt,name=avrOut,proto3" json:"avrOut,omitempty"`
+	// bytes saved by message compression
+	SavedIn  int64 `protobuf:"varint,6,opt,name=savedIn,proto3" json:"savedIn,omitempty"`
+	SavedOut int64 `protobuf:"varint,7,opt,name=savedOut,proto3" json:"savedOut,omitempty"`
+	// count of tx and block hashes noticed again by the peer, which the peer already announced
+	DupTxNotice  int64 `protobuf:"varint,8,opt,name=dupTxNotice,proto3" json:"dupTxNotice,omitempty"`
+	DupBlkNotice int64 `protobuf:"varint,9,opt,name=dupBlkNotice,proto3" json:"dupBlkNotice,omitempty"`
 }
 
 func (x *PeerMetric) Reset() {
@@ -241,6 +247,34 @@ func (x *PeerMetric) GetAvrOut() int64 {
 	return 0
 }
 
+func (x *PeerMetric) GetSavedIn() int64 {
+	if x != nil {
+		return x.SavedIn
+	}
+	return 0
+}
+
+func (x *PeerMetric) GetSavedOut() int64 {
+	if x != nil {
+		return x.SavedOut
+	}
+	return 0
+}
+
+func (x *PeerMetric) GetDupTxNotice() int64 {
+	if x != nil {
+		return x.DupTxNotice
+	}
+	return 0
+}
+
+func (x *PeerMetric) GetDupBlkNotice() int64 {
+	if x != nil {
+		return x.DupBlkNotice
+	}
+	return 0
+}
+
 var File_metric_proto protoreflect.FileDescriptor
 
 var file_metric_proto_rawDesc = []byte{
@@ -252,7 +286,7 @@ var file_metric_proto_rawDesc = []byte{
 	0x22, 0x32, 0x0a, 0x07, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x70,
 	0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70,
 	0x65, 0x73, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x05, 0x70,
-	0x65, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0a, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x65, 0x65, 0x72, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x0a, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x74,
 	0x72, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73,
 	0x75, 0x6d, 0x49, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x75, 0x6d, 0x49,
@@ -260,11 +294,19 @@ var file_metric_proto_rawDesc = []byte{
 	0x52, 0x05, 0x61, 0x76, 0x72, 0x49, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x6d, 0x4f, 0x75,
 	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x75, 0x6d, 0x4f, 0x75, 0x74, 0x12,
 	0x16, 0x0a, 0x06, 0x61, 0x76, 0x72, 0x4f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x61, 0x76, 0x72, 0x4f, 0x75, 0x74, 0x2a, 0x2a, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47,
-	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x32, 0x50, 0x5f, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52,
-	0x4b, 0x10, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x06, 0x61, 0x76, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x61, 0x76, 0x65, 0x64,
+	0x49, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x61, 0x76, 0x65, 0x64, 0x49,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x61, 0x76, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x61, 0x76, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x75, 0x70, 0x54, 0x78, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x64, 0x75, 0x70, 0x54, 0x78, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x64, 0x75, 0x70, 0x42, 0x6c, 0x6b, 0x4e, 0x6f, 0x74, 0x69, 0x63, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x75, 0x70, 0x42, 0x6c, 0x6b, 0x4e, 0x6f, 0x74,
+	0x69, 0x63, 0x65, 0x2a, 0x2a, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x50, 0x32, 0x50, 0x5f, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x10, 0x01, 0x42,
+	0x08, 0x5a, 0x06, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aergoio/aergo/v2/internal/enc/proto"
+)
+
+const (
+	// MultisigAddressPrefix is the first byte of the address of a multisig account, which is never a prefix of a
+	// compressed public key, so that the address can't be signed by a single key.
+	MultisigAddressPrefix = 0x0D
+	// MaxMultisigKeys is the maximum number of the public keys of a multisig account.
+	MaxMultisigKeys = 16
+)
+
+// IsMultisigAddress returns true if the address is of a multisig account.
+func IsMultisigAddress(addr []byte) bool {
+	return len(addr) == AddressLength && addr[0] == MultisigAddressPrefix
+}
+
+// HasMultisigAccount returns true if the tx is sent by a multisig account. The sign of the tx is a MultisigSign
+// instead of a single signature.
+func (tx *Tx) HasMultisigAccount() bool {
+	return IsMultisigAddress(tx.GetBody().GetAccount())
+}
+
+// NewMultisigAccount returns a multisig account with the public keys sorted, so that the same keys and threshold
+// make the same account regardless of the order.
+func NewMultisigAccount(threshold uint32, pubkeys [][]byte) (*MultisigAccount, error) {
+	if len(pubkeys) == 0 || len(pubkeys) > MaxMultisigKeys {
+		return nil, fmt.Errorf("the number of public keys should be 1 to %d", MaxMultisigKeys)
+	}
+	if threshold == 0 || int(threshold) > len(pubkeys) {
+		return nil, fmt.Errorf("the threshold should be 1 to the number of public keys")
+	}
+	sorted := make([][]byte, len(pubkeys))
+	copy(sorted, pubkeys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	for i, pubkey := range sorted {
+		if len(pubkey) != AddressLength || (pubkey[0] != 0x02 && pubkey[0] != 0x03) {
+			return nil, fmt.Errorf("invalid public key %s", EncodeAddress(pubkey))
+		}
+		if i > 0 && bytes.Equal(sorted[i-1], pubkey) {
+			return nil, fmt.Errorf("duplicated public key %s", EncodeAddress(pubkey))
+		}
+	}
+	return &MultisigAccount{Threshold: threshold, Pubkeys: sorted}, nil
+}
+
+// ParseMultisigCreate returns the multisig account to create from the arguments of the system tx, which are the
+// threshold and the addresses of the keys.
+func ParseMultisigCreate(ci *CallInfo) (*MultisigAccount, error) {
+	if len(ci.Args) < 2 {
+		return nil, fmt.Errorf("invalid arguments in %s", ci)
+	}
+	var threshold uint64
+	switch v := ci.Args[0].(type) {
+	case float64:
+		threshold = uint64(v)
+		if float64(threshold) != v {
+			return nil, fmt.Errorf("invalid threshold %v", v)
+		}
+	case string:
+		var err error
+		if threshold, err = strconv.ParseUint(v, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid threshold %s", v)
+		}
+	default:
+		return nil, fmt.Errorf("invalid threshold %v", v)
+	}
+	if threshold > MaxMultisigKeys {
+		return nil, fmt.Errorf("the threshold should be 1 to the number of public keys")
+	}
+	pubkeys := make([][]byte, 0, len(ci.Args)-1)
+	for _, arg := range ci.Args[1:] {
+		encoded, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid public key %v", arg)
+		}
+		pubkey, err := DecodeAddress(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s", encoded)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return NewMultisigAccount(uint32(threshold), pubkeys)
+}
+
+// Address returns the address of the multisig account, which is derived from the threshold and the public keys.
+func (m *MultisigAccount) Address() []byte {
+	h := sha256.New()
+	h.Write([]byte("multisig"))
+	binary.Write(h, binary.BigEndian, m.GetThreshold())
+	for _, pubkey := range m.GetPubkeys() {
+		h.Write(pubkey)
+	}
+	return append([]byte{MultisigAddressPrefix}, h.Sum(nil)...)
+}
+
+// HasKey returns true if the public key is one of the keys of the multisig account.
+func (m *MultisigAccount) HasKey(pubkey []byte) bool {
+	for _, k := range m.GetPubkeys() {
+		if bytes.Equal(k, pubkey) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeMultisigSign decodes the sign of a tx sent by a multisig account. An empty sign has no signatures.
+func DecodeMultisigSign(sign []byte) (*MultisigSign, error) {
+	ms := &MultisigSign{}
+	if err := proto.Decode(sign, ms); err != nil {
+		return nil, err
+	}
+	if len(ms.Pubkeys) != len(ms.Signs) {
+		return nil, fmt.Errorf("the number of signatures mismatches the public keys")
+	}
+	return ms, nil
+}
+
+// Add adds the signature of the public key, or replaces the former one of the key.
+func (ms *MultisigSign) Add(pubkey, sign []byte) {
+	for i, k := range ms.Pubkeys {
+		if bytes.Equal(k, pubkey) {
+			ms.Signs[i] = sign
+			return
+		}
+	}
+	ms.Pubkeys = append(ms.Pubkeys, pubkey)
+	ms.Signs = append(ms.Signs, sign)
+}
+
+// Encode returns the sign of the tx.
+func (ms *MultisigSign) Encode() []byte {
+	b, _ := proto.Encode(ms)
+	return b
+}
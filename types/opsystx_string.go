@@ -12,12 +12,16 @@ func _() {
 	_ = x[OpvoteDAO-1]
 	_ = x[Opstake-2]
 	_ = x[Opunstake-3]
-	_ = x[OpSysTxMax-4]
+	_ = x[OpcreateMultisig-4]
+	_ = x[OprotateKey-5]
+	_ = x[Opdelegate-6]
+	_ = x[Opundelegate-7]
+	_ = x[OpSysTxMax-8]
 }
 
-const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpSysTxMax"
+const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpcreateMultisigOprotateKeyOpdelegateOpundelegateOpSysTxMax"
 
-var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 43}
+var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 49, 60, 70, 82, 92}
 
 func (i OpSysTx) String() string {
 	if i < 0 || i >= OpSysTx(len(_OpSysTx_index)-1) {
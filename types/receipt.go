@@ -125,7 +125,7 @@ func (r *Receipt) marshalBodyV2(b *bytes.Buffer, isMerkle bool) error {
 	return nil
 }
 
-func (r *Receipt) marshalStoreBinary() ([]byte, error) {
+func (r *Receipt) marshalStoreBinary(withEventFlags bool) ([]byte, error) {
 	var b bytes.Buffer
 
 	err := r.marshalBody(&b, false)
@@ -133,7 +133,7 @@ func (r *Receipt) marshalStoreBinary() ([]byte, error) {
 		return nil, err
 	}
 	for _, ev := range r.Events {
-		evB, err := ev.marshalStoreBinary(r)
+		evB, err := ev.marshalStoreBinary(r, withEventFlags)
 		if err != nil {
 			return nil, err
 		}
@@ -143,7 +143,7 @@ func (r *Receipt) marshalStoreBinary() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-func (r *Receipt) marshalStoreBinaryV2() ([]byte, error) {
+func (r *Receipt) marshalStoreBinaryV2(withEventFlags bool) ([]byte, error) {
 	var b bytes.Buffer
 
 	err := r.marshalBodyV2(&b, false)
@@ -151,7 +151,7 @@ func (r *Receipt) marshalStoreBinaryV2() ([]byte, error) {
 		return nil, err
 	}
 	for _, ev := range r.Events {
-		evB, err := ev.marshalStoreBinary(r)
+		evB, err := ev.marshalStoreBinary(r, withEventFlags)
 		if err != nil {
 			return nil, err
 		}
@@ -161,10 +161,9 @@ func (r *Receipt) marshalStoreBinaryV2() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-func (r *Receipt) unmarshalBody(data []byte) ([]byte, uint32) {
-	r.ContractAddress = data[:33]
-	status := data[33]
-	switch status {
+func (r *Receipt) unmarshalBody(sr *storeReader) uint32 {
+	r.ContractAddress = sr.next(33)
+	switch sr.byte() {
 	case successStatus:
 		r.Status = "SUCCESS"
 	case createdStatus:
@@ -174,37 +173,22 @@ func (r *Receipt) unmarshalBody(data []byte) ([]byte, uint32) {
 	case recreatedStatus:
 		r.Status = "RECREATED"
 	}
-	pos := uint32(34)
-	l := binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.Ret = string(data[pos : pos+l])
-	pos += l
-	r.TxHash = data[pos : pos+32]
-	pos += 32
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.FeeUsed = data[pos : pos+l]
-	pos += l
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.CumulativeFeeUsed = data[pos : pos+l]
-	pos += l
-	bloomCheck := data[pos]
-	pos += 1
-	if bloomCheck == 1 {
-		r.Bloom = data[pos : pos+BloomBitByte]
-		pos += BloomBitByte
-	}
-	pos += l
-	evCount := binary.LittleEndian.Uint32(data[pos:])
-
-	return data[pos+4:], evCount
-}
-
-func (r *Receipt) unmarshalBodyV2(data []byte) ([]byte, uint32) {
-	r.ContractAddress = data[:33]
-	status := data[33]
-	switch status {
+	r.Ret = string(sr.next(sr.uint32()))
+	r.TxHash = sr.next(32)
+	r.FeeUsed = sr.next(sr.uint32())
+	l := sr.uint32()
+	r.CumulativeFeeUsed = sr.next(l)
+	if sr.byte() == 1 {
+		r.Bloom = sr.next(BloomBitByte)
+	}
+	sr.next(l)
+
+	return sr.count()
+}
+
+func (r *Receipt) unmarshalBodyV2(sr *storeReader) uint32 {
+	r.ContractAddress = sr.next(33)
+	switch sr.byte() {
 	case successStatus:
 		r.Status = "SUCCESS"
 	case createdStatus:
@@ -214,74 +198,59 @@ func (r *Receipt) unmarshalBodyV2(data []byte) ([]byte, uint32) {
 	case recreatedStatus:
 		r.Status = "RECREATED"
 	}
-	pos := uint32(34)
-	l := binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.Ret = string(data[pos : pos+l])
-	pos += l
-	r.TxHash = data[pos : pos+32]
-	pos += 32
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.FeeUsed = data[pos : pos+l]
-	pos += l
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	r.CumulativeFeeUsed = data[pos : pos+l]
-	pos += l
-	ll := binary.LittleEndian.Uint64(data[pos:])
-	r.GasUsed = ll
-	pos += 8
-	if data[pos] == 1 {
+	r.Ret = string(sr.next(sr.uint32()))
+	r.TxHash = sr.next(32)
+	r.FeeUsed = sr.next(sr.uint32())
+	l := sr.uint32()
+	r.CumulativeFeeUsed = sr.next(l)
+	r.GasUsed = sr.uint64()
+	if sr.byte() == 1 {
 		r.FeeDelegation = true
 	}
-	pos += 1
-	bloomCheck := data[pos]
-	pos += 1
-	if bloomCheck == 1 {
-		r.Bloom = data[pos : pos+BloomBitByte]
-		pos += BloomBitByte
+	if sr.byte() == 1 {
+		r.Bloom = sr.next(BloomBitByte)
 	}
-	pos += l
-	evCount := binary.LittleEndian.Uint32(data[pos:])
+	sr.next(l)
 
-	return data[pos+4:], evCount
+	return sr.count()
 }
 
-func (r *Receipt) unmarshalStoreBinary(data []byte) ([]byte, error) {
-	evData, evCount := r.unmarshalBody(data)
+func (r *Receipt) unmarshalStoreBinary(sr *storeReader, withEventFlags bool) error {
+	evCount := r.unmarshalBody(sr)
+	if sr.err != nil {
+		return sr.err
+	}
 
 	r.Events = make([]*Event, evCount)
-	var err error
 	for i := uint32(0); i < evCount; i++ {
 		var ev Event
-		evData, err = ev.unmarshalStoreBinary(evData, r)
-		if err != nil {
-			return nil, err
+		if err := ev.unmarshalStoreBinary(sr, r, withEventFlags); err != nil {
+			return err
 		}
 		r.Events[i] = &ev
 	}
-	return evData, nil
+	return nil
 }
 
-func (r *Receipt) unmarshalStoreBinaryV2(data []byte) ([]byte, error) {
-	evData, evCount := r.unmarshalBodyV2(data)
+func (r *Receipt) unmarshalStoreBinaryV2(sr *storeReader, withEventFlags bool) error {
+	evCount := r.unmarshalBodyV2(sr)
+	if sr.err != nil {
+		return sr.err
+	}
 
 	r.Events = make([]*Event, evCount)
-	var err error
 	for i := uint32(0); i < evCount; i++ {
 		var ev Event
-		evData, err = ev.unmarshalStoreBinary(evData, r)
-		if err != nil {
-			return nil, err
+		if err := ev.unmarshalStoreBinary(sr, r, withEventFlags); err != nil {
+			return err
 		}
 		r.Events[i] = &ev
 	}
-	return evData, nil
+	return nil
 }
 
 func (r *Receipt) MarshalBinaryTest() ([]byte, error) {
-	return r.marshalStoreBinaryV2()
+	return r.marshalStoreBinaryV2(true)
 }
 
 func (r *Receipt) MarshalMerkleBinary() ([]byte, error) {
@@ -323,14 +292,18 @@ func (r *Receipt) MarshalMerkleBinaryV2() ([]byte, error) {
 }
 
 func (r *Receipt) UnmarshalBinaryTest(data []byte) error {
-	_, err := r.unmarshalStoreBinaryV2(data)
-	return err
+	return r.unmarshalStoreBinaryV2(&storeReader{data: data}, true)
 }
 
 func (r *Receipt) ReadFrom(data []byte) ([]byte, error) {
-	evData, evCount := r.unmarshalBody(data)
+	sr := &storeReader{data: data}
+	evCount := r.unmarshalBody(sr)
+	if sr.err != nil {
+		return nil, sr.err
+	}
 
 	r.Events = make([]*Event, evCount)
+	evData := sr.data
 	var err error
 	for i := uint32(0); i < evCount; i++ {
 		var ev Event
@@ -429,11 +402,21 @@ func (r *Receipt) BloomFilter(fi *FilterInfo) bool {
 	}
 
 	if bf.Test(fi.ContractAddress) || bf.Test([]byte(fi.EventName)) {
-		return true
+		return testTopics(&bf, fi)
 	}
 	return false
 }
 
+// testTopics checks that every topic required by the filter may be in the bloom.
+func testTopics(bf *bloom.BloomFilter, fi *FilterInfo) bool {
+	for _, topic := range fi.Topics {
+		if len(topic) != 0 && !bf.Test(topic) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Receipt) SetMemoryInfo(blkHash []byte, blkNo BlockNo, txIdx int32) {
 	r.BlockNo = blkNo
 	r.BlockHash = blkHash
@@ -519,7 +502,7 @@ func (rs *Receipts) BloomFilter(fi *FilterInfo) bool {
 	}
 	bf := (*bloom.BloomFilter)(rs.bloom)
 	if bf.Test(fi.ContractAddress) || bf.Test([]byte(fi.EventName)) {
-		return true
+		return testTopics(bf, fi)
 	}
 	return false
 }
@@ -546,15 +529,21 @@ func (rs *Receipts) MarshalBinary() ([]byte, error) {
 	var b bytes.Buffer
 	l := make([]byte, 4)
 
+	// the receipts without event topics keep the format of the older versions
+	var flags byte
+	withEventFlags := rs.hasEventTopics()
+	if withEventFlags {
+		flags |= receiptsHasEventFlags
+	}
 	if rs.bloom != nil {
-		b.WriteByte(1)
+		b.WriteByte(flags | receiptsHasBloom)
 		bloomB, err := (*bloom.BloomFilter)(rs.bloom).GobEncode()
 		if err != nil {
 			return nil, err
 		}
 		b.Write(bloomB[24:])
 	} else {
-		b.WriteByte(0)
+		b.WriteByte(flags)
 	}
 	binary.LittleEndian.PutUint32(l, uint32(len(rs.receipts)))
 	b.Write(l)
@@ -562,9 +551,9 @@ func (rs *Receipts) MarshalBinary() ([]byte, error) {
 	var err error
 	for _, r := range rs.receipts {
 		if rs.hardForkConfig.IsV2Fork(rs.blockNo) {
-			rB, err = r.marshalStoreBinaryV2()
+			rB, err = r.marshalStoreBinaryV2(withEventFlags)
 		} else {
-			rB, err = r.marshalStoreBinary()
+			rB, err = r.marshalStoreBinary(withEventFlags)
 		}
 		if err != nil {
 			return nil, err
@@ -575,10 +564,31 @@ func (rs *Receipts) MarshalBinary() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+func (rs *Receipts) hasEventTopics() bool {
+	for _, r := range rs.receipts {
+		for _, ev := range r.Events {
+			if len(ev.Topics) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (rs *Receipts) UnmarshalBinary(data []byte) error {
-	checkBloom := data[0]
-	pos := 1
-	if checkBloom == 1 {
+	sr := &storeReader{data: data}
+	flags := sr.byte()
+	if sr.err != nil {
+		return sr.err
+	}
+	if flags&^(receiptsHasBloom|receiptsHasEventFlags) != 0 {
+		return fmt.Errorf("unknown flags of the stored receipts: %#x", flags)
+	}
+	if flags&receiptsHasBloom != 0 {
+		bloomB := sr.next(BloomBitByte)
+		if sr.err != nil {
+			return sr.err
+		}
 		var buffer bytes.Buffer
 		var bf bloom.BloomFilter
 		l := make([]byte, 8)
@@ -588,25 +598,26 @@ func (rs *Receipts) UnmarshalBinary(data []byte) error {
 		buffer.Write(l)
 		binary.BigEndian.PutUint64(l, BloomBitBits)
 		buffer.Write(l)
-		buffer.Write(data[pos : pos+BloomBitByte])
+		buffer.Write(bloomB)
 		_, err := bf.ReadFrom(&buffer)
 		if err != nil {
 			return err
 		}
-		pos += BloomBitByte
 		rs.bloom = (*bloomFilter)(&bf)
 	}
-	rCount := binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
+	rCount := sr.count()
+	if sr.err != nil {
+		return sr.err
+	}
+	withEventFlags := flags&receiptsHasEventFlags != 0
 	rs.receipts = make([]*Receipt, rCount)
-	unread := data[pos:]
 	var err error
 	for i := uint32(0); i < rCount; i++ {
 		var r Receipt
 		if rs.hardForkConfig.IsV2Fork(rs.blockNo) {
-			unread, err = r.unmarshalStoreBinaryV2(unread)
+			err = r.unmarshalStoreBinaryV2(sr, withEventFlags)
 		} else {
-			unread, err = r.unmarshalStoreBinary(unread)
+			err = r.unmarshalStoreBinary(sr, withEventFlags)
 		}
 		if err != nil {
 			return err
@@ -646,9 +657,22 @@ func (ev *Event) MarshalBinary() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-func (ev *Event) marshalStoreBinary(r *Receipt) ([]byte, error) {
+func (ev *Event) marshalStoreBinary(r *Receipt, withFlags bool) ([]byte, error) {
 	var b bytes.Buffer
 	l := make([]byte, 4)
+	if withFlags {
+		if len(ev.Topics) > 0 {
+			b.WriteByte(eventHasTopics)
+			b.WriteByte(byte(len(ev.Topics)))
+			for _, topic := range ev.Topics {
+				b.Write(topic)
+			}
+		} else {
+			b.WriteByte(0)
+		}
+	} else if len(ev.Topics) > 0 {
+		return nil, errors.New("event topics without the event flags")
+	}
 	if bytes.Equal(r.ContractAddress, ev.ContractAddress) {
 		b.WriteByte(0)
 	} else {
@@ -667,66 +691,112 @@ func (ev *Event) marshalStoreBinary(r *Receipt) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-func (ev *Event) unmarshalStoreBinary(data []byte, r *Receipt) ([]byte, error) {
-	var pos uint32
-	if data[0] == 0 {
+func (ev *Event) unmarshalStoreBinary(sr *storeReader, r *Receipt, withFlags bool) error {
+	if withFlags {
+		flags := sr.byte()
+		if flags&^eventHasTopics != 0 {
+			return fmt.Errorf("unknown flags of the stored event: %#x", flags)
+		}
+		if flags&eventHasTopics != 0 {
+			n := int(sr.byte())
+			if n > MaxEventTopics {
+				return fmt.Errorf("too many topics of the stored event: %d", n)
+			}
+			ev.Topics = make([][]byte, n)
+			for i := 0; i < n; i++ {
+				ev.Topics[i] = sr.next(EventTopicLength)
+			}
+		}
+	}
+	if len(sr.data) > 0 && sr.data[0] == 0 {
 		ev.ContractAddress = r.ContractAddress
-		pos += 1
+		sr.next(1)
 	} else {
-		ev.ContractAddress = data[:33]
-		pos += 33
+		ev.ContractAddress = sr.next(33)
 	}
-	l := binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	ev.EventName = string(data[pos : pos+l])
-	pos += l
-
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	ev.JsonArgs = string(data[pos : pos+l])
-	pos += l
+	ev.EventName = string(sr.next(sr.uint32()))
+	ev.JsonArgs = string(sr.next(sr.uint32()))
+	ev.EventIdx = int32(sr.uint32())
 
-	ev.EventIdx = int32(binary.LittleEndian.Uint32(data[pos:]))
-	pos += 4
-
-	return data[pos:], nil
+	return sr.err
 }
 
 func (ev *Event) MarshalMerkleBinary() ([]byte, error) {
 	var b bytes.Buffer
 	ev.marshalCommonBinary(&b)
+	for _, topic := range ev.Topics {
+		b.Write(topic)
+	}
 	return b.Bytes(), nil
 }
 
 func (ev *Event) UnmarshalBinary(data []byte) ([]byte, error) {
-	pos := uint32(33)
-	ev.ContractAddress = data[:pos]
-
-	l := binary.LittleEndian.Uint32(data[33:])
-	pos += 4
-	ev.EventName = string(data[pos : pos+l])
-	pos += l
+	sr := &storeReader{data: data}
+	ev.ContractAddress = sr.next(33)
+	ev.EventName = string(sr.next(sr.uint32()))
+	ev.JsonArgs = string(sr.next(sr.uint32()))
+	ev.TxHash = sr.next(32)
+	ev.EventIdx = int32(sr.uint32())
+	ev.BlockHash = sr.next(32)
+	ev.BlockNo = sr.uint64()
+	ev.TxIndex = int32(sr.uint32())
+
+	return sr.data, sr.err
+}
 
-	l = binary.LittleEndian.Uint32(data[pos:])
-	pos += 4
-	ev.JsonArgs = string(data[pos : pos+l])
-	pos += l
+var errTruncatedReceipt = errors.New("truncated receipt data")
 
-	ev.TxHash = data[pos : pos+32]
-	pos += 32
+// storeReader decodes the stored receipts and events. A read past the end
+// of the data returns nothing and sets err, which the decoder returns
+// instead of panicking on a truncated or corrupt record.
+type storeReader struct {
+	data []byte
+	err  error
+}
 
-	ev.EventIdx = int32(binary.LittleEndian.Uint32(data[pos:]))
-	pos += 4
+func (sr *storeReader) next(n uint32) []byte {
+	if sr.err != nil {
+		return nil
+	}
+	if uint64(n) > uint64(len(sr.data)) {
+		sr.data, sr.err = nil, errTruncatedReceipt
+		return nil
+	}
+	b := sr.data[:n]
+	sr.data = sr.data[n:]
+	return b
+}
 
-	ev.BlockHash = data[pos : pos+32]
-	pos += 32
+func (sr *storeReader) byte() byte {
+	if b := sr.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
 
-	ev.BlockNo = binary.LittleEndian.Uint64(data[pos:])
-	pos += 8
+func (sr *storeReader) uint32() uint32 {
+	if b := sr.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
 
-	ev.TxIndex = int32(binary.LittleEndian.Uint32(data[pos:]))
+func (sr *storeReader) uint64() uint64 {
+	if b := sr.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
 
-	return data[pos+4:], nil
+// count reads the number of the following records, each of which takes at
+// least a byte, so a corrupt count fails here rather than at the allocation.
+func (sr *storeReader) count() uint32 {
+	n := sr.uint32()
+	if sr.err == nil && uint64(n) > uint64(len(sr.data)) {
+		sr.data, sr.err = nil, errTruncatedReceipt
+		return 0
+	}
+	return n
 }
 
 func (ev *Event) MarshalJSON() ([]byte, error) {
@@ -747,6 +817,18 @@ func (ev *Event) MarshalJSON() ([]byte, error) {
 	b.WriteString(fmt.Sprintf("%d", ev.BlockNo))
 	b.WriteString(`,"TxIndex":`)
 	b.WriteString(fmt.Sprintf("%d", ev.TxIndex))
+	if len(ev.Topics) > 0 {
+		b.WriteString(`,"Topics":[`)
+		for i, topic := range ev.Topics {
+			if i != 0 {
+				b.WriteString(`,`)
+			}
+			b.WriteString(`"`)
+			b.WriteString(base58.Encode(topic))
+			b.WriteString(`"`)
+		}
+		b.WriteString(`]`)
+	}
 	b.WriteString(`}`)
 	return b.Bytes(), nil
 }
@@ -823,6 +905,14 @@ func (ev *Event) Filter(filter *FilterInfo, argFilter []ArgFilter) bool {
 	if len(filter.EventName) != 0 && ev.EventName != filter.EventName {
 		return false
 	}
+	for i, topic := range filter.Topics {
+		if len(topic) == 0 {
+			continue
+		}
+		if i >= len(ev.Topics) || !bytes.Equal(ev.Topics[i], topic) {
+			return false
+		}
+	}
 	if argFilter != nil {
 		var args []interface{}
 		err := json.Unmarshal([]byte(ev.JsonArgs), &args)
@@ -853,6 +943,35 @@ type ArgFilter struct {
 const MAXBLOCKRANGE = 10000
 const padprefix = 0x80
 
+const (
+	// MaxEventTopics is the maximum number of the indexed args of an event.
+	MaxEventTopics = 4
+	// EventTopicLength is the length of a topic, which is the sha256 hash of the JSON encoded arg.
+	EventTopicLength = 32
+)
+
+// The flags of the stored receipts of a block, written in their first byte.
+const (
+	receiptsHasBloom = 1 << iota
+	// every stored event starts with its own flags
+	receiptsHasEventFlags
+)
+
+// The flags of a stored event.
+const (
+	eventHasTopics = 1 << iota
+)
+
+// EventTopic returns the topic of an indexed event arg given as JSON, e.g. `"alice"` or `100`.
+func EventTopic(jsonArg []byte) ([]byte, error) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, jsonArg); err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(b.Bytes())
+	return h[:], nil
+}
+
 func AddressPadding(addr []byte) []byte {
 	id := make([]byte, AddressLength)
 	id[0] = padprefix
@@ -875,6 +994,14 @@ func (fi *FilterInfo) ValidateCheck(to uint64) error {
 	} else if len(fi.ContractAddress) != AddressLength {
 		return errors.New("invalid contractAddress:" + string(fi.ContractAddress))
 	}
+	if len(fi.Topics) > MaxEventTopics {
+		return fmt.Errorf("too many topics %d (max %d)", len(fi.Topics), MaxEventTopics)
+	}
+	for i, topic := range fi.Topics {
+		if len(topic) != 0 && len(topic) != EventTopicLength {
+			return fmt.Errorf("invalid topic at %d: length %d", i, len(topic))
+		}
+	}
 	if fi.RecentBlockCnt > 0 {
 		if fi.RecentBlockCnt > MAXBLOCKRANGE {
 			return errors.New(fmt.Sprintf("too large value at recentBlockCnt %d (max %d)",
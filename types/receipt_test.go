@@ -0,0 +1,131 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willf/bloom"
+)
+
+func TestEventTopics(t *testing.T) {
+	contract := make([]byte, AddressLength)
+	contract[0] = 0x0c
+	to, err := EventTopic([]byte(` "alice" `))
+	require.NoError(t, err)
+	toOther, err := EventTopic([]byte(`"bob"`))
+	require.NoError(t, err)
+	amount, err := EventTopic([]byte(`100`))
+	require.NoError(t, err)
+	assert.Len(t, to, EventTopicLength)
+
+	r := NewReceipt(contract, "SUCCESS", "")
+	r.TxHash = make([]byte, 32)
+	r.Events = []*Event{
+		{ContractAddress: contract, EventName: "transfer", JsonArgs: `["alice",100]`, Topics: [][]byte{to, amount}},
+		{ContractAddress: contract, EventName: "transfer", JsonArgs: `["bob",100]`},
+	}
+
+	// the events with topics and without them are both restored from the store
+	b, err := r.MarshalBinaryTest()
+	require.NoError(t, err)
+	var restored Receipt
+	require.NoError(t, restored.UnmarshalBinaryTest(b))
+	require.Len(t, restored.Events, 2)
+	assert.Equal(t, [][]byte{to, amount}, restored.Events[0].Topics)
+	assert.Empty(t, restored.Events[1].Topics)
+	assert.Equal(t, `["bob",100]`, restored.Events[1].JsonArgs)
+
+	// the topics are matched by position and an empty topic matches any
+	fi := &FilterInfo{ContractAddress: contract, Topics: [][]byte{to}}
+	assert.True(t, r.Events[0].Filter(fi, nil))
+	assert.False(t, r.Events[1].Filter(fi, nil))
+	fi.Topics = [][]byte{nil, amount}
+	assert.True(t, r.Events[0].Filter(fi, nil))
+	fi.Topics = [][]byte{toOther}
+	assert.False(t, r.Events[0].Filter(fi, nil))
+
+	// the bloom of the receipt includes the topics
+	bf := bloom.New(BloomBitBits, BloomHashKNum)
+	for _, e := range r.Events {
+		bf.Add(e.ContractAddress)
+		bf.Add([]byte(e.EventName))
+		for _, topic := range e.Topics {
+			bf.Add(topic)
+		}
+	}
+	bb, _ := bf.GobEncode()
+	r.Bloom = bb[24:]
+	fi.Topics = [][]byte{to}
+	assert.True(t, r.BloomFilter(fi))
+
+	fi.Topics = [][]byte{{1, 2, 3}}
+	assert.Error(t, fi.ValidateCheck(0))
+	fi.Topics = make([][]byte, MaxEventTopics+1)
+	assert.Error(t, fi.ValidateCheck(0))
+}
+
+func TestReceiptsStoreBinary(t *testing.T) {
+	contract := make([]byte, AddressLength)
+	contract[0] = 0x0c
+	other := make([]byte, AddressLength)
+	other[0] = 0x0d
+	topic, err := EventTopic([]byte(`"alice"`))
+	require.NoError(t, err)
+
+	newReceipts := func(topics [][]byte) *Receipts {
+		r := NewReceipt(contract, "SUCCESS", `"ok"`)
+		r.TxHash = make([]byte, 32)
+		r.FeeUsed = []byte{1, 2}
+		r.GasUsed = 100
+		r.Events = []*Event{
+			{ContractAddress: contract, EventName: "transfer", JsonArgs: `["alice",100]`, Topics: topics},
+			{ContractAddress: other, EventName: "other", JsonArgs: `[]`},
+		}
+		rs := &Receipts{}
+		rs.SetHardFork(DummyBlockVersionner(2), 1)
+		rs.Set([]*Receipt{r})
+		require.NoError(t, rs.MergeBloom(bloom.New(BloomBitBits, BloomHashKNum)))
+		return rs
+	}
+
+	tests := []struct {
+		name      string
+		topics    [][]byte
+		wantFlags byte
+	}{
+		{"withoutTopics", nil, receiptsHasBloom},
+		{"withTopics", [][]byte{topic}, receiptsHasBloom | receiptsHasEventFlags},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := newReceipts(tt.topics)
+			b, err := rs.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFlags, b[0])
+
+			restored := &Receipts{}
+			restored.SetHardFork(DummyBlockVersionner(2), 1)
+			require.NoError(t, restored.UnmarshalBinary(b))
+			require.Len(t, restored.Get(), 1)
+			events := restored.Get()[0].Events
+			require.Len(t, events, 2)
+			assert.Equal(t, len(tt.topics), len(events[0].Topics))
+			assert.Equal(t, contract, events[0].ContractAddress)
+			assert.Equal(t, other, events[1].ContractAddress)
+			assert.Equal(t, "other", events[1].EventName)
+
+			// every truncation of the stored receipts fails without a panic
+			for n := 0; n < len(b); n++ {
+				truncated := &Receipts{}
+				truncated.SetHardFork(DummyBlockVersionner(2), 1)
+				assert.Error(t, truncated.UnmarshalBinary(b[:n]), "length %d", n)
+			}
+
+			// unknown flags are rejected
+			corrupt := append([]byte{}, b...)
+			corrupt[0] |= 0x80
+			assert.Error(t, restored.UnmarshalBinary(corrupt))
+		})
+	}
+}
@@ -19,49 +19,62 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AergoRPCService_NodeState_FullMethodName               = "/types.AergoRPCService/NodeState"
-	AergoRPCService_Metric_FullMethodName                  = "/types.AergoRPCService/Metric"
-	AergoRPCService_Blockchain_FullMethodName              = "/types.AergoRPCService/Blockchain"
-	AergoRPCService_GetChainInfo_FullMethodName            = "/types.AergoRPCService/GetChainInfo"
-	AergoRPCService_ChainStat_FullMethodName               = "/types.AergoRPCService/ChainStat"
-	AergoRPCService_ListBlockHeaders_FullMethodName        = "/types.AergoRPCService/ListBlockHeaders"
-	AergoRPCService_ListBlockMetadata_FullMethodName       = "/types.AergoRPCService/ListBlockMetadata"
-	AergoRPCService_ListBlockStream_FullMethodName         = "/types.AergoRPCService/ListBlockStream"
-	AergoRPCService_ListBlockMetadataStream_FullMethodName = "/types.AergoRPCService/ListBlockMetadataStream"
-	AergoRPCService_GetBlock_FullMethodName                = "/types.AergoRPCService/GetBlock"
-	AergoRPCService_GetBlockMetadata_FullMethodName        = "/types.AergoRPCService/GetBlockMetadata"
-	AergoRPCService_GetBlockBody_FullMethodName            = "/types.AergoRPCService/GetBlockBody"
-	AergoRPCService_GetTX_FullMethodName                   = "/types.AergoRPCService/GetTX"
-	AergoRPCService_GetBlockTX_FullMethodName              = "/types.AergoRPCService/GetBlockTX"
-	AergoRPCService_GetReceipt_FullMethodName              = "/types.AergoRPCService/GetReceipt"
-	AergoRPCService_GetInternalOperations_FullMethodName   = "/types.AergoRPCService/GetInternalOperations"
-	AergoRPCService_GetABI_FullMethodName                  = "/types.AergoRPCService/GetABI"
-	AergoRPCService_SendTX_FullMethodName                  = "/types.AergoRPCService/SendTX"
-	AergoRPCService_SignTX_FullMethodName                  = "/types.AergoRPCService/SignTX"
-	AergoRPCService_VerifyTX_FullMethodName                = "/types.AergoRPCService/VerifyTX"
-	AergoRPCService_CommitTX_FullMethodName                = "/types.AergoRPCService/CommitTX"
-	AergoRPCService_GetState_FullMethodName                = "/types.AergoRPCService/GetState"
-	AergoRPCService_GetStateAndProof_FullMethodName        = "/types.AergoRPCService/GetStateAndProof"
-	AergoRPCService_CreateAccount_FullMethodName           = "/types.AergoRPCService/CreateAccount"
-	AergoRPCService_GetAccounts_FullMethodName             = "/types.AergoRPCService/GetAccounts"
-	AergoRPCService_LockAccount_FullMethodName             = "/types.AergoRPCService/LockAccount"
-	AergoRPCService_UnlockAccount_FullMethodName           = "/types.AergoRPCService/UnlockAccount"
-	AergoRPCService_ImportAccount_FullMethodName           = "/types.AergoRPCService/ImportAccount"
-	AergoRPCService_ExportAccount_FullMethodName           = "/types.AergoRPCService/ExportAccount"
-	AergoRPCService_ExportAccountKeystore_FullMethodName   = "/types.AergoRPCService/ExportAccountKeystore"
-	AergoRPCService_QueryContract_FullMethodName           = "/types.AergoRPCService/QueryContract"
-	AergoRPCService_QueryContractState_FullMethodName      = "/types.AergoRPCService/QueryContractState"
-	AergoRPCService_GetPeers_FullMethodName                = "/types.AergoRPCService/GetPeers"
-	AergoRPCService_GetVotes_FullMethodName                = "/types.AergoRPCService/GetVotes"
-	AergoRPCService_GetAccountVotes_FullMethodName         = "/types.AergoRPCService/GetAccountVotes"
-	AergoRPCService_GetStaking_FullMethodName              = "/types.AergoRPCService/GetStaking"
-	AergoRPCService_GetNameInfo_FullMethodName             = "/types.AergoRPCService/GetNameInfo"
-	AergoRPCService_ListEventStream_FullMethodName         = "/types.AergoRPCService/ListEventStream"
-	AergoRPCService_ListEvents_FullMethodName              = "/types.AergoRPCService/ListEvents"
-	AergoRPCService_GetServerInfo_FullMethodName           = "/types.AergoRPCService/GetServerInfo"
-	AergoRPCService_GetConsensusInfo_FullMethodName        = "/types.AergoRPCService/GetConsensusInfo"
-	AergoRPCService_GetEnterpriseConfig_FullMethodName     = "/types.AergoRPCService/GetEnterpriseConfig"
-	AergoRPCService_GetConfChangeProgress_FullMethodName   = "/types.AergoRPCService/GetConfChangeProgress"
+	AergoRPCService_NodeState_FullMethodName                = "/types.AergoRPCService/NodeState"
+	AergoRPCService_Metric_FullMethodName                   = "/types.AergoRPCService/Metric"
+	AergoRPCService_Blockchain_FullMethodName               = "/types.AergoRPCService/Blockchain"
+	AergoRPCService_GetChainInfo_FullMethodName             = "/types.AergoRPCService/GetChainInfo"
+	AergoRPCService_ChainStat_FullMethodName                = "/types.AergoRPCService/ChainStat"
+	AergoRPCService_ListBlockHeaders_FullMethodName         = "/types.AergoRPCService/ListBlockHeaders"
+	AergoRPCService_ListBlockMetadata_FullMethodName        = "/types.AergoRPCService/ListBlockMetadata"
+	AergoRPCService_ListBlockStream_FullMethodName          = "/types.AergoRPCService/ListBlockStream"
+	AergoRPCService_ListBlockMetadataStream_FullMethodName  = "/types.AergoRPCService/ListBlockMetadataStream"
+	AergoRPCService_GetBlock_FullMethodName                 = "/types.AergoRPCService/GetBlock"
+	AergoRPCService_GetBlockMetadata_FullMethodName         = "/types.AergoRPCService/GetBlockMetadata"
+	AergoRPCService_GetBlockBody_FullMethodName             = "/types.AergoRPCService/GetBlockBody"
+	AergoRPCService_GetTX_FullMethodName                    = "/types.AergoRPCService/GetTX"
+	AergoRPCService_GetBlockTX_FullMethodName               = "/types.AergoRPCService/GetBlockTX"
+	AergoRPCService_GetReceipt_FullMethodName               = "/types.AergoRPCService/GetReceipt"
+	AergoRPCService_GetInternalOperations_FullMethodName    = "/types.AergoRPCService/GetInternalOperations"
+	AergoRPCService_GetABI_FullMethodName                   = "/types.AergoRPCService/GetABI"
+	AergoRPCService_SendTX_FullMethodName                   = "/types.AergoRPCService/SendTX"
+	AergoRPCService_SignTX_FullMethodName                   = "/types.AergoRPCService/SignTX"
+	AergoRPCService_VerifyTX_FullMethodName                 = "/types.AergoRPCService/VerifyTX"
+	AergoRPCService_CommitTX_FullMethodName                 = "/types.AergoRPCService/CommitTX"
+	AergoRPCService_GetState_FullMethodName                 = "/types.AergoRPCService/GetState"
+	AergoRPCService_GetStateAndProof_FullMethodName         = "/types.AergoRPCService/GetStateAndProof"
+	AergoRPCService_CreateAccount_FullMethodName            = "/types.AergoRPCService/CreateAccount"
+	AergoRPCService_GetAccounts_FullMethodName              = "/types.AergoRPCService/GetAccounts"
+	AergoRPCService_LockAccount_FullMethodName              = "/types.AergoRPCService/LockAccount"
+	AergoRPCService_UnlockAccount_FullMethodName            = "/types.AergoRPCService/UnlockAccount"
+	AergoRPCService_ImportAccount_FullMethodName            = "/types.AergoRPCService/ImportAccount"
+	AergoRPCService_ExportAccount_FullMethodName            = "/types.AergoRPCService/ExportAccount"
+	AergoRPCService_ExportAccountKeystore_FullMethodName    = "/types.AergoRPCService/ExportAccountKeystore"
+	AergoRPCService_QueryContract_FullMethodName            = "/types.AergoRPCService/QueryContract"
+	AergoRPCService_QueryContractState_FullMethodName       = "/types.AergoRPCService/QueryContractState"
+	AergoRPCService_GetPeers_FullMethodName                 = "/types.AergoRPCService/GetPeers"
+	AergoRPCService_GetVotes_FullMethodName                 = "/types.AergoRPCService/GetVotes"
+	AergoRPCService_GetAccountVotes_FullMethodName          = "/types.AergoRPCService/GetAccountVotes"
+	AergoRPCService_GetStaking_FullMethodName               = "/types.AergoRPCService/GetStaking"
+	AergoRPCService_GetNameInfo_FullMethodName              = "/types.AergoRPCService/GetNameInfo"
+	AergoRPCService_ListEventStream_FullMethodName          = "/types.AergoRPCService/ListEventStream"
+	AergoRPCService_ListEvents_FullMethodName               = "/types.AergoRPCService/ListEvents"
+	AergoRPCService_GetServerInfo_FullMethodName            = "/types.AergoRPCService/GetServerInfo"
+	AergoRPCService_GetConsensusInfo_FullMethodName         = "/types.AergoRPCService/GetConsensusInfo"
+	AergoRPCService_GetEnterpriseConfig_FullMethodName      = "/types.AergoRPCService/GetEnterpriseConfig"
+	AergoRPCService_GetConfChangeProgress_FullMethodName    = "/types.AergoRPCService/GetConfChangeProgress"
+	AergoRPCService_TraceTx_FullMethodName                  = "/types.AergoRPCService/TraceTx"
+	AergoRPCService_GetScheduledCallReceipts_FullMethodName = "/types.AergoRPCService/GetScheduledCallReceipts"
+	AergoRPCService_ListScheduledCalls_FullMethodName       = "/types.AergoRPCService/ListScheduledCalls"
+	AergoRPCService_VerifyContractSource_FullMethodName     = "/types.AergoRPCService/VerifyContractSource"
+	AergoRPCService_GetContractSource_FullMethodName        = "/types.AergoRPCService/GetContractSource"
+	AergoRPCService_ProfileTx_FullMethodName                = "/types.AergoRPCService/ProfileTx"
+	AergoRPCService_GetKeyRotations_FullMethodName          = "/types.AergoRPCService/GetKeyRotations"
+	AergoRPCService_GetDelegations_FullMethodName           = "/types.AergoRPCService/GetDelegations"
+	AergoRPCService_GetSystemParams_FullMethodName          = "/types.AergoRPCService/GetSystemParams"
+	AergoRPCService_GetReverseName_FullMethodName           = "/types.AergoRPCService/GetReverseName"
+	AergoRPCService_GetNameRecords_FullMethodName           = "/types.AergoRPCService/GetNameRecords"
+	AergoRPCService_ListEnterpriseHistory_FullMethodName    = "/types.AergoRPCService/ListEnterpriseHistory"
+	AergoRPCService_ListEnterpriseProposals_FullMethodName  = "/types.AergoRPCService/ListEnterpriseProposals"
 )
 
 // AergoRPCServiceClient is the client API for AergoRPCService service.
@@ -154,6 +167,32 @@ type AergoRPCServiceClient interface {
 	GetEnterpriseConfig(ctx context.Context, in *EnterpriseConfigKey, opts ...grpc.CallOption) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ConfChangeProgress, error)
+	// Re-executes a transaction on the state of its parent block and returns the execution trace in JSON
+	TraceTx(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*SingleBytes, error)
+	// Return the receipts of the scheduled calls executed at the start of a block, in JSON
+	GetScheduledCallReceipts(ctx context.Context, in *BlockNumberParam, opts ...grpc.CallOption) (*SingleBytes, error)
+	// Return the queued scheduled calls of a contract, or all of them if the address is empty, in JSON
+	ListScheduledCalls(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*SingleBytes, error)
+	// Compile the source of a contract and register it on the node if it matches the deployed code
+	VerifyContractSource(ctx context.Context, in *ContractSource, opts ...grpc.CallOption) (*ContractSource, error)
+	// Return the verified source of a contract
+	GetContractSource(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ContractSource, error)
+	// Execute a transaction on the best block state without committing it, and return the gas consumed by its call stacks
+	ProfileTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*SingleBytes, error)
+	// Return the key rotation history of an account
+	GetKeyRotations(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*KeyRotationList, error)
+	// Return the stake delegation of an account and the delegations it received
+	GetDelegations(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*DelegationList, error)
+	// Return the governable system parameters with their pending values
+	GetSystemParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemParamList, error)
+	// Return the name verified by its forward record for an address
+	GetReverseName(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*NameInfo, error)
+	// Return the text records of a name
+	GetNameRecords(ctx context.Context, in *Name, opts ...grpc.CallOption) (*NameRecordList, error)
+	// Returns the audit log of the enterprise config changes, latest first
+	ListEnterpriseHistory(ctx context.Context, in *EnterpriseHistoryQuery, opts ...grpc.CallOption) (*EnterpriseHistoryList, error)
+	// Returns the approval policy of enterprise and the pending proposals
+	ListEnterpriseProposals(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnterpriseProposalList, error)
 }
 
 type aergoRPCServiceClient struct {
@@ -620,6 +659,123 @@ func (c *aergoRPCServiceClient) GetConfChangeProgress(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) TraceTx(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*SingleBytes, error) {
+	out := new(SingleBytes)
+	err := c.cc.Invoke(ctx, AergoRPCService_TraceTx_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetScheduledCallReceipts(ctx context.Context, in *BlockNumberParam, opts ...grpc.CallOption) (*SingleBytes, error) {
+	out := new(SingleBytes)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetScheduledCallReceipts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListScheduledCalls(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*SingleBytes, error) {
+	out := new(SingleBytes)
+	err := c.cc.Invoke(ctx, AergoRPCService_ListScheduledCalls_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) VerifyContractSource(ctx context.Context, in *ContractSource, opts ...grpc.CallOption) (*ContractSource, error) {
+	out := new(ContractSource)
+	err := c.cc.Invoke(ctx, AergoRPCService_VerifyContractSource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetContractSource(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ContractSource, error) {
+	out := new(ContractSource)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetContractSource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ProfileTx(ctx context.Context, in *Tx, opts ...grpc.CallOption) (*SingleBytes, error) {
+	out := new(SingleBytes)
+	err := c.cc.Invoke(ctx, AergoRPCService_ProfileTx_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetKeyRotations(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*KeyRotationList, error) {
+	out := new(KeyRotationList)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetKeyRotations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetDelegations(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*DelegationList, error) {
+	out := new(DelegationList)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetDelegations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetSystemParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemParamList, error) {
+	out := new(SystemParamList)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetSystemParams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetReverseName(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*NameInfo, error) {
+	out := new(NameInfo)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetReverseName_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetNameRecords(ctx context.Context, in *Name, opts ...grpc.CallOption) (*NameRecordList, error) {
+	out := new(NameRecordList)
+	err := c.cc.Invoke(ctx, AergoRPCService_GetNameRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListEnterpriseHistory(ctx context.Context, in *EnterpriseHistoryQuery, opts ...grpc.CallOption) (*EnterpriseHistoryList, error) {
+	out := new(EnterpriseHistoryList)
+	err := c.cc.Invoke(ctx, AergoRPCService_ListEnterpriseHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ListEnterpriseProposals(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnterpriseProposalList, error) {
+	out := new(EnterpriseProposalList)
+	err := c.cc.Invoke(ctx, AergoRPCService_ListEnterpriseProposals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AergoRPCServiceServer is the server API for AergoRPCService service.
 // All implementations must embed UnimplementedAergoRPCServiceServer
 // for forward compatibility
@@ -710,6 +866,32 @@ type AergoRPCServiceServer interface {
 	GetEnterpriseConfig(context.Context, *EnterpriseConfigKey) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(context.Context, *SingleBytes) (*ConfChangeProgress, error)
+	// Re-executes a transaction on the state of its parent block and returns the execution trace in JSON
+	TraceTx(context.Context, *SingleBytes) (*SingleBytes, error)
+	// Return the receipts of the scheduled calls executed at the start of a block, in JSON
+	GetScheduledCallReceipts(context.Context, *BlockNumberParam) (*SingleBytes, error)
+	// Return the queued scheduled calls of a contract, or all of them if the address is empty, in JSON
+	ListScheduledCalls(context.Context, *SingleBytes) (*SingleBytes, error)
+	// Compile the source of a contract and register it on the node if it matches the deployed code
+	VerifyContractSource(context.Context, *ContractSource) (*ContractSource, error)
+	// Return the verified source of a contract
+	GetContractSource(context.Context, *SingleBytes) (*ContractSource, error)
+	// Execute a transaction on the best block state without committing it, and return the gas consumed by its call stacks
+	ProfileTx(context.Context, *Tx) (*SingleBytes, error)
+	// Return the key rotation history of an account
+	GetKeyRotations(context.Context, *AccountAddress) (*KeyRotationList, error)
+	// Return the stake delegation of an account and the delegations it received
+	GetDelegations(context.Context, *AccountAddress) (*DelegationList, error)
+	// Return the governable system parameters with their pending values
+	GetSystemParams(context.Context, *Empty) (*SystemParamList, error)
+	// Return the name verified by its forward record for an address
+	GetReverseName(context.Context, *AccountAddress) (*NameInfo, error)
+	// Return the text records of a name
+	GetNameRecords(context.Context, *Name) (*NameRecordList, error)
+	// Returns the audit log of the enterprise config changes, latest first
+	ListEnterpriseHistory(context.Context, *EnterpriseHistoryQuery) (*EnterpriseHistoryList, error)
+	// Returns the approval policy of enterprise and the pending proposals
+	ListEnterpriseProposals(context.Context, *Empty) (*EnterpriseProposalList, error)
 	mustEmbedUnimplementedAergoRPCServiceServer()
 }
 
@@ -846,6 +1028,45 @@ func (UnimplementedAergoRPCServiceServer) GetEnterpriseConfig(context.Context, *
 func (UnimplementedAergoRPCServiceServer) GetConfChangeProgress(context.Context, *SingleBytes) (*ConfChangeProgress, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfChangeProgress not implemented")
 }
+func (UnimplementedAergoRPCServiceServer) TraceTx(context.Context, *SingleBytes) (*SingleBytes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TraceTx not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetScheduledCallReceipts(context.Context, *BlockNumberParam) (*SingleBytes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledCallReceipts not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) ListScheduledCalls(context.Context, *SingleBytes) (*SingleBytes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledCalls not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) VerifyContractSource(context.Context, *ContractSource) (*ContractSource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyContractSource not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetContractSource(context.Context, *SingleBytes) (*ContractSource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContractSource not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) ProfileTx(context.Context, *Tx) (*SingleBytes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileTx not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetKeyRotations(context.Context, *AccountAddress) (*KeyRotationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyRotations not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetDelegations(context.Context, *AccountAddress) (*DelegationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDelegations not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetSystemParams(context.Context, *Empty) (*SystemParamList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemParams not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetReverseName(context.Context, *AccountAddress) (*NameInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReverseName not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) GetNameRecords(context.Context, *Name) (*NameRecordList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNameRecords not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) ListEnterpriseHistory(context.Context, *EnterpriseHistoryQuery) (*EnterpriseHistoryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnterpriseHistory not implemented")
+}
+func (UnimplementedAergoRPCServiceServer) ListEnterpriseProposals(context.Context, *Empty) (*EnterpriseProposalList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnterpriseProposals not implemented")
+}
 func (UnimplementedAergoRPCServiceServer) mustEmbedUnimplementedAergoRPCServiceServer() {}
 
 // UnsafeAergoRPCServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -1642,6 +1863,240 @@ func _AergoRPCService_GetConfChangeProgress_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_TraceTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).TraceTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_TraceTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).TraceTx(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetScheduledCallReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockNumberParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetScheduledCallReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetScheduledCallReceipts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetScheduledCallReceipts(ctx, req.(*BlockNumberParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListScheduledCalls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListScheduledCalls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_ListScheduledCalls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListScheduledCalls(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_VerifyContractSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContractSource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).VerifyContractSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_VerifyContractSource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).VerifyContractSource(ctx, req.(*ContractSource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetContractSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SingleBytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetContractSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetContractSource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetContractSource(ctx, req.(*SingleBytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ProfileTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tx)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ProfileTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_ProfileTx_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ProfileTx(ctx, req.(*Tx))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetKeyRotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetKeyRotations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetKeyRotations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetKeyRotations(ctx, req.(*AccountAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetDelegations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetDelegations(ctx, req.(*AccountAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetSystemParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetSystemParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetSystemParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetSystemParams(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetReverseName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetReverseName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetReverseName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetReverseName(ctx, req.(*AccountAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetNameRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Name)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetNameRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_GetNameRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetNameRecords(ctx, req.(*Name))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListEnterpriseHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterpriseHistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListEnterpriseHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_ListEnterpriseHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListEnterpriseHistory(ctx, req.(*EnterpriseHistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ListEnterpriseProposals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListEnterpriseProposals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AergoRPCService_ListEnterpriseProposals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListEnterpriseProposals(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AergoRPCService_ServiceDesc is the grpc.ServiceDesc for AergoRPCService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1809,6 +2264,58 @@ var AergoRPCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfChangeProgress",
 			Handler:    _AergoRPCService_GetConfChangeProgress_Handler,
 		},
+		{
+			MethodName: "TraceTx",
+			Handler:    _AergoRPCService_TraceTx_Handler,
+		},
+		{
+			MethodName: "GetScheduledCallReceipts",
+			Handler:    _AergoRPCService_GetScheduledCallReceipts_Handler,
+		},
+		{
+			MethodName: "ListScheduledCalls",
+			Handler:    _AergoRPCService_ListScheduledCalls_Handler,
+		},
+		{
+			MethodName: "VerifyContractSource",
+			Handler:    _AergoRPCService_VerifyContractSource_Handler,
+		},
+		{
+			MethodName: "GetContractSource",
+			Handler:    _AergoRPCService_GetContractSource_Handler,
+		},
+		{
+			MethodName: "ProfileTx",
+			Handler:    _AergoRPCService_ProfileTx_Handler,
+		},
+		{
+			MethodName: "GetKeyRotations",
+			Handler:    _AergoRPCService_GetKeyRotations_Handler,
+		},
+		{
+			MethodName: "GetDelegations",
+			Handler:    _AergoRPCService_GetDelegations_Handler,
+		},
+		{
+			MethodName: "GetSystemParams",
+			Handler:    _AergoRPCService_GetSystemParams_Handler,
+		},
+		{
+			MethodName: "GetReverseName",
+			Handler:    _AergoRPCService_GetReverseName_Handler,
+		},
+		{
+			MethodName: "GetNameRecords",
+			Handler:    _AergoRPCService_GetNameRecords_Handler,
+		},
+		{
+			MethodName: "ListEnterpriseHistory",
+			Handler:    _AergoRPCService_ListEnterpriseHistory_Handler,
+		},
+		{
+			MethodName: "ListEnterpriseProposals",
+			Handler:    _AergoRPCService_ListEnterpriseProposals_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
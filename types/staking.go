@@ -1,7 +1,34 @@
 package types
 
-import "math/big"
+import (
+	"fmt"
+	"math/big"
+)
 
 func (s *Staking) GetAmountBigInt() *big.Int {
 	return new(big.Int).SetBytes(s.GetAmount())
 }
+
+func (d *Delegation) GetAmountBigInt() *big.Int {
+	return new(big.Int).SetBytes(d.GetAmount())
+}
+
+func (l *DelegationList) GetReceivedBigInt() *big.Int {
+	return new(big.Int).SetBytes(l.GetReceived())
+}
+
+// ParseDelegate returns the delegate account from the argument of the delegation tx.
+func ParseDelegate(ci *CallInfo) ([]byte, error) {
+	if len(ci.Args) != 1 {
+		return nil, fmt.Errorf("invalid arguments in %s", ci)
+	}
+	encoded, ok := ci.Args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid delegate %v", ci.Args[0])
+	}
+	delegate, err := DecodeAddress(encoded)
+	if err != nil || len(delegate) != AddressLength {
+		return nil, fmt.Errorf("invalid delegate %s", encoded)
+	}
+	return delegate, nil
+}
@@ -154,6 +154,8 @@ func (st *State) Clone() *State {
 		CodeHash:         st.CodeHash,
 		StorageRoot:      st.StorageRoot,
 		SqlRecoveryPoint: st.SqlRecoveryPoint,
+		SourceHash:       st.SourceHash,
+		SigningKey:       st.SigningKey,
 	}
 }
 
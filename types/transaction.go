@@ -17,6 +17,9 @@ import (
 const SetContractOwner = "v1setOwner"
 const NameCreate = "v1createName"
 const NameUpdate = "v1updateName"
+const NameRenew = "v1renewName"
+const NameSetReverse = "v1setReverseName"
+const NameSetRecord = "v1setNameRecord"
 
 const TxMaxSize = 200 * 1024
 
@@ -61,6 +64,8 @@ type Transaction interface {
 type transaction struct {
 	Tx              *Tx
 	VerifiedAccount Address
+
+	verifiedKey []byte // the signing key which the sign is verified with, not to verify again
 }
 
 var _ Transaction = (*transaction)(nil)
@@ -126,6 +131,10 @@ func (tx *transaction) Validate(chainidhash []byte, isPublic bool) error {
 		return ErrTxInvalidRecipient
 	}
 
+	if until := tx.GetBody().GetValidUntilBlock(); until != 0 && tx.GetBody().GetValidAfterBlock() > until {
+		return ErrTxInvalidValidity
+	}
+
 	switch tx.GetBody().Type {
 	case TxType_REDEPLOY:
 		if isPublic {
@@ -232,6 +241,34 @@ func ValidateSystemTx(tx *TxBody) error {
 			}
 			unique[encoded]++
 		}
+	case OpcreateMultisig:
+		if _, err := ParseMultisigCreate(&ci); err != nil {
+			return err
+		}
+		if tx.GetAmountBigInt().Sign() != 0 {
+			return ErrTxInvalidAmount
+		}
+	case OprotateKey:
+		if _, err := ParseRotateKey(&ci); err != nil {
+			return err
+		}
+		if tx.GetAmountBigInt().Sign() != 0 {
+			return ErrTxInvalidAmount
+		}
+	case Opdelegate:
+		if _, err := ParseDelegate(&ci); err != nil {
+			return err
+		}
+		if tx.GetAmountBigInt().Sign() == 0 {
+			return ErrTxInvalidAmount
+		}
+	case Opundelegate:
+		if len(ci.Args) != 0 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		if tx.GetAmountBigInt().Sign() == 0 {
+			return ErrTxInvalidAmount
+		}
 	default:
 		return ErrTxInvalidPayload
 	}
@@ -265,6 +302,47 @@ func validateNameTx(tx *TxBody) error {
 		if len(to) > AddressLength {
 			return fmt.Errorf("too long name %s", string(tx.GetPayload()))
 		}
+	case NameRenew:
+		if err := _validateNameTx(tx, &ci); err != nil {
+			return err
+		}
+		if len(ci.Args) != 1 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		if IsSubName([]byte(ci.Args[0].(string))) {
+			return fmt.Errorf("subname is renewed with its root name")
+		}
+	case NameSetReverse:
+		if len(ci.Args) != 1 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		// an empty name clears the reverse record
+		if nameParam, ok := ci.Args[0].(string); !ok || nameParam != "" {
+			if err := _validateNameTx(tx, &ci); err != nil {
+				return err
+			}
+		}
+	case NameSetRecord:
+		if err := _validateNameTx(tx, &ci); err != nil {
+			return err
+		}
+		if len(ci.Args) != 3 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		key, ok := ci.Args[1].(string)
+		if !ok {
+			return fmt.Errorf("invalid record key in %s", ci)
+		}
+		if err := validateRecordKey(key); err != nil {
+			return err
+		}
+		value, ok := ci.Args[2].(string)
+		if !ok {
+			return fmt.Errorf("invalid record value in %s", ci)
+		}
+		if len(value) > NameRecordValueMaxLength {
+			return fmt.Errorf("too long record value of %s", key)
+		}
 	case SetContractOwner:
 		owner, ok := ci.Args[0].(string)
 		if !ok {
@@ -289,6 +367,9 @@ func _validateNameTx(tx *TxBody, ci *CallInfo) error {
 		return fmt.Errorf("invalid arguments in %s", nameParam)
 	}
 
+	if IsSubName([]byte(nameParam)) {
+		return validateSubName(tx, nameParam)
+	}
 	if len(nameParam) > NameLength {
 		return fmt.Errorf("too long name %s", string(tx.GetPayload()))
 	}
@@ -301,10 +382,48 @@ func _validateNameTx(tx *TxBody, ci *CallInfo) error {
 	return nil
 }
 
+// validateSubName checks that the labels of the subname are allowed and its
+// root is a name.
+func validateSubName(tx *TxBody, name string) error {
+	if len(name) > SubNameMaxLength {
+		return fmt.Errorf("too long name %s", string(tx.GetPayload()))
+	}
+	labels := strings.Split(name, ".")
+	if len(labels[len(labels)-1]) != NameLength {
+		return fmt.Errorf("invalid root name of %s", name)
+	}
+	for _, label := range labels {
+		if len(label) == 0 {
+			return fmt.Errorf("empty label in %s", name)
+		}
+		if err := validateAllowedChar([]byte(label)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRecordKey checks that the key of a name record (e.g. url, avatar)
+// consists of the allowed characters.
+func validateRecordKey(key string) error {
+	if len(key) == 0 || len(key) > NameRecordKeyMaxLength {
+		return fmt.Errorf("invalid length of record key %s", key)
+	}
+	for _, char := range key {
+		if !strings.ContainsRune(allowedRecordKeyChar, char) {
+			return fmt.Errorf("not allowed character in record key %s", key)
+		}
+	}
+	return nil
+}
+
 func (tx *transaction) ValidateWithSenderState(senderState *State, gasPrice *big.Int, version int32) error {
 	if (senderState.GetNonce() + 1) > tx.GetBody().GetNonce() {
 		return ErrTxNonceTooLow
 	}
+	if err := tx.validateSigningKey(senderState); err != nil {
+		return err
+	}
 	amount := tx.GetBody().GetAmountBigInt()
 	balance := senderState.GetBalanceBigInt()
 	switch tx.GetBody().GetType() {
@@ -344,6 +463,51 @@ func (tx *transaction) ValidateWithSenderState(senderState *State, gasPrice *big
 	return nil
 }
 
+// HasValidity returns true if the tx has the block range in which it can be included.
+func (tx *TxBody) HasValidity() bool {
+	return tx.GetValidAfterBlock() != 0 || tx.GetValidUntilBlock() != 0
+}
+
+// IsExpired returns true if the tx can't be included in the block of blockNo or after it.
+func (tx *TxBody) IsExpired(blockNo BlockNo) bool {
+	return tx.GetValidUntilBlock() != 0 && blockNo > tx.GetValidUntilBlock()
+}
+
+// ValidateWithBlockNo checks that the tx can be included in the block of blockNo.
+func (tx *Tx) ValidateWithBlockNo(blockNo BlockNo, version int32) error {
+	body := tx.GetBody()
+	if !body.HasValidity() {
+		return nil
+	}
+	if version < 6 {
+		return ErrTxValidityNotSupported
+	}
+	if blockNo < body.GetValidAfterBlock() {
+		return ErrTxNotYetValid
+	}
+	if body.IsExpired(blockNo) {
+		return ErrTxExpired
+	}
+	return nil
+}
+
+// validateSigningKey checks that the tx is signed by the key bound to the sender by key rotation. The signature
+// verifier checks the sign with the state of the previous block, so the key rotated in the same block is checked here.
+// The tx of a name account is signed by the owner of the name, not by the destination which is the sender state, so
+// it is checked on the execution by the key of the owner.
+func (tx *transaction) validateSigningKey(senderState *State) error {
+	signingKey := senderState.GetSigningKey()
+	if len(signingKey) == 0 || tx.GetTx().HasMultisigAccount() || tx.GetTx().NeedNameVerify() ||
+		bytes.Equal(tx.verifiedKey, signingKey) {
+		return nil
+	}
+	if err := tx.GetTx().VerifySign(signingKey); err != nil {
+		return ErrSignNotMatch
+	}
+	tx.verifiedKey = signingKey
+	return nil
+}
+
 // TODO : refoctor after ContractState move to types
 func (tx *Tx) ValidateWithContractState(contractState *State) error {
 	//in system.ValidateSystemTx
@@ -385,6 +549,9 @@ func (tx *transaction) Clone() *transaction {
 		GasPrice:  tx.GetBody().GasPrice,
 		Type:      tx.GetBody().Type,
 		Sign:      tx.GetBody().Sign,
+
+		ValidAfterBlock: tx.GetBody().ValidAfterBlock,
+		ValidUntilBlock: tx.GetBody().ValidUntilBlock,
 	}
 	res := &transaction{
 		Tx: &Tx{Body: body},
@@ -409,6 +576,7 @@ func (tx *transaction) ValidateMaxFee(balance, gasPrice *big.Int, version int32)
 }
 
 const allowedNameChar = "abcdefghijklmnopqrstuvwxyz1234567890"
+const allowedRecordKeyChar = allowedNameChar + ".-_"
 
 func validateAllowedChar(param []byte) error {
 	if param == nil {
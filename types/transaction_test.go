@@ -2,9 +2,12 @@ package types
 
 import (
 	"encoding/json"
+	"math/big"
 	"strconv"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 )
@@ -101,6 +104,46 @@ func TestGovernanceTypeTransaction(t *testing.T) {
 	err = transaction.Validate(chainid, false)
 	assert.Error(t, err, "invalid name length in update")
 
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1createName", "Args":["pay.AB1234567890"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.NoError(t, err, "create subname")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1createName", "Args":["pay.AB12345"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.Error(t, err, "invalid root name length of subname")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1createName", "Args":["pay..AB1234567890"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.Error(t, err, "empty label of subname")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1renewName", "Args":["AB1234567890"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.NoError(t, err, "renew name")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1renewName", "Args":["pay.AB1234567890"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.Error(t, err, "renew subname")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1setReverseName", "Args":[""]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.NoError(t, err, "clear reverse name")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1setNameRecord", "Args":["AB1234567890","url","https://aergo.io"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.NoError(t, err, "set name record")
+
+	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1setNameRecord", "Args":["AB1234567890","URL!","https://aergo.io"]}`)
+	transaction.GetTx().Hash = transaction.CalculateTxHash()
+	err = transaction.Validate(chainid, false)
+	assert.Error(t, err, "invalid record key")
+
 	transaction.GetTx().GetBody().Payload = []byte(`{"Name":"v1voteDAO", "Args":["BPCOUNT","3", "3"]}`)
 	transaction.GetTx().Hash = transaction.CalculateTxHash()
 	err = transaction.Validate(chainid, false)
@@ -129,3 +172,108 @@ func buildVoteBPPayloadEx(count int, err int) []byte {
 	payload, _ := json.Marshal(ci)
 	return payload
 }
+
+func TestTxValidity(t *testing.T) {
+	const testSender = "AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4"
+	account, err := DecodeAddress(testSender)
+	assert.NoError(t, err, "should success to decode test address")
+	chainid := []byte("chainid")
+	tx := &Tx{
+		Body: &TxBody{
+			Nonce:       1,
+			Account:     account,
+			Recipient:   account,
+			Type:        TxType_TRANSFER,
+			ChainIdHash: chainid,
+		},
+	}
+	noValidity := tx.CalculateTxHash()
+
+	// the hash of the tx without the validity window doesn't change
+	tx.Body.ValidAfterBlock = 10
+	tx.Body.ValidUntilBlock = 20
+	assert.NotEqual(t, noValidity, tx.CalculateTxHash())
+	tx.Body.ValidUntilBlock = 0
+	assert.NotEqual(t, noValidity, tx.CalculateTxHash())
+	tx.Body.ValidAfterBlock = 0
+	assert.Equal(t, noValidity, tx.CalculateTxHash())
+	assert.NoError(t, tx.ValidateWithBlockNo(1, 0), "no validity window before the hardfork")
+
+	transaction := NewTransaction(tx)
+	tx.Body.ValidAfterBlock = 20
+	tx.Body.ValidUntilBlock = 10
+	tx.Hash = tx.CalculateTxHash()
+	assert.Equal(t, ErrTxInvalidValidity, transaction.Validate(chainid, false))
+
+	tx.Body.ValidAfterBlock = 10
+	tx.Body.ValidUntilBlock = 20
+	tx.Hash = tx.CalculateTxHash()
+	assert.NoError(t, transaction.Validate(chainid, false))
+
+	assert.Equal(t, ErrTxValidityNotSupported, tx.ValidateWithBlockNo(15, 5))
+	assert.Equal(t, ErrTxNotYetValid, tx.ValidateWithBlockNo(9, 6))
+	assert.NoError(t, tx.ValidateWithBlockNo(10, 6))
+	assert.NoError(t, tx.ValidateWithBlockNo(20, 6))
+	assert.Equal(t, ErrTxExpired, tx.ValidateWithBlockNo(21, 6))
+	assert.False(t, tx.Body.IsExpired(20))
+	assert.True(t, tx.Body.IsExpired(21))
+
+	// no upper limit
+	tx.Body.ValidUntilBlock = 0
+	assert.NoError(t, tx.ValidateWithBlockNo(1000000, 6))
+	assert.False(t, tx.Body.IsExpired(1000000))
+}
+
+func TestTxSigningKey(t *testing.T) {
+	oldKey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	newKey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	account := oldKey.PubKey().SerializeCompressed()
+	signingKey := newKey.PubKey().SerializeCompressed()
+
+	ci := &CallInfo{Name: OprotateKey.Cmd(), Args: []interface{}{EncodeAddress(signingKey)}}
+	parsed, err := ParseRotateKey(ci)
+	assert.NoError(t, err)
+	assert.Equal(t, signingKey, parsed)
+	for _, args := range [][]interface{}{
+		nil,
+		{1},
+		{"aergo.name"},
+		{EncodeAddress(signingKey), EncodeAddress(account)},
+	} {
+		_, err := ParseRotateKey(&CallInfo{Name: OprotateKey.Cmd(), Args: args})
+		assert.Error(t, err, args)
+	}
+
+	sign := func(key *btcec.PrivateKey) Transaction {
+		tx := &Tx{Body: &TxBody{
+			Nonce:     1,
+			Account:   account,
+			Recipient: account,
+			Type:      TxType_TRANSFER,
+		}}
+		tx.Body.Sign = ecdsa.Sign(key, tx.Body.CalculateHashWithoutSign()).Serialize()
+		tx.Hash = tx.CalculateTxHash()
+		return NewTransaction(tx)
+	}
+	state := &State{Balance: NewAmount(1, Aergo).Bytes()}
+	assert.Equal(t, account, state.SigningKeyOf(account))
+	assert.NoError(t, sign(oldKey).ValidateWithSenderState(state, big.NewInt(1), 6), "not rotated, the sign is verified with the address")
+
+	state.SigningKey = signingKey
+	assert.Equal(t, signingKey, state.SigningKeyOf(account))
+	assert.Equal(t, ErrSignNotMatch, sign(oldKey).ValidateWithSenderState(state, big.NewInt(1), 6))
+	assert.NoError(t, sign(newKey).ValidateWithSenderState(state, big.NewInt(1), 6))
+
+	// the tx of a name account is signed by the owner, so the key of the destination is not checked
+	nameTx := &Tx{Body: &TxBody{
+		Nonce:     1,
+		Account:   []byte("AB1234567890"),
+		Recipient: account,
+		Type:      TxType_TRANSFER,
+	}}
+	nameTx.Body.Sign = ecdsa.Sign(oldKey, nameTx.Body.CalculateHashWithoutSign()).Serialize()
+	nameTx.Hash = nameTx.CalculateTxHash()
+	assert.NoError(t, NewTransaction(nameTx).ValidateWithSenderState(state, big.NewInt(1), 6))
+}
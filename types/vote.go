@@ -59,6 +59,14 @@ const (
 	Opstake
 	// Opunstake represents a unstaking tranaction.
 	Opunstake
+	// OpcreateMultisig represents a transaction creating a multisig account.
+	OpcreateMultisig
+	// OprotateKey represents a transaction binding a new signing key to the sender.
+	OprotateKey
+	// Opdelegate represents a transaction delegating the stake to a delegate account.
+	Opdelegate
+	// Opundelegate represents a transaction taking back the delegated stake.
+	Opundelegate
 	// OpSysTxMax is the maximum of system tx OP numbers.
 	OpSysTxMax
 